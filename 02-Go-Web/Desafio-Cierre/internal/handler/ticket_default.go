@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"app/internal"
+	"app/platform/web/request"
+	"app/platform/web/response"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NewHandlerTicketDefault creates a new default handler of the tickets
+func NewHandlerTicketDefault(sv internal.ServiceTicket) *HandlerTicketDefault {
+	return &HandlerTicketDefault{
+		sv: sv,
+	}
+}
+
+// HandlerTicketDefault represents the default handler of the tickets
+type HandlerTicketDefault struct {
+	// sv represents the service of the tickets
+	sv internal.ServiceTicket
+}
+
+// GetTotal returns the total amount of tickets
+func (h *HandlerTicketDefault) GetTotal() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// ...
+
+		// process
+		total, err := h.sv.GetTotalAmountTickets()
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    total,
+		})
+	}
+}
+
+// GetByCountry returns the amount of tickets to the destination country in the path
+func (h *HandlerTicketDefault) GetByCountry() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		country := chi.URLParam(r, "dest")
+
+		// process
+		total, err := h.sv.GetTicketsAmountByDestinationCountry(country)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    total,
+		})
+	}
+}
+
+// GetAverage returns the percentage of the tickets that go to the destination country in the path
+func (h *HandlerTicketDefault) GetAverage() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		country := chi.URLParam(r, "dest")
+
+		// process
+		percentage, err := h.sv.GetPercentageTicketsByDestinationCountry(country)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    percentage,
+		})
+	}
+}
+
+// GetByPeriod returns the amount of tickets of each period of the day
+func (h *HandlerTicketDefault) GetByPeriod() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// ...
+
+		// process
+		totals, err := h.sv.GetTicketsAmountByPeriod()
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    totals,
+		})
+	}
+}
+
+// GetById returns the ticket with the id in the path
+func (h *HandlerTicketDefault) GetById() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		t, err := h.sv.GetTicketById(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrTicketNotFound):
+				response.Error(w, http.StatusNotFound, "ticket not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    t,
+		})
+	}
+}
+
+// Create creates a new ticket with the attributes in the body
+func (h *HandlerTicketDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body internal.TicketAttributes
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		t := internal.Ticket{Attributes: body}
+		err = h.sv.CreateTicket(&t)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrTicketInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    t,
+		})
+	}
+}
+
+// Update replaces the attributes of the ticket with the id in the path with the ones in the body
+func (h *HandlerTicketDefault) Update() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body internal.TicketAttributes
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		t := internal.Ticket{Id: id, Attributes: body}
+		err = h.sv.UpdateTicket(t)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrTicketInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrTicketNotFound):
+				response.Error(w, http.StatusNotFound, "ticket not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    t,
+		})
+	}
+}
+
+// Delete deletes the ticket with the id in the path
+func (h *HandlerTicketDefault) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.DeleteTicket(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrTicketNotFound):
+				response.Error(w, http.StatusNotFound, "ticket not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// Search returns the tickets that match the query parameters and the statistics of their prices
+// - query parameters: country, from and to (HH:MM), min_price, max_price and email_domain, all of them optional
+func (h *HandlerTicketDefault) Search() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query
+		q := r.URL.Query()
+		query := internal.TicketQuery{
+			Country:     q.Get("country"),
+			HourFrom:    q.Get("from"),
+			HourTo:      q.Get("to"),
+			EmailDomain: q.Get("email_domain"),
+		}
+		if query.HourFrom != "" {
+			if _, _, err := internal.ParseHour(query.HourFrom); err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid from")
+				return
+			}
+		}
+		if query.HourTo != "" {
+			if _, _, err := internal.ParseHour(query.HourTo); err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid to")
+				return
+			}
+		}
+		if q.Has("min_price") {
+			minPrice, err := strconv.ParseFloat(q.Get("min_price"), 64)
+			if err != nil || minPrice < 0 {
+				response.Error(w, http.StatusBadRequest, "invalid min_price")
+				return
+			}
+			query.MinPrice = &minPrice
+		}
+		if q.Has("max_price") {
+			maxPrice, err := strconv.ParseFloat(q.Get("max_price"), 64)
+			if err != nil || maxPrice < 0 {
+				response.Error(w, http.StatusBadRequest, "invalid max_price")
+				return
+			}
+			query.MaxPrice = &maxPrice
+		}
+
+		// process
+		t, stats, err := h.sv.SearchTickets(query)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		// - serialize the tickets, sorted by id
+		tickets := make([]internal.Ticket, 0, len(t))
+		for k, v := range t {
+			tickets = append(tickets, internal.Ticket{Id: k, Attributes: v})
+		}
+		sort.Slice(tickets, func(i, j int) bool { return tickets[i].Id < tickets[j].Id })
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data": map[string]any{
+				"tickets": tickets,
+				"stats":   stats,
+			},
+		})
+	}
+}
@@ -0,0 +1,359 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/handler"
+	"app/internal/repository"
+	"app/internal/service"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// withURLParam returns the request with the chi url parameter key set to value
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+// Tests for HandlerTicketDefault.GetTotal
+func TestHandlerTicketDefault_GetTotal(t *testing.T) {
+	t.Run("success to get the total of tickets", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: "Brazil", Hour: "22:30", Price: 200},
+			}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getTotal", nil)
+		res := httptest.NewRecorder()
+		hd.GetTotal()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":2}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Get)
+	})
+
+	t.Run("failure when the repository fails", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			err = errors.New("disk failure")
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getTotal", nil)
+		res := httptest.NewRecorder()
+		hd.GetTotal()(res, req)
+
+		// assert
+		expectedCode := http.StatusInternalServerError
+		expectedBody := `{"status":"Internal Server Error","message":"internal server error"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for HandlerTicketDefault.GetByCountry
+func TestHandlerTicketDefault_GetByCountry(t *testing.T) {
+	t.Run("success to get the tickets of the country in the path", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		var searched string
+		rp.FuncGetTicketByDestinationCountry = func(country string) (t map[int]internal.TicketAttributes, err error) {
+			searched = country
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: country, Hour: "10:00", Price: 100},
+			}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getByCountry/USA", nil)
+		req = withURLParam(req, "dest", "USA")
+		res := httptest.NewRecorder()
+		hd.GetByCountry()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":1}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, "USA", searched)
+	})
+}
+
+// Tests for HandlerTicketDefault.GetAverage
+func TestHandlerTicketDefault_GetAverage(t *testing.T) {
+	t.Run("success to get the percentage of the country in the path", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: "Brazil", Hour: "22:30", Price: 200},
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: "Brazil", Hour: "3:15", Price: 300},
+				4: {Name: "Jill", Email: "jill@gmail.com", Country: "Chile", Hour: "15:45", Price: 400},
+			}
+			return
+		}
+		rp.FuncGetTicketByDestinationCountry = func(country string) (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: country, Hour: "22:30", Price: 200},
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: country, Hour: "3:15", Price: 300},
+			}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getAverage/Brazil", nil)
+		req = withURLParam(req, "dest", "Brazil")
+		res := httptest.NewRecorder()
+		hd.GetAverage()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":50}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("success without tickets, the percentage is zero", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getAverage/Brazil", nil)
+		req = withURLParam(req, "dest", "Brazil")
+		res := httptest.NewRecorder()
+		hd.GetAverage()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":0}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.GetTicketByDestinationCountry)
+	})
+}
+
+// Tests for HandlerTicketDefault.GetByPeriod
+func TestHandlerTicketDefault_GetByPeriod(t *testing.T) {
+	t.Run("success to get every period, also the ones without tickets", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: "USA", Hour: "22:30", Price: 200},
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: "USA", Hour: "3:15", Price: 300},
+				4: {Name: "Jill", Email: "jill@gmail.com", Country: "USA", Hour: "6:59", Price: 400},
+			}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getByPeriod", nil)
+		res := httptest.NewRecorder()
+		hd.GetByPeriod()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"early_morning":2,"morning":1,"afternoon":0,"night":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure with a ticket hour that can not be parsed", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "25:00", Price: 100},
+			}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets/getByPeriod", nil)
+		res := httptest.NewRecorder()
+		hd.GetByPeriod()(res, req)
+
+		// assert
+		expectedCode := http.StatusInternalServerError
+		expectedBody := `{"status":"Internal Server Error","message":"internal server error"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for HandlerTicketDefault.Search
+func TestHandlerTicketDefault_Search(t *testing.T) {
+	t.Run("success to get the tickets sorted by id and their price statistics", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		var searched internal.TicketQuery
+		rp.FuncSearchTickets = func(query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+			searched = query
+			t = map[int]internal.TicketAttributes{
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: "USA", Hour: "23:15", Price: 0},
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "22:00", Price: 0},
+			}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets?country=USA&from=22:00&to=02:00&max_price=0&email_domain=gmail.com", nil)
+		res := httptest.NewRecorder()
+		hd.Search()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{
+			"tickets":[
+				{"id":1,"attributes":{"name":"John","email":"johndoe@gmail.com","country":"USA","hour":"22:00","price":0}},
+				{"id":3,"attributes":{"name":"Jim","email":"jim@gmail.com","country":"USA","hour":"23:15","price":0}}
+			],
+			"stats":{"count":2,"min":0,"max":0,"avg":0,"median":0}
+		}}`
+		maxPrice := 0.0
+		expectedQuery := internal.TicketQuery{Country: "USA", HourFrom: "22:00", HourTo: "02:00", MaxPrice: &maxPrice, EmailDomain: "gmail.com"}
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, expectedQuery, searched)
+	})
+
+	t.Run("success without tickets, the statistics are zero", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncSearchTickets = func(query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{}
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets?country=Narnia", nil)
+		res := httptest.NewRecorder()
+		hd.Search()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"tickets":[],"stats":{"count":0,"min":0,"max":0,"avg":0,"median":0}}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("failure with an invalid query parameter", func(t *testing.T) {
+		// arrange
+		type testCase struct {
+			target          string
+			expectedMessage string
+		}
+		testCases := []testCase{
+			{target: "/tickets?from=25:00", expectedMessage: "invalid from"},
+			{target: "/tickets?from=morning", expectedMessage: "invalid from"},
+			{target: "/tickets?to=12:60", expectedMessage: "invalid to"},
+			{target: "/tickets?to=12", expectedMessage: "invalid to"},
+			{target: "/tickets?min_price=-1", expectedMessage: "invalid min_price"},
+			{target: "/tickets?max_price=cheap", expectedMessage: "invalid max_price"},
+		}
+
+		for _, tc := range testCases {
+			// - repository: mock
+			rp := repository.NewRepositoryTicketMock()
+			// - handler
+			hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+			// act
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			res := httptest.NewRecorder()
+			hd.Search()(res, req)
+
+			// assert
+			expectedCode := http.StatusBadRequest
+			expectedBody := `{"status":"Bad Request","message":"` + tc.expectedMessage + `"}`
+			require.Equal(t, expectedCode, res.Code, tc.target)
+			require.JSONEq(t, expectedBody, res.Body.String())
+			require.Equal(t, 0, rp.Spy.SearchTickets)
+		}
+	})
+
+	t.Run("failure when the repository fails", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncSearchTickets = func(query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+			err = errors.New("disk failure")
+			return
+		}
+		// - handler
+		hd := handler.NewHandlerTicketDefault(service.NewServiceTicketDefault(rp))
+
+		// act
+		req := httptest.NewRequest(http.MethodGet, "/tickets", nil)
+		res := httptest.NewRecorder()
+		hd.Search()(res, req)
+
+		// assert
+		expectedCode := http.StatusInternalServerError
+		expectedBody := `{"status":"Internal Server Error","message":"internal server error"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
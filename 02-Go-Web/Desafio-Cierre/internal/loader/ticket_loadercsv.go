@@ -0,0 +1,274 @@
+package loader
+
+import (
+	"app/internal"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrLoaderTicketFieldCount is returned when a record does not have the 6 fields of a ticket
+	ErrLoaderTicketFieldCount = errors.New("loader: invalid number of fields")
+	// ErrLoaderTicketFormat is returned when a record is not valid CSV
+	ErrLoaderTicketFormat = errors.New("loader: invalid csv format")
+	// ErrLoaderTicketId is returned when the id of a ticket is not a positive integer
+	ErrLoaderTicketId = errors.New("loader: invalid id")
+	// ErrLoaderTicketIdDuplicated is returned when the id of a ticket was already loaded from a previous record
+	ErrLoaderTicketIdDuplicated = errors.New("loader: duplicated id")
+	// ErrLoaderTicketPrice is returned when the price of a ticket is not a non-negative number
+	ErrLoaderTicketPrice = errors.New("loader: invalid price")
+)
+
+// RowError represents a record of the CSV file that could not be loaded
+type RowError struct {
+	// Line represents the line of the record in the file, starting at 1
+	Line int
+	// Err represents the reason the record was rejected
+	Err error
+}
+
+// Error returns the message of the error, prefixed with the line of the record
+func (e *RowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+// Unwrap returns the reason the record was rejected
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// LoadSummary represents the result of loading the CSV file
+type LoadSummary struct {
+	// Loaded represents the number of tickets loaded
+	Loaded int
+	// Rejected represents the records that were skipped, only filled when the loader skips bad rows
+	Rejected []*RowError
+	// LastId represents the highest id of the loaded tickets
+	LastId int
+}
+
+// NewLoaderTicketCSV creates a new ticket loader from a CSV file
+// - strict makes the first bad record fail the load, otherwise bad records are skipped and reported in the summary
+func NewLoaderTicketCSV(filePath string, strict bool) *LoaderTicketCSV {
+	return &LoaderTicketCSV{
+		filePath: filePath,
+		strict:   strict,
+	}
+}
+
+// LoaderTicketCSV represents a ticket loader from a CSV file
+// - each record is: id, name, email, country, hour (HH:MM), price
+type LoaderTicketCSV struct {
+	filePath string
+	strict   bool
+	// rejected represents the raw text of the records skipped by the last load, written back by Store so they are not lost
+	rejected []byte
+}
+
+// Load loads the tickets from the CSV file
+func (l *LoaderTicketCSV) Load() (t map[int]internal.TicketAttributes, summary LoadSummary, err error) {
+	// open the file
+	// - the whole content is read, so the raw text of a skipped record can be kept
+	data, err := os.ReadFile(l.filePath)
+	if err != nil {
+		err = fmt.Errorf("error opening file: %w", err)
+		return
+	}
+
+	// read the file
+	r := csv.NewReader(bytes.NewReader(data))
+	// - the number of fields is checked for each record, so a bad one can be skipped
+	r.FieldsPerRecord = -1
+
+	// read the records
+	t = make(map[int]internal.TicketAttributes)
+	l.rejected = nil
+	for {
+		start := r.InputOffset()
+		record, errRead := r.Read()
+		if errRead == io.EOF {
+			break
+		}
+
+		// serialize the record
+		var rowErr *RowError
+		var id int
+		var ticket internal.TicketAttributes
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(errRead, &parseErr):
+			rowErr = &RowError{Line: parseErr.Line, Err: fmt.Errorf("%w: %v", ErrLoaderTicketFormat, parseErr.Err)}
+		case errRead != nil:
+			err = fmt.Errorf("error reading record: %w", errRead)
+			return
+		default:
+			line, _ := r.FieldPos(0)
+			id, ticket, rowErr = parseTicket(line, record)
+			if rowErr == nil {
+				if _, ok := t[id]; ok {
+					rowErr = &RowError{Line: line, Err: fmt.Errorf("%w: %d", ErrLoaderTicketIdDuplicated, id)}
+				}
+			}
+		}
+
+		// reject the record
+		if rowErr != nil {
+			if l.strict {
+				err = rowErr
+				return
+			}
+			summary.Rejected = append(summary.Rejected, rowErr)
+			l.rejected = append(l.rejected, data[start:r.InputOffset()]...)
+			if len(l.rejected) > 0 && l.rejected[len(l.rejected)-1] != '\n' {
+				l.rejected = append(l.rejected, '\n')
+			}
+			continue
+		}
+
+		// add the ticket to the map
+		t[id] = ticket
+		summary.Loaded++
+		if id > summary.LastId {
+			summary.LastId = id
+		}
+	}
+
+	return
+}
+
+// parseTicket parses the fields of a record into the id and the attributes of a ticket
+func parseTicket(line int, record []string) (id int, ticket internal.TicketAttributes, rowErr *RowError) {
+	// check the number of fields
+	if len(record) != 6 {
+		rowErr = &RowError{Line: line, Err: fmt.Errorf("%w: expected 6, got %d", ErrLoaderTicketFieldCount, len(record))}
+		return
+	}
+
+	// id
+	id, err := strconv.Atoi(strings.TrimSpace(record[0]))
+	if err != nil || id <= 0 {
+		rowErr = &RowError{Line: line, Err: fmt.Errorf("%w: %q", ErrLoaderTicketId, record[0])}
+		return
+	}
+
+	// hour
+	hour := strings.TrimSpace(record[4])
+	_, _, err = internal.ParseHour(hour)
+	if err != nil {
+		rowErr = &RowError{Line: line, Err: err}
+		return
+	}
+
+	// price
+	price, err := strconv.ParseFloat(strings.TrimSpace(record[5]), 64)
+	if err != nil || price < 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		rowErr = &RowError{Line: line, Err: fmt.Errorf("%w: %q", ErrLoaderTicketPrice, record[5])}
+		return
+	}
+
+	ticket = internal.TicketAttributes{
+		Name:    record[1],
+		Email:   record[2],
+		Country: record[3],
+		Hour:    hour,
+		Price:   price,
+	}
+	return
+}
+
+// Store writes the tickets to the CSV file, sorted by id
+// - the records skipped by the last load are written back as they were after the tickets,
+// so the file keeps them to be fixed by hand and a later load still prefers the tickets with the same id
+// - the tickets are written to a temporary file in the same directory which then replaces the CSV file,
+// so a failure while writing leaves the previous content untouched
+func (l *LoaderTicketCSV) Store(t map[int]internal.TicketAttributes) (err error) {
+	// create the temporary file
+	f, err := os.CreateTemp(filepath.Dir(l.filePath), filepath.Base(l.filePath)+".tmp-*")
+	if err != nil {
+		err = fmt.Errorf("error creating file: %w", err)
+		return
+	}
+	defer func() {
+		// the temporary file is only left behind if it could not replace the CSV file
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+
+	// write the records
+	ids := make([]int, 0, len(t))
+	for id := range t {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	w := csv.NewWriter(f)
+	for _, id := range ids {
+		ticket := t[id]
+		record := []string{
+			strconv.Itoa(id),
+			ticket.Name,
+			ticket.Email,
+			ticket.Country,
+			ticket.Hour,
+			strconv.FormatFloat(ticket.Price, 'f', -1, 64),
+		}
+		err = w.Write(record)
+		if err != nil {
+			err = fmt.Errorf("error writing record: %w", err)
+			return
+		}
+	}
+	w.Flush()
+	err = w.Error()
+	if err != nil {
+		err = fmt.Errorf("error writing record: %w", err)
+		return
+	}
+	_, err = f.Write(l.rejected)
+	if err != nil {
+		err = fmt.Errorf("error writing record: %w", err)
+		return
+	}
+
+	// keep the permissions of the CSV file
+	mode := os.FileMode(0644)
+	if info, errStat := os.Stat(l.filePath); errStat == nil {
+		mode = info.Mode().Perm()
+	}
+	err = f.Chmod(mode)
+	if err != nil {
+		err = fmt.Errorf("error setting file permissions: %w", err)
+		return
+	}
+
+	// make sure the content is on disk before replacing the CSV file
+	err = f.Sync()
+	if err != nil {
+		err = fmt.Errorf("error syncing file: %w", err)
+		return
+	}
+	err = f.Close()
+	if err != nil {
+		err = fmt.Errorf("error closing file: %w", err)
+		return
+	}
+
+	// replace the CSV file
+	err = os.Rename(f.Name(), l.filePath)
+	if err != nil {
+		err = fmt.Errorf("error replacing file: %w", err)
+		return
+	}
+
+	return
+}
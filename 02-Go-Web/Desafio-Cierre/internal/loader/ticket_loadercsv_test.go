@@ -0,0 +1,143 @@
+package loader_test
+
+import (
+	"app/internal"
+	"app/internal/loader"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile writes the content to a temporary file and returns its path
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tickets.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// Tests for LoaderTicketCSV.Load
+func TestLoaderTicketCSV_Load(t *testing.T) {
+	t.Run("success to load the tickets", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, "1,John,johndoe@gmail.com,USA,10:00,100\n7,Jane,janedoe@gmail.com,China,0:31,199.5\n")
+		ld := loader.NewLoaderTicketCSV(path, true)
+
+		// act
+		tickets, summary, err := ld.Load()
+
+		// assert
+		expectedTickets := map[int]internal.TicketAttributes{
+			1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+			7: {Name: "Jane", Email: "janedoe@gmail.com", Country: "China", Hour: "0:31", Price: 199.5},
+		}
+		expectedSummary := loader.LoadSummary{Loaded: 2, LastId: 7}
+		require.NoError(t, err)
+		require.Equal(t, expectedTickets, tickets)
+		require.Equal(t, expectedSummary, summary)
+	})
+
+	t.Run("failure on the first bad row in strict mode", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, "1,John,johndoe@gmail.com,USA,10:00,100\n2,Jane,janedoe@gmail.com,China,25:00,200\n3,Jim,jim@gmail.com,Peru,1:00,abc\n")
+		ld := loader.NewLoaderTicketCSV(path, true)
+
+		// act
+		_, _, err := ld.Load()
+
+		// assert
+		var rowErr *loader.RowError
+		require.ErrorAs(t, err, &rowErr)
+		require.Equal(t, 2, rowErr.Line)
+		require.ErrorIs(t, err, internal.ErrTicketHourInvalid)
+	})
+
+	t.Run("success skipping the bad rows", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, "1,John,johndoe@gmail.com,USA,10:00,100\n"+
+			"x,Jane,janedoe@gmail.com,China,11:00,200\n"+
+			"3,Jim,jim@gmail.com,Peru,1:00,abc\n"+
+			"4,Jill,jill@gmail.com,Brazil,1:00\n"+
+			"1,Joe,joe@gmail.com,Chile,2:00,50\n"+
+			"6,Jack,jack@gmail.com,Chile,9:60,50\n"+
+			"5,Jen,jen@gmail.com,Chile,23:59,50\n")
+		ld := loader.NewLoaderTicketCSV(path, false)
+
+		// act
+		tickets, summary, err := ld.Load()
+
+		// assert
+		require.NoError(t, err)
+		require.Len(t, tickets, 2)
+		require.Equal(t, 2, summary.Loaded)
+		require.Equal(t, 5, summary.LastId)
+		expectedErrs := []error{loader.ErrLoaderTicketId, loader.ErrLoaderTicketPrice, loader.ErrLoaderTicketFieldCount, loader.ErrLoaderTicketIdDuplicated, internal.ErrTicketHourInvalid}
+		require.Len(t, summary.Rejected, len(expectedErrs))
+		for i, expectedErr := range expectedErrs {
+			require.Equal(t, i+2, summary.Rejected[i].Line)
+			require.ErrorIs(t, summary.Rejected[i], expectedErr)
+		}
+	})
+
+	t.Run("failure to open the file", func(t *testing.T) {
+		// arrange
+		ld := loader.NewLoaderTicketCSV(filepath.Join(t.TempDir(), "missing.csv"), true)
+
+		// act
+		tickets, _, err := ld.Load()
+
+		// assert
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.Nil(t, tickets)
+	})
+}
+
+// Tests for LoaderTicketCSV.Store
+func TestLoaderTicketCSV_Store(t *testing.T) {
+	t.Run("success to store the tickets sorted by id", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, "")
+		ld := loader.NewLoaderTicketCSV(path, true)
+		tickets := map[int]internal.TicketAttributes{
+			7: {Name: "Jane", Email: "janedoe@gmail.com", Country: "China", Hour: "0:31", Price: 199.5},
+			1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+		}
+
+		// act
+		err := ld.Store(tickets)
+
+		// assert
+		expectedContent := "1,John,johndoe@gmail.com,USA,10:00,100\n7,Jane,janedoe@gmail.com,China,0:31,199.5\n"
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expectedContent, string(content))
+	})
+
+	t.Run("success keeping the rows skipped by the load", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, "1,John,johndoe@gmail.com,USA,10:00,100\n"+
+			"3,Jim,jim@gmail.com,Peru,1:00,abc\n"+
+			"4,Jill,\"jill\"@gmail.com,Brazil,1:00,10\n"+
+			"5,Jen,jen@gmail.com,Chile,23:59,50")
+		ld := loader.NewLoaderTicketCSV(path, false)
+		tickets, summary, err := ld.Load()
+		require.NoError(t, err)
+		require.Len(t, summary.Rejected, 2)
+		delete(tickets, 1)
+
+		// act
+		err = ld.Store(tickets)
+
+		// assert
+		expectedContent := "5,Jen,jen@gmail.com,Chile,23:59,50\n" +
+			"3,Jim,jim@gmail.com,Peru,1:00,abc\n" +
+			"4,Jill,\"jill\"@gmail.com,Brazil,1:00,10\n"
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expectedContent, string(content))
+	})
+}
@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"app/internal"
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// StorerTicket represents the storage where the tickets are written back to
+type StorerTicket interface {
+	// Store replaces the stored tickets with the given ones
+	Store(t map[int]internal.TicketAttributes) (err error)
+}
+
+// NewRepositoryTicketMap creates a new repository for tickets in a map
+// - lastId is the highest id of the tickets in db, new tickets get the following ones
+// - every write is stored through st before it returns, st can be nil to keep the tickets only in memory
+func NewRepositoryTicketMap(db map[int]internal.TicketAttributes, lastId int, st StorerTicket) *RepositoryTicketMap {
+	// default values
+	if db == nil {
+		db = make(map[int]internal.TicketAttributes)
+	}
+
+	return &RepositoryTicketMap{
+		db:     db,
+		lastId: lastId,
+		st:     st,
+	}
+}
+
+// RepositoryTicketMap implements the repository interface for tickets in a map
+type RepositoryTicketMap struct {
+	// mu guards db and lastId
+	mu sync.RWMutex
+
+	// db represents the database in a map
+	// - key: id of the ticket
+	// - value: ticket
+	db map[int]internal.TicketAttributes
+
+	// lastId represents the last id of the ticket
+	lastId int
+
+	// st represents the storage the writes are stored through
+	st StorerTicket
+}
+
+// GetAll returns all the tickets
+func (r *RepositoryTicketMap) Get(ctx context.Context) (t map[int]internal.TicketAttributes, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// create a copy of the map
+	t = make(map[int]internal.TicketAttributes, len(r.db))
+	for k, v := range r.db {
+		t[k] = v
+	}
+
+	return
+}
+
+// GetTicketByDestinationCountry returns the tickets filtered by destination country
+func (r *RepositoryTicketMap) GetTicketByDestinationCountry(ctx context.Context, country string) (t map[int]internal.TicketAttributes, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// create a copy of the map
+	t = make(map[int]internal.TicketAttributes)
+	for k, v := range r.db {
+		if v.Country == country {
+			t[k] = v
+		}
+	}
+
+	return
+}
+
+// SearchTickets returns the tickets that match the query
+// - the hours of the query must be valid HH:MM hours, internal.ErrTicketHourInvalid is returned otherwise
+func (r *RepositoryTicketMap) SearchTickets(ctx context.Context, query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+	// parse the hour range, in minutes of the day
+	from, to := 0, 24*60-1
+	if query.HourFrom != "" {
+		from, err = minutesOf(query.HourFrom)
+		if err != nil {
+			return
+		}
+	}
+	if query.HourTo != "" {
+		to, err = minutesOf(query.HourTo)
+		if err != nil {
+			return
+		}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// search the tickets
+	t = make(map[int]internal.TicketAttributes)
+	for k, v := range r.db {
+		// check if each query field is set
+		if query.Country != "" && query.Country != v.Country {
+			continue
+		}
+		if query.MinPrice != nil && v.Price < *query.MinPrice {
+			continue
+		}
+		if query.MaxPrice != nil && v.Price > *query.MaxPrice {
+			continue
+		}
+		if query.EmailDomain != "" {
+			_, domain, _ := strings.Cut(v.Email, "@")
+			if !strings.EqualFold(domain, query.EmailDomain) {
+				continue
+			}
+		}
+		if query.HourFrom != "" || query.HourTo != "" {
+			minutes, errHour := minutesOf(v.Hour)
+			if errHour != nil {
+				continue
+			}
+			// - a range that wraps around midnight matches the hours after from or before to
+			inRange := minutes >= from && minutes <= to
+			if from > to {
+				inRange = minutes >= from || minutes <= to
+			}
+			if !inRange {
+				continue
+			}
+		}
+
+		// add the ticket to the result
+		t[k] = v
+	}
+
+	return
+}
+
+// minutesOf returns the minutes of the day of an hour in the HH:MM format
+func minutesOf(hour string) (minutes int, err error) {
+	hh, mm, err := internal.ParseHour(hour)
+	if err != nil {
+		return
+	}
+
+	minutes = hh*60 + mm
+	return
+}
+
+// GetById returns the ticket with the given id
+func (r *RepositoryTicketMap) GetById(ctx context.Context, id int) (t internal.Ticket, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attributes, ok := r.db[id]
+	if !ok {
+		err = internal.ErrTicketNotFound
+		return
+	}
+
+	t = internal.Ticket{Id: id, Attributes: attributes}
+	return
+}
+
+// Save saves a new ticket with the id following lastId
+func (r *RepositoryTicketMap) Save(ctx context.Context, t *internal.Ticket) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// save the ticket
+	id := r.lastId + 1
+	r.db[id] = (*t).Attributes
+
+	// store the tickets, the ticket is discarded if they can not be stored
+	err = r.store()
+	if err != nil {
+		delete(r.db, id)
+		return
+	}
+
+	r.lastId = id
+	(*t).Id = id
+	return
+}
+
+// Update replaces the attributes of an existing ticket
+func (r *RepositoryTicketMap) Update(ctx context.Context, t internal.Ticket) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the ticket exists
+	previous, ok := r.db[t.Id]
+	if !ok {
+		err = internal.ErrTicketNotFound
+		return
+	}
+
+	// update the ticket
+	r.db[t.Id] = t.Attributes
+
+	// store the tickets, the previous attributes are restored if they can not be stored
+	err = r.store()
+	if err != nil {
+		r.db[t.Id] = previous
+		return
+	}
+
+	return
+}
+
+// Delete deletes the ticket with the given id
+// - the id is not reused by later tickets
+func (r *RepositoryTicketMap) Delete(ctx context.Context, id int) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the ticket exists
+	previous, ok := r.db[id]
+	if !ok {
+		err = internal.ErrTicketNotFound
+		return
+	}
+
+	// delete the ticket
+	delete(r.db, id)
+
+	// store the tickets, the ticket is restored if they can not be stored
+	err = r.store()
+	if err != nil {
+		r.db[id] = previous
+		return
+	}
+
+	return
+}
+
+// store writes the tickets through the storage, the caller must hold the write lock
+func (r *RepositoryTicketMap) store() (err error) {
+	if r.st == nil {
+		return
+	}
+
+	err = r.st.Store(r.db)
+	if err != nil {
+		err = fmt.Errorf("error storing tickets: %w", err)
+		return
+	}
+
+	return
+}
@@ -0,0 +1,237 @@
+package repository_test
+
+import (
+	"app/internal"
+	"app/internal/loader"
+	"app/internal/repository"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// storerTicketStub is a storage that fails with err
+type storerTicketStub struct {
+	err error
+}
+
+// Store returns the error of the stub
+func (s *storerTicketStub) Store(t map[int]internal.TicketAttributes) (err error) {
+	return s.err
+}
+
+// Tests for RepositoryTicketMap writes
+func TestRepositoryTicketMap_Write(t *testing.T) {
+	t.Run("success to save, update and delete storing through the csv file", func(t *testing.T) {
+		// arrange
+		path := filepath.Join(t.TempDir(), "tickets.csv")
+		require.NoError(t, os.WriteFile(path, []byte("1,John,johndoe@gmail.com,USA,10:00,100\n3,Jane,janedoe@gmail.com,China,0:31,200\n"), 0644))
+		ld := loader.NewLoaderTicketCSV(path, true)
+		db, summary, err := ld.Load()
+		require.NoError(t, err)
+		rp := repository.NewRepositoryTicketMap(db, summary.LastId, ld)
+
+		// act
+		ticket := internal.Ticket{Attributes: internal.TicketAttributes{Name: "Jim", Email: "jim@gmail.com", Country: "Peru", Hour: "15:45", Price: 99.5}}
+		errSave := rp.Save(context.Background(), &ticket)
+		errUpdate := rp.Update(context.Background(), internal.Ticket{Id: 1, Attributes: internal.TicketAttributes{Name: "John", Email: "johndoe@gmail.com", Country: "Chile", Hour: "11:00", Price: 150}})
+		errDelete := rp.Delete(context.Background(), 3)
+
+		// assert
+		require.NoError(t, errSave)
+		require.NoError(t, errUpdate)
+		require.NoError(t, errDelete)
+		require.Equal(t, 4, ticket.Id)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, "1,John,johndoe@gmail.com,Chile,11:00,150\n4,Jim,jim@gmail.com,Peru,15:45,99.5\n", string(content))
+		// - no temporary file is left behind
+		entries, err := os.ReadDir(filepath.Dir(path))
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("failure with a ticket that does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewRepositoryTicketMap(nil, 0, nil)
+
+		// act
+		_, errGet := rp.GetById(context.Background(), 1)
+		errUpdate := rp.Update(context.Background(), internal.Ticket{Id: 1})
+		errDelete := rp.Delete(context.Background(), 1)
+
+		// assert
+		require.ErrorIs(t, errGet, internal.ErrTicketNotFound)
+		require.ErrorIs(t, errUpdate, internal.ErrTicketNotFound)
+		require.ErrorIs(t, errDelete, internal.ErrTicketNotFound)
+	})
+
+	t.Run("failure to store keeps the previous tickets", func(t *testing.T) {
+		// arrange
+		errStore := errors.New("disk full")
+		db := map[int]internal.TicketAttributes{
+			1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+		}
+		rp := repository.NewRepositoryTicketMap(db, 1, &storerTicketStub{err: errStore})
+
+		// act
+		ticket := internal.Ticket{Attributes: internal.TicketAttributes{Name: "Jim", Email: "jim@gmail.com", Country: "Peru", Hour: "15:45", Price: 99.5}}
+		errSave := rp.Save(context.Background(), &ticket)
+		errUpdate := rp.Update(context.Background(), internal.Ticket{Id: 1, Attributes: ticket.Attributes})
+		errDelete := rp.Delete(context.Background(), 1)
+
+		// assert
+		require.ErrorIs(t, errSave, errStore)
+		require.ErrorIs(t, errUpdate, errStore)
+		require.ErrorIs(t, errDelete, errStore)
+		require.Zero(t, ticket.Id)
+		tickets, err := rp.Get(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, map[int]internal.TicketAttributes{
+			1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+		}, tickets)
+	})
+
+	t.Run("success to save concurrently with unique ids", func(t *testing.T) {
+		// arrange
+		rp := repository.NewRepositoryTicketMap(nil, 10, nil)
+
+		// act
+		// - the goroutines send their errors, as require can only stop the test from its own goroutine
+		var wg sync.WaitGroup
+		ids := make([]int, 50)
+		errs := make(chan error, len(ids))
+		for i := range ids {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				ticket := internal.Ticket{Attributes: internal.TicketAttributes{Name: "Jim", Email: "jim@gmail.com", Country: "Peru", Hour: "15:45", Price: 99.5}}
+				errs <- rp.Save(context.Background(), &ticket)
+				ids[i] = ticket.Id
+			}(i)
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, _ = rp.Get(context.Background())
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		seen := make(map[int]bool, len(ids))
+		for _, id := range ids {
+			require.Greater(t, id, 10)
+			require.False(t, seen[id])
+			seen[id] = true
+		}
+	})
+}
+
+// Tests for RepositoryTicketMap.SearchTickets
+func TestRepositoryTicketMap_SearchTickets(t *testing.T) {
+	db := map[int]internal.TicketAttributes{
+		1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+		2: {Name: "Jane", Email: "janedoe@yahoo.com", Country: "China", Hour: "22:30", Price: 200},
+		3: {Name: "Jim", Email: "jim@Gmail.com", Country: "China", Hour: "3:15", Price: 300},
+		4: {Name: "Jill", Email: "jill@gmail.com", Country: "Brazil", Hour: "15:45", Price: 400},
+		5: {Name: "Joe", Email: "joe@hotmail.com", Country: "Peru", Hour: "18:00", Price: 0},
+	}
+	price := func(v float64) *float64 { return &v }
+	type input struct {
+		query internal.TicketQuery
+	}
+	type output struct {
+		ids []int
+		err error
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	testCases := []testCase{
+		{
+			name:   "success - query not set - return all tickets",
+			input:  input{query: internal.TicketQuery{}},
+			output: output{ids: []int{1, 2, 3, 4, 5}},
+		},
+		{
+			name:   "success - country",
+			input:  input{query: internal.TicketQuery{Country: "China"}},
+			output: output{ids: []int{2, 3}},
+		},
+		{
+			name:   "success - price range",
+			input:  input{query: internal.TicketQuery{MinPrice: price(150), MaxPrice: price(300)}},
+			output: output{ids: []int{2, 3}},
+		},
+		{
+			name:   "success - max price zero - only free tickets",
+			input:  input{query: internal.TicketQuery{MaxPrice: price(0)}},
+			output: output{ids: []int{5}},
+		},
+		{
+			name:   "success - min price zero - all tickets",
+			input:  input{query: internal.TicketQuery{MinPrice: price(0)}},
+			output: output{ids: []int{1, 2, 3, 4, 5}},
+		},
+		{
+			name:   "success - email domain, case insensitive",
+			input:  input{query: internal.TicketQuery{EmailDomain: "gmail.com"}},
+			output: output{ids: []int{1, 3, 4}},
+		},
+		{
+			name:   "success - hour range",
+			input:  input{query: internal.TicketQuery{HourFrom: "9:00", HourTo: "15:45"}},
+			output: output{ids: []int{1, 4}},
+		},
+		{
+			name:   "success - hour range around midnight",
+			input:  input{query: internal.TicketQuery{HourFrom: "22:00", HourTo: "4:00"}},
+			output: output{ids: []int{2, 3}},
+		},
+		{
+			name:   "success - combined filters",
+			input:  input{query: internal.TicketQuery{Country: "China", HourTo: "12:00", EmailDomain: "gmail.com"}},
+			output: output{ids: []int{3}},
+		},
+		{
+			name:   "failure - invalid hour",
+			input:  input{query: internal.TicketQuery{HourFrom: "24:00"}},
+			output: output{err: internal.ErrTicketHourInvalid},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			rp := repository.NewRepositoryTicketMap(db, 4, nil)
+
+			// act
+			tickets, err := rp.SearchTickets(context.Background(), tc.input.query)
+
+			// assert
+			if tc.output.err != nil {
+				require.ErrorIs(t, err, tc.output.err)
+				return
+			}
+			require.NoError(t, err)
+			expected := make(map[int]internal.TicketAttributes, len(tc.output.ids))
+			for _, id := range tc.output.ids {
+				expected[id] = db[id]
+			}
+			require.Equal(t, expected, tickets)
+		})
+	}
+}
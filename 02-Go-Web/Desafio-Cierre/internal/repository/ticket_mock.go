@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"app/internal"
+	"context"
+)
+
+// NewRepositoryTicketMock creates a new repository for tickets in a map
+func NewRepositoryTicketMock() *RepositoryTicketMock {
+	return &RepositoryTicketMock{}
+}
+
+// RepositoryTicketMock implements the repository interface for tickets
+type RepositoryTicketMock struct {
+	// FuncGet represents the mock for the Get function
+	FuncGet func() (t map[int]internal.TicketAttributes, err error)
+	// FuncGetTicketByDestinationCountry
+	FuncGetTicketByDestinationCountry func(country string) (t map[int]internal.TicketAttributes, err error)
+	// FuncGetById represents the mock for the GetById function
+	FuncGetById func(id int) (t internal.Ticket, err error)
+	// FuncSave represents the mock for the Save function
+	FuncSave func(t *internal.Ticket) (err error)
+	// FuncUpdate represents the mock for the Update function
+	FuncUpdate func(t internal.Ticket) (err error)
+	// FuncDelete represents the mock for the Delete function
+	FuncDelete func(id int) (err error)
+	// FuncSearchTickets represents the mock for the SearchTickets function
+	FuncSearchTickets func(query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error)
+
+	// Spy verifies if the methods were called
+	Spy struct {
+		// Get represents the spy for the Get function
+		Get int
+		// GetTicketByDestinationCountry represents the spy for the GetTicketByDestinationCountry function
+		GetTicketByDestinationCountry int
+		// GetById represents the spy for the GetById function
+		GetById int
+		// Save represents the spy for the Save function
+		Save int
+		// Update represents the spy for the Update function
+		Update int
+		// Delete represents the spy for the Delete function
+		Delete int
+		// SearchTickets represents the spy for the SearchTickets function
+		SearchTickets int
+	}
+}
+
+// GetAll returns all the tickets
+func (r *RepositoryTicketMock) Get(ctx context.Context) (t map[int]internal.TicketAttributes, err error) {
+	// spy
+	r.Spy.Get++
+
+	// mock
+	t, err = r.FuncGet()
+	return
+}
+
+// GetTicketByDestinationCountry returns the tickets filtered by destination country
+func (r *RepositoryTicketMock) GetTicketByDestinationCountry(ctx context.Context, country string) (t map[int]internal.TicketAttributes, err error) {
+	// spy
+	r.Spy.GetTicketByDestinationCountry++
+
+	// mock
+	t, err = r.FuncGetTicketByDestinationCountry(country)
+	return
+}
+
+// GetById returns the ticket with the given id
+func (r *RepositoryTicketMock) GetById(ctx context.Context, id int) (t internal.Ticket, err error) {
+	// spy
+	r.Spy.GetById++
+
+	// mock
+	t, err = r.FuncGetById(id)
+	return
+}
+
+// Save saves a new ticket
+func (r *RepositoryTicketMock) Save(ctx context.Context, t *internal.Ticket) (err error) {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	err = r.FuncSave(t)
+	return
+}
+
+// Update replaces the attributes of an existing ticket
+func (r *RepositoryTicketMock) Update(ctx context.Context, t internal.Ticket) (err error) {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	err = r.FuncUpdate(t)
+	return
+}
+
+// Delete deletes the ticket with the given id
+func (r *RepositoryTicketMock) Delete(ctx context.Context, id int) (err error) {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	err = r.FuncDelete(id)
+	return
+}
+
+// SearchTickets returns the tickets that match the query
+func (r *RepositoryTicketMock) SearchTickets(ctx context.Context, query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+	// spy
+	r.Spy.SearchTickets++
+
+	// mock
+	t, err = r.FuncSearchTickets(query)
+	return
+}
\ No newline at end of file
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"app/internal"
+	"context"
+	"sort"
+)
+
+// ServiceTicketDefault represents the default service of the tickets
+type ServiceTicketDefault struct {
+	// rp represents the repository of the tickets
+	rp internal.RepositoryTicket
+}
+
+// NewServiceTicketDefault creates a new default service of the tickets
+func NewServiceTicketDefault(rp internal.RepositoryTicket) *ServiceTicketDefault {
+	return &ServiceTicketDefault{
+		rp: rp,
+	}
+}
+
+// GetTotalAmountTickets returns the total number of tickets
+func (s *ServiceTicketDefault) GetTotalAmountTickets() (total int, err error) {
+	// get the tickets
+	t, err := s.rp.Get(context.Background())
+	if err != nil {
+		return
+	}
+
+	total = len(t)
+	return
+}
+
+// GetTicketsAmountByDestinationCountry returns the number of tickets to a destination country
+func (s *ServiceTicketDefault) GetTicketsAmountByDestinationCountry(country string) (total int, err error) {
+	// get the tickets of the country
+	t, err := s.rp.GetTicketByDestinationCountry(context.Background(), country)
+	if err != nil {
+		return
+	}
+
+	total = len(t)
+	return
+}
+
+// GetPercentageTicketsByDestinationCountry returns the percentage of the tickets that go to a destination country
+// - without tickets the percentage is zero
+func (s *ServiceTicketDefault) GetPercentageTicketsByDestinationCountry(country string) (percentage float64, err error) {
+	// get the total of tickets
+	total, err := s.GetTotalAmountTickets()
+	if err != nil {
+		return
+	}
+	if total == 0 {
+		return
+	}
+
+	// get the tickets of the country
+	totalCountry, err := s.GetTicketsAmountByDestinationCountry(country)
+	if err != nil {
+		return
+	}
+
+	percentage = float64(totalCountry) / float64(total) * 100
+	return
+}
+
+// GetTicketsAmountByPeriod returns the number of tickets of each period of the day
+// - every period is present in the result, with zero if no ticket departs in it
+func (s *ServiceTicketDefault) GetTicketsAmountByPeriod() (totals map[internal.TicketPeriod]int, err error) {
+	// get the tickets
+	t, err := s.rp.Get(context.Background())
+	if err != nil {
+		return
+	}
+
+	// count the tickets of each period
+	totals = make(map[internal.TicketPeriod]int, len(internal.TicketPeriods))
+	for _, p := range internal.TicketPeriods {
+		totals[p] = 0
+	}
+	for _, v := range t {
+		var p internal.TicketPeriod
+		p, err = internal.PeriodOf(v.Hour)
+		if err != nil {
+			totals = nil
+			return
+		}
+		totals[p]++
+	}
+
+	return
+}
+
+// GetTicketById returns the ticket with the given id
+func (s *ServiceTicketDefault) GetTicketById(id int) (t internal.Ticket, err error) {
+	t, err = s.rp.GetById(context.Background(), id)
+	return
+}
+
+// CreateTicket validates and saves a new ticket
+func (s *ServiceTicketDefault) CreateTicket(t *internal.Ticket) (err error) {
+	// validate the ticket
+	err = (*t).Attributes.Validate()
+	if err != nil {
+		return
+	}
+
+	// save the ticket
+	err = s.rp.Save(context.Background(), t)
+	return
+}
+
+// UpdateTicket validates and replaces the attributes of an existing ticket
+func (s *ServiceTicketDefault) UpdateTicket(t internal.Ticket) (err error) {
+	// validate the ticket
+	err = t.Attributes.Validate()
+	if err != nil {
+		return
+	}
+
+	// update the ticket
+	err = s.rp.Update(context.Background(), t)
+	return
+}
+
+// DeleteTicket deletes the ticket with the given id
+func (s *ServiceTicketDefault) DeleteTicket(id int) (err error) {
+	err = s.rp.Delete(context.Background(), id)
+	return
+}
+
+// SearchTickets returns the tickets that match the query and the statistics of their prices
+// - without tickets the statistics are zero
+func (s *ServiceTicketDefault) SearchTickets(query internal.TicketQuery) (t map[int]internal.TicketAttributes, stats internal.TicketPriceStats, err error) {
+	// search the tickets
+	t, err = s.rp.SearchTickets(context.Background(), query)
+	if err != nil {
+		return
+	}
+	if len(t) == 0 {
+		return
+	}
+
+	// sort the prices
+	prices := make([]float64, 0, len(t))
+	for _, v := range t {
+		prices = append(prices, v.Price)
+	}
+	sort.Float64s(prices)
+
+	// compute the statistics
+	var sum float64
+	for _, p := range prices {
+		sum += p
+	}
+	n := len(prices)
+	stats = internal.TicketPriceStats{
+		Count:  n,
+		Min:    prices[0],
+		Max:    prices[n-1],
+		Avg:    sum / float64(n),
+		Median: prices[n/2],
+	}
+	if n%2 == 0 {
+		stats.Median = (prices[n/2-1] + prices[n/2]) / 2
+	}
+	return
+}
\ No newline at end of file
@@ -0,0 +1,309 @@
+package service_test
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"app/internal/service"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for ServiceTicketDefault.GetTotalAmountTickets
+func TestServiceTicketDefault_GetTotalAmountTickets(t *testing.T) {
+	t.Run("success to get total tickets", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {
+					Name:    "John",
+					Email:   "johndoe@gmail.com",
+					Country: "USA",
+					Hour:    "10:00",
+					Price:   100,
+				},
+			}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		total, err := sv.GetTotalAmountTickets()
+
+		// assert
+		expectedTotal := 1
+		require.NoError(t, err)
+		require.Equal(t, expectedTotal, total)
+	})
+}
+
+// Tests for ServiceTicketDefault.GetTicketsAmountByDestinationCountry
+func TestServiceTicketDefault_GetTicketsAmountByDestinationCountry(t *testing.T) {
+	t.Run("success to get the tickets of a country", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGetTicketByDestinationCountry = func(country string) (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: country, Hour: "10:00", Price: 100},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: country, Hour: "22:30", Price: 200},
+			}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		total, err := sv.GetTicketsAmountByDestinationCountry("USA")
+
+		// assert
+		expectedTotal := 2
+		require.NoError(t, err)
+		require.Equal(t, expectedTotal, total)
+		require.Equal(t, 1, rp.Spy.GetTicketByDestinationCountry)
+	})
+}
+
+// Tests for ServiceTicketDefault.GetPercentageTicketsByDestinationCountry
+func TestServiceTicketDefault_GetPercentageTicketsByDestinationCountry(t *testing.T) {
+	t.Run("success to get the percentage of a country", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: "China", Hour: "22:30", Price: 200},
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: "China", Hour: "3:15", Price: 300},
+				4: {Name: "Jill", Email: "jill@gmail.com", Country: "Brazil", Hour: "15:45", Price: 400},
+			}
+			return
+		}
+		rp.FuncGetTicketByDestinationCountry = func(country string) (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100},
+			}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		percentage, err := sv.GetPercentageTicketsByDestinationCountry("USA")
+
+		// assert
+		expectedPercentage := 25.0
+		require.NoError(t, err)
+		require.Equal(t, expectedPercentage, percentage)
+	})
+
+	t.Run("success without tickets", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		percentage, err := sv.GetPercentageTicketsByDestinationCountry("USA")
+
+		// assert
+		require.NoError(t, err)
+		require.Zero(t, percentage)
+		require.Equal(t, 0, rp.Spy.GetTicketByDestinationCountry)
+	})
+}
+
+// Tests for ServiceTicketDefault.GetTicketsAmountByPeriod
+func TestServiceTicketDefault_GetTicketsAmountByPeriod(t *testing.T) {
+	t.Run("success to get the tickets of each period", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "0:00", Price: 100},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: "China", Hour: "6:59", Price: 200},
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: "China", Hour: "12:59", Price: 300},
+				4: {Name: "Jill", Email: "jill@gmail.com", Country: "Brazil", Hour: "23:59", Price: 400},
+			}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		totals, err := sv.GetTicketsAmountByPeriod()
+
+		// assert
+		expectedTotals := map[internal.TicketPeriod]int{
+			internal.TicketPeriodEarlyMorning: 2,
+			internal.TicketPeriodMorning:      1,
+			internal.TicketPeriodAfternoon:    0,
+			internal.TicketPeriodNight:        1,
+		}
+		require.NoError(t, err)
+		require.Equal(t, expectedTotals, totals)
+	})
+
+	t.Run("failure with an invalid hour", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncGet = func() (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "25:00", Price: 100},
+			}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		totals, err := sv.GetTicketsAmountByPeriod()
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTicketHourInvalid)
+		require.Nil(t, totals)
+	})
+}
+
+// Tests for ServiceTicketDefault.CreateTicket
+func TestServiceTicketDefault_CreateTicket(t *testing.T) {
+	t.Run("success to create a ticket", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncSave = func(t *internal.Ticket) (err error) {
+			(*t).Id = 1001
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		ticket := internal.Ticket{Attributes: internal.TicketAttributes{Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100}}
+		err := sv.CreateTicket(&ticket)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1001, ticket.Id)
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("failure with invalid attributes", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		ticket := internal.Ticket{Attributes: internal.TicketAttributes{Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:75", Price: 100}}
+		err := sv.CreateTicket(&ticket)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTicketInvalid)
+		require.ErrorIs(t, err, internal.ErrTicketHourInvalid)
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for ServiceTicketDefault.UpdateTicket
+func TestServiceTicketDefault_UpdateTicket(t *testing.T) {
+	t.Run("failure with a ticket that does not exist", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncUpdate = func(t internal.Ticket) (err error) {
+			err = internal.ErrTicketNotFound
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		err := sv.UpdateTicket(internal.Ticket{Id: 1, Attributes: internal.TicketAttributes{Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 100}})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrTicketNotFound)
+		require.Equal(t, 1, rp.Spy.Update)
+	})
+}
+
+// Tests for ServiceTicketDefault.SearchTickets
+func TestServiceTicketDefault_SearchTickets(t *testing.T) {
+	t.Run("success to get the price statistics", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncSearchTickets = func(query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{
+				1: {Name: "John", Email: "johndoe@gmail.com", Country: "USA", Hour: "10:00", Price: 400},
+				2: {Name: "Jane", Email: "janedoe@gmail.com", Country: "USA", Hour: "22:30", Price: 100},
+				3: {Name: "Jim", Email: "jim@gmail.com", Country: "USA", Hour: "3:15", Price: 200},
+				4: {Name: "Jill", Email: "jill@gmail.com", Country: "USA", Hour: "15:45", Price: 500},
+			}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		tickets, stats, err := sv.SearchTickets(internal.TicketQuery{Country: "USA"})
+
+		// assert
+		expectedStats := internal.TicketPriceStats{Count: 4, Min: 100, Max: 500, Avg: 300, Median: 300}
+		require.NoError(t, err)
+		require.Len(t, tickets, 4)
+		require.Equal(t, expectedStats, stats)
+	})
+
+	t.Run("success without tickets", func(t *testing.T) {
+		// arrange
+		// - repository: mock
+		rp := repository.NewRepositoryTicketMock()
+		// - repository: set-up
+		rp.FuncSearchTickets = func(query internal.TicketQuery) (t map[int]internal.TicketAttributes, err error) {
+			t = map[int]internal.TicketAttributes{}
+			return
+		}
+
+		// - service
+		sv := service.NewServiceTicketDefault(rp)
+
+		// act
+		tickets, stats, err := sv.SearchTickets(internal.TicketQuery{Country: "Narnia"})
+
+		// assert
+		require.NoError(t, err)
+		require.Empty(t, tickets)
+		require.Equal(t, internal.TicketPriceStats{}, stats)
+	})
+}
\ No newline at end of file
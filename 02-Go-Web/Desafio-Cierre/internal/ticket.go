@@ -0,0 +1,202 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TicketAttributes is an struct that represents a ticket
+type TicketAttributes struct {
+	// Name represents the name of the owner of the ticket
+	Name string `json:"name"`
+	// Email represents the email of the owner of the ticket
+	Email string `json:"email"`
+	// Country represents the destination country of the ticket
+	Country string `json:"country"`
+	// Hour represents the hour of the ticket
+	Hour string `json:"hour"`
+	// Price represents the price of the ticket
+	Price float64 `json:"price"`
+}
+
+// Ticket represents a ticket
+type Ticket struct {
+	// Id represents the id of the ticket
+	Id int `json:"id"`
+	// Attributes represents the attributes of the ticket
+	Attributes TicketAttributes `json:"attributes"`
+}
+
+// TicketQuery represents a search of tickets, the zero value of a field leaves it unset
+// - every set field must match for a ticket to be found
+// - the prices are pointers, as zero is a valid price to search for
+type TicketQuery struct {
+	// Country represents the destination country of the tickets
+	Country string
+	// HourFrom represents the earliest hour of the tickets, in the HH:MM format
+	HourFrom string
+	// HourTo represents the latest hour of the tickets, in the HH:MM format
+	// - if it is before HourFrom the range wraps around midnight
+	HourTo string
+	// MinPrice represents the lowest price of the tickets
+	MinPrice *float64
+	// MaxPrice represents the highest price of the tickets
+	MaxPrice *float64
+	// EmailDomain represents the domain of the email of the owners of the tickets, e.g. gmail.com
+	EmailDomain string
+}
+
+// TicketPriceStats represents the statistics of the prices of a set of tickets
+type TicketPriceStats struct {
+	// Count represents the number of tickets
+	Count int `json:"count"`
+	// Min represents the lowest price
+	Min float64 `json:"min"`
+	// Max represents the highest price
+	Max float64 `json:"max"`
+	// Avg represents the mean price
+	Avg float64 `json:"avg"`
+	// Median represents the median price, the mean of the two middle prices for an even count
+	Median float64 `json:"median"`
+}
+
+// TicketPeriod represents a band of the day in which a ticket departs
+type TicketPeriod string
+
+const (
+	// TicketPeriodEarlyMorning represents the hours from 0:00 to 6:59
+	TicketPeriodEarlyMorning TicketPeriod = "early_morning"
+	// TicketPeriodMorning represents the hours from 7:00 to 12:59
+	TicketPeriodMorning TicketPeriod = "morning"
+	// TicketPeriodAfternoon represents the hours from 13:00 to 19:59
+	TicketPeriodAfternoon TicketPeriod = "afternoon"
+	// TicketPeriodNight represents the hours from 20:00 to 23:59
+	TicketPeriodNight TicketPeriod = "night"
+)
+
+// TicketPeriods represents every period of the day, in order
+var TicketPeriods = []TicketPeriod{TicketPeriodEarlyMorning, TicketPeriodMorning, TicketPeriodAfternoon, TicketPeriodNight}
+
+var (
+	// ErrTicketHourInvalid is returned when the hour of a ticket is not a valid HH:MM hour
+	ErrTicketHourInvalid = errors.New("ticket: invalid hour")
+	// ErrTicketInvalid is returned when the attributes of a ticket are not valid
+	ErrTicketInvalid = errors.New("ticket: invalid attributes")
+	// ErrTicketNotFound is returned when the ticket does not exist
+	ErrTicketNotFound = errors.New("ticket: not found")
+)
+
+// Validate returns ErrTicketInvalid wrapped with the first attribute that is not valid
+func (t TicketAttributes) Validate() (err error) {
+	switch {
+	case t.Name == "":
+		err = fmt.Errorf("%w: name is required", ErrTicketInvalid)
+	case t.Email == "":
+		err = fmt.Errorf("%w: email is required", ErrTicketInvalid)
+	case t.Country == "":
+		err = fmt.Errorf("%w: country is required", ErrTicketInvalid)
+	case t.Price < 0:
+		err = fmt.Errorf("%w: price must not be negative", ErrTicketInvalid)
+	default:
+		_, _, errHour := ParseHour(t.Hour)
+		if errHour != nil {
+			err = fmt.Errorf("%w: %w", ErrTicketInvalid, errHour)
+		}
+	}
+	return
+}
+
+// ParseHour returns the hour and the minutes of an hour in the HH:MM format
+func ParseHour(hour string) (hh int, mm int, err error) {
+	h, m, ok := strings.Cut(hour, ":")
+	if !ok {
+		err = fmt.Errorf("%w: %q", ErrTicketHourInvalid, hour)
+		return
+	}
+	hh, errH := strconv.Atoi(h)
+	mm, errM := strconv.Atoi(m)
+	if errH != nil || errM != nil || hh < 0 || hh > 23 || mm < 0 || mm > 59 {
+		hh, mm = 0, 0
+		err = fmt.Errorf("%w: %q", ErrTicketHourInvalid, hour)
+		return
+	}
+	return
+}
+
+// PeriodOf returns the period of the day of an hour in the HH:MM format
+func PeriodOf(hour string) (period TicketPeriod, err error) {
+	// parse the hour
+	hh, _, err := ParseHour(hour)
+	if err != nil {
+		return
+	}
+
+	// get the period
+	switch {
+	case hh < 7:
+		period = TicketPeriodEarlyMorning
+	case hh < 13:
+		period = TicketPeriodMorning
+	case hh < 20:
+		period = TicketPeriodAfternoon
+	default:
+		period = TicketPeriodNight
+	}
+	return
+}
+
+// RepositoryTicket represents the repository interface for tickets
+type RepositoryTicket interface {
+	// GetAll returns all the tickets
+	Get(ctx context.Context) (t map[int]TicketAttributes, err error)
+
+	// GetTicketByDestinationCountry returns the tickets filtered by destination country
+	GetTicketByDestinationCountry(ctx context.Context, country string) (t map[int]TicketAttributes, err error)
+
+	// GetById returns the ticket with the given id, ErrTicketNotFound if it does not exist
+	GetById(ctx context.Context, id int) (t Ticket, err error)
+
+	// Save saves a new ticket and sets its id
+	Save(ctx context.Context, t *Ticket) (err error)
+
+	// Update replaces the attributes of an existing ticket, ErrTicketNotFound if it does not exist
+	Update(ctx context.Context, t Ticket) (err error)
+
+	// Delete deletes the ticket with the given id, ErrTicketNotFound if it does not exist
+	Delete(ctx context.Context, id int) (err error)
+
+	// SearchTickets returns the tickets that match the query
+	SearchTickets(ctx context.Context, query TicketQuery) (t map[int]TicketAttributes, err error)
+}
+
+type ServiceTicket interface {
+	// GetTotalAmountTickets returns the total amount of tickets
+	GetTotalAmountTickets() (total int, err error)
+
+	// GetTicketsAmountByDestinationCountry returns the amount of tickets filtered by destination country
+	GetTicketsAmountByDestinationCountry(country string) (total int, err error)
+
+	// GetPercentageTicketsByDestinationCountry returns the percentage of tickets filtered by destination country
+	GetPercentageTicketsByDestinationCountry(country string) (percentage float64, err error)
+
+	// GetTicketsAmountByPeriod returns the amount of tickets of each period of the day
+	GetTicketsAmountByPeriod() (totals map[TicketPeriod]int, err error)
+
+	// GetTicketById returns the ticket with the given id
+	GetTicketById(id int) (t Ticket, err error)
+
+	// CreateTicket validates and saves a new ticket, setting its id
+	CreateTicket(t *Ticket) (err error)
+
+	// UpdateTicket validates and replaces the attributes of an existing ticket
+	UpdateTicket(t Ticket) (err error)
+
+	// DeleteTicket deletes the ticket with the given id
+	DeleteTicket(id int) (err error)
+
+	// SearchTickets returns the tickets that match the query and the statistics of their prices
+	SearchTickets(query TicketQuery) (t map[int]TicketAttributes, stats TicketPriceStats, err error)
+}
\ No newline at end of file
@@ -1,6 +1,10 @@
 package main
 
 import (
+	"app/internal/handler"
+	"app/internal/loader"
+	"app/internal/repository"
+	"app/internal/service"
 	"fmt"
 	"net/http"
 	"os"
@@ -15,8 +19,9 @@ func main() {
 	// application
 	// - config
 	cfg := &ConfigAppDefault{
-		ServerAddr: os.Getenv("SERVER_ADDR"),
-		DbFile:     os.Getenv("DB_FILE"),
+		ServerAddr:    os.Getenv("SERVER_ADDR"),
+		DbFile:        os.Getenv("DB_FILE"),
+		DbSkipBadRows: os.Getenv("DB_SKIP_BAD_ROWS") == "true",
 	}
 	app := NewApplicationDefault(cfg)
 
@@ -41,6 +46,8 @@ type ConfigAppDefault struct {
 	ServerAddr string
 	// dbFile represents the path to the database file
 	DbFile string
+	// DbSkipBadRows represents whether the rows of the database file that can not be loaded are skipped instead of failing the set up
+	DbSkipBadRows bool
 }
 
 // NewApplicationDefault creates a new default application
@@ -49,7 +56,7 @@ func NewApplicationDefault(cfg *ConfigAppDefault) *ApplicationDefault {
 	defaultRouter := chi.NewRouter()
 	defaultConfig := &ConfigAppDefault{
 		ServerAddr: ":8080",
-		DbFile:     "",
+		DbFile:     "tickets.csv",
 	}
 	if cfg != nil {
 		if cfg.ServerAddr != "" {
@@ -58,12 +65,14 @@ func NewApplicationDefault(cfg *ConfigAppDefault) *ApplicationDefault {
 		if cfg.DbFile != "" {
 			defaultConfig.DbFile = cfg.DbFile
 		}
+		defaultConfig.DbSkipBadRows = cfg.DbSkipBadRows
 	}			
 
 	return &ApplicationDefault{
-		rt:         defaultRouter,
-		serverAddr: defaultConfig.ServerAddr,
-		dbFile:     defaultConfig.DbFile,
+		rt:            defaultRouter,
+		serverAddr:    defaultConfig.ServerAddr,
+		dbFile:        defaultConfig.DbFile,
+		dbSkipBadRows: defaultConfig.DbSkipBadRows,
 	}
 }
 
@@ -76,19 +85,29 @@ type ApplicationDefault struct {
 	serverAddr string
 	// dbFile represents the path to the database file
 	dbFile string
+	// dbSkipBadRows represents whether the bad rows of the database file are skipped
+	dbSkipBadRows bool
 }
 
 
 // SetUp sets up the application
 func (a *ApplicationDefault) SetUp() (err error) {
 	// dependencies
-	db, err := loader.NewLoaderTicketCSV(a.dbFile)
+	ld := loader.NewLoaderTicketCSV(a.dbFile, !a.dbSkipBadRows)
+	db, summary, err := ld.Load()
 	if err != nil {
 		return
 	}
-	rp := repository.NewRepositoryTicketMap(db)
-	// service ...
-	// handler ...
+	fmt.Printf("loaded %d tickets, rejected %d rows\n", summary.Loaded, len(summary.Rejected))
+	for _, rowErr := range summary.Rejected {
+		fmt.Println(rowErr)
+	}
+	// - the writes are stored back to the database file
+	rp := repository.NewRepositoryTicketMap(db, summary.LastId, ld)
+	// service
+	sv := service.NewServiceTicketDefault(rp)
+	// handler
+	hd := handler.NewHandlerTicketDefault(sv)
 
 	// routes
 	(*a).rt.Get("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -96,6 +115,26 @@ func (a *ApplicationDefault) SetUp() (err error) {
 		w.Header().Set("Content-Type", "text/plain")
 		w.Write([]byte("OK"))
 	})
+	(*a).rt.Route("/tickets", func(rt chi.Router) {
+		// GET /tickets
+		rt.Get("/", hd.Search())
+		// GET /tickets/getTotal
+		rt.Get("/getTotal", hd.GetTotal())
+		// GET /tickets/getByCountry/{dest}
+		rt.Get("/getByCountry/{dest}", hd.GetByCountry())
+		// GET /tickets/getAverage/{dest}
+		rt.Get("/getAverage/{dest}", hd.GetAverage())
+		// GET /tickets/getByPeriod
+		rt.Get("/getByPeriod", hd.GetByPeriod())
+		// GET /tickets/{id}
+		rt.Get("/{id}", hd.GetById())
+		// POST /tickets
+		rt.Post("/", hd.Create())
+		// PUT /tickets/{id}
+		rt.Put("/{id}", hd.Update())
+		// DELETE /tickets/{id}
+		rt.Delete("/{id}", hd.Delete())
+	})
 	
 	return
 }
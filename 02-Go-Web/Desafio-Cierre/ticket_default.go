@@ -1,19 +0,0 @@
-package service
-
-// ServiceTicketDefault represents the default service of the tickets
-type ServiceTicketDefault struct {
-	// rp represents the repository of the tickets
-	rp repository.RepositoryTicket
-}
-
-// NewServiceTicketDefault creates a new default service of the tickets
-func NewServiceTicketDefault(rp repository.RepositoryTicket) *ServiceTicketDefault {
-	return &ServiceTicketDefault{
-		rp: rp,
-	}
-}
-
-// GetTotalTickets returns the total number of tickets
-func (s *ServiceTicketDefault) GetTotalTickets() (total int, err error) {
-	return
-}
\ No newline at end of file
@@ -42,4 +42,9 @@ INSERT INTO `sales` (`quantity`,`invoice_id`,`product_id`) VALUES
 (5,5,5),
 (6,6,6),
 (7,7,7),
-(8,8,8);
\ No newline at end of file
+(8,8,8);
+-- Add data to table tax_rates
+INSERT INTO `tax_rates` (`category`,`rate`) VALUES
+('food',10.5),
+('electronics',21),
+('books',0);
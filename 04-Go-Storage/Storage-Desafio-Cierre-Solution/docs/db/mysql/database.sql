@@ -19,6 +19,10 @@ CREATE TABLE `invoices` (
     `id` int NOT NULL AUTO_INCREMENT,
     `datetime` datetime DEFAULT NULL,
     `customer_id` int DEFAULT NULL,
+    `status` varchar(10) NOT NULL DEFAULT 'draft',
+    `subtotal` float NOT NULL DEFAULT 0,
+    `discount` float NOT NULL DEFAULT 0,
+    `tax` float NOT NULL DEFAULT 0,
     `total` float DEFAULT NULL,
     PRIMARY KEY (`id`),
     KEY `idx_invoices_customer_id` (`customer_id`),
@@ -30,13 +34,24 @@ CREATE TABLE `products` (
     `id` int NOT NULL AUTO_INCREMENT,
     `description` varchar(100) DEFAULT NULL,
     `price` float DEFAULT NULL,
+    `category` varchar(45) NOT NULL DEFAULT '',
     PRIMARY KEY (`id`)
 );
 
+-- Table structure for table `tax_rates`
+-- - there is no endpoint to write them, seed them running docs/db/mysql/data.sql and list them with GET /tax-rates
+-- - a product whose category has no rate is taxed at 0
+CREATE TABLE `tax_rates` (
+    `category` varchar(45) NOT NULL,
+    `rate` float NOT NULL DEFAULT 0,
+    PRIMARY KEY (`category`)
+);
+
 -- Table structure for table `sales`
 CREATE TABLE `sales` (
     `id` int NOT NULL AUTO_INCREMENT,
     `quantity` int DEFAULT NULL,
+    `discount` float NOT NULL DEFAULT 0,
     `invoice_id` int DEFAULT NULL,
     `product_id` int DEFAULT NULL,
     PRIMARY KEY (`id`),
@@ -44,4 +59,18 @@ CREATE TABLE `sales` (
     KEY `idx_sales_product_id` (`product_id`),
     CONSTRAINT `fk_sales_invoice_id` FOREIGN KEY (`invoice_id`) REFERENCES `invoices` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
     CONSTRAINT `fk_sales_product_id` FOREIGN KEY (`product_id`) REFERENCES `products` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+-- Table structure for table `audit_log`
+CREATE TABLE `audit_log` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `entity` varchar(45) NOT NULL,
+    `entity_id` int NOT NULL,
+    `operation` varchar(20) NOT NULL,
+    `before` json DEFAULT NULL,
+    `after` json DEFAULT NULL,
+    `request_id` varchar(100) NOT NULL DEFAULT '',
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_audit_log_entity` (`entity`, `entity_id`)
 );
\ No newline at end of file
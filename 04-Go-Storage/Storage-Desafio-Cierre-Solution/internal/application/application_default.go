@@ -1,6 +1,7 @@
 package application
 
 import (
+	"app/internal"
 	"app/internal/handler"
 	"app/internal/repository"
 	"app/internal/service"
@@ -76,25 +77,33 @@ func (a *ApplicationDefault) SetUp() (err error) {
 		return
 	}
 	// - repository
-	rpCustomer := repository.NewCustomersMySQL(a.db)
-	rpProduct := repository.NewProductsMySQL(a.db)
-	rpInvoice := repository.NewInvoicesMySQL(a.db)
-	rpSale := repository.NewSalesMySQL(a.db)
+	rpAudit := repository.NewAuditsMySQL(a.db)
+	rpCustomer := repository.NewCustomersAudit(repository.NewCustomersMySQL(a.db), rpAudit)
+	rpProduct := repository.NewProductsAudit(repository.NewProductsMySQL(a.db), rpAudit)
+	rpInvoice := repository.NewInvoicesAudit(repository.NewInvoicesMySQL(a.db), rpAudit)
+	rpSale := repository.NewSalesAudit(repository.NewSalesMySQL(a.db), rpAudit)
+	rpTaxRate := repository.NewTaxRatesMySQL(a.db)
 	// - service
+	svAudit := service.NewAuditsDefault(rpAudit)
 	svCustomer := service.NewCustomersDefault(rpCustomer)
 	svProduct := service.NewProductsDefault(rpProduct)
 	svInvoice := service.NewInvoicesDefault(rpInvoice)
 	svSale := service.NewSalesDefault(rpSale)
+	svTaxRate := service.NewTaxRatesDefault(rpTaxRate)
 	// - handler
+	hdAudit := handler.NewAuditsDefault(svAudit)
 	hdCustomer := handler.NewCustomersDefault(svCustomer)
 	hdProduct := handler.NewProductsDefault(svProduct)
 	hdInvoice := handler.NewInvoicesDefault(svInvoice)
 	hdSale := handler.NewSalesDefault(svSale)
+	hdTaxRate := handler.NewTaxRatesDefault(svTaxRate)
 
 	// routes
 	// - router
 	a.router = chi.NewRouter()
 	// - middlewares
+	a.router.Use(middleware.RequestID)
+	a.router.Use(requestIdAudit)
 	a.router.Use(middleware.Logger)
 	a.router.Use(middleware.Recoverer)
 	// - endpoints
@@ -123,6 +132,10 @@ func (a *ApplicationDefault) SetUp() (err error) {
 		r.Post("/", hdInvoice.Create())
 		// - PUT /invoices/total
 		r.Put("/total", hdInvoice.UpdateAllTotal())
+		// - GET /invoices/{id}
+		r.Get("/{id}", hdInvoice.GetById())
+		// - PATCH /invoices/{id}/status
+		r.Patch("/{id}/status", hdInvoice.UpdateStatus())
 	})
 	a.router.Route("/sales", func(r chi.Router) {
 		// - GET /sales
@@ -130,6 +143,14 @@ func (a *ApplicationDefault) SetUp() (err error) {
 		// - POST /sales
 		r.Post("/", hdSale.Create())
 	})
+	a.router.Route("/tax-rates", func(r chi.Router) {
+		// - GET /tax-rates
+		r.Get("/", hdTaxRate.GetAll())
+	})
+	a.router.Route("/audit", func(r chi.Router) {
+		// - GET /audit?entity=&id=
+		r.Get("/", hdAudit.GetByEntity())
+	})
 
 	return
 }
@@ -138,4 +159,12 @@ func (a *ApplicationDefault) SetUp() (err error) {
 func (a *ApplicationDefault) Run() (err error) {
 	err = http.ListenAndServe(a.cfgAddr, a.router)
 	return
-}
\ No newline at end of file
+}
+
+// requestIdAudit puts the id of the request in its context, so the writes it performs are recorded with it in the audit log.
+func requestIdAudit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := internal.ContextWithRequestId(r.Context(), middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,55 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	// AuditOperationCreate is the operation recorded when an entity is saved.
+	AuditOperationCreate = "create"
+	// AuditOperationUpdate is the operation recorded when an entity is updated.
+	AuditOperationUpdate = "update"
+	// AuditOperationDelete is the operation recorded when an entity is deleted.
+	AuditOperationDelete = "delete"
+)
+
+// AuditAttributes is the struct that represents the attributes of an audit entry.
+type AuditAttributes struct {
+	// Entity is the name of the audited entity (e.g. invoices).
+	Entity string
+	// EntityId is the id of the audited entity (0 when the operation affects many of them).
+	EntityId int
+	// Operation is the write operation performed over the entity.
+	Operation string
+	// Before is the JSON of the entity before the operation (only the changed fields on update).
+	Before json.RawMessage
+	// After is the JSON of the entity after the operation (only the changed fields on update).
+	After json.RawMessage
+	// RequestId is the id of the request that performed the operation.
+	RequestId string
+	// CreatedAt is the datetime of the operation.
+	CreatedAt string
+}
+
+// Audit is the struct that represents an audit entry.
+type Audit struct {
+	// Id is the unique identifier of the audit entry.
+	Id int
+	// AuditAttributes is the attributes of the audit entry.
+	AuditAttributes
+}
+
+// requestIdKey is the context key of the request id recorded in the audit log.
+type requestIdKey struct{}
+
+// ContextWithRequestId returns a copy of the context that carries the request id recorded in the audit log.
+func ContextWithRequestId(ctx context.Context, requestId string) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, requestId)
+}
+
+// RequestIdFromContext returns the request id carried by the context, empty when there is none.
+func RequestIdFromContext(ctx context.Context) (requestId string) {
+	requestId, _ = ctx.Value(requestIdKey{}).(string)
+	return
+}
@@ -0,0 +1,14 @@
+package internal
+
+import "context"
+
+// RepositoryAudit is the interface that wraps the basic methods that an audit repository should implement.
+type RepositoryAudit interface {
+	// FindByEntity returns the audit entries of an entity, filtered by its id if it is not zero.
+	FindByEntity(entity string, entityId int) (a []Audit, err error)
+	// Save saves an audit entry.
+	Save(ctx context.Context, a *Audit) (err error)
+	// Transaction runs fn in a transaction shared by the repositories of the same database, through the context it receives.
+	// - the audit entries saved by fn are committed or rolled back together with the changes they record
+	Transaction(ctx context.Context, fn func(ctx context.Context) (err error)) (err error)
+}
@@ -0,0 +1,7 @@
+package internal
+
+// ServiceAudit is the interface that wraps the basic methods that an audit service should implement.
+type ServiceAudit interface {
+	// FindByEntity returns the audit entries of an entity, filtered by its id if it is not zero.
+	FindByEntity(entity string, entityId int) (a []Audit, err error)
+}
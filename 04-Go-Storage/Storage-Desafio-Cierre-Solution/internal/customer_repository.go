@@ -1,5 +1,7 @@
 package internal
 
+import "context"
+
 // RepositoryCustomer is the interface that wraps the basic methods that a customer repository should implement.
 type RepositoryCustomer interface {
 	// FindAll returns all customers saved in the database.
@@ -9,5 +11,5 @@ type RepositoryCustomer interface {
 	// FindInvoicesByCondition returns the total invoices by customer condition.
 	FindInvoicesByCondition() (c []CustomerInvoicesByCondition, err error)
 	// Save saves a customer into the database.
-	Save(c *Customer) (err error)
+	Save(ctx context.Context, c *Customer) (err error)
 }
\ No newline at end of file
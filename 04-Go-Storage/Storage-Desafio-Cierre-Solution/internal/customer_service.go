@@ -1,5 +1,7 @@
 package internal
 
+import "context"
+
 // ServiceCustomer is the interface that wraps the basic methods that a customer service should implement.
 type ServiceCustomer interface {
 	// FindAll returns all customers
@@ -9,5 +11,5 @@ type ServiceCustomer interface {
 	// FindInvoicesByCondition returns the total invoices by customer condition
 	FindInvoicesByCondition() (c []CustomerInvoicesByCondition, err error)
 	// Save saves a customer
-	Save(c *Customer) (err error)
+	Save(ctx context.Context, c *Customer) (err error)
 }
\ No newline at end of file
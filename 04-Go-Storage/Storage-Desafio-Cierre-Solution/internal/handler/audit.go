@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"app/internal"
+	"app/platform/web/response"
+)
+
+// NewAuditsDefault returns a new AuditsDefault
+func NewAuditsDefault(sv internal.ServiceAudit) *AuditsDefault {
+	return &AuditsDefault{sv: sv}
+}
+
+// AuditsDefault is a struct that returns the audit handlers
+type AuditsDefault struct {
+	// sv is the audit's service
+	sv internal.ServiceAudit
+}
+
+// AuditJSON is a struct that represents an audit entry in JSON format
+type AuditJSON struct {
+	Id        int             `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityId  int             `json:"entity_id"`
+	Operation string          `json:"operation"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	RequestId string          `json:"request_id"`
+	CreatedAt string          `json:"created_at"`
+}
+// GetByEntity returns the audit entries of an entity (?entity=&id=)
+func (h *AuditsDefault) GetByEntity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query: entity
+		entity := r.URL.Query().Get("entity")
+		if entity == "" {
+			response.Error(w, http.StatusBadRequest, "entity is required")
+			return
+		}
+		// - query: id (optional)
+		var id int
+		if r.URL.Query().Has("id") {
+			var err error
+			id, err = strconv.Atoi(r.URL.Query().Get("id"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid id")
+				return
+			}
+		}
+
+		// process
+		a, err := h.sv.FindByEntity(entity, id)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "error getting audit entries")
+			return
+		}
+
+		// response
+		// - serialize
+		aJSON := make([]AuditJSON, len(a))
+		for ix, v := range a {
+			aJSON[ix] = AuditJSON{
+				Id:        v.Id,
+				Entity:    v.Entity,
+				EntityId:  v.EntityId,
+				Operation: v.Operation,
+				Before:    v.Before,
+				After:     v.After,
+				RequestId: v.RequestId,
+				CreatedAt: v.CreatedAt,
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "audit entries found",
+			"data":    aJSON,
+		})
+	}
+}
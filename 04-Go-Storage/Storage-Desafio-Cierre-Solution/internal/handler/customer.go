@@ -155,7 +155,7 @@ func (h *CustomersDefault) Create() http.HandlerFunc {
 			},
 		}
 		// - save
-		err = h.sv.Save(&c)
+		err = h.sv.Save(r.Context(), &c)
 		if err != nil {
 			response.Error(w, http.StatusInternalServerError, "error saving customer")
 			return
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"app/internal"
 	"app/platform/web/request"
 	"app/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewInvoicesDefault returns a new InvoicesDefault
@@ -23,9 +27,27 @@ type InvoicesDefault struct {
 type InvoiceJSON struct {
 	Id         int     `json:"id"`
 	Datetime   string  `json:"datetime"`
+	Status     string  `json:"status"`
+	Subtotal   float64 `json:"subtotal"`
+	Discount   float64 `json:"discount"`
+	Tax        float64 `json:"tax"`
 	Total      float64 `json:"total"`
 	CustomerId int     `json:"customer_id"`
 }
+
+// newInvoiceJSON serializes an invoice into its JSON representation
+func newInvoiceJSON(i internal.Invoice) InvoiceJSON {
+	return InvoiceJSON{
+		Id:         i.Id,
+		Datetime:   i.Datetime,
+		Status:     i.Status,
+		Subtotal:   i.Subtotal,
+		Discount:   i.Discount,
+		Tax:        i.Tax,
+		Total:      i.Total,
+		CustomerId: i.CustomerId,
+	}
+}
 // GetAll returns all invoices
 func (h *InvoicesDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -33,7 +55,7 @@ func (h *InvoicesDefault) GetAll() http.HandlerFunc {
 		// ...
 
 		// process
-		i, err := h.sv.FindAll()
+		i, err := h.sv.FindAll(r.Context())
 		if err != nil {
 			response.Error(w, http.StatusInternalServerError, "error getting invoices")
 			return
@@ -43,12 +65,7 @@ func (h *InvoicesDefault) GetAll() http.HandlerFunc {
 		// - serialize
 		ivJSON := make([]InvoiceJSON, len(i))
 		for ix, v := range i {
-			ivJSON[ix] = InvoiceJSON{
-				Id:         v.Id,
-				Datetime:   v.Datetime,
-				Total:      v.Total,
-				CustomerId: v.CustomerId,
-			}
+			ivJSON[ix] = newInvoiceJSON(v)
 		}
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "invoices found",
@@ -57,6 +74,37 @@ func (h *InvoicesDefault) GetAll() http.HandlerFunc {
 	}
 }
 
+// GetById returns an invoice by its id
+func (h *InvoicesDefault) GetById() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - path parameter: id
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		i, err := h.sv.FindById(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrRepositoryInvoiceNotFound):
+				response.Error(w, http.StatusNotFound, "invoice not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "error getting invoice")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "invoice found",
+			"data":    newInvoiceJSON(i),
+		})
+	}
+}
+
 // RequestBodyInvoice is a struct that represents the request body for a invoice
 type RequestBodyInvoice struct {
 	Datetime   string  `json:"datetime"`
@@ -85,7 +133,7 @@ func (h *InvoicesDefault) Create() http.HandlerFunc {
 			},
 		}
 		// - save
-		err = h.sv.Save(&i)
+		err = h.sv.Save(r.Context(), &i)
 		if err != nil {
 			response.Error(w, http.StatusInternalServerError, "error saving invoice")
 			return
@@ -93,15 +141,9 @@ func (h *InvoicesDefault) Create() http.HandlerFunc {
 
 		// response
 		// - serialize
-		iv := InvoiceJSON{
-			Id:         i.Id,
-			Datetime:   i.Datetime,
-			Total:      i.Total,
-			CustomerId: i.CustomerId,
-		}
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "invoice created",
-			"data":    iv,
+			"data":    newInvoiceJSON(i),
 		})
 	}
 }
@@ -113,7 +155,7 @@ func (h *InvoicesDefault) UpdateAllTotal() http.HandlerFunc {
 		// ...
 
 		// process
-		err := h.sv.UpdateAllTotal()
+		err := h.sv.UpdateAllTotal(r.Context())
 		if err != nil {
 			response.Error(w, http.StatusInternalServerError, "error updating invoices total")
 			return
@@ -125,4 +167,50 @@ func (h *InvoicesDefault) UpdateAllTotal() http.HandlerFunc {
 			"data": nil,
 		})
 	}
-}
\ No newline at end of file
+}
+
+// RequestBodyInvoiceStatus is a struct that represents the request body to change the status of an invoice
+type RequestBodyInvoiceStatus struct {
+	Status string `json:"status"`
+}
+// UpdateStatus moves an invoice through the draft -> issued -> paid / void workflow
+func (h *InvoicesDefault) UpdateStatus() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - path parameter: id
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - body
+		var reqBody RequestBodyInvoiceStatus
+		err = request.JSON(r, &reqBody)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "error parsing request body")
+			return
+		}
+
+		// process
+		i, err := h.sv.UpdateStatus(r.Context(), id, reqBody.Status)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrRepositoryInvoiceNotFound):
+				response.Error(w, http.StatusNotFound, "invoice not found")
+			case errors.Is(err, internal.ErrServiceInvoiceInvalidStatus):
+				response.Error(w, http.StatusUnprocessableEntity, "invalid invoice status")
+			case errors.Is(err, internal.ErrServiceInvoiceStatusTransition):
+				response.Error(w, http.StatusConflict, "invoice status transition not allowed")
+			default:
+				response.Error(w, http.StatusInternalServerError, "error updating invoice status")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "invoice status updated",
+			"data":    newInvoiceJSON(i),
+		})
+	}
+}
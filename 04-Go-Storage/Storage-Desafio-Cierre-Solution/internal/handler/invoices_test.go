@@ -4,11 +4,14 @@ import (
 	"app/internal/handler"
 	"app/internal/repository"
 	"app/internal/service"
+	"context"
 	"database/sql"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/stretchr/testify/require"
 )
 
@@ -106,4 +109,189 @@ func TestInvoicesDefault_UpdateAllTotal(t *testing.T) {
 		require.Equal(t, expectedCode, response.Code)
 		require.JSONEq(t, expectedBody, response.Body.String())
 	})
-}
\ No newline at end of file
+}
+
+// TestInvoicesDefault_UpdateStatus tests the handler
+func TestInvoicesDefault_UpdateStatus(t *testing.T) {
+	t.Run("case 1: success - issues a draft invoice freezing its totals", func(t *testing.T) {
+		// arrange
+		// - database: connection
+		db, err := sql.Open("txdb", "")
+		require.NoError(t, err)
+		defer db.Close()
+		// - database: set-up
+		err = func (db *sql.DB) error {
+			// insert tax rates
+			_, err := db.Exec("INSERT INTO tax_rates (`category`, `rate`) VALUES ('food', 10);")
+			if err != nil {
+				return err
+			}
+			// insert customers
+			_, err = db.Exec("INSERT INTO customers (`id`, `first_name`, `last_name`, `condition`) VALUES (1, 'John', 'Doe', 1);")
+			if err != nil {
+				return err
+			}
+			// insert invoices
+			_, err = db.Exec("INSERT INTO invoices (`id`, `datetime`, `status`, `total`, `customer_id`) VALUES (1, '2024-01-01', 'draft', 0, 1);")
+			if err != nil {
+				return err
+			}
+			// insert products
+			_, err = db.Exec(
+				"INSERT INTO products (`id`, `price`, `category`) VALUES" +
+				"(1, 10, 'food')," +
+				"(2, 20, '');",
+			)
+			if err != nil {
+				return err
+			}
+			// insert sales
+			_, err = db.Exec(
+				"INSERT INTO sales (`id`, `invoice_id`, `product_id`, `quantity`, `discount`) VALUES" +
+				"(1, 1, 1, 2, 50)," +
+				"(2, 1, 2, 1, 0);",
+			)
+			if err != nil {
+				return err
+			}
+			return nil
+		}(db)
+		require.NoError(t, err)
+
+		// - repository: mysql
+		rp := repository.NewInvoicesMySQL(db)
+		// - service: default
+		sv := service.NewInvoicesDefault(rp)
+		// - handler
+		hd := handler.NewInvoicesDefault(sv)
+		hdFunc := hd.UpdateStatus()
+
+		// act
+		request := httptest.NewRequest(http.MethodPatch, "/invoices/1/status", strings.NewReader(`{"status":"issued"}`))
+		request.Header.Set("Content-Type", "application/json")
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, chiCtx))
+		response := httptest.NewRecorder()
+		hdFunc(response, request)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `
+			{
+				"message": "invoice status updated",
+				"data": {
+					"id": 1,
+					"datetime": "2024-01-01 00:00:00",
+					"status": "issued",
+					"subtotal": 40,
+					"discount": 10,
+					"tax": 1,
+					"total": 31,
+					"customer_id": 1
+				}
+			}
+		`
+		require.Equal(t, expectedCode, response.Code)
+		require.JSONEq(t, expectedBody, response.Body.String())
+	})
+
+	t.Run("case 2: error - paid invoices can not go back to draft", func(t *testing.T) {
+		// arrange
+		// - database: connection
+		db, err := sql.Open("txdb", "")
+		require.NoError(t, err)
+		defer db.Close()
+		// - database: set-up
+		_, err = db.Exec("INSERT INTO customers (`id`, `first_name`, `last_name`, `condition`) VALUES (1, 'John', 'Doe', 1);")
+		require.NoError(t, err)
+		_, err = db.Exec("INSERT INTO invoices (`id`, `datetime`, `status`, `total`, `customer_id`) VALUES (1, '2024-01-01', 'paid', 0, 1);")
+		require.NoError(t, err)
+
+		// - repository: mysql
+		rp := repository.NewInvoicesMySQL(db)
+		// - service: default
+		sv := service.NewInvoicesDefault(rp)
+		// - handler
+		hd := handler.NewInvoicesDefault(sv)
+		hdFunc := hd.UpdateStatus()
+
+		// act
+		request := httptest.NewRequest(http.MethodPatch, "/invoices/1/status", strings.NewReader(`{"status":"draft"}`))
+		request.Header.Set("Content-Type", "application/json")
+		chiCtx := chi.NewRouteContext()
+		chiCtx.URLParams.Add("id", "1")
+		request = request.WithContext(context.WithValue(request.Context(), chi.RouteCtxKey, chiCtx))
+		response := httptest.NewRecorder()
+		hdFunc(response, request)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"invoice status transition not allowed"}`
+		require.Equal(t, expectedCode, response.Code)
+		require.JSONEq(t, expectedBody, response.Body.String())
+	})
+}
+
+// TestSalesDefault_Create tests the handler adding sales to the invoices
+func TestSalesDefault_Create(t *testing.T) {
+	t.Run("case 1: error - sales can not be added to issued or paid invoices", func(t *testing.T) {
+		for _, status := range []string{"issued", "paid"} {
+			// arrange
+			// - database: connection
+			db, err := sql.Open("txdb", "")
+			require.NoError(t, err)
+			defer db.Close()
+			// - database: set-up
+			_, err = db.Exec("INSERT INTO customers (`id`, `first_name`, `last_name`, `condition`) VALUES (1, 'John', 'Doe', 1);")
+			require.NoError(t, err)
+			_, err = db.Exec("INSERT INTO invoices (`id`, `datetime`, `status`, `total`, `customer_id`) VALUES (1, '2024-01-01', ?, 0, 1);", status)
+			require.NoError(t, err)
+			_, err = db.Exec("INSERT INTO products (`id`, `price`) VALUES (1, 10);")
+			require.NoError(t, err)
+
+			// - repository: mysql
+			rp := repository.NewSalesMySQL(db)
+			// - service: default
+			sv := service.NewSalesDefault(rp)
+			// - handler
+			hd := handler.NewSalesDefault(sv)
+			hdFunc := hd.Create()
+
+			// act
+			request := httptest.NewRequest(http.MethodPost, "/sales", strings.NewReader(`{"quantity":1,"discount":0,"product_id":1,"invoice_id":1}`))
+			request.Header.Set("Content-Type", "application/json")
+			response := httptest.NewRecorder()
+			hdFunc(response, request)
+
+			// assert
+			expectedCode := http.StatusConflict
+			expectedBody := `{"status":"Conflict","message":"sales can only be added to draft invoices"}`
+			require.Equal(t, expectedCode, response.Code, status)
+			require.JSONEq(t, expectedBody, response.Body.String(), status)
+			var count int
+			err = db.QueryRow("SELECT COUNT(*) FROM sales").Scan(&count)
+			require.NoError(t, err)
+			require.Zero(t, count, status)
+		}
+	})
+
+	t.Run("case 2: error - the discount must be between 0 and 100", func(t *testing.T) {
+		// arrange
+		// - handler: the sale is rejected before reaching the repository
+		hd := handler.NewSalesDefault(service.NewSalesDefault(nil))
+		hdFunc := hd.Create()
+
+		// act
+		request := httptest.NewRequest(http.MethodPost, "/sales", strings.NewReader(`{"quantity":1,"discount":101,"product_id":1,"invoice_id":1}`))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		hdFunc(response, request)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"discount must be between 0 and 100"}`
+		require.Equal(t, expectedCode, response.Code)
+		require.JSONEq(t, expectedBody, response.Body.String())
+	})
+}
@@ -24,6 +24,7 @@ type ProductJSON struct {
 	Id          int     `json:"id"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	Category    string  `json:"category"`
 }
 // GetAll returns all products
 func (h *ProductsDefault) GetAll() http.HandlerFunc {
@@ -46,6 +47,7 @@ func (h *ProductsDefault) GetAll() http.HandlerFunc {
 				Id:          v.Id,
 				Description: v.Description,
 				Price:       v.Price,
+				Category:    v.Category,
 			}
 		}
 		response.JSON(w, http.StatusOK, map[string]any{
@@ -93,6 +95,7 @@ func (h *ProductsDefault) GetTopProductsByAmountSold() http.HandlerFunc {
 type RequestBodyProduct struct {
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	Category    string  `json:"category"`
 }
 // Create creates a new product
 func (h *ProductsDefault) Create() http.HandlerFunc {
@@ -112,10 +115,11 @@ func (h *ProductsDefault) Create() http.HandlerFunc {
 			ProductAttributes: internal.ProductAttributes{
 				Description: reqBody.Description,
 				Price:       reqBody.Price,
+				Category:    reqBody.Category,
 			},
 		}
 		// - save
-		err = h.sv.Save(&p)
+		err = h.sv.Save(r.Context(), &p)
 		if err != nil {
 			response.Error(w, http.StatusInternalServerError, "error creating product")
 			return
@@ -127,6 +131,7 @@ func (h *ProductsDefault) Create() http.HandlerFunc {
 			Id:          p.Id,
 			Description: p.Description,
 			Price:       p.Price,
+			Category:    p.Category,
 		}
 		response.JSON(w, http.StatusCreated, map[string]any{
 			"message": "product created",
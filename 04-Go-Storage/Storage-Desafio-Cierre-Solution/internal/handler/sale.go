@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 
 	"app/internal"
@@ -23,6 +24,7 @@ type SalesDefault struct {
 type SaleJSON struct {
 	Id int `json:"id"`
 	Quantity int `json:"quantity"`
+	Discount float64 `json:"discount"`
 	ProductId int `json:"product_id"`
 	InvoiceId int `json:"invoice_id"`
 }
@@ -47,6 +49,7 @@ func (h *SalesDefault) GetAll() http.HandlerFunc {
 			sJSON[ix] = SaleJSON{
 				Id:        v.Id,
 				Quantity: v.Quantity,
+				Discount: v.Discount,
 				ProductId:  v.ProductId,
 				InvoiceId: v.InvoiceId,
 			}
@@ -61,6 +64,7 @@ func (h *SalesDefault) GetAll() http.HandlerFunc {
 // RequestBodySale is a struct that represents the request body for a sale
 type RequestBodySale struct {
 	Quantity int `json:"quantity"`
+	Discount float64 `json:"discount"`
 	ProductId int `json:"product_id"`
 	InvoiceId int `json:"invoice_id"`
 }
@@ -81,14 +85,24 @@ func (h *SalesDefault) Create() http.HandlerFunc {
 		s := internal.Sale{
 			SaleAttributes: internal.SaleAttributes{
 				Quantity: reqBody.Quantity,
+				Discount: reqBody.Discount,
 				ProductId: reqBody.ProductId,
 				InvoiceId: reqBody.InvoiceId,
 			},
 		}
 		// - save
-		err = h.sv.Save(&s)
+		err = h.sv.Save(r.Context(), &s)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "error saving sale")
+			switch {
+			case errors.Is(err, internal.ErrServiceSaleInvalidDiscount):
+				response.Error(w, http.StatusUnprocessableEntity, "discount must be between 0 and 100")
+			case errors.Is(err, internal.ErrRepositorySaleInvoiceNotFound):
+				response.Error(w, http.StatusNotFound, "invoice not found")
+			case errors.Is(err, internal.ErrRepositorySaleInvoiceNotDraft):
+				response.Error(w, http.StatusConflict, "sales can only be added to draft invoices")
+			default:
+				response.Error(w, http.StatusInternalServerError, "error saving sale")
+			}
 			return
 		}
 
@@ -97,6 +111,7 @@ func (h *SalesDefault) Create() http.HandlerFunc {
 		sa := SaleJSON{
 			Id:        s.Id,
 			Quantity: s.Quantity,
+			Discount: s.Discount,
 			ProductId:  s.ProductId,
 			InvoiceId: s.InvoiceId,
 		}
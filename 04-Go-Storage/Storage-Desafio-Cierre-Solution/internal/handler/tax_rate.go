@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+
+	"app/internal"
+	"app/platform/web/response"
+)
+
+// NewTaxRatesDefault returns a new TaxRatesDefault
+func NewTaxRatesDefault(sv internal.ServiceTaxRate) *TaxRatesDefault {
+	return &TaxRatesDefault{sv: sv}
+}
+
+// TaxRatesDefault is a struct that returns the tax rate handlers
+type TaxRatesDefault struct {
+	// sv is the tax rate's service
+	sv internal.ServiceTaxRate
+}
+
+// TaxRateJSON is a struct that represents a tax rate in JSON format
+type TaxRateJSON struct {
+	Category string  `json:"category"`
+	Rate     float64 `json:"rate"`
+}
+
+// GetAll returns all tax rates
+func (h *TaxRatesDefault) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// ...
+
+		// process
+		t, err := h.sv.FindAll()
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "error getting tax rates")
+			return
+		}
+
+		// response
+		// - serialize
+		tJSON := make([]TaxRateJSON, len(t))
+		for ix, v := range t {
+			tJSON[ix] = TaxRateJSON{
+				Category: v.Category,
+				Rate:     v.Rate,
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "tax rates found",
+			"data":    tJSON,
+		})
+	}
+}
@@ -1,9 +1,45 @@
 package internal
 
+const (
+	// InvoiceStatusDraft is the status of an invoice that still accepts sales.
+	InvoiceStatusDraft = "draft"
+	// InvoiceStatusIssued is the status of an invoice that was sent to the customer.
+	InvoiceStatusIssued = "issued"
+	// InvoiceStatusPaid is the status of an invoice that was paid by the customer.
+	InvoiceStatusPaid = "paid"
+	// InvoiceStatusVoid is the status of an invoice that was cancelled.
+	InvoiceStatusVoid = "void"
+)
+
+// invoiceStatusTransitions is the set of allowed transitions between invoice statuses.
+var invoiceStatusTransitions = map[string][]string{
+	InvoiceStatusDraft:  {InvoiceStatusIssued, InvoiceStatusVoid},
+	InvoiceStatusIssued: {InvoiceStatusPaid, InvoiceStatusVoid},
+}
+
+// InvoiceStatusTransitionAllowed returns true if an invoice can go from one status to the other.
+func InvoiceStatusTransitionAllowed(from, to string) (ok bool) {
+	for _, v := range invoiceStatusTransitions[from] {
+		if v == to {
+			ok = true
+			return
+		}
+	}
+	return
+}
+
 // InvoiceAttributes is the struct that represents the attributes of an invoice.
 type InvoiceAttributes struct {
 	// Datetime is the datetime of the invoice.
 	Datetime string
+	// Status is the status of the invoice (draft, issued, paid or void).
+	Status string
+	// Subtotal is the sum of the sales of the invoice before discounts and taxes.
+	Subtotal float64
+	// Discount is the sum of the discounts applied to the sales of the invoice.
+	Discount float64
+	// Tax is the sum of the taxes applied to the sales of the invoice.
+	Tax float64
 	// Total is the total of the invoice.
 	Total float64
 	// CustomerId is the customer id of the invoice.
@@ -16,4 +52,4 @@ type Invoice struct {
 	Id int
 	// InvoiceAttributes is the attributes of the invoice.
 	InvoiceAttributes
-}
\ No newline at end of file
+}
@@ -1,11 +1,27 @@
 package internal
 
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrRepositoryInvoiceNotFound is returned when an invoice is not found.
+	ErrRepositoryInvoiceNotFound = errors.New("repository: invoice not found")
+	// ErrRepositoryInvoiceStatusTransition is returned when an invoice can not go from its current status to the requested one.
+	ErrRepositoryInvoiceStatusTransition = errors.New("repository: invoice status transition not allowed")
+)
+
 // RepositoryInvoice is the interface that wraps the basic methods that an invoice repository should implement.
 type RepositoryInvoice interface {
 	// FindAll returns all invoices
-	FindAll() (i []Invoice, err error)
+	FindAll(ctx context.Context) (i []Invoice, err error)
+	// FindById returns an invoice by its id
+	FindById(ctx context.Context, id int) (i Invoice, err error)
 	// Save saves an invoice
-	Save(i *Invoice) (err error)
-	// UpdateAllTotal updates all invoices total
-	UpdateAllTotal() (err error)
-}
\ No newline at end of file
+	Save(ctx context.Context, i *Invoice) (err error)
+	// UpdateAllTotal updates the total of all draft invoices
+	UpdateAllTotal(ctx context.Context) (err error)
+	// UpdateStatus moves an invoice to a new status, freezing its totals when issued
+	UpdateStatus(ctx context.Context, id int, status string) (err error)
+}
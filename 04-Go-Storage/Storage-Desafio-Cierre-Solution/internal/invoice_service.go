@@ -1,11 +1,27 @@
 package internal
 
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrServiceInvoiceInvalidStatus is returned when the status of an invoice is unknown.
+	ErrServiceInvoiceInvalidStatus = errors.New("service: invalid invoice status")
+	// ErrServiceInvoiceStatusTransition is returned when an invoice can not go to the requested status.
+	ErrServiceInvoiceStatusTransition = errors.New("service: invoice status transition not allowed")
+)
+
 // ServiceInvoice is the interface that wraps the basic methods that an invoice service should implement.
 type ServiceInvoice interface {
 	// FindAll returns all invoices
-	FindAll() (i []Invoice, err error)
+	FindAll(ctx context.Context) (i []Invoice, err error)
+	// FindById returns an invoice by its id
+	FindById(ctx context.Context, id int) (i Invoice, err error)
 	// Save saves an invoice
-	Save(i *Invoice) (err error)
-	// UpdateAllTotal updates all invoices total
-	UpdateAllTotal() (err error)
-}
\ No newline at end of file
+	Save(ctx context.Context, i *Invoice) (err error)
+	// UpdateAllTotal updates the total of all draft invoices
+	UpdateAllTotal(ctx context.Context) (err error)
+	// UpdateStatus moves an invoice to a new status, freezing its totals when issued
+	UpdateStatus(ctx context.Context, id int, status string) (i Invoice, err error)
+}
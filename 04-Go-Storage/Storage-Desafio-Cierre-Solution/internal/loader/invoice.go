@@ -40,6 +40,7 @@ func (l *InvoicesJSON) Load() (i []internal.Invoice, err error) {
 			Id: v.Id,
 			InvoiceAttributes: internal.InvoiceAttributes{
 				Datetime:   v.Datetime,
+				Status:     internal.InvoiceStatusDraft,
 				Total:		v.Total,
 				CustomerId: v.CustomerId,
 			},
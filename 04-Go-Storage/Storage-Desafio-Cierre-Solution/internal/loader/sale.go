@@ -21,6 +21,7 @@ type SalesJSON struct {
 type SaleJSON struct {
 	Id         int     `json:"id"`
 	Quantity   int     `json:"quantity"`
+	Discount   float64 `json:"discount"`
 	ProductId  int     `json:"product_id"`
 	InvoiceId  int     `json:"invoice_id"`
 }
@@ -40,6 +41,7 @@ func (l *SalesJSON) Load() (s []internal.Sale, err error) {
 			Id: v.Id,
 			SaleAttributes: internal.SaleAttributes{
 				Quantity:  v.Quantity,
+				Discount:  v.Discount,
 				ProductId: v.ProductId,
 				InvoiceId: v.InvoiceId,
 			},
@@ -1,6 +1,8 @@
 package migrator
 
 import (
+	"context"
+
 	"app/internal"
 )
 
@@ -31,7 +33,7 @@ func (m *MigratorCustomerToDatabase) Migrate() (err error) {
 
 	// save each customer
 	for _, v := range c {
-		err = m.rp.Save(&v)
+		err = m.rp.Save(context.Background(), &v)
 		if err != nil {
 			return
 		}
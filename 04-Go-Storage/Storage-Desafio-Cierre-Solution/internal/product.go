@@ -6,6 +6,8 @@ type ProductAttributes struct {
 	Description string
 	// Price is the price of the product.
 	Price float64
+	// Category is the category of the product, used to look up its tax rate.
+	Category string
 }
 
 // Product is the struct that represents a product.
@@ -1,5 +1,7 @@
 package internal
 
+import "context"
+
 // RepositoryProduct is the interface that wraps the basic methods that a product repository must have.
 type RepositoryProduct interface {
 	// FindAll returns all products saved in the database.
@@ -7,5 +9,5 @@ type RepositoryProduct interface {
 	// FindTopProductsByAmountSold returns the top sold products.
 	FindTopProductsByAmountSold(limit int) (p []ProductAmountSold, err error)
 	// Save saves a product into the database.
-	Save(p *Product) (err error)
+	Save(ctx context.Context, p *Product) (err error)
 }
\ No newline at end of file
@@ -1,5 +1,7 @@
 package internal
 
+import "context"
+
 // ServiceProduct is the interface that wraps the basic Product methods.
 type ServiceProduct interface {
 	// FindAll returns all products.
@@ -7,5 +9,5 @@ type ServiceProduct interface {
 	// FindTopProductsByAmountSold returns the top products by amount sold.
 	FindTopProductsByAmountSold(limit int) (p []ProductAmountSold, err error)
 	// Save saves a product.
-	Save(p *Product) (err error)
+	Save(ctx context.Context, p *Product) (err error)
 }
\ No newline at end of file
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"app/internal"
+)
+
+// auditor records the write operations of a decorated repository in the audit log.
+// - the entries are saved in the transaction of the context, together with the change they record
+// - the request id is taken from the context
+type auditor struct {
+	// rp is the repository where the audit entries are saved.
+	rp internal.RepositoryAudit
+	// entity is the name of the audited entity.
+	entity string
+}
+
+// create records the creation of an entity.
+func (a auditor) create(ctx context.Context, id int, entity any) (err error) {
+	after, err := json.Marshal(entity)
+	if err != nil {
+		return
+	}
+	err = a.record(ctx, id, internal.AuditOperationCreate, nil, after)
+	return
+}
+
+// update records the fields that changed between two versions of an entity, nothing if none did.
+func (a auditor) update(ctx context.Context, id int, prev, next any) (err error) {
+	// decode both versions as field -> value
+	var prevFields, nextFields map[string]json.RawMessage
+	b, err := json.Marshal(prev)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &prevFields)
+	if err != nil {
+		return
+	}
+	b, err = json.Marshal(next)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &nextFields)
+	if err != nil {
+		return
+	}
+
+	// keep the changed fields
+	prevChanged := make(map[string]json.RawMessage)
+	nextChanged := make(map[string]json.RawMessage)
+	for k, v := range nextFields {
+		if !bytes.Equal(prevFields[k], v) {
+			prevChanged[k] = prevFields[k]
+			nextChanged[k] = v
+		}
+	}
+	if len(nextChanged) == 0 {
+		return
+	}
+	before, err := json.Marshal(prevChanged)
+	if err != nil {
+		return
+	}
+	after, err := json.Marshal(nextChanged)
+	if err != nil {
+		return
+	}
+
+	err = a.record(ctx, id, internal.AuditOperationUpdate, before, after)
+	return
+}
+
+// record saves an audit entry.
+func (a auditor) record(ctx context.Context, id int, operation string, before, after json.RawMessage) (err error) {
+	au := internal.Audit{
+		AuditAttributes: internal.AuditAttributes{
+			Entity:    a.entity,
+			EntityId:  id,
+			Operation: operation,
+			Before:    before,
+			After:     after,
+			RequestId: internal.RequestIdFromContext(ctx),
+		},
+	}
+	err = a.rp.Save(ctx, &au)
+	return
+}
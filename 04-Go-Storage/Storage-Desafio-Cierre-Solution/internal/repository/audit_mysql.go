@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"app/internal"
+)
+
+// NewAuditsMySQL creates new mysql repository for audit entity.
+func NewAuditsMySQL(db *sql.DB) *AuditsMySQL {
+	return &AuditsMySQL{db}
+}
+
+// AuditsMySQL is the MySQL repository implementation for audit entity.
+type AuditsMySQL struct {
+	// db is the database connection.
+	db *sql.DB
+}
+
+// FindByEntity returns the audit entries of an entity from the database, filtered by its id if it is not zero.
+func (r *AuditsMySQL) FindByEntity(entity string, entityId int) (a []internal.Audit, err error) {
+	// build the query
+	query := "SELECT `id`, `entity`, `entity_id`, `operation`, `before`, `after`, `request_id`, `created_at` FROM audit_log WHERE `entity` = ?"
+	args := []any{entity}
+	if entityId != 0 {
+		query += " AND `entity_id` = ?"
+		args = append(args, entityId)
+	}
+	query += " ORDER BY `id`"
+
+	// execute the query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		var au internal.Audit
+		var before, after []byte
+		// scan the row into the audit entry
+		err := rows.Scan(&au.Id, &au.Entity, &au.EntityId, &au.Operation, &before, &after, &au.RequestId, &au.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		au.Before = before
+		au.After = after
+		// append the audit entry to the slice
+		a = append(a, au)
+	}
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Save saves the audit entry into the database, in the transaction of the context if there is one.
+func (r *AuditsMySQL) Save(ctx context.Context, a *internal.Audit) (err error) {
+	// null json columns when there is nothing to record
+	var before, after any
+	if len((*a).Before) > 0 {
+		before = string((*a).Before)
+	}
+	if len((*a).After) > 0 {
+		after = string((*a).After)
+	}
+
+	// execute the query
+	res, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"INSERT INTO audit_log (`entity`, `entity_id`, `operation`, `before`, `after`, `request_id`) VALUES (?, ?, ?, ?, ?, ?)",
+		(*a).Entity, (*a).EntityId, (*a).Operation, before, after, (*a).RequestId,
+	)
+	if err != nil {
+		return err
+	}
+
+	// get the last inserted id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	// set the id
+	(*a).Id = int(id)
+
+	return
+}
+
+// Transaction runs fn in a transaction, shared through the context by the repositories of the same database.
+func (r *AuditsMySQL) Transaction(ctx context.Context, fn func(ctx context.Context) (err error)) (err error) {
+	err = transaction(ctx, r.db, fn)
+	return
+}
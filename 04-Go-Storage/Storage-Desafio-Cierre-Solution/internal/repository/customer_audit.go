@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"app/internal"
+)
+
+// NewCustomersAudit creates new repository for customer entity that records its writes in the audit log.
+func NewCustomersAudit(rp internal.RepositoryCustomer, rpAudit internal.RepositoryAudit) *CustomersAudit {
+	return &CustomersAudit{
+		RepositoryCustomer: rp,
+		au:                 auditor{rp: rpAudit, entity: "customers"},
+	}
+}
+
+// CustomersAudit is a decorator of the customer repository that records its writes in the audit log.
+type CustomersAudit struct {
+	// RepositoryCustomer is the decorated repository.
+	internal.RepositoryCustomer
+	// au records the audit entries.
+	au auditor
+}
+
+// Save saves the customer and records its creation, in the same transaction.
+func (r *CustomersAudit) Save(ctx context.Context, c *internal.Customer) (err error) {
+	err = r.au.rp.Transaction(ctx, func(ctx context.Context) (err error) {
+		err = r.RepositoryCustomer.Save(ctx, c)
+		if err != nil {
+			return
+		}
+
+		err = r.au.create(ctx, (*c).Id, *c)
+		return
+	})
+	return
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 
 	"app/internal"
@@ -111,10 +112,11 @@ func (r *CustomersMySQL) FindInvoicesByCondition() (c []internal.CustomerInvoice
 	return
 }
 
-// Save saves the customer into the database.
-func (r *CustomersMySQL) Save(c *internal.Customer) (err error) {
+// Save saves the customer into the database, in the transaction of the context if there is one.
+func (r *CustomersMySQL) Save(ctx context.Context, c *internal.Customer) (err error) {
 	// execute query
-	res, err := r.db.Exec(
+	res, err := conn(ctx, r.db).ExecContext(
+		ctx,
 		"INSERT INTO customers (`first_name`, `last_name`, `condition`) VALUES (?, ?, ?)",
 		(*c).FirstName, (*c).LastName, (*c).Condition,
 	)
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+
+	"app/internal"
+)
+
+// NewInvoicesAudit creates new repository for invoice entity that records its writes in the audit log.
+func NewInvoicesAudit(rp internal.RepositoryInvoice, rpAudit internal.RepositoryAudit) *InvoicesAudit {
+	return &InvoicesAudit{
+		RepositoryInvoice: rp,
+		au:                auditor{rp: rpAudit, entity: "invoices"},
+	}
+}
+
+// InvoicesAudit is a decorator of the invoice repository that records its writes in the audit log.
+type InvoicesAudit struct {
+	// RepositoryInvoice is the decorated repository.
+	internal.RepositoryInvoice
+	// au records the audit entries.
+	au auditor
+}
+
+// Save saves the invoice and records its creation, in the same transaction.
+func (r *InvoicesAudit) Save(ctx context.Context, i *internal.Invoice) (err error) {
+	err = r.au.rp.Transaction(ctx, func(ctx context.Context) (err error) {
+		err = r.RepositoryInvoice.Save(ctx, i)
+		if err != nil {
+			return
+		}
+
+		err = r.au.create(ctx, (*i).Id, *i)
+		return
+	})
+	return
+}
+
+// UpdateAllTotal updates the total of all draft invoices and records the change of each invoice, in the same transaction.
+func (r *InvoicesAudit) UpdateAllTotal(ctx context.Context) (err error) {
+	err = r.au.rp.Transaction(ctx, func(ctx context.Context) (err error) {
+		// get the invoices before the update
+		prev, err := r.RepositoryInvoice.FindAll(ctx)
+		if err != nil {
+			return
+		}
+
+		// update the invoices
+		err = r.RepositoryInvoice.UpdateAllTotal(ctx)
+		if err != nil {
+			return
+		}
+
+		// get the invoices after the update
+		next, err := r.RepositoryInvoice.FindAll(ctx)
+		if err != nil {
+			return
+		}
+
+		// record the invoices whose totals changed
+		prevById := make(map[int]internal.Invoice, len(prev))
+		for _, v := range prev {
+			prevById[v.Id] = v
+		}
+		for _, v := range next {
+			err = r.au.update(ctx, v.Id, prevById[v.Id], v)
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// UpdateStatus updates the status of an invoice and records the change, in the same transaction.
+func (r *InvoicesAudit) UpdateStatus(ctx context.Context, id int, status string) (err error) {
+	err = r.au.rp.Transaction(ctx, func(ctx context.Context) (err error) {
+		// get the invoice before the update
+		prev, err := r.RepositoryInvoice.FindById(ctx, id)
+		if err != nil {
+			return
+		}
+
+		// update the invoice
+		err = r.RepositoryInvoice.UpdateStatus(ctx, id, status)
+		if err != nil {
+			return
+		}
+
+		// get the invoice after the update
+		next, err := r.RepositoryInvoice.FindById(ctx, id)
+		if err != nil {
+			return
+		}
+
+		err = r.au.update(ctx, id, prev, next)
+		return
+	})
+	return
+}
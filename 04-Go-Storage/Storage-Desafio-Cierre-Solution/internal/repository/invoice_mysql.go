@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 
 	"app/internal"
 )
@@ -18,9 +20,13 @@ type InvoicesMySQL struct {
 }
 
 // FindAll returns all invoices from the database.
-func (r *InvoicesMySQL) FindAll() (i []internal.Invoice, err error) {
+// - in the transaction of the context they are locked until it ends
+func (r *InvoicesMySQL) FindAll(ctx context.Context) (i []internal.Invoice, err error) {
 	// execute the query
-	rows, err := r.db.Query("SELECT `id`, `datetime`, `total`, `customer_id` FROM invoices")
+	rows, err := conn(ctx, r.db).QueryContext(
+		ctx,
+		forUpdate(ctx, "SELECT `id`, `datetime`, `status`, `subtotal`, `discount`, `tax`, `total`, `customer_id` FROM invoices"),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +36,7 @@ func (r *InvoicesMySQL) FindAll() (i []internal.Invoice, err error) {
 	for rows.Next() {
 		var iv internal.Invoice
 		// scan the row into the invoice
-		err := rows.Scan(&iv.Id, &iv.Datetime, &iv.Total, &iv.CustomerId)
+		err := rows.Scan(&iv.Id, &iv.Datetime, &iv.Status, &iv.Subtotal, &iv.Discount, &iv.Tax, &iv.Total, &iv.CustomerId)
 		if err != nil {
 			return nil, err
 		}
@@ -45,12 +51,35 @@ func (r *InvoicesMySQL) FindAll() (i []internal.Invoice, err error) {
 	return
 }
 
-// Save saves the invoice into the database.
-func (r *InvoicesMySQL) Save(i *internal.Invoice) (err error) {
+// FindById returns an invoice by its id from the database.
+// - in the transaction of the context it is locked until it ends
+func (r *InvoicesMySQL) FindById(ctx context.Context, id int) (i internal.Invoice, err error) {
 	// execute the query
-	res, err := r.db.Exec(
-		"INSERT INTO invoices (`datetime`, `total`, `customer_id`) VALUES (?, ?, ?)",
-		(*i).Datetime, (*i).Total, (*i).CustomerId,
+	row := conn(ctx, r.db).QueryRowContext(
+		ctx,
+		forUpdate(ctx, "SELECT `id`, `datetime`, `status`, `subtotal`, `discount`, `tax`, `total`, `customer_id` FROM invoices WHERE `id` = ?"),
+		id,
+	)
+
+	// scan the row into the invoice
+	err = row.Scan(&i.Id, &i.Datetime, &i.Status, &i.Subtotal, &i.Discount, &i.Tax, &i.Total, &i.CustomerId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			err = internal.ErrRepositoryInvoiceNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// Save saves the invoice into the database, in the transaction of the context if there is one.
+func (r *InvoicesMySQL) Save(ctx context.Context, i *internal.Invoice) (err error) {
+	// execute the query
+	res, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"INSERT INTO invoices (`datetime`, `status`, `subtotal`, `discount`, `tax`, `total`, `customer_id`) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		(*i).Datetime, (*i).Status, (*i).Subtotal, (*i).Discount, (*i).Tax, (*i).Total, (*i).CustomerId,
 	)
 	if err != nil {
 		return err
@@ -68,13 +97,79 @@ func (r *InvoicesMySQL) Save(i *internal.Invoice) (err error) {
 	return
 }
 
-// UpdateAllTotal updates all invoices total
-func (r *InvoicesMySQL) UpdateAllTotal() (err error) {
+// queryInvoiceTotals calculates the totals of the invoices from their sales.
+// - subtotal: quantity * price
+// - discount: subtotal of each sale * sale discount percentage
+// - tax: discounted subtotal of each sale * tax rate percentage of the product category
+const queryInvoiceTotals = "SELECT s.`invoice_id`, " +
+	"SUM(s.`quantity` * p.`price`) AS `subtotal`, " +
+	"SUM(s.`quantity` * p.`price` * s.`discount` / 100) AS `discount`, " +
+	"SUM(s.`quantity` * p.`price` * (1 - s.`discount` / 100) * COALESCE(t.`rate`, 0) / 100) AS `tax` " +
+	"FROM `sales` s INNER JOIN `products` p ON s.`product_id` = p.`id` " +
+	"LEFT JOIN `tax_rates` t ON p.`category` = t.`category` "
+
+// querySetTotals sets the totals of the invoices joined with their calculated totals.
+const querySetTotals = "SET i.`subtotal` = COALESCE(x.`subtotal`, 0), " +
+	"i.`discount` = COALESCE(x.`discount`, 0), " +
+	"i.`tax` = COALESCE(x.`tax`, 0), " +
+	"i.`total` = COALESCE(x.`subtotal` - x.`discount` + x.`tax`, 0) "
+
+// queryUpdateTotal recalculates the totals of the draft invoices from their sales.
+const queryUpdateTotal = "UPDATE `invoices` as i LEFT JOIN (" +
+	queryInvoiceTotals +
+	"GROUP BY s.`invoice_id`" +
+	") as x ON x.`invoice_id` = i.`id` " +
+	querySetTotals +
+	"WHERE i.`status` = 'draft'"
+
+// queryUpdateTotalById recalculates the totals of a draft invoice from its sales, only reading the sales of that invoice.
+const queryUpdateTotalById = "UPDATE `invoices` as i LEFT JOIN (" +
+	queryInvoiceTotals +
+	"WHERE s.`invoice_id` = ? GROUP BY s.`invoice_id`" +
+	") as x ON x.`invoice_id` = i.`id` " +
+	querySetTotals +
+	"WHERE i.`status` = 'draft' AND i.`id` = ?"
+
+// UpdateAllTotal updates the total of all draft invoices, in the transaction of the context if there is one.
+func (r *InvoicesMySQL) UpdateAllTotal(ctx context.Context) (err error) {
 	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `invoices` as i SET i.`total` = " +
-		"(SELECT SUM(s.`quantity` * p.`price`) FROM `sales` s INNER JOIN `products` p ON s.`product_id` = p.`id` " +
-		"WHERE s.`invoice_id` = i.`id`)",
-	)
+	_, err = conn(ctx, r.db).ExecContext(ctx, queryUpdateTotal)
+	return
+}
+
+// UpdateStatus moves an invoice to a new status, if the transition from its current status is allowed.
+// - the invoice is locked while it changes, so concurrent transitions and new sales wait for it
+// - draft -> issued: the totals are recalculated one last time, in the same transaction
+// - it runs in the transaction of the context if there is one, otherwise in its own
+func (r *InvoicesMySQL) UpdateStatus(ctx context.Context, id int, status string) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		tx := conn(ctx, r.db)
+
+		// lock the invoice and check its status
+		var current string
+		err = tx.QueryRowContext(ctx, "SELECT `status` FROM invoices WHERE `id` = ? FOR UPDATE", id).Scan(&current)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = internal.ErrRepositoryInvoiceNotFound
+			}
+			return
+		}
+		if !internal.InvoiceStatusTransitionAllowed(current, status) {
+			err = internal.ErrRepositoryInvoiceStatusTransition
+			return
+		}
+
+		// freeze the totals before issuing
+		if status == internal.InvoiceStatusIssued {
+			_, err = tx.ExecContext(ctx, queryUpdateTotalById, id, id)
+			if err != nil {
+				return
+			}
+		}
+
+		// update the status
+		_, err = tx.ExecContext(ctx, "UPDATE `invoices` SET `status` = ? WHERE `id` = ?", status, id)
+		return
+	})
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"app/internal"
+)
+
+// NewProductsAudit creates new repository for product entity that records its writes in the audit log.
+func NewProductsAudit(rp internal.RepositoryProduct, rpAudit internal.RepositoryAudit) *ProductsAudit {
+	return &ProductsAudit{
+		RepositoryProduct: rp,
+		au:                auditor{rp: rpAudit, entity: "products"},
+	}
+}
+
+// ProductsAudit is a decorator of the product repository that records its writes in the audit log.
+type ProductsAudit struct {
+	// RepositoryProduct is the decorated repository.
+	internal.RepositoryProduct
+	// au records the audit entries.
+	au auditor
+}
+
+// Save saves the product and records its creation, in the same transaction.
+func (r *ProductsAudit) Save(ctx context.Context, p *internal.Product) (err error) {
+	err = r.au.rp.Transaction(ctx, func(ctx context.Context) (err error) {
+		err = r.RepositoryProduct.Save(ctx, p)
+		if err != nil {
+			return
+		}
+
+		err = r.au.create(ctx, (*p).Id, *p)
+		return
+	})
+	return
+}
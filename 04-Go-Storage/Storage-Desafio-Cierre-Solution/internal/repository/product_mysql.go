@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 
 	"app/internal"
@@ -20,7 +21,7 @@ type ProductsMySQL struct {
 // FindAll returns all products from the database.
 func (r *ProductsMySQL) FindAll() (p []internal.Product, err error) {
 	// execute the query
-	rows, err := r.db.Query("SELECT `id`, `description`, `price` FROM products")
+	rows, err := r.db.Query("SELECT `id`, `description`, `price`, `category` FROM products")
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +31,7 @@ func (r *ProductsMySQL) FindAll() (p []internal.Product, err error) {
 	for rows.Next() {
 		var pr internal.Product
 		// scan the row into the product
-		err := rows.Scan(&pr.Id, &pr.Description, &pr.Price)
+		err := rows.Scan(&pr.Id, &pr.Description, &pr.Price, &pr.Category)
 		if err != nil {
 			return nil, err
 		}
@@ -78,12 +79,13 @@ func (r *ProductsMySQL) FindTopProductsByAmountSold(limit int) (p []internal.Pro
 	return
 }
 
-// Save saves the product into the database.
-func (r *ProductsMySQL) Save(p *internal.Product) (err error) {
+// Save saves the product into the database, in the transaction of the context if there is one.
+func (r *ProductsMySQL) Save(ctx context.Context, p *internal.Product) (err error) {
 	// execute the query
-	res, err := r.db.Exec(
-		"INSERT INTO products (`description`, `price`) VALUES (?, ?)",
-		(*p).Description, (*p).Price,
+	res, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"INSERT INTO products (`description`, `price`, `category`) VALUES (?, ?, ?)",
+		(*p).Description, (*p).Price, (*p).Category,
 	)
 	if err != nil {
 		return err
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"app/internal"
+)
+
+// NewSalesAudit creates new repository for sale entity that records its writes in the audit log.
+func NewSalesAudit(rp internal.RepositorySale, rpAudit internal.RepositoryAudit) *SalesAudit {
+	return &SalesAudit{
+		RepositorySale: rp,
+		au:             auditor{rp: rpAudit, entity: "sales"},
+	}
+}
+
+// SalesAudit is a decorator of the sale repository that records its writes in the audit log.
+type SalesAudit struct {
+	// RepositorySale is the decorated repository.
+	internal.RepositorySale
+	// au records the audit entries.
+	au auditor
+}
+
+// Save saves the sale and records its creation, in the same transaction.
+func (r *SalesAudit) Save(ctx context.Context, s *internal.Sale) (err error) {
+	err = r.au.rp.Transaction(ctx, func(ctx context.Context) (err error) {
+		err = r.RepositorySale.Save(ctx, s)
+		if err != nil {
+			return
+		}
+
+		err = r.au.create(ctx, (*s).Id, *s)
+		return
+	})
+	return
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 
 	"app/internal"
 )
@@ -20,7 +22,7 @@ type SalesMySQL struct {
 // FindAll returns all sales from the database.
 func (r *SalesMySQL) FindAll() (s []internal.Sale, err error) {
 	// execute the query
-	rows, err := r.db.Query("SELECT `id`, `quantity`, `product_id`, `invoice_id` FROM sales")
+	rows, err := r.db.Query("SELECT `id`, `quantity`, `discount`, `product_id`, `invoice_id` FROM sales")
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +32,7 @@ func (r *SalesMySQL) FindAll() (s []internal.Sale, err error) {
 	for rows.Next() {
 		var sa internal.Sale
 		// scan the row into the sale
-		err := rows.Scan(&sa.Id, &sa.Quantity, &sa.ProductId, &sa.InvoiceId)
+		err := rows.Scan(&sa.Id, &sa.Quantity, &sa.Discount, &sa.ProductId, &sa.InvoiceId)
 		if err != nil {
 			return nil, err
 		}
@@ -45,25 +47,45 @@ func (r *SalesMySQL) FindAll() (s []internal.Sale, err error) {
 	return
 }
 
-// Save saves the sale into the database.
-func (r *SalesMySQL) Save(s *internal.Sale) (err error) {
-	// execute the query
-	res, err := r.db.Exec(
-		"INSERT INTO sales (`quantity`, `product_id`, `invoice_id`) VALUES (?, ?, ?)",
-		(*s).Quantity, (*s).ProductId, (*s).InvoiceId,
-	)
-	if err != nil {
-		return err
-	}
+// Save saves the sale into the database, only if its invoice is still a draft.
+// - it runs in the transaction of the context if there is one, otherwise in its own
+func (r *SalesMySQL) Save(ctx context.Context, s *internal.Sale) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		tx := conn(ctx, r.db)
 
-	// get the last inserted id
-	id, err := res.LastInsertId()
-	if err != nil {
-		return err
-	}
+		// lock the invoice and check its status
+		var status string
+		err = tx.QueryRowContext(ctx, "SELECT `status` FROM invoices WHERE `id` = ? FOR UPDATE", (*s).InvoiceId).Scan(&status)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				err = internal.ErrRepositorySaleInvoiceNotFound
+			}
+			return
+		}
+		if status != internal.InvoiceStatusDraft {
+			err = internal.ErrRepositorySaleInvoiceNotDraft
+			return
+		}
 
-	// set the id
-	(*s).Id = int(id)
+		// execute the query
+		res, err := tx.ExecContext(
+			ctx,
+			"INSERT INTO sales (`quantity`, `discount`, `product_id`, `invoice_id`) VALUES (?, ?, ?, ?)",
+			(*s).Quantity, (*s).Discount, (*s).ProductId, (*s).InvoiceId,
+		)
+		if err != nil {
+			return
+		}
 
+		// get the last inserted id
+		id, err := res.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id
+		(*s).Id = int(id)
+		return
+	})
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"database/sql"
+
+	"app/internal"
+)
+
+// NewTaxRatesMySQL creates new mysql repository for tax rate entity.
+func NewTaxRatesMySQL(db *sql.DB) *TaxRatesMySQL {
+	return &TaxRatesMySQL{db}
+}
+
+// TaxRatesMySQL is the MySQL repository implementation for tax rate entity.
+type TaxRatesMySQL struct {
+	// db is the database connection.
+	db *sql.DB
+}
+
+// FindAll returns all tax rates from the database, sorted by category.
+func (r *TaxRatesMySQL) FindAll() (t []internal.TaxRate, err error) {
+	// execute the query
+	rows, err := r.db.Query("SELECT `category`, `rate` FROM tax_rates ORDER BY `category`")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		var tr internal.TaxRate
+		// scan the row into the tax rate
+		err := rows.Scan(&tr.Category, &tr.Rate)
+		if err != nil {
+			return nil, err
+		}
+		// append the tax rate to the slice
+		t = append(t, tr)
+	}
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// txKey is the context key of the transaction shared by the mysql repositories.
+type txKey struct{}
+
+// executor is the set of methods shared by a database connection and a transaction.
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// conn returns the transaction carried by the context, or the database connection when there is none.
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// transaction runs fn in the transaction carried by the context.
+// - when there is none, a new one is begun and committed if fn succeeds, or rolled back otherwise
+func transaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context) (err error)) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		err = fn(ctx)
+		return
+	}
+
+	// begin the transaction
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	// run fn
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	// commit the transaction
+	err = tx.Commit()
+	return
+}
+
+// forUpdate locks the rows read by the query when it runs in a transaction, so they do not change until it ends.
+func forUpdate(ctx context.Context, query string) string {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return query + " FOR UPDATE"
+	}
+	return query
+}
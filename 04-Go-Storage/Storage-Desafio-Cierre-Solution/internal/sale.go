@@ -4,6 +4,8 @@ package internal
 type SaleAttributes struct {
 	// Quantity is the quantity of the sale.
 	Quantity int
+	// Discount is the discount percentage (0 to 100) applied to the sale.
+	Discount float64
 	// ProductId is the product id of the sale.
 	ProductId int
 	// InvoiceId is the invoice id of the sale.
@@ -16,4 +18,4 @@ type Sale struct {
 	Id int
 	// SaleAttributes is the attributes of the sale.
 	SaleAttributes
-}
\ No newline at end of file
+}
@@ -1,9 +1,21 @@
 package internal
 
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrRepositorySaleInvoiceNotFound is returned when the invoice of a sale is not found.
+	ErrRepositorySaleInvoiceNotFound = errors.New("repository: sale invoice not found")
+	// ErrRepositorySaleInvoiceNotDraft is returned when a sale is added to an invoice that is not a draft.
+	ErrRepositorySaleInvoiceNotDraft = errors.New("repository: sale invoice is not a draft")
+)
+
 // RepositorySale is the interface that wraps the basic Sale methods.
 type RepositorySale interface {
 	// FindAll returns all sales.
 	FindAll() (s []Sale, err error)
 	// Save saves a sale.
-	Save(s *Sale) (err error)
-}
\ No newline at end of file
+	Save(ctx context.Context, s *Sale) (err error)
+}
@@ -1,9 +1,19 @@
 package internal
 
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrServiceSaleInvalidDiscount is returned when the discount of a sale is not between 0 and 100.
+	ErrServiceSaleInvalidDiscount = errors.New("service: sale discount must be between 0 and 100")
+)
+
 // ServiceSale is the interface that wraps the basic ServiceSale methods.
 type ServiceSale interface {
 	// FindAll returns all sales.
 	FindAll() (s []Sale, err error)
-	// Save saves a sale.
-	Save(s *Sale) (err error)
+	// Save saves a sale, its discount must be between 0 and 100.
+	Save(ctx context.Context, s *Sale) (err error)
 }
\ No newline at end of file
@@ -0,0 +1,20 @@
+package service
+
+import "app/internal"
+
+// NewAuditsDefault creates new default service for audit entity.
+func NewAuditsDefault(rp internal.RepositoryAudit) *AuditsDefault {
+	return &AuditsDefault{rp}
+}
+
+// AuditsDefault is the default service implementation for audit entity.
+type AuditsDefault struct {
+	// rp is the repository for audit entity.
+	rp internal.RepositoryAudit
+}
+
+// FindByEntity returns the audit entries of an entity.
+func (s *AuditsDefault) FindByEntity(entity string, entityId int) (a []internal.Audit, err error) {
+	a, err = s.rp.FindByEntity(entity, entityId)
+	return
+}
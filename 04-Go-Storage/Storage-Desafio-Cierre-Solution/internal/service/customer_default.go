@@ -1,6 +1,10 @@
 package service
 
-import "app/internal"
+import (
+	"context"
+
+	"app/internal"
+)
 
 // NewCustomersDefault creates new default service for customer entity.
 func NewCustomersDefault(rp internal.RepositoryCustomer) *CustomersDefault {
@@ -32,7 +36,7 @@ func (s *CustomersDefault) FindInvoicesByCondition() (c []internal.CustomerInvoi
 }
 
 // Save saves the customer.
-func (s *CustomersDefault) Save(c *internal.Customer) (err error) {
-	err = s.rp.Save(c)
+func (s *CustomersDefault) Save(ctx context.Context, c *internal.Customer) (err error) {
+	err = s.rp.Save(ctx, c)
 	return
 }
\ No newline at end of file
@@ -1,6 +1,11 @@
 package service
 
-import "app/internal"
+import (
+	"context"
+	"errors"
+
+	"app/internal"
+)
 
 // NewInvoicesDefault creates new default service for invoice entity.
 func NewInvoicesDefault(rp internal.RepositoryInvoice) *InvoicesDefault {
@@ -14,19 +19,52 @@ type InvoicesDefault struct {
 }
 
 // FindAll returns all invoices.
-func (s *InvoicesDefault) FindAll() (i []internal.Invoice, err error) {
-	i, err = s.rp.FindAll()
+func (s *InvoicesDefault) FindAll(ctx context.Context) (i []internal.Invoice, err error) {
+	i, err = s.rp.FindAll(ctx)
+	return
+}
+
+// FindById returns an invoice by its id.
+func (s *InvoicesDefault) FindById(ctx context.Context, id int) (i internal.Invoice, err error) {
+	i, err = s.rp.FindById(ctx, id)
+	return
+}
+
+// Save saves the invoice. New invoices always start as a draft.
+func (s *InvoicesDefault) Save(ctx context.Context, i *internal.Invoice) (err error) {
+	(*i).Status = internal.InvoiceStatusDraft
+	err = s.rp.Save(ctx, i)
 	return
 }
 
-// Save saves the invoice.
-func (s *InvoicesDefault) Save(i *internal.Invoice) (err error) {
-	err = s.rp.Save(i)
+// UpdateAllTotal updates the total of all draft invoices.
+func (s *InvoicesDefault) UpdateAllTotal(ctx context.Context) (err error) {
+	err = s.rp.UpdateAllTotal(ctx)
 	return
 }
 
-// UpdateAllTotal updates all invoices total.
-func (s *InvoicesDefault) UpdateAllTotal() (err error) {
-	err = s.rp.UpdateAllTotal()
+// UpdateStatus moves an invoice to a new status.
+// - draft -> issued: the totals are recalculated one last time and kept from then on
+// - draft -> void, issued -> paid, issued -> void
+func (s *InvoicesDefault) UpdateStatus(ctx context.Context, id int, status string) (i internal.Invoice, err error) {
+	// validate the status
+	switch status {
+	case internal.InvoiceStatusDraft, internal.InvoiceStatusIssued, internal.InvoiceStatusPaid, internal.InvoiceStatusVoid:
+	default:
+		err = internal.ErrServiceInvoiceInvalidStatus
+		return
+	}
+
+	// update the status
+	// - the repository checks the transition against the current status while the invoice is locked
+	err = s.rp.UpdateStatus(ctx, id, status)
+	if err != nil {
+		if errors.Is(err, internal.ErrRepositoryInvoiceStatusTransition) {
+			err = internal.ErrServiceInvoiceStatusTransition
+		}
+		return
+	}
+
+	i, err = s.rp.FindById(ctx, id)
 	return
-}
\ No newline at end of file
+}
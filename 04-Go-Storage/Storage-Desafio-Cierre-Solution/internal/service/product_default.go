@@ -1,6 +1,10 @@
 package service
 
-import "app/internal"
+import (
+	"context"
+
+	"app/internal"
+)
 
 // NewProductsDefault creates new default service for product entity.
 func NewProductsDefault(rp internal.RepositoryProduct) *ProducstDefault {
@@ -26,7 +30,7 @@ func (s *ProducstDefault) FindTopProductsByAmountSold(limit int) (p []internal.P
 }
 
 // Save saves the product.
-func (s *ProducstDefault) Save(p *internal.Product) (err error) {
-	err = s.rp.Save(p)
+func (s *ProducstDefault) Save(ctx context.Context, p *internal.Product) (err error) {
+	err = s.rp.Save(ctx, p)
 	return
 }
\ No newline at end of file
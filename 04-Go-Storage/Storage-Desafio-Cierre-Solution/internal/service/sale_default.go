@@ -1,6 +1,10 @@
 package service
 
-import "app/internal"
+import (
+	"context"
+
+	"app/internal"
+)
 
 // NewSalesDefault creates new default service for sale entity.
 func NewSalesDefault(rp internal.RepositorySale) *SalesDefault {
@@ -20,7 +24,13 @@ func (sv *SalesDefault) FindAll() (s []internal.Sale, err error) {
 }
 
 // Save saves the sale.
-func (sv *SalesDefault) Save(s *internal.Sale) (err error) {
-	err = sv.rp.Save(s)
+// - the discount is a percentage, so it must be between 0 and 100
+func (sv *SalesDefault) Save(ctx context.Context, s *internal.Sale) (err error) {
+	if (*s).Discount < 0 || (*s).Discount > 100 {
+		err = internal.ErrServiceSaleInvalidDiscount
+		return
+	}
+
+	err = sv.rp.Save(ctx, s)
 	return
 }
\ No newline at end of file
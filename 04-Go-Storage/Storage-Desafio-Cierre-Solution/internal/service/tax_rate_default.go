@@ -0,0 +1,20 @@
+package service
+
+import "app/internal"
+
+// NewTaxRatesDefault creates new default service for tax rate entity.
+func NewTaxRatesDefault(rp internal.RepositoryTaxRate) *TaxRatesDefault {
+	return &TaxRatesDefault{rp}
+}
+
+// TaxRatesDefault is the default service implementation for tax rate entity.
+type TaxRatesDefault struct {
+	// rp is the repository for tax rate entity.
+	rp internal.RepositoryTaxRate
+}
+
+// FindAll returns all tax rates.
+func (s *TaxRatesDefault) FindAll() (t []internal.TaxRate, err error) {
+	t, err = s.rp.FindAll()
+	return
+}
@@ -0,0 +1,9 @@
+package internal
+
+// TaxRate is the struct that represents the tax rate of a product category.
+type TaxRate struct {
+	// Category is the product category the rate applies to.
+	Category string
+	// Rate is the tax percentage applied to the products of the category.
+	Rate float64
+}
@@ -0,0 +1,7 @@
+package internal
+
+// RepositoryTaxRate is the interface that wraps the basic methods that a tax rate repository must have.
+type RepositoryTaxRate interface {
+	// FindAll returns all tax rates saved in the database.
+	FindAll() (t []TaxRate, err error)
+}
@@ -0,0 +1,7 @@
+package internal
+
+// ServiceTaxRate is the interface that wraps the basic TaxRate methods.
+type ServiceTaxRate interface {
+	// FindAll returns all tax rates.
+	FindAll() (t []TaxRate, err error)
+}
@@ -0,0 +1,16 @@
+-- DDL
+DROP DATABASE IF EXISTS `products_test_db`;
+
+CREATE DATABASE `products_test_db`;
+
+USE `products_test_db`;
+
+CREATE TABLE `products` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `description` varchar(255) NOT NULL,
+    `price` decimal(10,2) NOT NULL,
+    `seller_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_products_seller_id` (`seller_id`),
+    KEY `idx_products_price` (`price`)
+);
@@ -3,7 +3,9 @@ module app
 go 1.21
 
 require (
+	github.com/DATA-DOG/go-txdb v0.1.7
 	github.com/go-chi/chi/v5 v5.0.10
+	github.com/go-sql-driver/mysql v1.7.1
 	github.com/stretchr/testify v1.8.4
 )
 
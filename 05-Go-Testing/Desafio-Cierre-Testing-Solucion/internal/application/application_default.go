@@ -1,18 +1,24 @@
 package application
 
 import (
+	"app/internal"
 	"app/internal/handler"
 	"app/internal/repository"
+	"database/sql"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-sql-driver/mysql"
 )
 
 // ConfigApplicationDefault is the configuration of the application.
 type ConfigApplicationDefault struct {
 	// Addr is the address of the application.
 	Addr string
+	// Db is the configuration of the database.
+	// - if nil, the products are kept in memory
+	Db *mysql.Config
 }
 
 // NewApplicationDefault returns a new ApplicationDefault.
@@ -26,11 +32,15 @@ func NewApplicationDefault(cfg *ConfigApplicationDefault) *ApplicationDefault {
 		if cfg.Addr != "" {
 			defaultCfg.Addr = cfg.Addr
 		}
+		if cfg.Db != nil {
+			defaultCfg.Db = cfg.Db
+		}
 	}
 
 	return &ApplicationDefault{
-		rt:   defaultRt,
-		addr: defaultCfg.Addr,
+		rt:    defaultRt,
+		addr:  defaultCfg.Addr,
+		cfgDb: defaultCfg.Db,
 	}
 }
 
@@ -40,11 +50,18 @@ type ApplicationDefault struct {
 	rt *chi.Mux
 	// addr is the address of the application.
 	addr string
+	// cfgDb is the configuration of the database.
+	cfgDb *mysql.Config
+	// db is the database connection.
+	db *sql.DB
 }
 
 // TearDown tears down the application.
 // - should be used as a defer function
 func (a *ApplicationDefault) TearDown() (err error) {
+	if a.db != nil {
+		err = a.db.Close()
+	}
 	return
 }
 
@@ -52,7 +69,22 @@ func (a *ApplicationDefault) TearDown() (err error) {
 func (a *ApplicationDefault) SetUp() (err error) {
 	// dependencies
 	// - repository
-	rpProduct := repository.NewProductsMap(nil)
+	var rpProduct internal.RepositoryProducts
+	if a.cfgDb == nil {
+		rpProduct = repository.NewProductsMap(nil)
+	} else {
+		// - db: init
+		a.db, err = sql.Open("mysql", a.cfgDb.FormatDSN())
+		if err != nil {
+			return
+		}
+		// - db: ping
+		err = a.db.Ping()
+		if err != nil {
+			return
+		}
+		rpProduct = repository.NewProductsMySQL(a.db)
+	}
 	// - handler
 	hdProduct := handler.NewProductsDefault(rpProduct)
 
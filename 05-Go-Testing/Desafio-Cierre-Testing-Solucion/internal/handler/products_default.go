@@ -3,7 +3,9 @@ package handler
 import (
 	"app/internal"
 	"app/platform/web/response"
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -29,38 +31,41 @@ type ProductJSON struct {
 }
 
 // GetProducts returns a list of products that match the query.
+// - query params: id, seller_id, description (contained), price_min, price_max,
+// sort (id, description, price or seller_id), order (asc or desc) and limit, all of them optional
 func (h *ProductsDefault) GetProducts() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// request
 		// - query
-		var query internal.ProductQuery
-		if r.URL.Query().Has("id") {
-			var err error
-			query.Id, err = strconv.Atoi(r.URL.Query().Get("id"))
-			if err != nil {
-				response.Error(w, http.StatusBadRequest, "invalid id")
-				return
-			}
+		query, err := parseProductQuery(r.URL.Query())
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, err.Error())
+			return
 		}
 
 		// process
 		// - search products
 		p, err := h.rp.SearchProducts(query)
 		if err != nil {
-			response.Error(w, http.StatusInternalServerError, "internal error")
+			switch {
+			case errors.Is(err, internal.ErrProductQueryInvalid):
+				response.Error(w, http.StatusBadRequest, err.Error())
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal error")
+			}
 			return
 		}
 
 		// response
-		// - serialize products
-		data := make(map[int]ProductJSON)
-		for k, v := range p {
-			data[k] = ProductJSON{
+		// - serialize products, in the order of the query
+		data := make([]ProductJSON, 0, len(p))
+		for _, v := range p {
+			data = append(data, ProductJSON{
 				Id:          v.Id,
 				Description: v.Description,
 				Price:       v.Price,
 				SellerId:    v.SellerId,
-			}
+			})
 		}
 		response.JSON(w, http.StatusOK, map[string]any{
 			"message": "success",
@@ -68,3 +73,56 @@ func (h *ProductsDefault) GetProducts() http.HandlerFunc {
 		})
 	}
 }
+
+// parseProductQuery parses the query params of a search of products.
+func parseProductQuery(values url.Values) (query internal.ProductQuery, err error) {
+	// integers
+	integers := []struct {
+		param string
+		ptr   *int
+	}{{"id", &query.Id}, {"seller_id", &query.SellerId}, {"limit", &query.Limit}}
+	for _, i := range integers {
+		if !values.Has(i.param) {
+			continue
+		}
+		*i.ptr, err = strconv.Atoi(values.Get(i.param))
+		if err != nil {
+			err = errors.New("invalid " + i.param)
+			return
+		}
+	}
+
+	// prices, set even if zero
+	prices := []struct {
+		param string
+		ptr   **float64
+	}{{"price_min", &query.PriceMin}, {"price_max", &query.PriceMax}}
+	for _, p := range prices {
+		if !values.Has(p.param) {
+			continue
+		}
+		var price float64
+		price, err = strconv.ParseFloat(values.Get(p.param), 64)
+		if err != nil {
+			err = errors.New("invalid " + p.param)
+			return
+		}
+		*p.ptr = &price
+	}
+
+	// description and sort
+	query.Description = values.Get("description")
+	query.Sort = internal.ProductSort(values.Get("sort"))
+	switch values.Get("order") {
+	case "", "asc":
+	case "desc":
+		query.Desc = true
+	default:
+		err = errors.New("invalid order")
+		return
+	}
+
+	// validate the query
+	err = query.Validate()
+	return
+}
@@ -8,11 +8,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
+// price returns a price bound of a query.
+func price(v float64) *float64 {
+	return &v
+}
+
 // Tests for HandlerProduct GetProducts method.
 func TestHandlerProduct_GetProducts(t *testing.T) {
 	type arrange struct {
@@ -42,9 +48,9 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			arrange: arrange{
 				rpMock: func() *repository.ProductsMock {
 					rpMock := repository.NewProductsMock()
-					rpMock.FuncSearchProducts = func(query internal.ProductQuery) (map[int]internal.Product, error) {
-						return map[int]internal.Product{
-							1: {
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
+						return []internal.Product{
+							{
 								Id: 		1,
 								ProductAttributes: internal.ProductAttributes{
 									Description: "product 1",
@@ -52,7 +58,7 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 									SellerId:    1,
 								},
 							},
-							2: {
+							{
 								Id: 		2,
 								ProductAttributes: internal.ProductAttributes{
 									Description: "product 2",
@@ -75,10 +81,10 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			output: output{
 				code: http.StatusOK,
 				body: `
-					{"message": "success", "data": {
-						"1": {"id": 1, "description": "product 1", "price": 1.1, "seller_id": 1},
-						"2": {"id": 2, "description": "product 2", "price": 2.2, "seller_id": 2}
-					}}
+					{"message": "success", "data": [
+						{"id": 1, "description": "product 1", "price": 1.1, "seller_id": 1},
+						{"id": 2, "description": "product 2", "price": 2.2, "seller_id": 2}
+					]}
 				`,
 				headers: http.Header{
 					"Content-Type": []string{"application/json"},
@@ -91,8 +97,8 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			arrange: arrange{
 				rpMock: func() *repository.ProductsMock {
 					rpMock := repository.NewProductsMock()
-					rpMock.FuncSearchProducts = func(query internal.ProductQuery) (map[int]internal.Product, error) {
-						return map[int]internal.Product{}, nil
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
+						return []internal.Product{}, nil
 					}
 					return rpMock
 				},
@@ -107,7 +113,7 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			output: output{
 				code: http.StatusOK,
 				body: `
-					{"message": "success", "data": {}}
+					{"message": "success", "data": []}
 				`,
 				headers: http.Header{
 					"Content-Type": []string{"application/json"},
@@ -120,9 +126,9 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			arrange: arrange{
 				rpMock: func() *repository.ProductsMock {
 					rpMock := repository.NewProductsMock()
-					rpMock.FuncSearchProducts = func(query internal.ProductQuery) (map[int]internal.Product, error) {
-						return map[int]internal.Product{
-							1: {
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
+						return []internal.Product{
+							{
 								Id: 		1,
 								ProductAttributes: internal.ProductAttributes{
 									Description: "product 1",
@@ -148,9 +154,9 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			output: output{
 				code: http.StatusOK,
 				body: `
-					{"message": "success", "data": {
-						"1": {"id": 1, "description": "product 1", "price": 1.1, "seller_id": 1}
-					}}
+					{"message": "success", "data": [
+						{"id": 1, "description": "product 1", "price": 1.1, "seller_id": 1}
+					]}
 				`,
 				headers: http.Header{
 					"Content-Type": []string{"application/json"},
@@ -163,8 +169,8 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			arrange: arrange{
 				rpMock: func() *repository.ProductsMock {
 					rpMock := repository.NewProductsMock()
-					rpMock.FuncSearchProducts = func(query internal.ProductQuery) (map[int]internal.Product, error) {
-						return map[int]internal.Product{}, nil
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
+						return []internal.Product{}, nil
 					}
 					return rpMock
 				},
@@ -182,7 +188,7 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			output: output{
 				code: http.StatusOK,
 				body: `
-					{"message": "success", "data": {}}
+					{"message": "success", "data": []}
 				`,
 				headers: http.Header{
 					"Content-Type": []string{"application/json"},
@@ -195,7 +201,7 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 			arrange: arrange{
 				rpMock: func() *repository.ProductsMock {
 					rpMock := repository.NewProductsMock()
-					rpMock.FuncSearchProducts = func(query internal.ProductQuery) (map[int]internal.Product, error) {
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
 						return nil, errors.New("repository error")
 					}
 					return rpMock
@@ -220,6 +226,198 @@ func TestHandlerProduct_GetProducts(t *testing.T) {
 				},
 			},
 		},
+		// case 6: success search products - full query
+		{
+			name: "success search products - full query",
+			arrange: arrange{
+				rpMock: func() *repository.ProductsMock {
+					rpMock := repository.NewProductsMock()
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
+						expectedQuery := internal.ProductQuery{
+							Id:          1,
+							SellerId:    2,
+							Description: "product",
+							PriceMin:    price(1.5),
+							PriceMax:    price(10),
+							Sort:        internal.ProductSortPrice,
+							Desc:        true,
+							Limit:       5,
+						}
+						if !reflect.DeepEqual(query, expectedQuery) {
+							return nil, errors.New("unexpected query")
+						}
+						return []internal.Product{
+							{
+								Id: 1,
+								ProductAttributes: internal.ProductAttributes{
+									Description: "product 1",
+									Price:       2.2,
+									SellerId:    2,
+								},
+							},
+						}, nil
+					}
+					return rpMock
+				},
+			},
+			input: input{
+				request: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?id=1&seller_id=2&description=product&price_min=1.5&price_max=10&sort=price&order=desc&limit=5", nil)
+					return r
+				},
+				response: httptest.NewRecorder(),
+			},
+			output: output{
+				code: http.StatusOK,
+				body: `
+					{"message": "success", "data": [
+						{"id": 1, "description": "product 1", "price": 2.2, "seller_id": 2}
+					]}
+				`,
+				headers: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
+		// case 7: error search products - invalid limit
+		{
+			name: "error search products - invalid limit",
+			arrange: arrange{
+				rpMock: func() *repository.ProductsMock {
+					return repository.NewProductsMock()
+				},
+			},
+			input: input{
+				request: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?limit=ten", nil)
+					return r
+				},
+				response: httptest.NewRecorder(),
+			},
+			output: output{
+				code: http.StatusBadRequest,
+				body: fmt.Sprintf(
+					`{"status": "%s", "message": "%s"}`,
+					http.StatusText(http.StatusBadRequest),
+					"invalid limit",
+				),
+				headers: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
+		// case 8: error search products - invalid order
+		{
+			name: "error search products - invalid order",
+			arrange: arrange{
+				rpMock: func() *repository.ProductsMock {
+					return repository.NewProductsMock()
+				},
+			},
+			input: input{
+				request: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?sort=price&order=up", nil)
+					return r
+				},
+				response: httptest.NewRecorder(),
+			},
+			output: output{
+				code: http.StatusBadRequest,
+				body: fmt.Sprintf(
+					`{"status": "%s", "message": "%s"}`,
+					http.StatusText(http.StatusBadRequest),
+					"invalid order",
+				),
+				headers: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
+		// case 9: error search products - price range
+		{
+			name: "error search products - price range",
+			arrange: arrange{
+				rpMock: func() *repository.ProductsMock {
+					return repository.NewProductsMock()
+				},
+			},
+			input: input{
+				request: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?price_min=10&price_max=5", nil)
+					return r
+				},
+				response: httptest.NewRecorder(),
+			},
+			output: output{
+				code: http.StatusBadRequest,
+				body: fmt.Sprintf(
+					`{"status": "%s", "message": "%s"}`,
+					http.StatusText(http.StatusBadRequest),
+					"product query: invalid: price_min must not be greater than price_max",
+				),
+				headers: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
+		// case 10: success search products - price max zero
+		{
+			name: "success search products - price max zero",
+			arrange: arrange{
+				rpMock: func() *repository.ProductsMock {
+					rpMock := repository.NewProductsMock()
+					rpMock.FuncSearchProducts = func(query internal.ProductQuery) ([]internal.Product, error) {
+						expectedQuery := internal.ProductQuery{PriceMax: price(0)}
+						if !reflect.DeepEqual(query, expectedQuery) {
+							return nil, errors.New("unexpected query")
+						}
+						return []internal.Product{}, nil
+					}
+					return rpMock
+				},
+			},
+			input: input{
+				request: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?price_max=0", nil)
+					return r
+				},
+				response: httptest.NewRecorder(),
+			},
+			output: output{
+				code: http.StatusOK,
+				body: `{"message": "success", "data": []}`,
+				headers: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
+		// case 11: error search products - price min greater than price max zero
+		{
+			name: "error search products - price min greater than price max zero",
+			arrange: arrange{
+				rpMock: func() *repository.ProductsMock {
+					return repository.NewProductsMock()
+				},
+			},
+			input: input{
+				request: func() *http.Request {
+					r := httptest.NewRequest("GET", "/?price_min=10&price_max=0", nil)
+					return r
+				},
+				response: httptest.NewRecorder(),
+			},
+			output: output{
+				code: http.StatusBadRequest,
+				body: fmt.Sprintf(
+					`{"status": "%s", "message": "%s"}`,
+					http.StatusText(http.StatusBadRequest),
+					"product query: invalid: price_min must not be greater than price_max",
+				),
+				headers: http.Header{
+					"Content-Type": []string{"application/json"},
+				},
+			},
+		},
 	}
 
 	// run test cases
@@ -1,5 +1,15 @@
 package internal
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrProductQueryInvalid is returned when a query has invalid values.
+	ErrProductQueryInvalid = errors.New("product query: invalid")
+)
+
 // ProductAttributes is an struct that represents a product.
 type ProductAttributes struct {
 	// Description is the description of the product.
@@ -18,8 +28,60 @@ type Product struct {
 	ProductAttributes
 }
 
+// ProductSort is the field the products of a query are sorted by.
+type ProductSort string
+
+const (
+	// ProductSortId sorts the products by id.
+	ProductSortId ProductSort = "id"
+	// ProductSortDescription sorts the products by description.
+	ProductSortDescription ProductSort = "description"
+	// ProductSortPrice sorts the products by price.
+	ProductSortPrice ProductSort = "price"
+	// ProductSortSellerId sorts the products by seller id.
+	ProductSortSellerId ProductSort = "seller_id"
+)
+
 // ProductQuery is an struct that represents a query to the storage.
+// - the zero value of each field means it is not set, prices are not set if nil so a zero price is a bound
 type ProductQuery struct {
 	// Id is the unique identifier of the product.
-	Id	   	int
+	Id int
+	// SellerId is the id of the seller of the product.
+	SellerId int
+	// Description is a text the description of the product contains, case insensitive.
+	Description string
+	// PriceMin is the minimum price of the product, included.
+	PriceMin *float64
+	// PriceMax is the maximum price of the product, included.
+	PriceMax *float64
+	// Sort is the field the products are sorted by, by id if not set.
+	// - products with the same value are sorted by id, in the same order
+	Sort ProductSort
+	// Desc sorts the products in descending order.
+	Desc bool
+	// Limit is the maximum number of products, applied after sorting.
+	Limit int
+}
+
+// Validate validates the query.
+func (q ProductQuery) Validate() (err error) {
+	switch {
+	case q.Id < 0:
+		return fmt.Errorf("%w: id must not be negative", ErrProductQueryInvalid)
+	case q.SellerId < 0:
+		return fmt.Errorf("%w: seller_id must not be negative", ErrProductQueryInvalid)
+	case (q.PriceMin != nil && *q.PriceMin < 0) || (q.PriceMax != nil && *q.PriceMax < 0):
+		return fmt.Errorf("%w: prices must not be negative", ErrProductQueryInvalid)
+	case q.PriceMin != nil && q.PriceMax != nil && *q.PriceMin > *q.PriceMax:
+		return fmt.Errorf("%w: price_min must not be greater than price_max", ErrProductQueryInvalid)
+	case q.Limit < 0:
+		return fmt.Errorf("%w: limit must not be negative", ErrProductQueryInvalid)
+	}
+	switch q.Sort {
+	case "", ProductSortId, ProductSortDescription, ProductSortPrice, ProductSortSellerId:
+	default:
+		return fmt.Errorf("%w: unknown sort %s", ErrProductQueryInvalid, q.Sort)
+	}
+	return
 }
\ No newline at end of file
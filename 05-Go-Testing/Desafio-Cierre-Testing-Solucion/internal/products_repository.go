@@ -1,7 +1,14 @@
 package internal
 
+import "errors"
+
+var (
+	// ErrRepositoryProductNotFound is returned when a product does not exist.
+	ErrRepositoryProductNotFound = errors.New("repository: product not found")
+)
+
 // RepositoryProducts is an interface that represents a repository.
 type RepositoryProducts interface {
-	// SearchProducts returns a list of products that match the query.
-	SearchProducts(query ProductQuery) (p map[int]Product, err error)
+	// SearchProducts returns a list of products that match the query, in the order of the query.
+	SearchProducts(query ProductQuery) (p []Product, err error)
 }
\ No newline at end of file
@@ -0,0 +1,175 @@
+package repository_test
+
+import (
+	"app/internal"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// conformanceProducts are the products every repository is arranged with by conformanceSearchProducts.
+var conformanceProducts = []internal.Product{
+	{Id: 1, ProductAttributes: internal.ProductAttributes{Description: "Laptop Pro", Price: 1500, SellerId: 1}},
+	{Id: 2, ProductAttributes: internal.ProductAttributes{Description: "laptop sleeve", Price: 25.5, SellerId: 2}},
+	{Id: 3, ProductAttributes: internal.ProductAttributes{Description: "Phone", Price: 800, SellerId: 1}},
+	{Id: 4, ProductAttributes: internal.ProductAttributes{Description: "Phone case 100%", Price: 15, SellerId: 3}},
+	{Id: 5, ProductAttributes: internal.ProductAttributes{Description: "Desk", Price: 300, SellerId: 2}},
+	{Id: 6, ProductAttributes: internal.ProductAttributes{Description: "Monitor", Price: 300, SellerId: 3}},
+}
+
+// price returns a price bound of a query.
+func price(v float64) *float64 {
+	return &v
+}
+
+// conformanceSearchProducts checks a repository searches the products as the query describes.
+// - newRepository returns the repository to test with the given products stored
+func conformanceSearchProducts(t *testing.T, newRepository func(t *testing.T, products []internal.Product) internal.RepositoryProducts) {
+	type input struct {
+		query internal.ProductQuery
+	}
+	type output struct {
+		ids []int
+		err error
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	testCases := []testCase{
+		// success
+		{
+			name:   "success - query not set - return all products by id",
+			input:  input{query: internal.ProductQuery{}},
+			output: output{ids: []int{1, 2, 3, 4, 5, 6}},
+		},
+		{
+			name:   "success - id",
+			input:  input{query: internal.ProductQuery{Id: 3}},
+			output: output{ids: []int{3}},
+		},
+		{
+			name:   "success - id that does not exist - return empty list",
+			input:  input{query: internal.ProductQuery{Id: 7}},
+			output: output{ids: []int{}},
+		},
+		{
+			name:   "success - seller id",
+			input:  input{query: internal.ProductQuery{SellerId: 1}},
+			output: output{ids: []int{1, 3}},
+		},
+		{
+			name:   "success - description contains, case insensitive",
+			input:  input{query: internal.ProductQuery{Description: "LAPTOP"}},
+			output: output{ids: []int{1, 2}},
+		},
+		{
+			name:   "success - description contains, wildcards matched literally",
+			input:  input{query: internal.ProductQuery{Description: "0%"}},
+			output: output{ids: []int{4}},
+		},
+		{
+			name:   "success - description contains, underscore matched literally",
+			input:  input{query: internal.ProductQuery{Description: "_a"}},
+			output: output{ids: []int{}},
+		},
+		{
+			name:   "success - price range, bounds included",
+			input:  input{query: internal.ProductQuery{PriceMin: price(300), PriceMax: price(800)}},
+			output: output{ids: []int{3, 5, 6}},
+		},
+		{
+			name:   "success - price min",
+			input:  input{query: internal.ProductQuery{PriceMin: price(800)}},
+			output: output{ids: []int{1, 3}},
+		},
+		{
+			name:   "success - price min zero, bounds included",
+			input:  input{query: internal.ProductQuery{PriceMin: price(0), PriceMax: price(15)}},
+			output: output{ids: []int{4}},
+		},
+		{
+			name:   "success - price max zero is a bound - return empty list",
+			input:  input{query: internal.ProductQuery{PriceMax: price(0)}},
+			output: output{ids: []int{}},
+		},
+		{
+			name:   "success - sort by price, ties by id",
+			input:  input{query: internal.ProductQuery{Sort: internal.ProductSortPrice}},
+			output: output{ids: []int{4, 2, 5, 6, 3, 1}},
+		},
+		{
+			name:   "success - sort by price descending, ties by id descending",
+			input:  input{query: internal.ProductQuery{Sort: internal.ProductSortPrice, Desc: true}},
+			output: output{ids: []int{1, 3, 6, 5, 2, 4}},
+		},
+		{
+			name:   "success - sort by description, case insensitive",
+			input:  input{query: internal.ProductQuery{Sort: internal.ProductSortDescription}},
+			output: output{ids: []int{5, 1, 2, 6, 3, 4}},
+		},
+		{
+			name:   "success - sort by seller id descending",
+			input:  input{query: internal.ProductQuery{Sort: internal.ProductSortSellerId, Desc: true}},
+			output: output{ids: []int{6, 4, 5, 2, 3, 1}},
+		},
+		{
+			name:   "success - limit",
+			input:  input{query: internal.ProductQuery{Limit: 2}},
+			output: output{ids: []int{1, 2}},
+		},
+		{
+			name:   "success - combined filters, sort and limit",
+			input:  input{query: internal.ProductQuery{Description: "phone", PriceMax: price(1000), Sort: internal.ProductSortPrice, Desc: true, Limit: 1}},
+			output: output{ids: []int{3}},
+		},
+		// failure
+		{
+			name:   "failure - unknown sort",
+			input:  input{query: internal.ProductQuery{Sort: "color"}},
+			output: output{err: internal.ErrProductQueryInvalid},
+		},
+		{
+			name:   "failure - negative price max",
+			input:  input{query: internal.ProductQuery{PriceMax: price(-1)}},
+			output: output{err: internal.ErrProductQueryInvalid},
+		},
+		{
+			name:   "failure - price min greater than a zero price max",
+			input:  input{query: internal.ProductQuery{PriceMin: price(10), PriceMax: price(0)}},
+			output: output{err: internal.ErrProductQueryInvalid},
+		},
+		{
+			name:   "failure - price min greater than price max",
+			input:  input{query: internal.ProductQuery{PriceMin: price(800), PriceMax: price(300)}},
+			output: output{err: internal.ErrProductQueryInvalid},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			rp := newRepository(t, conformanceProducts)
+
+			// act
+			p, err := rp.SearchProducts(tc.input.query)
+
+			// assert
+			if tc.output.err != nil {
+				require.ErrorIs(t, err, tc.output.err)
+				require.Nil(t, p)
+				return
+			}
+			require.NoError(t, err)
+			expected := make([]internal.Product, 0, len(tc.output.ids))
+			for _, id := range tc.output.ids {
+				expected = append(expected, conformanceProducts[id-1])
+			}
+			require.Equal(t, expected, p)
+		})
+	}
+}
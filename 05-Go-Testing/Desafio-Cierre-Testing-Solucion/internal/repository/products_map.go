@@ -1,8 +1,16 @@
 package repository
 
-import "app/internal"
+import (
+	"app/internal"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
 
 // NewProductsMap returns a new ProductsMap.
+// - db is owned by the repository from then on, it must be changed through its methods so the indexes are kept
 func NewProductsMap(db map[int]internal.Product) *ProductsMap {
 	// default values
 	defaultDb := make(map[int]internal.Product)
@@ -10,31 +18,391 @@ func NewProductsMap(db map[int]internal.Product) *ProductsMap {
 		defaultDb = db
 	}
 
-	return &ProductsMap{
-		db: defaultDb,
+	rp := &ProductsMap{
+		db:       defaultDb,
+		bySeller: make(map[int]map[int]struct{}),
+		byPrice:  make([]int, 0, len(defaultDb)),
+		byToken:  make(map[string]map[int]struct{}),
 	}
+
+	// build the indexes
+	for k, v := range defaultDb {
+		if k > rp.lastId {
+			rp.lastId = k
+		}
+		rp.indexSeller(k, v)
+		rp.indexToken(k, v)
+		rp.byPrice = append(rp.byPrice, k)
+	}
+	sort.Slice(rp.byPrice, func(i, j int) bool {
+		return rp.lessPrice(rp.byPrice[i], rp.byPrice[j])
+	})
+
+	return rp
 }
 
 // ProductAttributes is an struct that implements the RepositoryProducts interface.
+// - the searches use secondary indexes to select the candidates, which are checked against the query
 type ProductsMap struct {
+	// mu guards the products and the indexes.
+	mu sync.RWMutex
 	// db is the map of products.
 	db map[int]internal.Product
+	// lastId is the highest id of the products, new products get the following ones.
+	lastId int
+
+	// bySeller is the index of the ids of the products of each seller.
+	bySeller map[int]map[int]struct{}
+	// byPrice is the index of the ids of the products sorted by price, ties by id.
+	byPrice []int
+	// byToken is the index of the ids of the products with each token of the description, lower case.
+	byToken map[string]map[int]struct{}
 }
 
-// SearchProducts returns a list of products that match the query.
-func (r *ProductsMap) SearchProducts(query internal.ProductQuery) (p map[int]internal.Product, err error) {
-	p = make(map[int]internal.Product)
+// SearchProducts returns a list of products that match the query, in the order of the query.
+func (r *ProductsMap) SearchProducts(query internal.ProductQuery) (p []internal.Product, err error) {
+	// validate the query
+	err = query.Validate()
+	if err != nil {
+		return
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 
 	// search the products
+	// - the smallest set of candidates of the indexes, all the products if no index applies
+	ids, ok := r.candidates(query)
+	if !ok {
+		p = r.scan(query)
+	} else {
+		p = r.filter(ids, query)
+	}
+
+	// sort and limit the products
+	p = sortProducts(p, query)
+	return
+}
+
+// Save saves a new product with the id following the last one.
+func (r *ProductsMap) Save(p *internal.Product) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// save the product
+	r.lastId++
+	(*p).Id = r.lastId
+	r.db[(*p).Id] = *p
+
+	// index the product
+	r.index((*p).Id, *p)
+	return
+}
+
+// Update replaces the attributes of an existing product.
+func (r *ProductsMap) Update(p internal.Product) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the product exists
+	previous, ok := r.db[p.Id]
+	if !ok {
+		err = fmt.Errorf("%w: id %d", internal.ErrRepositoryProductNotFound, p.Id)
+		return
+	}
+
+	// update the product, reindexing it
+	r.unindex(p.Id, previous)
+	r.db[p.Id] = p
+	r.index(p.Id, p)
+	return
+}
+
+// Delete deletes the product with the given id.
+// - the id is not reused by later products
+func (r *ProductsMap) Delete(id int) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the product exists
+	previous, ok := r.db[id]
+	if !ok {
+		err = fmt.Errorf("%w: id %d", internal.ErrRepositoryProductNotFound, id)
+		return
+	}
+
+	// delete the product and its entries of the indexes
+	r.unindex(id, previous)
+	delete(r.db, id)
+	return
+}
+
+// candidates returns the ids of the products that may match the query, from the seller or price index with the fewest of them.
+// - ok is false if no index applies to the query
+func (r *ProductsMap) candidates(query internal.ProductQuery) (ids []int, ok bool) {
+	// id: at most one product
+	if query.Id > 0 {
+		ids, ok = []int{query.Id}, true
+		return
+	}
+
+	// choose the smallest set of the indexes
+	choose := func(set []int) {
+		if !ok || len(set) < len(ids) {
+			ids, ok = set, true
+		}
+	}
+	if query.SellerId > 0 {
+		choose(keys(r.bySeller[query.SellerId]))
+	}
+	if query.PriceMin != nil || query.PriceMax != nil {
+		choose(r.priceRange(query.PriceMin, query.PriceMax))
+	}
+	// - the description index is the costliest to read, so it is only used when the others do not apply
+	if !ok && query.Description != "" {
+		ids, ok = r.descriptionCandidates(query.Description)
+	}
+	return
+}
+
+// priceRange returns the ids of the products whose price is within a range, bounds included.
+// - a nil bound means the range is not bounded on that side
+func (r *ProductsMap) priceRange(min, max *float64) (ids []int) {
+	lo := 0
+	if min != nil {
+		lo = sort.Search(len(r.byPrice), func(i int) bool {
+			return r.db[r.byPrice[i]].Price >= *min
+		})
+	}
+	hi := len(r.byPrice)
+	if max != nil {
+		hi = sort.Search(len(r.byPrice), func(i int) bool {
+			return r.db[r.byPrice[i]].Price > *max
+		})
+	}
+	if lo >= hi {
+		return
+	}
+	ids = r.byPrice[lo:hi]
+	return
+}
+
+// descriptionCandidates returns the ids of the products whose description may contain the text.
+// - every token of the text must be part of a token of the description, the products with all of them are returned
+// - found is false if the text has no tokens, e.g. only punctuation, so the index does not apply
+func (r *ProductsMap) descriptionCandidates(text string) (ids []int, found bool) {
+	tokens := tokenize(text)
+	if len(tokens) == 0 {
+		return
+	}
+	found = true
+
+	// intersect the products of each token of the text
+	var result map[int]struct{}
+	for _, token := range tokens {
+		// - products with a token that contains the token of the text
+		matches := make(map[int]struct{})
+		for indexed, set := range r.byToken {
+			if !strings.Contains(indexed, token) {
+				continue
+			}
+			for id := range set {
+				if result == nil {
+					matches[id] = struct{}{}
+					continue
+				}
+				if _, ok := result[id]; ok {
+					matches[id] = struct{}{}
+				}
+			}
+		}
+		result = matches
+		if len(result) == 0 {
+			break
+		}
+	}
+
+	ids = keys(result)
+	return
+}
+
+// scan returns the products that match the query, checking all of them.
+func (r *ProductsMap) scan(query internal.ProductQuery) (p []internal.Product) {
+	p = make([]internal.Product, 0)
+	description := strings.ToLower(query.Description)
 	for k, v := range r.db {
-		// check if each query field is set
-		if query.Id > 0 && query.Id != k {
-			continue
+		if matchProduct(k, v, query, description) {
+			p = append(p, v)
 		}
+	}
+	return
+}
+
+// filter returns the products of the ids that match the query.
+func (r *ProductsMap) filter(ids []int, query internal.ProductQuery) (p []internal.Product) {
+	p = make([]internal.Product, 0, len(ids))
+	description := strings.ToLower(query.Description)
+	for _, k := range ids {
+		v, ok := r.db[k]
+		if ok && matchProduct(k, v, query, description) {
+			p = append(p, v)
+		}
+	}
+	return
+}
+
+// index adds a product to the indexes.
+func (r *ProductsMap) index(id int, p internal.Product) {
+	r.indexSeller(id, p)
+	r.indexToken(id, p)
+
+	// price: insert in order
+	i := sort.Search(len(r.byPrice), func(i int) bool {
+		return !r.lessPriceOf(r.byPrice[i], r.db[r.byPrice[i]], id, p)
+	})
+	r.byPrice = append(r.byPrice, 0)
+	copy(r.byPrice[i+1:], r.byPrice[i:])
+	r.byPrice[i] = id
+}
+
+// unindex removes a product from the indexes, p must be the product as it was indexed.
+func (r *ProductsMap) unindex(id int, p internal.Product) {
+	// seller
+	delete(r.bySeller[p.SellerId], id)
+	if len(r.bySeller[p.SellerId]) == 0 {
+		delete(r.bySeller, p.SellerId)
+	}
+
+	// tokens
+	for _, token := range tokenize(p.Description) {
+		delete(r.byToken[token], id)
+		if len(r.byToken[token]) == 0 {
+			delete(r.byToken, token)
+		}
+	}
+
+	// price: remove from its position
+	i := sort.Search(len(r.byPrice), func(i int) bool {
+		return !r.lessPriceOf(r.byPrice[i], r.db[r.byPrice[i]], id, p)
+	})
+	if i < len(r.byPrice) && r.byPrice[i] == id {
+		r.byPrice = append(r.byPrice[:i], r.byPrice[i+1:]...)
+	}
+}
+
+// indexSeller adds a product to the index of sellers.
+func (r *ProductsMap) indexSeller(id int, p internal.Product) {
+	if r.bySeller[p.SellerId] == nil {
+		r.bySeller[p.SellerId] = make(map[int]struct{})
+	}
+	r.bySeller[p.SellerId][id] = struct{}{}
+}
+
+// indexToken adds a product to the index of tokens.
+func (r *ProductsMap) indexToken(id int, p internal.Product) {
+	for _, token := range tokenize(p.Description) {
+		if r.byToken[token] == nil {
+			r.byToken[token] = make(map[int]struct{})
+		}
+		r.byToken[token][id] = struct{}{}
+	}
+}
+
+// lessPrice reports whether the product a goes before the product b in the index of prices.
+func (r *ProductsMap) lessPrice(a, b int) bool {
+	return r.lessPriceOf(a, r.db[a], b, r.db[b])
+}
+
+// lessPriceOf reports whether the product a goes before the product b in the index of prices, given their values.
+func (r *ProductsMap) lessPriceOf(idA int, a internal.Product, idB int, b internal.Product) bool {
+	if a.Price != b.Price {
+		return a.Price < b.Price
+	}
+	return idA < idB
+}
+
+// matchProduct reports whether a product matches the query, description is the one of the query in lower case.
+func matchProduct(k int, v internal.Product, query internal.ProductQuery, description string) bool {
+	// check if each query field is set
+	if query.Id > 0 && query.Id != k {
+		return false
+	}
+	if query.SellerId > 0 && query.SellerId != v.SellerId {
+		return false
+	}
+	if description != "" && !strings.Contains(strings.ToLower(v.Description), description) {
+		return false
+	}
+	if query.PriceMin != nil && v.Price < *query.PriceMin {
+		return false
+	}
+	if query.PriceMax != nil && v.Price > *query.PriceMax {
+		return false
+	}
+	return true
+}
 
-		// add the product to the result
-		p[k] = v
+// sortProducts sorts the products and limits them as the query describes.
+func sortProducts(p []internal.Product, query internal.ProductQuery) []internal.Product {
+	// sort the products
+	sort.Slice(p, func(i, j int) bool {
+		return lessProduct(p[i], p[j], query.Sort, query.Desc)
+	})
+
+	// limit the products
+	if query.Limit > 0 && len(p) > query.Limit {
+		p = p[:query.Limit]
 	}
+	return p
+}
 
+// lessProduct reports whether a goes before b sorting by field, ties are sorted by id.
+// - descriptions are compared case insensitive, as the collation of the mysql table
+func lessProduct(a, b internal.Product, field internal.ProductSort, desc bool) bool {
+	// compare the field
+	var cmp int
+	switch field {
+	case internal.ProductSortDescription:
+		cmp = strings.Compare(strings.ToLower(a.Description), strings.ToLower(b.Description))
+	case internal.ProductSortPrice:
+		switch {
+		case a.Price < b.Price:
+			cmp = -1
+		case a.Price > b.Price:
+			cmp = 1
+		}
+	case internal.ProductSortSellerId:
+		cmp = a.SellerId - b.SellerId
+	}
+	if cmp == 0 {
+		cmp = a.Id - b.Id
+	}
+
+	if desc {
+		return cmp > 0
+	}
+	return cmp < 0
+}
+
+// tokenize returns the distinct tokens of a text in lower case, the runs of letters and digits.
+func tokenize(text string) (tokens []string) {
+	seen := make(map[string]bool)
+	for _, token := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return
+}
+
+// keys returns the keys of a set.
+func keys(set map[int]struct{}) (k []int) {
+	k = make([]int, 0, len(set))
+	for key := range set {
+		k = append(k, key)
+	}
 	return
-}
\ No newline at end of file
+}
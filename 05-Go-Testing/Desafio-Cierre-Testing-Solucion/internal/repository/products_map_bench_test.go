@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"app/internal"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// benchmarkCatalogue returns a repository with n products of 1000 sellers and descriptions of two words.
+func benchmarkCatalogue(n int) *ProductsMap {
+	words := []string{"phone", "laptop", "desk", "chair", "monitor", "keyboard", "mouse", "cable", "lamp", "speaker"}
+	colors := []string{"red", "blue", "green", "black", "white", "silver", "gold", "grey"}
+	rnd := rand.New(rand.NewSource(1))
+
+	db := make(map[int]internal.Product, n)
+	for id := 1; id <= n; id++ {
+		db[id] = internal.Product{
+			Id: id,
+			ProductAttributes: internal.ProductAttributes{
+				Description: fmt.Sprintf("%s %s %d", colors[rnd.Intn(len(colors))], words[rnd.Intn(len(words))], rnd.Intn(n)),
+				Price:       float64(rnd.Intn(1000000)) / 100,
+				SellerId:    rnd.Intn(1000) + 1,
+			},
+		}
+	}
+	return NewProductsMap(db)
+}
+
+// Benchmarks for ProductsMap - SearchProducts, through the indexes versus scanning every product
+func BenchmarkProductsMap_SearchProducts(b *testing.B) {
+	rp := benchmarkCatalogue(100000)
+	price := func(v float64) *float64 { return &v }
+	queries := []struct {
+		name  string
+		query internal.ProductQuery
+	}{
+		{name: "seller", query: internal.ProductQuery{SellerId: 42}},
+		{name: "price range", query: internal.ProductQuery{PriceMin: price(100), PriceMax: price(150)}},
+		{name: "description", query: internal.ProductQuery{Description: "4242"}},
+		{name: "combined", query: internal.ProductQuery{SellerId: 42, PriceMin: price(1000), Description: "red"}},
+	}
+
+	for _, q := range queries {
+		b.Run(q.name+"/indexed", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = rp.SearchProducts(q.query)
+			}
+		})
+		b.Run(q.name+"/scan", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rp.mu.RLock()
+				p := rp.scan(q.query)
+				_ = sortProducts(p, q.query)
+				rp.mu.RUnlock()
+			}
+		})
+	}
+}
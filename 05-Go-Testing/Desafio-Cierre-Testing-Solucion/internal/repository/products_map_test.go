@@ -3,6 +3,8 @@ package repository_test
 import (
 	"app/internal"
 	"app/internal/repository"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -17,7 +19,7 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 		query internal.ProductQuery
 	}
 	type output struct {
-		p      map[int]internal.Product
+		p      []internal.Product
 		err    error
 		errMsg string
 	}
@@ -60,8 +62,8 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 				query: internal.ProductQuery{},
 			},
 			output: output{
-				p: map[int]internal.Product{
-					1: {
+				p: []internal.Product{
+					{
 						Id:    1,
 						ProductAttributes: internal.ProductAttributes{
 							Description: "Product 1",
@@ -69,7 +71,7 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 							SellerId:    1,
 						},
 					},
-					2: {
+					{
 						Id:    2,
 						ProductAttributes: internal.ProductAttributes{
 							Description: "Product 2",
@@ -94,7 +96,7 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 				query: internal.ProductQuery{},
 			},
 			output: output{
-				p: make(map[int]internal.Product),
+				p: []internal.Product{},
 				err: nil,
 				errMsg: "",
 			},
@@ -130,8 +132,8 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 				},
 			},
 			output: output{
-				p: map[int]internal.Product{
-					1: {
+				p: []internal.Product{
+					{
 						Id:    1,
 						ProductAttributes: internal.ProductAttributes{
 							Description: "Product 1",
@@ -175,7 +177,7 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 				},
 			},
 			output: output{
-				p: map[int]internal.Product{},
+				p: []internal.Product{},
 				err: nil,
 				errMsg: "",
 			},
@@ -202,3 +204,137 @@ func TestProductsMap_SearchProducts(t *testing.T) {
 		})
 	}
 }
+
+// Tests for ProductsMap - SearchProducts, shared with the other repositories
+func TestProductsMap_SearchProducts_Conformance(t *testing.T) {
+	conformanceSearchProducts(t, func(t *testing.T, products []internal.Product) internal.RepositoryProducts {
+		db := make(map[int]internal.Product, len(products))
+		for _, p := range products {
+			db[p.Id] = p
+		}
+		return repository.NewProductsMap(db)
+	})
+}
+
+// Tests for ProductsMap - Save, Update and Delete
+func TestProductsMap_Write(t *testing.T) {
+	t.Run("success to save, update and delete keeping the indexes", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductsMap(map[int]internal.Product{
+			3: {Id: 3, ProductAttributes: internal.ProductAttributes{Description: "Phone", Price: 800, SellerId: 1}},
+		})
+
+		// act
+		saved := internal.Product{ProductAttributes: internal.ProductAttributes{Description: "Laptop Pro", Price: 1500, SellerId: 1}}
+		errSave := rp.Save(&saved)
+		updated := internal.Product{Id: 3, ProductAttributes: internal.ProductAttributes{Description: "Tablet", Price: 300, SellerId: 2}}
+		errUpdate := rp.Update(updated)
+
+		// assert
+		require.NoError(t, errSave)
+		require.NoError(t, errUpdate)
+		require.Equal(t, 4, saved.Id)
+		// - seller
+		p, err := rp.SearchProducts(internal.ProductQuery{SellerId: 1})
+		require.NoError(t, err)
+		require.Equal(t, []internal.Product{saved}, p)
+		// - price
+		p, err = rp.SearchProducts(internal.ProductQuery{PriceMax: price(500)})
+		require.NoError(t, err)
+		require.Equal(t, []internal.Product{updated}, p)
+		// - description
+		p, err = rp.SearchProducts(internal.ProductQuery{Description: "phone"})
+		require.NoError(t, err)
+		require.Empty(t, p)
+
+		// act
+		errDelete := rp.Delete(4)
+
+		// assert
+		require.NoError(t, errDelete)
+		p, err = rp.SearchProducts(internal.ProductQuery{Description: "laptop"})
+		require.NoError(t, err)
+		require.Empty(t, p)
+		p, err = rp.SearchProducts(internal.ProductQuery{PriceMin: price(1000)})
+		require.NoError(t, err)
+		require.Empty(t, p)
+	})
+
+	t.Run("failure with a product that does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductsMap(nil)
+
+		// act
+		errUpdate := rp.Update(internal.Product{Id: 1})
+		errDelete := rp.Delete(1)
+
+		// assert
+		require.ErrorIs(t, errUpdate, internal.ErrRepositoryProductNotFound)
+		require.ErrorIs(t, errDelete, internal.ErrRepositoryProductNotFound)
+	})
+
+	t.Run("success to write concurrently keeping the indexes consistent", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductsMap(nil)
+		descriptions := []string{"Red Phone", "Blue Laptop", "red laptop sleeve", "Green Desk"}
+
+		// act
+		// - the goroutines send their errors, as require can only stop the test from its own goroutine
+		var wg sync.WaitGroup
+		errs := make(chan error, 50*4)
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				p := internal.Product{ProductAttributes: internal.ProductAttributes{Description: descriptions[i%4], Price: float64(i * 10), SellerId: i % 3}}
+				err := rp.Save(&p)
+				errs <- err
+				if err != nil {
+					return
+				}
+				p.Description = descriptions[(i+1)%4]
+				p.Price = float64(i * 7)
+				p.SellerId = i % 5
+				errs <- rp.Update(p)
+				if i%4 == 0 {
+					errs <- rp.Delete(p.Id)
+				}
+			}(i)
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				_, err := rp.SearchProducts(internal.ProductQuery{SellerId: i % 5, PriceMin: price(50), Description: "red"})
+				errs <- err
+			}(i)
+		}
+		wg.Wait()
+		close(errs)
+
+		// assert
+		for err := range errs {
+			require.NoError(t, err)
+		}
+		// - each search through the indexes returns the same products as filtering all of them
+		all, err := rp.SearchProducts(internal.ProductQuery{})
+		require.NoError(t, err)
+		require.Len(t, all, 37)
+		queries := []internal.ProductQuery{
+			{SellerId: 1}, {SellerId: 4}, {PriceMin: price(100), PriceMax: price(200)}, {PriceMax: price(70)}, {PriceMax: price(0)},
+			{Description: "red"}, {Description: "LAPTOP SLE"}, {Description: "red", SellerId: 2, PriceMin: price(30)},
+		}
+		for _, q := range queries {
+			p, err := rp.SearchProducts(q)
+			require.NoError(t, err)
+			expected := make([]internal.Product, 0)
+			for _, v := range all {
+				if (q.SellerId == 0 || v.SellerId == q.SellerId) &&
+					(q.PriceMin == nil || v.Price >= *q.PriceMin) &&
+					(q.PriceMax == nil || v.Price <= *q.PriceMax) &&
+					strings.Contains(strings.ToLower(v.Description), strings.ToLower(q.Description)) {
+					expected = append(expected, v)
+				}
+			}
+			require.Equal(t, expected, p, "query %+v", q)
+		}
+	})
+}
@@ -10,7 +10,7 @@ func NewProductsMock() *ProductsMock {
 // ProductsMock is an struct that implements the Prosduct interface.
 type ProductsMock struct {
 	// FuncSearchProducts is the function that proxy the SearchProducts method.
-	FuncSearchProducts func(query internal.ProductQuery) (p map[int]internal.Product, err error)
+	FuncSearchProducts func(query internal.ProductQuery) (p []internal.Product, err error)
 	// Spy
 	Spy struct {
 		// SearchProducts is the number of times the SearchProducts method is called.
@@ -19,7 +19,7 @@ type ProductsMock struct {
 }
 
 // SearchProducts returns a list of products that match the query.
-func (r *ProductsMock) SearchProducts(query internal.ProductQuery) (p map[int]internal.Product, err error) {
+func (r *ProductsMock) SearchProducts(query internal.ProductQuery) (p []internal.Product, err error) {
 	// spy
 	r.Spy.SearchProducts++
 
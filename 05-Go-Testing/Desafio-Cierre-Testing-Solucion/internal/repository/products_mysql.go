@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"app/internal"
+	"database/sql"
+	"strings"
+)
+
+// NewProductsMySQL returns a new ProductsMySQL.
+func NewProductsMySQL(db *sql.DB) *ProductsMySQL {
+	return &ProductsMySQL{
+		db: db,
+	}
+}
+
+// ProductsMySQL is an struct that implements the RepositoryProducts interface with a MySQL database.
+// - the description is compared with the collation of the table, case insensitive by default
+type ProductsMySQL struct {
+	// db is the database connection.
+	db *sql.DB
+}
+
+// productSortColumns are the columns of the sort fields of a query.
+var productSortColumns = map[internal.ProductSort]string{
+	"":                              "`id`",
+	internal.ProductSortId:          "`id`",
+	internal.ProductSortDescription: "`description`",
+	internal.ProductSortPrice:       "`price`",
+	internal.ProductSortSellerId:    "`seller_id`",
+}
+
+// SearchProducts returns a list of products that match the query, in the order of the query.
+func (r *ProductsMySQL) SearchProducts(query internal.ProductQuery) (p []internal.Product, err error) {
+	// validate the query
+	err = query.Validate()
+	if err != nil {
+		return
+	}
+
+	// build the query
+	statement, args := productsSearchSQL(query)
+
+	// execute the query
+	rows, err := r.db.Query(statement, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	p = make([]internal.Product, 0)
+	for rows.Next() {
+		var pr internal.Product
+		err = rows.Scan(&pr.Id, &pr.Description, &pr.Price, &pr.SellerId)
+		if err != nil {
+			p = nil
+			return
+		}
+		p = append(p, pr)
+	}
+	err = rows.Err()
+	if err != nil {
+		p = nil
+		return
+	}
+
+	return
+}
+
+// productsSearchSQL translates a query into a select statement and its arguments.
+func productsSearchSQL(query internal.ProductQuery) (statement string, args []any) {
+	var sb strings.Builder
+	sb.WriteString("SELECT `id`, `description`, `price`, `seller_id` FROM `products` WHERE 1 = 1")
+
+	// filters
+	if query.Id > 0 {
+		sb.WriteString(" AND `id` = ?")
+		args = append(args, query.Id)
+	}
+	if query.SellerId > 0 {
+		sb.WriteString(" AND `seller_id` = ?")
+		args = append(args, query.SellerId)
+	}
+	if query.Description != "" {
+		sb.WriteString(" AND `description` LIKE ?")
+		args = append(args, "%"+escapeLike(query.Description)+"%")
+	}
+	if query.PriceMin != nil {
+		sb.WriteString(" AND `price` >= ?")
+		args = append(args, *query.PriceMin)
+	}
+	if query.PriceMax != nil {
+		sb.WriteString(" AND `price` <= ?")
+		args = append(args, *query.PriceMax)
+	}
+
+	// sort, ties by id in the same order
+	direction := " ASC"
+	if query.Desc {
+		direction = " DESC"
+	}
+	sb.WriteString(" ORDER BY " + productSortColumns[query.Sort] + direction)
+	if query.Sort != "" && query.Sort != internal.ProductSortId {
+		sb.WriteString(", `id`" + direction)
+	}
+
+	// limit
+	if query.Limit > 0 {
+		sb.WriteString(" LIMIT ?")
+		args = append(args, query.Limit)
+	}
+
+	statement = sb.String()
+	return
+}
+
+// escapeLike escapes the wildcards of a text to be matched literally by LIKE, whose escape character is the backslash.
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
@@ -0,0 +1,68 @@
+package repository_test
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-txdb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+// dsnTest is the connection to the database of docs/db/database_test.sql.
+var dsnTest string
+
+func init() {
+	// db config
+	cfg := mysql.Config{
+		User:   "root",
+		Passwd: os.Getenv("MYSQL_ROOT_PASSWORD"),
+		Net:    "tcp",
+		Addr:   "localhost:3306",
+		DBName: "products_test_db",
+	}
+	if addr := os.Getenv("MYSQL_ADDR"); addr != "" {
+		cfg.Addr = addr
+	}
+	dsnTest = cfg.FormatDSN()
+	// register txdb driver
+	txdb.Register("txdb", "mysql", dsnTest)
+}
+
+// openTxdb opens a connection whose changes are rolled back when the test ends.
+// - the test is skipped if the database of docs/db/database_test.sql is not available
+func openTxdb(t *testing.T) *sql.DB {
+	t.Helper()
+
+	// check the database
+	db, err := sql.Open("mysql", dsnTest)
+	require.NoError(t, err)
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Skipf("database not available: %s", err)
+	}
+
+	// open the transaction
+	tx, err := sql.Open("txdb", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { tx.Close() })
+	return tx
+}
+
+// Tests for ProductsMySQL - SearchProducts, shared with the other repositories
+func TestProductsMySQL_SearchProducts_Conformance(t *testing.T) {
+	conformanceSearchProducts(t, func(t *testing.T, products []internal.Product) internal.RepositoryProducts {
+		db := openTxdb(t)
+		for _, p := range products {
+			_, err := db.Exec(
+				"INSERT INTO `products` (`id`, `description`, `price`, `seller_id`) VALUES (?, ?, ?, ?)",
+				p.Id, p.Description, p.Price, p.SellerId,
+			)
+			require.NoError(t, err)
+		}
+		return repository.NewProductsMySQL(db)
+	})
+}
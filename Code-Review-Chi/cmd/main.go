@@ -3,17 +3,38 @@ package main
 import (
 	"app/internal/application"
 	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 )
 
 func main() {
 	// env
-	// ...
+	// - STORAGE: memory (default), json_file or mysql
+	// - STORE_DEBOUNCE: time the changes are grouped for before writing them to the file, e.g. 500ms
+	// - LOADER_STRICT: true to fail the start if a vehicle of the file lacks a field or has an invalid value
+	storeDebounce, err := time.ParseDuration(os.Getenv("STORE_DEBOUNCE"))
+	if err != nil && os.Getenv("STORE_DEBOUNCE") != "" {
+		fmt.Println(err)
+		return
+	}
 
 	// app
 	// - config
 	cfg := &application.ConfigServerChi{
 		ServerAddress: ":8080",
 		LoaderFilePath: "docs/db/vehicles_100.json",
+		LoaderStrict: os.Getenv("LOADER_STRICT") == "true",
+		Storage: os.Getenv("STORAGE"),
+		StoreDebounce: storeDebounce,
+		Database: &mysql.Config{
+			User:   "root",
+			Passwd: "root",
+			Net:    "tcp",
+			Addr:   "localhost:3306",
+			DBName: "vehicles_db",
+		},
 	}
 	app := application.NewServerChi(cfg)
 	// - run
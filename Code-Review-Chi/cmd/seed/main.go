@@ -0,0 +1,47 @@
+package main
+
+import (
+	"app/internal/loader"
+	"app/internal/repository"
+	"database/sql"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// main seeds the vehicles table of docs/db/mysql/database.sql with the vehicles of docs/db/vehicles_100.json
+// - run it from the root of the module, once the database is created: go run ./cmd/seed
+func main() {
+	// dependencies
+	// - loader
+	ld := loader.NewVehicleJSONFile("docs/db/vehicles_100.json", false)
+	v, stats, err := ld.Load()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	// - db
+	cfg := &mysql.Config{
+		User:   "root",
+		Passwd: "root",
+		Net:    "tcp",
+		Addr:   "localhost:3306",
+		DBName: "vehicles_db",
+	}
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer db.Close()
+	// - repository
+	rp := repository.NewVehicleMySQL(db)
+
+	// seed
+	err = rp.Import(v)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("vehicles: %d seeded, %d records rejected by the loader\n", len(v), stats.Rejected)
+}
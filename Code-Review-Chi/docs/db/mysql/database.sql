@@ -0,0 +1,31 @@
+-- DDL
+DROP DATABASE IF EXISTS `vehicles_db`;
+
+CREATE DATABASE `vehicles_db`;
+
+USE `vehicles_db`;
+
+-- Table structure for table `vehicles`
+-- - the registrations repeated in the original data are kept, flagged as duplicated, so only the new ones must be unique
+-- - seed it with the vehicles of docs/db/vehicles_100.json running: go run ./cmd/seed
+CREATE TABLE `vehicles` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `brand` varchar(50) NOT NULL,
+    `model` varchar(50) NOT NULL,
+    `registration` varchar(20) NOT NULL,
+    `registration_duplicated` tinyint(1) NOT NULL DEFAULT 0,
+    `registration_unique` varchar(20) GENERATED ALWAYS AS (IF(`registration_duplicated`, NULL, `registration`)) VIRTUAL,
+    `color` varchar(30) NOT NULL,
+    `fabrication_year` int NOT NULL,
+    `capacity` int NOT NULL,
+    `max_speed` double NOT NULL,
+    `fuel_type` varchar(20) NOT NULL,
+    `transmission` varchar(20) NOT NULL,
+    `weight` double NOT NULL DEFAULT 0,
+    `height` double NOT NULL DEFAULT 0,
+    `length` double NOT NULL DEFAULT 0,
+    `width` double NOT NULL DEFAULT 0,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `idx_vehicles_registration` (`registration_unique`),
+    KEY `idx_vehicles_brand` (`brand`)
+);
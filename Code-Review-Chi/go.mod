@@ -3,6 +3,15 @@ module app
 go 1.21.2
 
 require (
+	github.com/DATA-DOG/go-txdb v0.1.7
 	github.com/bootcamp-go/web v1.0.0
 	github.com/go-chi/chi/v5 v5.0.11
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/stretchr/testify v1.8.4
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -1,14 +1,34 @@
 package application
 
 import (
+	"app/internal"
 	"app/internal/handler"
 	"app/internal/loader"
 	"app/internal/repository"
 	"app/internal/service"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// StorageMemory keeps the vehicles of the loader file in memory, the changes are lost on exit
+	StorageMemory = "memory"
+	// StorageJSONFile keeps the vehicles in memory and writes the changes back to the loader file
+	StorageJSONFile = "json_file"
+	// StorageMySQL keeps the vehicles in a MySQL database
+	StorageMySQL = "mysql"
 )
 
 // ConfigServerChi is a struct that represents the configuration for ServerChi
@@ -17,6 +37,14 @@ type ConfigServerChi struct {
 	ServerAddress string
 	// LoaderFilePath is the path to the file that contains the vehicles
 	LoaderFilePath string
+	// LoaderStrict fails the start if a vehicle of the file lacks a field or has an invalid value
+	LoaderStrict bool
+	// Storage is where the vehicles are kept, one of StorageMemory, StorageJSONFile or StorageMySQL
+	Storage string
+	// StoreDebounce is the time the changes are grouped for before writing them to the file, only for StorageJSONFile
+	StoreDebounce time.Duration
+	// Database is the configuration of the database, only for StorageMySQL
+	Database *mysql.Config
 }
 
 // NewServerChi is a function that returns a new instance of ServerChi
@@ -24,6 +52,7 @@ func NewServerChi(cfg *ConfigServerChi) *ServerChi {
 	// default values
 	defaultConfig := &ConfigServerChi{
 		ServerAddress: ":8080",
+		Storage:       StorageMemory,
 	}
 	if cfg != nil {
 		if cfg.ServerAddress != "" {
@@ -32,11 +61,27 @@ func NewServerChi(cfg *ConfigServerChi) *ServerChi {
 		if cfg.LoaderFilePath != "" {
 			defaultConfig.LoaderFilePath = cfg.LoaderFilePath
 		}
+		if cfg.LoaderStrict {
+			defaultConfig.LoaderStrict = cfg.LoaderStrict
+		}
+		if cfg.Storage != "" {
+			defaultConfig.Storage = cfg.Storage
+		}
+		if cfg.StoreDebounce > 0 {
+			defaultConfig.StoreDebounce = cfg.StoreDebounce
+		}
+		if cfg.Database != nil {
+			defaultConfig.Database = cfg.Database
+		}
 	}
 
 	return &ServerChi{
 		serverAddress: defaultConfig.ServerAddress,
 		loaderFilePath: defaultConfig.LoaderFilePath,
+		loaderStrict: defaultConfig.LoaderStrict,
+		storage: defaultConfig.Storage,
+		storeDebounce: defaultConfig.StoreDebounce,
+		database: defaultConfig.Database,
 	}
 }
 
@@ -46,19 +91,67 @@ type ServerChi struct {
 	serverAddress string
 	// loaderFilePath is the path to the file that contains the vehicles
 	loaderFilePath string
+	// loaderStrict fails the start if a vehicle of the file has an issue
+	loaderStrict bool
+	// storage is where the vehicles are kept
+	storage string
+	// storeDebounce is the time the changes are grouped for before writing them to the file
+	storeDebounce time.Duration
+	// database is the configuration of the database
+	database *mysql.Config
 }
 
 // Run is a method that runs the application
 func (a *ServerChi) Run() (err error) {
 	// dependencies
-	// - loader
-	ld := loader.NewVehicleJSONFile(a.loaderFilePath)
-	db, err := ld.Load()
-	if err != nil {
+	// - repository
+	var rp internal.VehicleRepository
+	switch a.storage {
+	case StorageMemory, StorageJSONFile:
+		// - loader
+		ld := loader.NewVehicleJSONFile(a.loaderFilePath, a.loaderStrict)
+		var db map[int]internal.Vehicle
+		var stats internal.VehicleLoadStats
+		db, stats, err = ld.Load()
+		if err != nil {
+			err = fmt.Errorf("application: error loading %s: %w", a.loaderFilePath, err)
+			return
+		}
+		printLoadStats(stats)
+		rpMap := repository.NewVehicleMap(db)
+		rp = rpMap
+		// - storage: the changes are written back through the loader
+		if a.storage == StorageJSONFile {
+			rpFile := repository.NewVehicleFile(rpMap, ld, a.storeDebounce)
+			defer func() {
+				if errClose := rpFile.Close(); errClose != nil && err == nil {
+					err = errClose
+				}
+			}()
+			rp = rpFile
+		}
+	case StorageMySQL:
+		if a.database == nil {
+			err = errors.New("application: database configuration is required for mysql storage")
+			return
+		}
+		// - db: init
+		var db *sql.DB
+		db, err = sql.Open("mysql", a.database.FormatDSN())
+		if err != nil {
+			return
+		}
+		defer db.Close()
+		// - db: ping
+		err = db.Ping()
+		if err != nil {
+			return
+		}
+		rp = repository.NewVehicleMySQL(db)
+	default:
+		err = fmt.Errorf("application: unknown storage %q", a.storage)
 		return
 	}
-	// - repository
-	rp := repository.NewVehicleMap(db)
 	// - service
 	sv := service.NewVehicleDefault(rp)
 	// - handler
@@ -72,9 +165,72 @@ func (a *ServerChi) Run() (err error) {
 	rt.Route("/vehicles", func(rt chi.Router) {
 		// - GET /vehicles
 		rt.Get("/", hd.GetAll())
+		// - GET /vehicles/brand/{brand}/between/{start_year}/{end_year}
+		rt.Get("/brand/{brand}/between/{start_year}/{end_year}", hd.GetByBrandAndYearRange())
+		// - GET /vehicles/fuel_type/{type}
+		rt.Get("/fuel_type/{type}", hd.GetByFuelType())
+		// - GET /vehicles/transmission/{type}
+		rt.Get("/transmission/{type}", hd.GetByTransmission())
+		// - GET /vehicles/dimensions
+		rt.Get("/dimensions", hd.GetByDimensions())
+		// - GET /vehicles/average_speed/brand/{brand}
+		rt.Get("/average_speed/brand/{brand}", hd.GetAverageMaxSpeedByBrand())
+		// - GET /vehicles/average_capacity/brand/{brand}
+		rt.Get("/average_capacity/brand/{brand}", hd.GetAverageCapacityByBrand())
+		// - POST /vehicles
+		rt.Post("/", hd.Create())
+		// - POST /vehicles/batch
+		rt.Post("/batch", hd.CreateBatch())
+		// - PUT /vehicles/{id}/update_speed
+		rt.Put("/{id}/update_speed", hd.UpdateMaxSpeed())
+		// - PUT /vehicles/{id}/update_fuel
+		rt.Put("/{id}/update_fuel", hd.UpdateFuelType())
+		// - DELETE /vehicles/{id}
+		rt.Delete("/{id}", hd.Delete())
 	})
 
 	// run server
-	err = http.ListenAndServe(a.serverAddress, rt)
+	// - shut down on interrupt, so the pending changes are stored before exiting
+	server := &http.Server{Addr: a.serverAddress, Handler: rt}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdown <- server.Shutdown(context.Background())
+	}()
+	err = server.ListenAndServe()
+	if errors.Is(err, http.ErrServerClosed) {
+		// - ListenAndServe returns as soon as the shutdown starts, the requests in flight are waited for
+		// before the storage is closed
+		err = <-shutdown
+	}
+	return
+}
+
+// printLoadStats is a function that prints the statistics of the load of the vehicles
+func printLoadStats(stats internal.VehicleLoadStats) {
+	fmt.Printf("vehicles: %d records, %d loaded (%d incomplete), %d rejected\n", stats.Records, stats.Loaded, stats.Incomplete, stats.Rejected)
+	// - fields, sorted by name
+	for _, field := range sortedKeys(stats.MissingFields) {
+		fmt.Printf("vehicles: field %s missing in %d records\n", field, stats.MissingFields[field])
+	}
+	for _, field := range sortedKeys(stats.ExtraFields) {
+		fmt.Printf("vehicles: unknown field %s ignored in %d records\n", field, stats.ExtraFields[field])
+	}
+	// - records that were not loaded
+	for _, issue := range stats.Issues {
+		if !errors.Is(issue, internal.ErrLoaderVehicleFieldMissing) || issue.Field == "id" {
+			fmt.Printf("vehicles: %v\n", issue)
+		}
+	}
+}
+
+// sortedKeys is a function that returns the keys of a map of counts in order
+func sortedKeys(m map[string]int) (keys []string) {
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
 	return
 }
\ No newline at end of file
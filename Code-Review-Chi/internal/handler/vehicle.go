@@ -2,9 +2,15 @@ package handler
 
 import (
 	"app/internal"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/bootcamp-go/web/request"
 	"github.com/bootcamp-go/web/response"
+	"github.com/go-chi/chi/v5"
 )
 
 // VehicleJSON is a struct that represents a vehicle in JSON format
@@ -51,28 +57,421 @@ func (h *VehicleDefault) GetAll() http.HandlerFunc {
 		}
 
 		// response
-		data := make(map[int]VehicleJSON)
-		for key, value := range v {
-			data[key] = VehicleJSON{
-				ID:              value.Id,
-				Brand:           value.Brand,
-				Model:           value.Model,
-				Registration:    value.Registration,
-				Color:           value.Color,
-				FabricationYear: value.FabricationYear,
-				Capacity:        value.Capacity,
-				MaxSpeed:        value.MaxSpeed,
-				FuelType:        value.FuelType,
-				Transmission:    value.Transmission,
-				Weight:          value.Weight,
-				Height:          value.Height,
-				Length:          value.Length,
-				Width:           value.Width,
-			}
+		data := serializeVehicles(v)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
+
+// GetByBrandAndYearRange is a method that returns a handler for the route GET /vehicles/brand/{brand}/between/{start_year}/{end_year}
+func (h *VehicleDefault) GetByBrandAndYearRange() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		brand := chi.URLParam(r, "brand")
+		startYear, err := strconv.Atoi(chi.URLParam(r, "start_year"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid start_year")
+			return
+		}
+		endYear, err := strconv.Atoi(chi.URLParam(r, "end_year"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid end_year")
+			return
 		}
+		if startYear > endYear {
+			response.Error(w, http.StatusBadRequest, "start_year must not be after end_year")
+			return
+		}
+
+		// process
+		// - get the vehicles of the brand between the years
+		v, err := h.sv.FindByBrandAndYearRange(brand, startYear, endYear)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
 		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    serializeVehicles(v),
+		})
+	}
+}
+
+// GetByFuelType is a method that returns a handler for the route GET /vehicles/fuel_type/{type}
+func (h *VehicleDefault) GetByFuelType() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		fuelType := chi.URLParam(r, "type")
+
+		// process
+		// - get the vehicles with the fuel type
+		v, err := h.sv.FindByFuelType(fuelType)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    serializeVehicles(v),
+		})
+	}
+}
+
+// GetByTransmission is a method that returns a handler for the route GET /vehicles/transmission/{type}
+func (h *VehicleDefault) GetByTransmission() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		transmission := chi.URLParam(r, "type")
+
+		// process
+		// - get the vehicles with the transmission
+		v, err := h.sv.FindByTransmission(transmission)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    serializeVehicles(v),
+		})
+	}
+}
+
+// GetByDimensions is a method that returns a handler for the route GET /vehicles/dimensions?length={min}-{max}&width={min}-{max}
+// - each range is optional, a missing range does not filter by that dimension
+func (h *VehicleDefault) GetByDimensions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		minLength, maxLength, err := parseRange(r.URL.Query().Get("length"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid length, expected min-max")
+			return
+		}
+		minWidth, maxWidth, err := parseRange(r.URL.Query().Get("width"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid width, expected min-max")
+			return
+		}
+
+		// process
+		// - get the vehicles within the dimensions
+		v, err := h.sv.FindByDimensions(minLength, maxLength, minWidth, maxWidth)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    serializeVehicles(v),
+		})
+	}
+}
+
+// GetAverageMaxSpeedByBrand is a method that returns a handler for the route GET /vehicles/average_speed/brand/{brand}
+func (h *VehicleDefault) GetAverageMaxSpeedByBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		brand := chi.URLParam(r, "brand")
+
+		// process
+		// - get the average maximum speed of the brand
+		average, err := h.sv.AverageMaxSpeedByBrand(brand)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    average,
+		})
+	}
+}
+
+// GetAverageCapacityByBrand is a method that returns a handler for the route GET /vehicles/average_capacity/brand/{brand}
+func (h *VehicleDefault) GetAverageCapacityByBrand() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		brand := chi.URLParam(r, "brand")
+
+		// process
+		// - get the average capacity of the brand
+		average, err := h.sv.AverageCapacityByBrand(brand)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    average,
+		})
+	}
+}
+
+// Create is a method that returns a handler for the route POST /vehicles
+func (h *VehicleDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body VehicleJSON
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		// - save the vehicle
+		v := deserializeVehicle(body)
+		err = h.sv.Save(&v)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    serializeVehicle(v),
+		})
+	}
+}
+
+// CreateBatch is a method that returns a handler for the route POST /vehicles/batch
+// - none of the vehicles is created if any of them fails
+func (h *VehicleDefault) CreateBatch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []VehicleJSON
+		err := request.JSON(r, &body)
+		if err != nil || len(body) == 0 {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		// - save the vehicles
+		v := make([]internal.Vehicle, 0, len(body))
+		for _, vh := range body {
+			v = append(v, deserializeVehicle(vh))
+		}
+		err = h.sv.SaveBatch(v)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		data := make([]VehicleJSON, 0, len(v))
+		for _, vh := range v {
+			data = append(data, serializeVehicle(vh))
+		}
+		response.JSON(w, http.StatusCreated, map[string]any{
 			"message": "success",
 			"data":    data,
 		})
 	}
 }
+
+// UpdateMaxSpeed is a method that returns a handler for the route PUT /vehicles/{id}/update_speed
+func (h *VehicleDefault) UpdateMaxSpeed() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body struct {
+			MaxSpeed *float64 `json:"max_speed"`
+		}
+		err = request.JSON(r, &body)
+		if err != nil || body.MaxSpeed == nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		// - update the speed of the vehicle
+		err = h.sv.UpdateMaxSpeed(id, *body.MaxSpeed)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+		})
+	}
+}
+
+// UpdateFuelType is a method that returns a handler for the route PUT /vehicles/{id}/update_fuel
+func (h *VehicleDefault) UpdateFuelType() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body struct {
+			FuelType string `json:"fuel_type"`
+		}
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		// - update the fuel type of the vehicle
+		err = h.sv.UpdateFuelType(id, body.FuelType)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+		})
+	}
+}
+
+// Delete is a method that returns a handler for the route DELETE /vehicles/{id}
+func (h *VehicleDefault) Delete() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		// - delete the vehicle
+		err = h.sv.Delete(id)
+		if err != nil {
+			responseVehicleError(w, err)
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// responseVehicleError is a function that writes the response for an error of the vehicle service
+func responseVehicleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, internal.ErrServiceNoVehicles):
+		response.Error(w, http.StatusNotFound, "no vehicles found with those criteria")
+	case errors.Is(err, internal.ErrVehicleInvalid):
+		response.Error(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, internal.ErrRepositoryVehicleNotFound):
+		response.Error(w, http.StatusNotFound, "vehicle not found")
+	case errors.Is(err, internal.ErrRepositoryVehicleAlreadyExists):
+		response.Error(w, http.StatusConflict, "a vehicle with the same registration already exists")
+	default:
+		response.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+// parseRange is a function that parses a range in the format min-max
+// - an empty range is the widest one, from 0 to the maximum float
+func parseRange(value string) (min float64, max float64, err error) {
+	if value == "" {
+		max = math.MaxFloat64
+		return
+	}
+
+	// split the range
+	minValue, maxValue, ok := strings.Cut(value, "-")
+	if !ok {
+		err = errors.New("handler: range must be min-max")
+		return
+	}
+
+	// parse the bounds
+	min, err = strconv.ParseFloat(minValue, 64)
+	if err != nil {
+		return
+	}
+	max, err = strconv.ParseFloat(maxValue, 64)
+	if err != nil {
+		return
+	}
+	if min > max {
+		err = errors.New("handler: range min must not exceed max")
+		return
+	}
+	return
+}
+
+// serializeVehicles is a function that serializes a map of vehicles into their JSON format
+func serializeVehicles(v map[int]internal.Vehicle) (data map[int]VehicleJSON) {
+	data = make(map[int]VehicleJSON)
+	for key, value := range v {
+		data[key] = serializeVehicle(value)
+	}
+	return
+}
+
+// serializeVehicle is a function that serializes a vehicle into its JSON format
+func serializeVehicle(v internal.Vehicle) (data VehicleJSON) {
+	data = VehicleJSON{
+		ID:              v.Id,
+		Brand:           v.Brand,
+		Model:           v.Model,
+		Registration:    v.Registration,
+		Color:           v.Color,
+		FabricationYear: v.FabricationYear,
+		Capacity:        v.Capacity,
+		MaxSpeed:        v.MaxSpeed,
+		FuelType:        v.FuelType,
+		Transmission:    v.Transmission,
+		Weight:          v.Weight,
+		Height:          v.Height,
+		Length:          v.Length,
+		Width:           v.Width,
+	}
+	return
+}
+
+// deserializeVehicle is a function that deserializes a vehicle from its JSON format
+// - the id is not deserialized, it is set when the vehicle is saved
+func deserializeVehicle(data VehicleJSON) (v internal.Vehicle) {
+	v = internal.Vehicle{
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           data.Brand,
+			Model:           data.Model,
+			Registration:    data.Registration,
+			Color:           data.Color,
+			FabricationYear: data.FabricationYear,
+			Capacity:        data.Capacity,
+			MaxSpeed:        data.MaxSpeed,
+			FuelType:        data.FuelType,
+			Transmission:    data.Transmission,
+			Weight:          data.Weight,
+			Dimensions: internal.Dimensions{
+				Height: data.Height,
+				Length: data.Length,
+				Width:  data.Width,
+			},
+		},
+	}
+	return
+}
\ No newline at end of file
@@ -0,0 +1,462 @@
+package handler_test
+
+import (
+	"app/internal"
+	"app/internal/handler"
+	"app/internal/repository"
+	"app/internal/service"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+)
+
+// newVehicle is a function that returns a vehicle with the given id, brand, registration and speed
+func newVehicle(id int, brand string, registration string, maxSpeed float64, capacity int) internal.Vehicle {
+	return internal.Vehicle{
+		Id: id,
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           brand,
+			Model:           "Model",
+			Registration:    registration,
+			Color:           "Red",
+			FabricationYear: 2000 + id,
+			Capacity:        capacity,
+			MaxSpeed:        maxSpeed,
+			FuelType:        "gasoline",
+			Transmission:    "manual",
+			Weight:          1000,
+			Dimensions:      internal.Dimensions{Height: 1.5, Length: float64(3 + id), Width: 1.5 + float64(id)/10},
+		},
+	}
+}
+
+// newHandler is a function that returns the vehicle handler over a map repository with three vehicles
+// - 1 and 2 are Ford, 3 is Fiat
+func newHandler() (hd *handler.VehicleDefault, rp *repository.VehicleMap) {
+	rp = repository.NewVehicleMap(map[int]internal.Vehicle{
+		1: newVehicle(1, "Ford", "A1", 150, 4),
+		2: newVehicle(2, "Ford", "A2", 200.5, 7),
+		3: newVehicle(3, "Fiat", "A3", 120, 5),
+	})
+	hd = handler.NewVehicleDefault(service.NewVehicleDefault(rp))
+	return
+}
+
+// newRequest is a function that returns a request with the given path parameters
+func newRequest(method string, target string, body string, params map[string]string) *http.Request {
+	r := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+	chiCtx := chi.NewRouteContext()
+	for key, value := range params {
+		chiCtx.URLParams.Add(key, value)
+	}
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, chiCtx))
+}
+
+// Tests for VehicleDefault - GetByDimensions
+func TestVehicleDefault_GetByDimensions(t *testing.T) {
+	type input struct {
+		query string
+	}
+	type output struct {
+		code int
+		body string
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	testCases := []testCase{
+		// success
+		{
+			name:   "success - length and width ranges, bounds included",
+			input:  input{query: "?length=4-5&width=1.6-1.7"},
+			output: output{code: http.StatusOK, body: `{"1":{"id":1},"2":{"id":2}}`},
+		},
+		{
+			name:   "success - only the length range",
+			input:  input{query: "?length=5.5-6"},
+			output: output{code: http.StatusOK, body: `{"3":{"id":3}}`},
+		},
+		{
+			name:   "success - no ranges",
+			input:  input{query: ""},
+			output: output{code: http.StatusOK, body: `{"1":{"id":1},"2":{"id":2},"3":{"id":3}}`},
+		},
+		// failure
+		{
+			name:   "failure - no vehicles within the ranges",
+			input:  input{query: "?length=10-20"},
+			output: output{code: http.StatusNotFound, body: `{"status":"Not Found","message":"no vehicles found with those criteria"}`},
+		},
+		{
+			name:   "failure - range without separator",
+			input:  input{query: "?length=4"},
+			output: output{code: http.StatusBadRequest, body: `{"status":"Bad Request","message":"invalid length, expected min-max"}`},
+		},
+		{
+			name:   "failure - range that is not a number",
+			input:  input{query: "?width=a-2"},
+			output: output{code: http.StatusBadRequest, body: `{"status":"Bad Request","message":"invalid width, expected min-max"}`},
+		},
+		{
+			name:   "failure - range with min over max",
+			input:  input{query: "?length=5-4"},
+			output: output{code: http.StatusBadRequest, body: `{"status":"Bad Request","message":"invalid length, expected min-max"}`},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			hd, _ := newHandler()
+			req := newRequest(http.MethodGet, "/vehicles/dimensions"+tc.input.query, "", nil)
+			res := httptest.NewRecorder()
+
+			// act
+			hd.GetByDimensions()(res, req)
+
+			// assert
+			require.Equal(t, tc.output.code, res.Code)
+			if tc.output.code != http.StatusOK {
+				require.JSONEq(t, tc.output.body, res.Body.String())
+				return
+			}
+			requireVehicleIds(t, tc.output.body, res.Body.String())
+		})
+	}
+}
+
+// Tests for VehicleDefault - GetByBrandAndYearRange
+func TestVehicleDefault_GetByBrandAndYearRange(t *testing.T) {
+	type input struct {
+		params map[string]string
+	}
+	type output struct {
+		code int
+		body string
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	testCases := []testCase{
+		// success
+		{
+			name:   "success - years included, brand case insensitive",
+			input:  input{params: map[string]string{"brand": "ford", "start_year": "2001", "end_year": "2002"}},
+			output: output{code: http.StatusOK, body: `{"1":{"id":1},"2":{"id":2}}`},
+		},
+		{
+			name:   "success - single year",
+			input:  input{params: map[string]string{"brand": "Ford", "start_year": "2002", "end_year": "2002"}},
+			output: output{code: http.StatusOK, body: `{"2":{"id":2}}`},
+		},
+		// failure
+		{
+			name:   "failure - no vehicles between the years",
+			input:  input{params: map[string]string{"brand": "Fiat", "start_year": "2001", "end_year": "2002"}},
+			output: output{code: http.StatusNotFound, body: `{"status":"Not Found","message":"no vehicles found with those criteria"}`},
+		},
+		{
+			name:   "failure - start year after end year",
+			input:  input{params: map[string]string{"brand": "Ford", "start_year": "2003", "end_year": "2001"}},
+			output: output{code: http.StatusBadRequest, body: `{"status":"Bad Request","message":"start_year must not be after end_year"}`},
+		},
+		{
+			name:   "failure - year that is not a number",
+			input:  input{params: map[string]string{"brand": "Ford", "start_year": "x", "end_year": "2001"}},
+			output: output{code: http.StatusBadRequest, body: `{"status":"Bad Request","message":"invalid start_year"}`},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			hd, _ := newHandler()
+			req := newRequest(http.MethodGet, "/vehicles/brand", "", tc.input.params)
+			res := httptest.NewRecorder()
+
+			// act
+			hd.GetByBrandAndYearRange()(res, req)
+
+			// assert
+			require.Equal(t, tc.output.code, res.Code)
+			if tc.output.code != http.StatusOK {
+				require.JSONEq(t, tc.output.body, res.Body.String())
+				return
+			}
+			requireVehicleIds(t, tc.output.body, res.Body.String())
+		})
+	}
+}
+
+// Tests for VehicleDefault - GetAverageMaxSpeedByBrand and GetAverageCapacityByBrand
+func TestVehicleDefault_GetAverageByBrand(t *testing.T) {
+	type input struct {
+		handler func(hd *handler.VehicleDefault) http.HandlerFunc
+		brand   string
+	}
+	type output struct {
+		code int
+		body string
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	averageSpeed := func(hd *handler.VehicleDefault) http.HandlerFunc { return hd.GetAverageMaxSpeedByBrand() }
+	averageCapacity := func(hd *handler.VehicleDefault) http.HandlerFunc { return hd.GetAverageCapacityByBrand() }
+	testCases := []testCase{
+		// success
+		{
+			name:   "success - average speed",
+			input:  input{handler: averageSpeed, brand: "Ford"},
+			output: output{code: http.StatusOK, body: `{"message":"success","data":175.25}`},
+		},
+		{
+			name:   "success - average capacity, not truncated",
+			input:  input{handler: averageCapacity, brand: "Ford"},
+			output: output{code: http.StatusOK, body: `{"message":"success","data":5.5}`},
+		},
+		{
+			name:   "success - average of a single vehicle",
+			input:  input{handler: averageSpeed, brand: "Fiat"},
+			output: output{code: http.StatusOK, body: `{"message":"success","data":120}`},
+		},
+		// failure
+		{
+			name:   "failure - average speed - brand without vehicles",
+			input:  input{handler: averageSpeed, brand: "Tesla"},
+			output: output{code: http.StatusNotFound, body: `{"status":"Not Found","message":"no vehicles found with those criteria"}`},
+		},
+		{
+			name:   "failure - average capacity - brand without vehicles",
+			input:  input{handler: averageCapacity, brand: "Tesla"},
+			output: output{code: http.StatusNotFound, body: `{"status":"Not Found","message":"no vehicles found with those criteria"}`},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			hd, _ := newHandler()
+			req := newRequest(http.MethodGet, "/vehicles/average", "", map[string]string{"brand": tc.input.brand})
+			res := httptest.NewRecorder()
+
+			// act
+			tc.input.handler(hd)(res, req)
+
+			// assert
+			require.Equal(t, tc.output.code, res.Code)
+			require.JSONEq(t, tc.output.body, res.Body.String())
+		})
+	}
+}
+
+// Tests for VehicleDefault - Create
+func TestVehicleDefault_Create(t *testing.T) {
+	type input struct {
+		body string
+	}
+	type output struct {
+		code     int
+		body     string
+		vehicles int
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	testCases := []testCase{
+		// success
+		{
+			name:  "success - vehicle created with the next id",
+			input: input{body: `{"brand":"Fiat","model":"Uno","registration":"B1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual","weight":900,"height":1.4,"length":3.6,"width":1.6}`},
+			output: output{
+				code:     http.StatusCreated,
+				body:     `{"message":"success","data":{"id":4,"brand":"Fiat","model":"Uno","registration":"B1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual","weight":900,"height":1.4,"length":3.6,"width":1.6}}`,
+				vehicles: 4,
+			},
+		},
+		// failure
+		{
+			name:  "failure - registration of another vehicle",
+			input: input{body: `{"brand":"Fiat","model":"Uno","registration":"A1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual"}`},
+			output: output{
+				code:     http.StatusConflict,
+				body:     `{"status":"Conflict","message":"a vehicle with the same registration already exists"}`,
+				vehicles: 3,
+			},
+		},
+		{
+			name:  "failure - invalid vehicle",
+			input: input{body: `{"brand":"Fiat","model":"Uno","registration":"B1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"electric","transmission":"manual"}`},
+			output: output{
+				code:     http.StatusBadRequest,
+				body:     `{"status":"Bad Request","message":"vehicle: invalid attributes: fuel_type must be one of biodiesel, diesel, gas, gasoline"}`,
+				vehicles: 3,
+			},
+		},
+		{
+			name:  "failure - body that is not a vehicle",
+			input: input{body: `{"brand":1}`},
+			output: output{
+				code:     http.StatusBadRequest,
+				body:     `{"status":"Bad Request","message":"invalid body"}`,
+				vehicles: 3,
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			hd, rp := newHandler()
+			req := newRequest(http.MethodPost, "/vehicles", tc.input.body, nil)
+			res := httptest.NewRecorder()
+
+			// act
+			hd.Create()(res, req)
+
+			// assert
+			require.Equal(t, tc.output.code, res.Code)
+			require.JSONEq(t, tc.output.body, res.Body.String())
+			v, err := rp.FindAll()
+			require.NoError(t, err)
+			require.Len(t, v, tc.output.vehicles)
+		})
+	}
+}
+
+// Tests for VehicleDefault - CreateBatch
+func TestVehicleDefault_CreateBatch(t *testing.T) {
+	type input struct {
+		body string
+	}
+	type output struct {
+		code     int
+		body     string
+		vehicles int
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	valid := `{"brand":"Fiat","model":"Uno","registration":"B1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual"}`
+	testCases := []testCase{
+		// success
+		{
+			name:  "success - all the vehicles are created",
+			input: input{body: "[" + valid + "," + strings.Replace(valid, "B1", "B2", 1) + "]"},
+			output: output{
+				code: http.StatusCreated,
+				body: `{"message":"success","data":[` +
+					`{"id":4,"brand":"Fiat","model":"Uno","registration":"B1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual","weight":0,"height":0,"length":0,"width":0},` +
+					`{"id":5,"brand":"Fiat","model":"Uno","registration":"B2","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual","weight":0,"height":0,"length":0,"width":0}]}`,
+				vehicles: 5,
+			},
+		},
+		// failure
+		{
+			name:  "failure - registration of another vehicle - none is created",
+			input: input{body: "[" + valid + "," + strings.Replace(valid, "B1", "A2", 1) + "]"},
+			output: output{
+				code:     http.StatusConflict,
+				body:     `{"status":"Conflict","message":"a vehicle with the same registration already exists"}`,
+				vehicles: 3,
+			},
+		},
+		{
+			name:  "failure - registration repeated in the batch - none is created",
+			input: input{body: "[" + valid + "," + valid + "]"},
+			output: output{
+				code:     http.StatusConflict,
+				body:     `{"status":"Conflict","message":"a vehicle with the same registration already exists"}`,
+				vehicles: 3,
+			},
+		},
+		{
+			name:  "failure - invalid vehicle - none is created",
+			input: input{body: "[" + valid + "," + strings.Replace(valid, `"passengers":4`, `"passengers":0`, 1) + "]"},
+			output: output{
+				code:     http.StatusBadRequest,
+				body:     `{"status":"Bad Request","message":"vehicle 1: vehicle: invalid attributes: passengers must be greater than zero"}`,
+				vehicles: 3,
+			},
+		},
+		{
+			name:  "failure - empty batch",
+			input: input{body: "[]"},
+			output: output{
+				code:     http.StatusBadRequest,
+				body:     `{"status":"Bad Request","message":"invalid body"}`,
+				vehicles: 3,
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			hd, rp := newHandler()
+			req := newRequest(http.MethodPost, "/vehicles/batch", tc.input.body, nil)
+			res := httptest.NewRecorder()
+
+			// act
+			hd.CreateBatch()(res, req)
+
+			// assert
+			require.Equal(t, tc.output.code, res.Code)
+			require.JSONEq(t, tc.output.body, res.Body.String())
+			v, err := rp.FindAll()
+			require.NoError(t, err)
+			require.Len(t, v, tc.output.vehicles)
+		})
+	}
+}
+
+// requireVehicleIds is a function that checks the response data has the vehicles with the expected ids, keyed by id
+func requireVehicleIds(t *testing.T, expected string, body string) {
+	t.Helper()
+
+	var expectedData map[string]struct {
+		ID int `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(expected), &expectedData))
+	var res struct {
+		Data map[string]struct {
+			ID int `json:"id"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(body), &res))
+	require.Equal(t, expectedData, res.Data)
+}
@@ -2,21 +2,32 @@ package loader
 
 import (
 	"app/internal"
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 )
 
 // NewVehicleJSONFile is a function that returns a new instance of VehicleJSONFile
-func NewVehicleJSONFile(path string) *VehicleJSONFile {
+// - strict fails the load on the first record with a missing field or an invalid value,
+// otherwise missing fields are zero-filled, invalid records are skipped and both are reported in the statistics
+func NewVehicleJSONFile(path string, strict bool) *VehicleJSONFile {
 	return &VehicleJSONFile{
-		path: path,
+		path:   path,
+		strict: strict,
 	}
 }
 
-// VehicleJSONFile is a struct that implements the LoaderVehicle interface
+// VehicleJSONFile is a struct that implements the VehicleLoader and VehicleStorer interfaces
 type VehicleJSONFile struct {
 	// path is the path to the file that contains the vehicles in JSON format
 	path string
+	// strict fails the load on the first record with an issue
+	strict bool
+	// rejected are the raw records not loaded by the last load, written back by Store so they are not lost
+	rejected []json.RawMessage
 }
 
 // VehicleJSON is a struct that represents a vehicle in JSON format
@@ -37,8 +48,11 @@ type VehicleJSON struct {
 	Width           float64 `json:"width"`
 }
 
-// Load is a method that loads the vehicles
-func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
+// Load is a method that loads the vehicles and returns the statistics of the load
+// - the fields that are not known are ignored and counted in the statistics
+// - the registration is accepted as a string or as a number
+// - a record without id, with a duplicated id or with a value of another type is not loaded
+func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, stats internal.VehicleLoadStats, err error) {
 	// open file
 	file, err := os.Open(l.path)
 	if err != nil {
@@ -46,16 +60,81 @@ func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
 	}
 	defer file.Close()
 
-	// decode file
-	var vehiclesJSON []VehicleJSON
-	err = json.NewDecoder(file).Decode(&vehiclesJSON)
+	// decode file, keeping the raw fields of each record
+	var raws []json.RawMessage
+	err = json.NewDecoder(file).Decode(&raws)
 	if err != nil {
 		return
 	}
+	records := make([]map[string]json.RawMessage, len(raws))
+	for index, raw := range raws {
+		err = json.Unmarshal(raw, &records[index])
+		if err != nil {
+			return
+		}
+	}
 
 	// serialize vehicles
+	l.rejected = nil
 	v = make(map[int]internal.Vehicle)
-	for _, vh := range vehiclesJSON {
+	stats = internal.VehicleLoadStats{
+		Records:       len(records),
+		MissingFields: make(map[string]int),
+		ExtraFields:   make(map[string]int),
+	}
+	var rejectedRaws []json.RawMessage
+	for index, record := range records {
+		var vh VehicleJSON
+		var issues []*internal.VehicleRecordError
+		rejected := false
+
+		// - known fields
+		fields := vh.fields()
+		for _, f := range fields {
+			raw, ok := record[f.name]
+			if !ok || string(raw) == "null" {
+				stats.MissingFields[f.name]++
+				// - a record without id can not be stored
+				if f.name == "id" {
+					rejected = true
+				}
+				issues = append(issues, &internal.VehicleRecordError{Index: index, Field: f.name, Err: internal.ErrLoaderVehicleFieldMissing})
+				continue
+			}
+			if errField := f.decode(raw); errField != nil {
+				rejected = true
+				issues = append(issues, &internal.VehicleRecordError{Index: index, Field: f.name, Err: fmt.Errorf("%w: %s", internal.ErrLoaderVehicleFieldType, raw)})
+			}
+		}
+		// - unknown fields
+		for name := range record {
+			if !hasField(fields, name) {
+				stats.ExtraFields[name]++
+			}
+		}
+		// - id
+		if _, ok := v[vh.Id]; ok && !rejected {
+			rejected = true
+			issues = append(issues, &internal.VehicleRecordError{Index: index, Field: "id", Err: fmt.Errorf("%w: %d", internal.ErrLoaderVehicleIdDuplicated, vh.Id)})
+		}
+
+		// report the issues of the record
+		if len(issues) > 0 && l.strict {
+			v = nil
+			err = issues[0]
+			return
+		}
+		stats.Issues = append(stats.Issues, issues...)
+		if rejected {
+			stats.Rejected++
+			rejectedRaws = append(rejectedRaws, raws[index])
+			continue
+		}
+		if len(issues) > 0 {
+			stats.Incomplete++
+		}
+
+		// add the vehicle
 		v[vh.Id] = internal.Vehicle{
 			Id: vh.Id,
 			VehicleAttributes: internal.VehicleAttributes{
@@ -76,7 +155,156 @@ func (l *VehicleJSONFile) Load() (v map[int]internal.Vehicle, err error) {
 				},
 			},
 		}
+		stats.Loaded++
 	}
+	l.rejected = rejectedRaws
 
 	return
 }
+
+// vehicleField is a struct that represents a field of a vehicle in JSON format
+type vehicleField struct {
+	// name is the name of the field in the JSON format
+	name string
+	// decode is a function that decodes the raw value into the field
+	decode func(raw json.RawMessage) (err error)
+}
+
+// fields is a method that returns the fields of the vehicle, decoding into vh
+func (vh *VehicleJSON) fields() (f []vehicleField) {
+	into := func(ptr any) func(raw json.RawMessage) error {
+		return func(raw json.RawMessage) error { return json.Unmarshal(raw, ptr) }
+	}
+	f = []vehicleField{
+		{name: "id", decode: into(&vh.Id)},
+		{name: "brand", decode: into(&vh.Brand)},
+		{name: "model", decode: into(&vh.Model)},
+		{name: "registration", decode: vh.decodeRegistration},
+		{name: "color", decode: into(&vh.Color)},
+		{name: "year", decode: into(&vh.FabricationYear)},
+		{name: "passengers", decode: into(&vh.Capacity)},
+		{name: "max_speed", decode: into(&vh.MaxSpeed)},
+		{name: "fuel_type", decode: into(&vh.FuelType)},
+		{name: "transmission", decode: into(&vh.Transmission)},
+		{name: "weight", decode: into(&vh.Weight)},
+		{name: "height", decode: into(&vh.Height)},
+		{name: "length", decode: into(&vh.Length)},
+		{name: "width", decode: into(&vh.Width)},
+	}
+	return
+}
+
+// decodeRegistration is a method that decodes the registration from a string or a number
+func (vh *VehicleJSON) decodeRegistration(raw json.RawMessage) (err error) {
+	// - string
+	err = json.Unmarshal(raw, &vh.Registration)
+	if err == nil {
+		return
+	}
+
+	// - number, kept as written
+	var number json.Number
+	if errNumber := json.Unmarshal(raw, &number); errNumber != nil || bytes.HasPrefix(raw, []byte(`"`)) {
+		return
+	}
+	vh.Registration = number.String()
+	err = nil
+	return
+}
+
+// hasField is a function that checks if a field is known
+func hasField(fields []vehicleField, name string) (ok bool) {
+	for _, f := range fields {
+		if f.name == name {
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+// Store is a method that replaces the vehicles of the file with the given ones, sorted by id
+// - the records not loaded by the last load are written back as they were after the vehicles,
+// so the file keeps them to be fixed by hand and a later load still prefers the vehicles with the same id
+// - the vehicles are written to a temporary file in the same directory that is renamed over the original one,
+// so the file is either fully updated or left untouched
+func (l *VehicleJSONFile) Store(v map[int]internal.Vehicle) (err error) {
+	// encode the vehicles, one per line as in the original file
+	ids := make([]int, 0, len(v))
+	for id := range v {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, id := range ids {
+		vh := v[id]
+		var line []byte
+		line, err = json.Marshal(VehicleJSON{
+			Id:              vh.Id,
+			Brand:           vh.Brand,
+			Model:           vh.Model,
+			Registration:    vh.Registration,
+			Color:           vh.Color,
+			FabricationYear: vh.FabricationYear,
+			Capacity:        vh.Capacity,
+			MaxSpeed:        vh.MaxSpeed,
+			FuelType:        vh.FuelType,
+			Transmission:    vh.Transmission,
+			Weight:          vh.Weight,
+			Height:          vh.Height,
+			Length:          vh.Length,
+			Width:           vh.Width,
+		})
+		if err != nil {
+			return
+		}
+		if i > 0 {
+			buf.WriteString(",\n")
+		}
+		buf.Write(line)
+	}
+	for i, raw := range l.rejected {
+		if len(ids) > 0 || i > 0 {
+			buf.WriteString(",\n")
+		}
+		err = json.Compact(&buf, raw)
+		if err != nil {
+			return
+		}
+	}
+	buf.WriteByte(']')
+
+	// write a temporary file
+	file, err := os.CreateTemp(filepath.Dir(l.path), filepath.Base(l.path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+	// - keep the permissions of the original file
+	if info, errStat := os.Stat(l.path); errStat == nil {
+		err = file.Chmod(info.Mode().Perm())
+		if err != nil {
+			file.Close()
+			return
+		}
+	}
+	_, err = file.Write(buf.Bytes())
+	if err != nil {
+		file.Close()
+		return
+	}
+	err = file.Sync()
+	if err != nil {
+		file.Close()
+		return
+	}
+	err = file.Close()
+	if err != nil {
+		return
+	}
+
+	// replace the original file
+	err = os.Rename(file.Name(), l.path)
+	return
+}
\ No newline at end of file
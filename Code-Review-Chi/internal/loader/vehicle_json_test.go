@@ -0,0 +1,207 @@
+package loader_test
+
+import (
+	"app/internal"
+	"app/internal/loader"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeFile is a function that writes the content to a temporary file and returns its path
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vehicles.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// Tests for VehicleJSONFile - Load
+func TestVehicleJSONFile_Load(t *testing.T) {
+	type input struct {
+		content string
+		strict  bool
+	}
+	type output struct {
+		v      map[int]internal.Vehicle
+		stats  internal.VehicleLoadStats
+		err    error
+		errMsg string
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	complete := `{"id":1,"brand":"Ford","model":"Focus","registration":"A1","color":"Red","year":2010,"passengers":5,"max_speed":180.5,"fuel_type":"gasoline","transmission":"manual","weight":1200,"height":1.5,"length":4.4,"width":1.8}`
+	vehicle := internal.Vehicle{
+		Id: 1,
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           "Ford",
+			Model:           "Focus",
+			Registration:    "A1",
+			Color:           "Red",
+			FabricationYear: 2010,
+			Capacity:        5,
+			MaxSpeed:        180.5,
+			FuelType:        "gasoline",
+			Transmission:    "manual",
+			Weight:          1200,
+			Dimensions:      internal.Dimensions{Height: 1.5, Length: 4.4, Width: 1.8},
+		},
+	}
+	testCases := []testCase{
+		// success
+		{
+			name:  "success - complete record",
+			input: input{content: "[" + complete + "]"},
+			output: output{
+				v:     map[int]internal.Vehicle{1: vehicle},
+				stats: internal.VehicleLoadStats{Records: 1, Loaded: 1, MissingFields: map[string]int{}, ExtraFields: map[string]int{}},
+			},
+		},
+		{
+			name:  "success - registration as a number and unknown fields",
+			input: input{content: `[{"id":1,"brand":"Ford","model":"Focus","registration":123,"color":"Red","year":2010,"passengers":5,"max_speed":180.5,"fuel_type":"gasoline","transmission":"manual","weight":1200,"height":1.5,"length":4.4,"width":1.8,"vin":"X","owner":null}]`},
+			output: output{
+				v: func() map[int]internal.Vehicle {
+					vh := vehicle
+					vh.Registration = "123"
+					return map[int]internal.Vehicle{1: vh}
+				}(),
+				stats: internal.VehicleLoadStats{Records: 1, Loaded: 1, MissingFields: map[string]int{}, ExtraFields: map[string]int{"owner": 1, "vin": 1}},
+			},
+		},
+		{
+			name:  "success - missing and null fields are zero-filled",
+			input: input{content: `[{"id":1,"brand":"Ford","model":"Focus","registration":"A1","color":"Red","year":2010,"passengers":5,"max_speed":180.5,"fuel_type":"gasoline","transmission":"manual","weight":null,"height":1.5,"width":1.8}]`},
+			output: output{
+				v: func() map[int]internal.Vehicle {
+					vh := vehicle
+					vh.Weight = 0
+					vh.Length = 0
+					return map[int]internal.Vehicle{1: vh}
+				}(),
+				stats: internal.VehicleLoadStats{
+					Records:       1,
+					Loaded:        1,
+					Incomplete:    1,
+					MissingFields: map[string]int{"weight": 1, "length": 1},
+					ExtraFields:   map[string]int{},
+					Issues: []*internal.VehicleRecordError{
+						{Index: 0, Field: "weight", Err: internal.ErrLoaderVehicleFieldMissing},
+						{Index: 0, Field: "length", Err: internal.ErrLoaderVehicleFieldMissing},
+					},
+				},
+			},
+		},
+		{
+			name: "success - records without id, with a value of another type or a duplicated id are rejected",
+			input: input{content: "[" + complete + ",\n" +
+				`{"brand":"Fiat","model":"Uno","registration":"B1","color":"Blue","year":2000,"passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual","weight":900,"height":1.4,"length":3.6,"width":1.6},` + "\n" +
+				`{"id":3,"brand":"Fiat","model":"Uno","registration":"B2","color":"Blue","year":"2000","passengers":4,"max_speed":150,"fuel_type":"gas","transmission":"manual","weight":900,"height":1.4,"length":3.6,"width":1.6},` + "\n" +
+				complete + "]"},
+			output: output{
+				v: map[int]internal.Vehicle{1: vehicle},
+				stats: internal.VehicleLoadStats{
+					Records:       4,
+					Loaded:        1,
+					Rejected:      3,
+					MissingFields: map[string]int{"id": 1},
+					ExtraFields:   map[string]int{},
+				},
+			},
+		},
+		// failure
+		{
+			name:   "failure - strict mode - missing field",
+			input:  input{content: `[{"id":1,"brand":"Ford"}]`, strict: true},
+			output: output{err: internal.ErrLoaderVehicleFieldMissing, errMsg: "record 0: field model: loader: field missing"},
+		},
+		{
+			name:   "failure - strict mode - value of another type",
+			input:  input{content: `[{"id":1,"brand":"Ford","model":"Focus","registration":"A1","color":"Red","year":2010,"passengers":"five","max_speed":180.5,"fuel_type":"gasoline","transmission":"manual","weight":1200,"height":1.5,"length":4.4,"width":1.8}]`, strict: true},
+			output: output{err: internal.ErrLoaderVehicleFieldType, errMsg: `record 0: field passengers: loader: field with invalid type: "five"`},
+		},
+		{
+			name:   "failure - strict mode - duplicated id",
+			input:  input{content: "[" + complete + "," + complete + "]", strict: true},
+			output: output{err: internal.ErrLoaderVehicleIdDuplicated, errMsg: "record 1: field id: loader: id duplicated: 1"},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			ld := loader.NewVehicleJSONFile(writeFile(t, tc.input.content), tc.input.strict)
+
+			// act
+			v, stats, err := ld.Load()
+
+			// assert
+			if tc.output.err != nil {
+				require.ErrorIs(t, err, tc.output.err)
+				require.EqualError(t, err, tc.output.errMsg)
+				require.Nil(t, v)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.output.v, v)
+			// - the issues of the rejected records are checked by their count
+			if tc.output.stats.Rejected > 0 {
+				require.Len(t, stats.Issues, tc.output.stats.Rejected)
+				stats.Issues = nil
+			}
+			require.Equal(t, tc.output.stats, stats)
+		})
+	}
+}
+
+// Tests for VehicleJSONFile - Store
+func TestVehicleJSONFile_Store(t *testing.T) {
+	t.Run("success - one vehicle per line, sorted by id", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, "[]")
+		ld := loader.NewVehicleJSONFile(path, false)
+		v := map[int]internal.Vehicle{
+			2: {Id: 2, VehicleAttributes: internal.VehicleAttributes{Brand: "Fiat", Registration: "B1", MaxSpeed: 150.25}},
+			1: {Id: 1, VehicleAttributes: internal.VehicleAttributes{Brand: "Ford", Registration: "A1", MaxSpeed: 180.5}},
+		}
+
+		// act
+		err := ld.Store(v)
+
+		// assert
+		expectedContent := `[{"id":1,"brand":"Ford","model":"","registration":"A1","color":"","year":0,"passengers":0,"max_speed":180.5,"fuel_type":"","transmission":"","weight":0,"height":0,"length":0,"width":0},` + "\n" +
+			`{"id":2,"brand":"Fiat","model":"","registration":"B1","color":"","year":0,"passengers":0,"max_speed":150.25,"fuel_type":"","transmission":"","weight":0,"height":0,"length":0,"width":0}]`
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expectedContent, string(content))
+	})
+
+	t.Run("success - the records rejected by the load are kept", func(t *testing.T) {
+		// arrange
+		path := writeFile(t, `[{"id":1,"brand":"Ford"},`+"\n"+`{"brand": "Fiat"},`+"\n"+`{"id": 1, "brand": "Peugeot"}]`)
+		ld := loader.NewVehicleJSONFile(path, false)
+		v, stats, err := ld.Load()
+		require.NoError(t, err)
+		require.Equal(t, 2, stats.Rejected)
+		delete(v, 1)
+
+		// act
+		err = ld.Store(v)
+
+		// assert
+		expectedContent := `[{"brand":"Fiat"},` + "\n" + `{"id":1,"brand":"Peugeot"}]`
+		require.NoError(t, err)
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, expectedContent, string(content))
+	})
+}
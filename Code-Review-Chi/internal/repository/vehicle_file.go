@@ -0,0 +1,186 @@
+package repository
+
+import (
+	"app/internal"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// NewVehicleFile is a function that returns a new instance of VehicleFile
+// - rp holds the vehicles read from the storage at start
+// - debounce is the time the writes are grouped for before storing them, zero stores every write before it returns
+func NewVehicleFile(rp *VehicleMap, st internal.VehicleStorer, debounce time.Duration) *VehicleFile {
+	return &VehicleFile{
+		rp:       rp,
+		st:       st,
+		debounce: debounce,
+	}
+}
+
+// VehicleFile is a struct that represents a vehicle repository stored in a file
+// - the vehicles are kept in memory by a VehicleMap and written through to the file on every change
+type VehicleFile struct {
+	// rp is the repository that keeps the vehicles in memory
+	rp *VehicleMap
+	// st is the storage where the vehicles are written to
+	st internal.VehicleStorer
+	// debounce is the time the writes are grouped for before storing them
+	debounce time.Duration
+
+	// mu serializes the writes and the stores
+	mu sync.Mutex
+	// timer is the pending store when the writes are debounced
+	timer *time.Timer
+	// pending is true when there are writes not stored yet
+	pending bool
+}
+
+// FindAll is a method that returns a map of all vehicles
+func (r *VehicleFile) FindAll() (v map[int]internal.Vehicle, err error) {
+	v, err = r.rp.FindAll()
+	return
+}
+
+// FindByBrand is a method that returns a map of the vehicles of a brand
+func (r *VehicleFile) FindByBrand(brand string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.rp.FindByBrand(brand)
+	return
+}
+
+// FindByBrandAndYearRange is a method that returns a map of the vehicles of a brand fabricated between two years, both included
+func (r *VehicleFile) FindByBrandAndYearRange(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = r.rp.FindByBrandAndYearRange(brand, startYear, endYear)
+	return
+}
+
+// FindByFuelType is a method that returns a map of the vehicles with a fuel type
+func (r *VehicleFile) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.rp.FindByFuelType(fuelType)
+	return
+}
+
+// FindByTransmission is a method that returns a map of the vehicles with a transmission
+func (r *VehicleFile) FindByTransmission(transmission string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.rp.FindByTransmission(transmission)
+	return
+}
+
+// FindByDimensions is a method that returns a map of the vehicles whose length and width are within a range, bounds included
+func (r *VehicleFile) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = r.rp.FindByDimensions(minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// Save is a method that saves a new vehicle, setting its id
+func (r *VehicleFile) Save(v *internal.Vehicle) (err error) {
+	err = r.write(func() error { return r.rp.Save(v) })
+	return
+}
+
+// SaveBatch is a method that saves new vehicles, setting their ids; none is saved if any of them fails
+func (r *VehicleFile) SaveBatch(v []internal.Vehicle) (err error) {
+	err = r.write(func() error { return r.rp.SaveBatch(v) })
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+func (r *VehicleFile) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = r.write(func() error { return r.rp.UpdateMaxSpeed(id, maxSpeed) })
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle
+func (r *VehicleFile) UpdateFuelType(id int, fuelType string) (err error) {
+	err = r.write(func() error { return r.rp.UpdateFuelType(id, fuelType) })
+	return
+}
+
+// Delete is a method that deletes a vehicle
+func (r *VehicleFile) Delete(id int) (err error) {
+	err = r.write(func() error { return r.rp.Delete(id) })
+	return
+}
+
+// Close is a method that stores the pending writes, it must be called before exiting when the writes are debounced
+func (r *VehicleFile) Close() (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// cancel the pending store and run it now
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	if !r.pending {
+		return
+	}
+	err = r.store()
+	return
+}
+
+// write is a method that applies a change to the vehicles in memory and stores them
+// - without debounce the change is undone if the vehicles can not be stored
+// - with debounce the store is scheduled and its errors are logged
+func (r *VehicleFile) write(change func() error) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// without debounce: apply the change and store it
+	if r.debounce <= 0 {
+		db, lastId := r.rp.snapshot()
+		err = change()
+		if err != nil {
+			return
+		}
+		err = r.store()
+		if err != nil {
+			r.rp.restore(db, lastId)
+			return
+		}
+		return
+	}
+
+	// with debounce: apply the change and schedule the store
+	err = change()
+	if err != nil {
+		return
+	}
+	r.pending = true
+	if r.timer == nil {
+		r.timer = time.AfterFunc(r.debounce, r.flush)
+		return
+	}
+	r.timer.Reset(r.debounce)
+	return
+}
+
+// flush is a method that runs the scheduled store
+func (r *VehicleFile) flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.timer = nil
+	if !r.pending {
+		return
+	}
+	if err := r.store(); err != nil {
+		log.Println(err)
+	}
+}
+
+// store is a method that writes the vehicles to the storage, the caller must hold the lock
+func (r *VehicleFile) store() (err error) {
+	v, err := r.rp.FindAll()
+	if err != nil {
+		return
+	}
+	err = r.st.Store(v)
+	if err != nil {
+		err = fmt.Errorf("repository: error storing vehicles: %w", err)
+		return
+	}
+	r.pending = false
+	return
+}
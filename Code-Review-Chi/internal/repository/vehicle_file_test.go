@@ -0,0 +1,161 @@
+package repository_test
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// storerStub is a struct that records the vehicles stored, failing with err if it is set
+type storerStub struct {
+	mu     sync.Mutex
+	err    error
+	stored []map[int]internal.Vehicle
+}
+
+// Store is a method that records the stored vehicles
+func (s *storerStub) Store(v map[int]internal.Vehicle) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		err = s.err
+		return
+	}
+	s.stored = append(s.stored, v)
+	return
+}
+
+// calls is a method that returns the vehicles stored so far
+func (s *storerStub) calls() (stored []map[int]internal.Vehicle) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored = append(stored, s.stored...)
+	return
+}
+
+// Tests for VehicleFile - writes without debounce
+func TestVehicleFile_Write(t *testing.T) {
+	t.Run("success - every write is stored before it returns", func(t *testing.T) {
+		// arrange
+		st := &storerStub{}
+		rp := repository.NewVehicleFile(repository.NewVehicleMap(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}), st, 0)
+
+		// act
+		v := newVehicle(0, "Fiat", "B1")
+		errSave := rp.Save(&v)
+		errSpeed := rp.UpdateMaxSpeed(1, 210.5)
+
+		// assert
+		updated := newVehicle(1, "Ford", "A1")
+		updated.MaxSpeed = 210.5
+		expectedStored := []map[int]internal.Vehicle{
+			{1: newVehicle(1, "Ford", "A1"), 2: newVehicle(2, "Fiat", "B1")},
+			{1: updated, 2: newVehicle(2, "Fiat", "B1")},
+		}
+		require.NoError(t, errSave)
+		require.NoError(t, errSpeed)
+		require.Equal(t, expectedStored, st.calls())
+	})
+
+	t.Run("failure - the write is rolled back when it can not be stored", func(t *testing.T) {
+		// arrange
+		errStore := errors.New("disk full")
+		st := &storerStub{err: errStore}
+		rp := repository.NewVehicleFile(repository.NewVehicleMap(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}), st, 0)
+
+		// act
+		v := newVehicle(0, "Fiat", "B1")
+		errSave := rp.Save(&v)
+		errDelete := rp.Delete(1)
+		db, _ := rp.FindAll()
+		// - once the storage works again, the id of the rolled back vehicle is given again
+		st.err = nil
+		retry := newVehicle(0, "Fiat", "B1")
+		errRetry := rp.Save(&retry)
+
+		// assert
+		require.ErrorIs(t, errSave, errStore)
+		require.ErrorIs(t, errDelete, errStore)
+		require.Equal(t, map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}, db)
+		require.NoError(t, errRetry)
+		require.Equal(t, 2, retry.Id)
+	})
+
+	t.Run("failure - a write that fails is not stored", func(t *testing.T) {
+		// arrange
+		st := &storerStub{}
+		rp := repository.NewVehicleFile(repository.NewVehicleMap(nil), st, 0)
+
+		// act
+		err := rp.Delete(1)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrRepositoryVehicleNotFound)
+		require.Empty(t, st.calls())
+	})
+}
+
+// Tests for VehicleFile - writes with debounce
+func TestVehicleFile_Debounce(t *testing.T) {
+	t.Run("success - the writes are grouped in a single store", func(t *testing.T) {
+		// arrange
+		st := &storerStub{}
+		rp := repository.NewVehicleFile(repository.NewVehicleMap(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}), st, 50*time.Millisecond)
+
+		// act
+		v := newVehicle(0, "Fiat", "B1")
+		errSave := rp.Save(&v)
+		errFuel := rp.UpdateFuelType(1, "diesel")
+		errDelete := rp.Delete(2)
+		storedBefore := st.calls()
+
+		// assert
+		updated := newVehicle(1, "Ford", "A1")
+		updated.FuelType = "diesel"
+		require.NoError(t, errSave)
+		require.NoError(t, errFuel)
+		require.NoError(t, errDelete)
+		require.Empty(t, storedBefore)
+		require.Eventually(t, func() bool { return len(st.calls()) > 0 }, time.Second, 10*time.Millisecond)
+		require.Equal(t, []map[int]internal.Vehicle{{1: updated}}, st.calls())
+	})
+
+	t.Run("success - close stores the pending writes", func(t *testing.T) {
+		// arrange
+		st := &storerStub{}
+		rp := repository.NewVehicleFile(repository.NewVehicleMap(nil), st, time.Hour)
+		v := newVehicle(0, "Fiat", "B1")
+		require.NoError(t, rp.Save(&v))
+
+		// act
+		errClose := rp.Close()
+		errCloseAgain := rp.Close()
+
+		// assert
+		require.NoError(t, errClose)
+		require.NoError(t, errCloseAgain)
+		require.Equal(t, []map[int]internal.Vehicle{{1: newVehicle(1, "Fiat", "B1")}}, st.calls())
+	})
+
+	t.Run("failure - close reports the pending writes that can not be stored", func(t *testing.T) {
+		// arrange
+		errStore := errors.New("disk full")
+		st := &storerStub{err: errStore}
+		rp := repository.NewVehicleFile(repository.NewVehicleMap(nil), st, time.Hour)
+		v := newVehicle(0, "Fiat", "B1")
+		require.NoError(t, rp.Save(&v))
+
+		// act
+		err := rp.Close()
+
+		// assert
+		require.ErrorIs(t, err, errStore)
+	})
+}
@@ -1,6 +1,11 @@
 package repository
 
-import "app/internal"
+import (
+	"app/internal"
+	"fmt"
+	"strings"
+	"sync"
+)
 
 // NewVehicleMap is a function that returns a new instance of VehicleMap
 func NewVehicleMap(db map[int]internal.Vehicle) *VehicleMap {
@@ -9,17 +14,31 @@ func NewVehicleMap(db map[int]internal.Vehicle) *VehicleMap {
 	if db != nil {
 		defaultDb = db
 	}
-	return &VehicleMap{db: defaultDb}
+	// last id
+	lastId := 0
+	for key := range defaultDb {
+		if key > lastId {
+			lastId = key
+		}
+	}
+	return &VehicleMap{db: defaultDb, lastId: lastId}
 }
 
 // VehicleMap is a struct that represents a vehicle repository
 type VehicleMap struct {
+	// mu guards db and lastId
+	mu sync.RWMutex
 	// db is a map of vehicles
 	db map[int]internal.Vehicle
+	// lastId is the highest id of the vehicles, new vehicles get the following ones
+	lastId int
 }
 
 // FindAll is a method that returns a map of all vehicles
 func (r *VehicleMap) FindAll() (v map[int]internal.Vehicle, err error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
 	v = make(map[int]internal.Vehicle)
 
 	// copy db
@@ -28,4 +47,189 @@ func (r *VehicleMap) FindAll() (v map[int]internal.Vehicle, err error) {
 	}
 
 	return
+}
+
+// FindByBrand is a method that returns a map of the vehicles of a brand
+func (r *VehicleMap) FindByBrand(brand string) (v map[int]internal.Vehicle, err error) {
+	v = r.filter(func(vh internal.Vehicle) bool {
+		return strings.EqualFold(vh.Brand, brand)
+	})
+	return
+}
+
+// FindByBrandAndYearRange is a method that returns a map of the vehicles of a brand fabricated between two years, both included
+func (r *VehicleMap) FindByBrandAndYearRange(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v = r.filter(func(vh internal.Vehicle) bool {
+		return strings.EqualFold(vh.Brand, brand) && vh.FabricationYear >= startYear && vh.FabricationYear <= endYear
+	})
+	return
+}
+
+// FindByFuelType is a method that returns a map of the vehicles with a fuel type
+func (r *VehicleMap) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v = r.filter(func(vh internal.Vehicle) bool {
+		return strings.EqualFold(vh.FuelType, fuelType)
+	})
+	return
+}
+
+// FindByTransmission is a method that returns a map of the vehicles with a transmission
+func (r *VehicleMap) FindByTransmission(transmission string) (v map[int]internal.Vehicle, err error) {
+	v = r.filter(func(vh internal.Vehicle) bool {
+		return strings.EqualFold(vh.Transmission, transmission)
+	})
+	return
+}
+
+// FindByDimensions is a method that returns a map of the vehicles whose length and width are within a range, bounds included
+func (r *VehicleMap) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v = r.filter(func(vh internal.Vehicle) bool {
+		return vh.Length >= minLength && vh.Length <= maxLength && vh.Width >= minWidth && vh.Width <= maxWidth
+	})
+	return
+}
+
+// filter is a method that returns a map of the vehicles that match a condition
+func (r *VehicleMap) filter(match func(vh internal.Vehicle) bool) (v map[int]internal.Vehicle) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	v = make(map[int]internal.Vehicle)
+
+	// copy the matching vehicles
+	for key, value := range r.db {
+		if match(value) {
+			v[key] = value
+		}
+	}
+
+	return
+}
+
+// Save is a method that saves a new vehicle, setting its id
+// - the registration must not belong to another vehicle
+func (r *VehicleMap) Save(v *internal.Vehicle) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the registration
+	if r.registrationExists((*v).Registration) {
+		err = fmt.Errorf("%w: registration %s", internal.ErrRepositoryVehicleAlreadyExists, (*v).Registration)
+		return
+	}
+
+	// save the vehicle
+	r.lastId++
+	(*v).Id = r.lastId
+	r.db[(*v).Id] = *v
+	return
+}
+
+// SaveBatch is a method that saves new vehicles, setting their ids; none is saved if any of them fails
+// - the registrations must not belong to other vehicles nor repeat within the batch
+func (r *VehicleMap) SaveBatch(v []internal.Vehicle) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the registrations
+	registrations := make(map[string]bool, len(v))
+	for _, vh := range v {
+		if registrations[vh.Registration] || r.registrationExists(vh.Registration) {
+			err = fmt.Errorf("%w: registration %s", internal.ErrRepositoryVehicleAlreadyExists, vh.Registration)
+			return
+		}
+		registrations[vh.Registration] = true
+	}
+
+	// save the vehicles
+	for i := range v {
+		r.lastId++
+		v[i].Id = r.lastId
+		r.db[v[i].Id] = v[i]
+	}
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+func (r *VehicleMap) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = r.update(id, func(vh *internal.Vehicle) {
+		vh.MaxSpeed = maxSpeed
+	})
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle
+func (r *VehicleMap) UpdateFuelType(id int, fuelType string) (err error) {
+	err = r.update(id, func(vh *internal.Vehicle) {
+		vh.FuelType = fuelType
+	})
+	return
+}
+
+// Delete is a method that deletes a vehicle
+// - the id is not reused by later vehicles
+func (r *VehicleMap) Delete(id int) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the vehicle exists
+	if _, ok := r.db[id]; !ok {
+		err = fmt.Errorf("%w: id %d", internal.ErrRepositoryVehicleNotFound, id)
+		return
+	}
+
+	// delete the vehicle
+	delete(r.db, id)
+	return
+}
+
+// update is a method that applies a change to an existing vehicle
+func (r *VehicleMap) update(id int, change func(vh *internal.Vehicle)) (err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// check the vehicle exists
+	vh, ok := r.db[id]
+	if !ok {
+		err = fmt.Errorf("%w: id %d", internal.ErrRepositoryVehicleNotFound, id)
+		return
+	}
+
+	// update the vehicle
+	change(&vh)
+	r.db[id] = vh
+	return
+}
+
+// registrationExists is a method that checks if a vehicle has the registration, the caller must hold the lock
+func (r *VehicleMap) registrationExists(registration string) (ok bool) {
+	for _, vh := range r.db {
+		if vh.Registration == registration {
+			ok = true
+			return
+		}
+	}
+	return
+}
+
+// snapshot is a method that returns a copy of the vehicles and the last id
+func (r *VehicleMap) snapshot() (db map[int]internal.Vehicle, lastId int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	db = make(map[int]internal.Vehicle, len(r.db))
+	for key, value := range r.db {
+		db[key] = value
+	}
+	lastId = r.lastId
+	return
+}
+
+// restore is a method that replaces the vehicles and the last id with a snapshot
+func (r *VehicleMap) restore(db map[int]internal.Vehicle, lastId int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.db = db
+	r.lastId = lastId
 }
\ No newline at end of file
@@ -0,0 +1,240 @@
+package repository_test
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newVehicle is a function that returns a vehicle with the given id, brand and registration
+func newVehicle(id int, brand string, registration string) internal.Vehicle {
+	return internal.Vehicle{
+		Id: id,
+		VehicleAttributes: internal.VehicleAttributes{
+			Brand:           brand,
+			Model:           "Model",
+			Registration:    registration,
+			Color:           "Red",
+			FabricationYear: 2010,
+			Capacity:        5,
+			MaxSpeed:        180,
+			FuelType:        "gasoline",
+			Transmission:    "manual",
+			Weight:          1200,
+			Dimensions:      internal.Dimensions{Height: 1.5, Length: 4.4, Width: 1.8},
+		},
+	}
+}
+
+// Tests for VehicleMap - Save
+func TestVehicleMap_Save(t *testing.T) {
+	type arrange struct {
+		db func() map[int]internal.Vehicle
+	}
+	type input struct {
+		v internal.Vehicle
+	}
+	type output struct {
+		id  int
+		err error
+		db  map[int]internal.Vehicle
+	}
+	type testCase struct {
+		name    string
+		arrange arrange
+		input   input
+		output  output
+	}
+
+	// test cases
+	testCases := []testCase{
+		// success
+		{
+			name: "success - empty repository - first id",
+			arrange: arrange{
+				db: func() map[int]internal.Vehicle { return nil },
+			},
+			input: input{v: newVehicle(0, "Ford", "A1")},
+			output: output{
+				id: 1,
+				db: map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")},
+			},
+		},
+		{
+			name: "success - id after the highest one",
+			arrange: arrange{
+				db: func() map[int]internal.Vehicle {
+					return map[int]internal.Vehicle{7: newVehicle(7, "Ford", "A1")}
+				},
+			},
+			input: input{v: newVehicle(0, "Ford", "A2")},
+			output: output{
+				id: 8,
+				db: map[int]internal.Vehicle{7: newVehicle(7, "Ford", "A1"), 8: newVehicle(8, "Ford", "A2")},
+			},
+		},
+		// failure
+		{
+			name: "failure - registration of another vehicle",
+			arrange: arrange{
+				db: func() map[int]internal.Vehicle {
+					return map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}
+				},
+			},
+			input: input{v: newVehicle(0, "Fiat", "A1")},
+			output: output{
+				err: internal.ErrRepositoryVehicleAlreadyExists,
+				db:  map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")},
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			rp := repository.NewVehicleMap(tc.arrange.db())
+
+			// act
+			v := tc.input.v
+			err := rp.Save(&v)
+
+			// assert
+			require.ErrorIs(t, err, tc.output.err)
+			require.Equal(t, tc.output.id, v.Id)
+			db, err := rp.FindAll()
+			require.NoError(t, err)
+			require.Equal(t, tc.output.db, db)
+		})
+	}
+}
+
+// Tests for VehicleMap - SaveBatch
+func TestVehicleMap_SaveBatch(t *testing.T) {
+	type input struct {
+		v []internal.Vehicle
+	}
+	type output struct {
+		ids []int
+		err error
+		db  map[int]internal.Vehicle
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	testCases := []testCase{
+		// success
+		{
+			name:  "success - all the vehicles are saved",
+			input: input{v: []internal.Vehicle{newVehicle(0, "Fiat", "B1"), newVehicle(0, "Fiat", "B2")}},
+			output: output{
+				ids: []int{2, 3},
+				db:  map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1"), 2: newVehicle(2, "Fiat", "B1"), 3: newVehicle(3, "Fiat", "B2")},
+			},
+		},
+		// failure
+		{
+			name:  "failure - registration of another vehicle - none is saved",
+			input: input{v: []internal.Vehicle{newVehicle(0, "Fiat", "B1"), newVehicle(0, "Fiat", "A1")}},
+			output: output{
+				ids: []int{0, 0},
+				err: internal.ErrRepositoryVehicleAlreadyExists,
+				db:  map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")},
+			},
+		},
+		{
+			name:  "failure - registration repeated in the batch - none is saved",
+			input: input{v: []internal.Vehicle{newVehicle(0, "Fiat", "B1"), newVehicle(0, "Fiat", "B1")}},
+			output: output{
+				ids: []int{0, 0},
+				err: internal.ErrRepositoryVehicleAlreadyExists,
+				db:  map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")},
+			},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			rp := repository.NewVehicleMap(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")})
+
+			// act
+			err := rp.SaveBatch(tc.input.v)
+
+			// assert
+			require.ErrorIs(t, err, tc.output.err)
+			ids := make([]int, len(tc.input.v))
+			for i, vh := range tc.input.v {
+				ids[i] = vh.Id
+			}
+			require.Equal(t, tc.output.ids, ids)
+			db, err := rp.FindAll()
+			require.NoError(t, err)
+			require.Equal(t, tc.output.db, db)
+		})
+	}
+}
+
+// Tests for VehicleMap - FindByDimensions
+func TestVehicleMap_FindByDimensions(t *testing.T) {
+	// arrange
+	small := newVehicle(1, "Fiat", "A1")
+	small.Length, small.Width = 3, 1.5
+	large := newVehicle(2, "Ford", "A2")
+	large.Length, large.Width = 5, 2
+	rp := repository.NewVehicleMap(map[int]internal.Vehicle{1: small, 2: large})
+
+	// act
+	v, err := rp.FindByDimensions(3, 4, 1.5, 2)
+
+	// assert
+	require.NoError(t, err)
+	require.Equal(t, map[int]internal.Vehicle{1: small}, v)
+}
+
+// Tests for VehicleMap - UpdateMaxSpeed, UpdateFuelType and Delete
+func TestVehicleMap_Write(t *testing.T) {
+	t.Run("success - updates and deletes the vehicle", func(t *testing.T) {
+		// arrange
+		rp := repository.NewVehicleMap(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")})
+
+		// act
+		errSpeed := rp.UpdateMaxSpeed(1, 210.5)
+		errFuel := rp.UpdateFuelType(1, "diesel")
+		updated, _ := rp.FindAll()
+		errDelete := rp.Delete(1)
+		deleted, _ := rp.FindAll()
+
+		// assert
+		expected := newVehicle(1, "Ford", "A1")
+		expected.MaxSpeed = 210.5
+		expected.FuelType = "diesel"
+		require.NoError(t, errSpeed)
+		require.NoError(t, errFuel)
+		require.NoError(t, errDelete)
+		require.Equal(t, map[int]internal.Vehicle{1: expected}, updated)
+		require.Empty(t, deleted)
+	})
+
+	t.Run("failure - vehicle not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewVehicleMap(nil)
+
+		// act
+		errSpeed := rp.UpdateMaxSpeed(1, 210.5)
+		errFuel := rp.UpdateFuelType(1, "diesel")
+		errDelete := rp.Delete(1)
+
+		// assert
+		require.ErrorIs(t, errSpeed, internal.ErrRepositoryVehicleNotFound)
+		require.ErrorIs(t, errFuel, internal.ErrRepositoryVehicleNotFound)
+		require.ErrorIs(t, errDelete, internal.ErrRepositoryVehicleNotFound)
+	})
+}
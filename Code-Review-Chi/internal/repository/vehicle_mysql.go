@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"app/internal"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const (
+	// vehicleColumns are the columns of the vehicles table in the order they are scanned
+	vehicleColumns = "`id`, `brand`, `model`, `registration`, `color`, `fabrication_year`, `capacity`, `max_speed`, `fuel_type`, `transmission`, `weight`, `height`, `length`, `width`"
+	// vehicleInsert is the statement that inserts a vehicle
+	vehicleInsert = "INSERT INTO vehicles (`brand`, `model`, `registration`, `color`, `fabrication_year`, `capacity`, `max_speed`, `fuel_type`, `transmission`, `weight`, `height`, `length`, `width`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	// vehicleImport is the statement that inserts a vehicle keeping its id, flagging if its registration is duplicated
+	vehicleImport = "INSERT INTO vehicles (`id`, `registration_duplicated`, `brand`, `model`, `registration`, `color`, `fabrication_year`, `capacity`, `max_speed`, `fuel_type`, `transmission`, `weight`, `height`, `length`, `width`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	// mysqlErrDuplicateEntry is the code of the error returned when a unique key is violated
+	mysqlErrDuplicateEntry = 1062
+)
+
+// NewVehicleMySQL is a function that returns a new instance of VehicleMySQL
+func NewVehicleMySQL(db *sql.DB) *VehicleMySQL {
+	return &VehicleMySQL{db: db}
+}
+
+// VehicleMySQL is a struct that represents a vehicle repository in a MySQL database
+// - the comparisons of the texts follow the collation of the table, case insensitive by default
+type VehicleMySQL struct {
+	// db is the database connection
+	db *sql.DB
+}
+
+// FindAll is a method that returns a map of all vehicles
+func (r *VehicleMySQL) FindAll() (v map[int]internal.Vehicle, err error) {
+	v, err = r.query("SELECT " + vehicleColumns + " FROM vehicles")
+	return
+}
+
+// FindByBrand is a method that returns a map of the vehicles of a brand
+func (r *VehicleMySQL) FindByBrand(brand string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.query("SELECT "+vehicleColumns+" FROM vehicles WHERE `brand` = ?", brand)
+	return
+}
+
+// FindByBrandAndYearRange is a method that returns a map of the vehicles of a brand fabricated between two years, both included
+func (r *VehicleMySQL) FindByBrandAndYearRange(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = r.query("SELECT "+vehicleColumns+" FROM vehicles WHERE `brand` = ? AND `fabrication_year` BETWEEN ? AND ?", brand, startYear, endYear)
+	return
+}
+
+// FindByFuelType is a method that returns a map of the vehicles with a fuel type
+func (r *VehicleMySQL) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.query("SELECT "+vehicleColumns+" FROM vehicles WHERE `fuel_type` = ?", fuelType)
+	return
+}
+
+// FindByTransmission is a method that returns a map of the vehicles with a transmission
+func (r *VehicleMySQL) FindByTransmission(transmission string) (v map[int]internal.Vehicle, err error) {
+	v, err = r.query("SELECT "+vehicleColumns+" FROM vehicles WHERE `transmission` = ?", transmission)
+	return
+}
+
+// FindByDimensions is a method that returns a map of the vehicles whose length and width are within a range, bounds included
+func (r *VehicleMySQL) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = r.query("SELECT "+vehicleColumns+" FROM vehicles WHERE `length` BETWEEN ? AND ? AND `width` BETWEEN ? AND ?", minLength, maxLength, minWidth, maxWidth)
+	return
+}
+
+// Save is a method that saves a new vehicle, setting its id
+func (r *VehicleMySQL) Save(v *internal.Vehicle) (err error) {
+	// execute the query
+	res, err := r.db.Exec(vehicleInsert, vehicleArgs(*v)...)
+	if err != nil {
+		err = vehicleError(err, *v)
+		return
+	}
+
+	// set the id
+	id, err := res.LastInsertId()
+	if err != nil {
+		return
+	}
+	(*v).Id = int(id)
+	return
+}
+
+// SaveBatch is a method that saves new vehicles, setting their ids; none is saved if any of them fails
+func (r *VehicleMySQL) SaveBatch(v []internal.Vehicle) (err error) {
+	// start a transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// insert the vehicles
+	ids := make([]int, len(v))
+	for i, vh := range v {
+		var res sql.Result
+		res, err = tx.Exec(vehicleInsert, vehicleArgs(vh)...)
+		if err != nil {
+			err = vehicleError(err, vh)
+			return
+		}
+		var id int64
+		id, err = res.LastInsertId()
+		if err != nil {
+			return
+		}
+		ids[i] = int(id)
+	}
+
+	// commit the transaction and set the ids
+	err = tx.Commit()
+	if err != nil {
+		return
+	}
+	for i := range v {
+		v[i].Id = ids[i]
+	}
+	return
+}
+
+// Import is a method that inserts vehicles keeping their ids, used to seed the database; none is inserted if any of them fails
+// - a registration already used by a vehicle with a lower id is kept, flagged as duplicated so it is not checked to be unique
+func (r *VehicleMySQL) Import(v map[int]internal.Vehicle) (err error) {
+	// start a transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+		}
+	}()
+
+	// insert the vehicles, sorted by id
+	ids := make([]int, 0, len(v))
+	for id := range v {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	registrations := make(map[string]bool)
+	for _, id := range ids {
+		vh := v[id]
+		// - the registrations are compared case insensitive, as the collation of the table
+		registration := strings.ToLower(vh.Registration)
+		args := append([]any{vh.Id, registrations[registration]}, vehicleArgs(vh)...)
+		_, err = tx.Exec(vehicleImport, args...)
+		if err != nil {
+			return
+		}
+		registrations[registration] = true
+	}
+
+	// commit the transaction
+	err = tx.Commit()
+	return
+}
+
+// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+func (r *VehicleMySQL) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	err = r.update(id, "UPDATE vehicles SET `max_speed` = ? WHERE `id` = ?", maxSpeed, id)
+	return
+}
+
+// UpdateFuelType is a method that updates the fuel type of a vehicle
+func (r *VehicleMySQL) UpdateFuelType(id int, fuelType string) (err error) {
+	err = r.update(id, "UPDATE vehicles SET `fuel_type` = ? WHERE `id` = ?", fuelType, id)
+	return
+}
+
+// Delete is a method that deletes a vehicle
+func (r *VehicleMySQL) Delete(id int) (err error) {
+	err = r.update(id, "DELETE FROM vehicles WHERE `id` = ?", id)
+	return
+}
+
+// query is a method that returns the vehicles selected by a query
+func (r *VehicleMySQL) query(query string, args ...any) (v map[int]internal.Vehicle, err error) {
+	// execute the query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	v = make(map[int]internal.Vehicle)
+	for rows.Next() {
+		var vh internal.Vehicle
+		err = rows.Scan(&vh.Id, &vh.Brand, &vh.Model, &vh.Registration, &vh.Color, &vh.FabricationYear, &vh.Capacity, &vh.MaxSpeed, &vh.FuelType, &vh.Transmission, &vh.Weight, &vh.Height, &vh.Length, &vh.Width)
+		if err != nil {
+			v = nil
+			return
+		}
+		v[vh.Id] = vh
+	}
+	err = rows.Err()
+	if err != nil {
+		v = nil
+		return
+	}
+
+	return
+}
+
+// update is a method that executes a statement on an existing vehicle
+// - mysql reports no affected rows when the values do not change, so the vehicle is looked up before failing
+func (r *VehicleMySQL) update(id int, statement string, args ...any) (err error) {
+	// execute the statement
+	res, err := r.db.Exec(statement, args...)
+	if err != nil {
+		return
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+	if affected > 0 {
+		return
+	}
+
+	// check the vehicle exists
+	var exists bool
+	err = r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM vehicles WHERE `id` = ?)", id).Scan(&exists)
+	if err != nil {
+		return
+	}
+	if !exists {
+		err = fmt.Errorf("%w: id %d", internal.ErrRepositoryVehicleNotFound, id)
+		return
+	}
+	return
+}
+
+// vehicleArgs is a function that returns the values of a vehicle in the order of vehicleInsert
+func vehicleArgs(v internal.Vehicle) (args []any) {
+	args = []any{v.Brand, v.Model, v.Registration, v.Color, v.FabricationYear, v.Capacity, v.MaxSpeed, v.FuelType, v.Transmission, v.Weight, v.Height, v.Length, v.Width}
+	return
+}
+
+// vehicleError is a function that translates the error of inserting a vehicle
+func vehicleError(err error, v internal.Vehicle) error {
+	var errMySQL *mysql.MySQLError
+	if errors.As(err, &errMySQL) && errMySQL.Number == mysqlErrDuplicateEntry {
+		return fmt.Errorf("%w: registration %s", internal.ErrRepositoryVehicleAlreadyExists, v.Registration)
+	}
+	return err
+}
@@ -0,0 +1,212 @@
+package repository_test
+
+import (
+	"app/internal"
+	"app/internal/repository"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-txdb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+// dsnTest is the connection to the database of docs/db/mysql/database_test.sql
+var dsnTest string
+
+func init() {
+	// db config
+	cfg := mysql.Config{
+		User:   "root",
+		Passwd: os.Getenv("MYSQL_ROOT_PASSWORD"),
+		Net:    "tcp",
+		Addr:   "localhost:3306",
+		DBName: "vehicles_test_db",
+	}
+	if addr := os.Getenv("MYSQL_ADDR"); addr != "" {
+		cfg.Addr = addr
+	}
+	dsnTest = cfg.FormatDSN()
+	// register txdb driver
+	txdb.Register("txdb", "mysql", dsnTest)
+}
+
+// openTxdb is a function that opens a connection whose changes are rolled back when the test ends
+// - the test is skipped if the database of docs/db/mysql/database_test.sql is not available
+func openTxdb(t *testing.T) *sql.DB {
+	t.Helper()
+
+	// check the database
+	db, err := sql.Open("mysql", dsnTest)
+	require.NoError(t, err)
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		t.Skipf("database not available: %s", err)
+	}
+
+	// open the transaction
+	tx, err := sql.Open("txdb", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { tx.Close() })
+	return tx
+}
+
+// Tests for VehicleMySQL - Import and the queries
+func TestVehicleMySQL_Find(t *testing.T) {
+	// arrange
+	db := openTxdb(t)
+	rp := repository.NewVehicleMySQL(db)
+	small := newVehicle(1, "Fiat", "A1")
+	small.Length, small.Width, small.MaxSpeed = 3, 1.5, 244.87
+	large := newVehicle(2, "Ford", "A2")
+	large.Length, large.Width, large.FabricationYear = 5, 2, 2020
+	require.NoError(t, rp.Import(map[int]internal.Vehicle{1: small, 2: large}))
+
+	t.Run("success - all the vehicles, the decimals are kept", func(t *testing.T) {
+		// act
+		v, err := rp.FindAll()
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, map[int]internal.Vehicle{1: small, 2: large}, v)
+	})
+
+	t.Run("success - brand case insensitive and years included", func(t *testing.T) {
+		// act
+		v, err := rp.FindByBrandAndYearRange("ford", 2010, 2020)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, map[int]internal.Vehicle{2: large}, v)
+	})
+
+	t.Run("success - dimensions, bounds included", func(t *testing.T) {
+		// act
+		v, err := rp.FindByDimensions(3, 4, 1.5, 2)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, map[int]internal.Vehicle{1: small}, v)
+	})
+}
+
+// Tests for VehicleMySQL - Import
+func TestVehicleMySQL_Import(t *testing.T) {
+	t.Run("success - the duplicated registrations are kept, the new ones must be unique", func(t *testing.T) {
+		// arrange
+		db := openTxdb(t)
+		rp := repository.NewVehicleMySQL(db)
+
+		// act
+		errImport := rp.Import(map[int]internal.Vehicle{
+			1: newVehicle(1, "Ford", "9"),
+			2: newVehicle(2, "Fiat", "9"),
+			3: newVehicle(3, "Fiat", "10"),
+		})
+		v := newVehicle(0, "Ford", "9")
+		errSave := rp.Save(&v)
+
+		// assert
+		require.NoError(t, errImport)
+		require.ErrorIs(t, errSave, internal.ErrRepositoryVehicleAlreadyExists)
+		all, err := rp.FindAll()
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+	})
+}
+
+// Tests for VehicleMySQL - Save and SaveBatch
+func TestVehicleMySQL_Save(t *testing.T) {
+	t.Run("success - the id is set", func(t *testing.T) {
+		// arrange
+		db := openTxdb(t)
+		rp := repository.NewVehicleMySQL(db)
+		require.NoError(t, rp.Import(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}))
+
+		// act
+		v := newVehicle(0, "Fiat", "B1")
+		err := rp.Save(&v)
+
+		// assert
+		// - the auto increment is not rolled back by txdb, so the id is only checked to follow the imported one
+		require.NoError(t, err)
+		require.Greater(t, v.Id, 1)
+		all, err := rp.FindAll()
+		require.NoError(t, err)
+		require.Equal(t, v, all[v.Id])
+	})
+
+	t.Run("failure - registration of another vehicle", func(t *testing.T) {
+		// arrange
+		db := openTxdb(t)
+		rp := repository.NewVehicleMySQL(db)
+		require.NoError(t, rp.Import(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}))
+
+		// act
+		v := newVehicle(0, "Fiat", "A1")
+		err := rp.Save(&v)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrRepositoryVehicleAlreadyExists)
+	})
+
+	t.Run("failure - batch with a registration of another vehicle - none is saved", func(t *testing.T) {
+		// arrange
+		db := openTxdb(t)
+		rp := repository.NewVehicleMySQL(db)
+		require.NoError(t, rp.Import(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}))
+
+		// act
+		err := rp.SaveBatch([]internal.Vehicle{newVehicle(0, "Fiat", "B1"), newVehicle(0, "Fiat", "A1")})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrRepositoryVehicleAlreadyExists)
+		v, err := rp.FindAll()
+		require.NoError(t, err)
+		require.Equal(t, map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}, v)
+	})
+}
+
+// Tests for VehicleMySQL - UpdateMaxSpeed, UpdateFuelType and Delete
+func TestVehicleMySQL_Write(t *testing.T) {
+	t.Run("success - updates and deletes the vehicle, also when the value does not change", func(t *testing.T) {
+		// arrange
+		db := openTxdb(t)
+		rp := repository.NewVehicleMySQL(db)
+		require.NoError(t, rp.Import(map[int]internal.Vehicle{1: newVehicle(1, "Ford", "A1")}))
+
+		// act
+		errSpeed := rp.UpdateMaxSpeed(1, 210.5)
+		errSpeedAgain := rp.UpdateMaxSpeed(1, 210.5)
+		errFuel := rp.UpdateFuelType(1, "diesel")
+		updated, _ := rp.FindAll()
+		errDelete := rp.Delete(1)
+		deleted, _ := rp.FindAll()
+
+		// assert
+		expected := newVehicle(1, "Ford", "A1")
+		expected.MaxSpeed = 210.5
+		expected.FuelType = "diesel"
+		require.NoError(t, errSpeed)
+		require.NoError(t, errSpeedAgain)
+		require.NoError(t, errFuel)
+		require.NoError(t, errDelete)
+		require.Equal(t, map[int]internal.Vehicle{1: expected}, updated)
+		require.Empty(t, deleted)
+	})
+
+	t.Run("failure - vehicle not found", func(t *testing.T) {
+		// arrange
+		db := openTxdb(t)
+		rp := repository.NewVehicleMySQL(db)
+
+		// act
+		errSpeed := rp.UpdateMaxSpeed(1, 210.5)
+		errDelete := rp.Delete(1)
+
+		// assert
+		require.ErrorIs(t, errSpeed, internal.ErrRepositoryVehicleNotFound)
+		require.ErrorIs(t, errDelete, internal.ErrRepositoryVehicleNotFound)
+	})
+}
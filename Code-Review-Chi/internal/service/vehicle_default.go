@@ -1,6 +1,9 @@
 package service
 
-import "app/internal"
+import (
+	"app/internal"
+	"fmt"
+)
 
 // NewVehicleDefault is a function that returns a new instance of VehicleDefault
 func NewVehicleDefault(rp internal.VehicleRepository) *VehicleDefault {
@@ -17,4 +20,159 @@ type VehicleDefault struct {
 func (s *VehicleDefault) FindAll() (v map[int]internal.Vehicle, err error) {
 	v, err = s.rp.FindAll()
 	return
+}
+
+// FindByBrandAndYearRange is a method that returns a map of the vehicles of a brand fabricated between two years, both included
+func (s *VehicleDefault) FindByBrandAndYearRange(brand string, startYear int, endYear int) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByBrandAndYearRange(brand, startYear, endYear)
+	if err != nil {
+		return
+	}
+	if len(v) == 0 {
+		err = internal.ErrServiceNoVehicles
+		return
+	}
+	return
+}
+
+// FindByFuelType is a method that returns a map of the vehicles with a fuel type
+func (s *VehicleDefault) FindByFuelType(fuelType string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByFuelType(fuelType)
+	if err != nil {
+		return
+	}
+	if len(v) == 0 {
+		err = internal.ErrServiceNoVehicles
+		return
+	}
+	return
+}
+
+// FindByTransmission is a method that returns a map of the vehicles with a transmission
+func (s *VehicleDefault) FindByTransmission(transmission string) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByTransmission(transmission)
+	if err != nil {
+		return
+	}
+	if len(v) == 0 {
+		err = internal.ErrServiceNoVehicles
+		return
+	}
+	return
+}
+
+// FindByDimensions is a method that returns a map of the vehicles whose length and width are within a range, bounds included
+func (s *VehicleDefault) FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]internal.Vehicle, err error) {
+	v, err = s.rp.FindByDimensions(minLength, maxLength, minWidth, maxWidth)
+	if err != nil {
+		return
+	}
+	if len(v) == 0 {
+		err = internal.ErrServiceNoVehicles
+		return
+	}
+	return
+}
+
+// AverageMaxSpeedByBrand is a method that returns the average maximum speed of the vehicles of a brand
+func (s *VehicleDefault) AverageMaxSpeedByBrand(brand string) (average float64, err error) {
+	// get the vehicles of the brand
+	v, err := s.rp.FindByBrand(brand)
+	if err != nil {
+		return
+	}
+	if len(v) == 0 {
+		err = internal.ErrServiceNoVehicles
+		return
+	}
+
+	// calculate the average
+	var total float64
+	for _, value := range v {
+		total += value.MaxSpeed
+	}
+	average = total / float64(len(v))
+	return
+}
+
+// AverageCapacityByBrand is a method that returns the average capacity of people of the vehicles of a brand
+func (s *VehicleDefault) AverageCapacityByBrand(brand string) (average float64, err error) {
+	// get the vehicles of the brand
+	v, err := s.rp.FindByBrand(brand)
+	if err != nil {
+		return
+	}
+	if len(v) == 0 {
+		err = internal.ErrServiceNoVehicles
+		return
+	}
+
+	// calculate the average
+	var total int
+	for _, value := range v {
+		total += value.Capacity
+	}
+	average = float64(total) / float64(len(v))
+	return
+}
+
+// Save is a method that validates and saves a new vehicle, setting its id
+func (s *VehicleDefault) Save(v *internal.Vehicle) (err error) {
+	// validate the vehicle
+	err = (*v).VehicleAttributes.Validate()
+	if err != nil {
+		return
+	}
+
+	// save the vehicle
+	err = s.rp.Save(v)
+	return
+}
+
+// SaveBatch is a method that validates and saves new vehicles, setting their ids; none is saved if any of them fails
+func (s *VehicleDefault) SaveBatch(v []internal.Vehicle) (err error) {
+	// validate the vehicles
+	for i, vh := range v {
+		err = vh.VehicleAttributes.Validate()
+		if err != nil {
+			err = fmt.Errorf("vehicle %d: %w", i, err)
+			return
+		}
+	}
+
+	// save the vehicles
+	err = s.rp.SaveBatch(v)
+	return
+}
+
+// UpdateMaxSpeed is a method that validates and updates the maximum speed of a vehicle
+func (s *VehicleDefault) UpdateMaxSpeed(id int, maxSpeed float64) (err error) {
+	// validate the speed
+	err = internal.ValidateMaxSpeed(maxSpeed)
+	if err != nil {
+		return
+	}
+
+	// update the vehicle
+	err = s.rp.UpdateMaxSpeed(id, maxSpeed)
+	return
+}
+
+// UpdateFuelType is a method that validates and updates the fuel type of a vehicle
+func (s *VehicleDefault) UpdateFuelType(id int, fuelType string) (err error) {
+	// validate the fuel type
+	err = internal.ValidateFuelType(fuelType)
+	if err != nil {
+		return
+	}
+
+	// update the vehicle
+	err = s.rp.UpdateFuelType(id, fuelType)
+	return
+}
+
+// Delete is a method that deletes a vehicle
+func (s *VehicleDefault) Delete(id int) (err error) {
+	err = s.rp.Delete(id)
+	return
 }
\ No newline at end of file
@@ -1,5 +1,25 @@
 package internal
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrVehicleInvalid is returned when the attributes of a vehicle are not valid
+	ErrVehicleInvalid = errors.New("vehicle: invalid attributes")
+)
+
+const (
+	// VehicleMinFabricationYear is the oldest fabrication year accepted for a vehicle
+	VehicleMinFabricationYear = 1900
+)
+
+// VehicleFuelTypes are the fuel types known for a vehicle
+var VehicleFuelTypes = []string{"biodiesel", "diesel", "gas", "gasoline"}
+
 // Dimensions is a struct that represents a dimension in 3d
 type Dimensions struct {
 	// Height is the height of the dimension
@@ -44,3 +64,60 @@ type Vehicle struct {
 	// VehicleAttribue is the attributes of a vehicle
 	VehicleAttributes
 }
+
+// Validate is a method that checks the attributes of a vehicle
+// - the fabrication year must be between VehicleMinFabricationYear and the next year
+// - the weight and the dimensions must not be negative
+// - the fuel type must be one of VehicleFuelTypes
+func (a VehicleAttributes) Validate() (err error) {
+	// required fields
+	switch {
+	case a.Brand == "":
+		return fmt.Errorf("%w: brand is required", ErrVehicleInvalid)
+	case a.Model == "":
+		return fmt.Errorf("%w: model is required", ErrVehicleInvalid)
+	case a.Registration == "":
+		return fmt.Errorf("%w: registration is required", ErrVehicleInvalid)
+	case a.Color == "":
+		return fmt.Errorf("%w: color is required", ErrVehicleInvalid)
+	case a.Transmission == "":
+		return fmt.Errorf("%w: transmission is required", ErrVehicleInvalid)
+	}
+
+	// ranges
+	maxYear := time.Now().Year() + 1
+	if a.FabricationYear < VehicleMinFabricationYear || a.FabricationYear > maxYear {
+		return fmt.Errorf("%w: year must be between %d and %d", ErrVehicleInvalid, VehicleMinFabricationYear, maxYear)
+	}
+	if a.Capacity <= 0 {
+		return fmt.Errorf("%w: passengers must be greater than zero", ErrVehicleInvalid)
+	}
+	err = ValidateMaxSpeed(a.MaxSpeed)
+	if err != nil {
+		return
+	}
+	if a.Weight < 0 || a.Height < 0 || a.Length < 0 || a.Width < 0 {
+		return fmt.Errorf("%w: weight and dimensions must not be negative", ErrVehicleInvalid)
+	}
+	err = ValidateFuelType(a.FuelType)
+	return
+}
+
+// ValidateMaxSpeed is a function that checks the maximum speed of a vehicle is greater than zero
+func ValidateMaxSpeed(maxSpeed float64) (err error) {
+	if maxSpeed <= 0 {
+		err = fmt.Errorf("%w: max_speed must be greater than zero", ErrVehicleInvalid)
+	}
+	return
+}
+
+// ValidateFuelType is a function that checks the fuel type of a vehicle is one of VehicleFuelTypes
+func ValidateFuelType(fuelType string) (err error) {
+	for _, known := range VehicleFuelTypes {
+		if fuelType == known {
+			return
+		}
+	}
+	err = fmt.Errorf("%w: fuel_type must be one of %s", ErrVehicleInvalid, strings.Join(VehicleFuelTypes, ", "))
+	return
+}
\ No newline at end of file
@@ -1,7 +1,65 @@
 package internal
 
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrLoaderVehicleFieldMissing is returned when a record of a vehicle lacks a field
+	ErrLoaderVehicleFieldMissing = errors.New("loader: field missing")
+	// ErrLoaderVehicleFieldType is returned when a field of a record of a vehicle has a value of another type
+	ErrLoaderVehicleFieldType = errors.New("loader: field with invalid type")
+	// ErrLoaderVehicleIdDuplicated is returned when two records of vehicles have the same id
+	ErrLoaderVehicleIdDuplicated = errors.New("loader: id duplicated")
+)
+
+// VehicleRecordError is a struct that represents an error in a record of a vehicle
+type VehicleRecordError struct {
+	// Index is the position of the record in the file, starting at 0
+	Index int
+	// Field is the name of the field of the record
+	Field string
+	// Err is the error of the field
+	Err error
+}
+
+// Error is a method that returns the message of the error
+func (e *VehicleRecordError) Error() string {
+	return fmt.Sprintf("record %d: field %s: %v", e.Index, e.Field, e.Err)
+}
+
+// Unwrap is a method that returns the error of the field
+func (e *VehicleRecordError) Unwrap() error {
+	return e.Err
+}
+
+// VehicleLoadStats is a struct that represents the statistics of a load of vehicles
+type VehicleLoadStats struct {
+	// Records is the number of records read
+	Records int
+	// Loaded is the number of vehicles loaded
+	Loaded int
+	// Incomplete is the number of vehicles loaded with missing fields, zero-filled
+	Incomplete int
+	// Rejected is the number of records not loaded
+	Rejected int
+	// MissingFields is the number of records that lack each field
+	MissingFields map[string]int
+	// ExtraFields is the number of records with each field that is not known, ignored
+	ExtraFields map[string]int
+	// Issues are the errors of the records, in the order of the file
+	Issues []*VehicleRecordError
+}
+
 // VehicleLoader is an interface that represents the loader for vehicles
 type VehicleLoader interface {
-	// Load is a method that loads the vehicles
-	Load() (v map[int]Vehicle, err error)
+	// Load is a method that loads the vehicles and returns the statistics of the load
+	Load() (v map[int]Vehicle, stats VehicleLoadStats, err error)
+}
+
+// VehicleStorer is an interface that represents the storage where the vehicles are written back to
+type VehicleStorer interface {
+	// Store is a method that replaces the stored vehicles with the given ones
+	Store(v map[int]Vehicle) (err error)
 }
\ No newline at end of file
@@ -1,7 +1,36 @@
 package internal
 
+import "errors"
+
+var (
+	// ErrRepositoryVehicleNotFound is returned when a vehicle does not exist
+	ErrRepositoryVehicleNotFound = errors.New("repository: vehicle not found")
+	// ErrRepositoryVehicleAlreadyExists is returned when a vehicle with the same registration already exists
+	ErrRepositoryVehicleAlreadyExists = errors.New("repository: vehicle already exists")
+)
+
 // VehicleRepository is an interface that represents a vehicle repository
 type VehicleRepository interface {
 	// FindAll is a method that returns a map of all vehicles
 	FindAll() (v map[int]Vehicle, err error)
+	// FindByBrand is a method that returns a map of the vehicles of a brand
+	FindByBrand(brand string) (v map[int]Vehicle, err error)
+	// FindByBrandAndYearRange is a method that returns a map of the vehicles of a brand fabricated between two years, both included
+	FindByBrandAndYearRange(brand string, startYear int, endYear int) (v map[int]Vehicle, err error)
+	// FindByFuelType is a method that returns a map of the vehicles with a fuel type
+	FindByFuelType(fuelType string) (v map[int]Vehicle, err error)
+	// FindByTransmission is a method that returns a map of the vehicles with a transmission
+	FindByTransmission(transmission string) (v map[int]Vehicle, err error)
+	// FindByDimensions is a method that returns a map of the vehicles whose length and width are within a range, bounds included
+	FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]Vehicle, err error)
+	// Save is a method that saves a new vehicle, setting its id
+	Save(v *Vehicle) (err error)
+	// SaveBatch is a method that saves new vehicles, setting their ids; none is saved if any of them fails
+	SaveBatch(v []Vehicle) (err error)
+	// UpdateMaxSpeed is a method that updates the maximum speed of a vehicle
+	UpdateMaxSpeed(id int, maxSpeed float64) (err error)
+	// UpdateFuelType is a method that updates the fuel type of a vehicle
+	UpdateFuelType(id int, fuelType string) (err error)
+	// Delete is a method that deletes a vehicle
+	Delete(id int) (err error)
 }
\ No newline at end of file
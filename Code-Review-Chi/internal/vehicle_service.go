@@ -1,7 +1,36 @@
 package internal
 
+import "errors"
+
+var (
+	// ErrServiceNoVehicles is returned when no vehicle matches the criteria
+	ErrServiceNoVehicles = errors.New("service: no vehicles found")
+)
+
 // VehicleService is an interface that represents a vehicle service
 type VehicleService interface {
 	// FindAll is a method that returns a map of all vehicles
 	FindAll() (v map[int]Vehicle, err error)
+	// FindByBrandAndYearRange is a method that returns a map of the vehicles of a brand fabricated between two years, both included
+	FindByBrandAndYearRange(brand string, startYear int, endYear int) (v map[int]Vehicle, err error)
+	// FindByFuelType is a method that returns a map of the vehicles with a fuel type
+	FindByFuelType(fuelType string) (v map[int]Vehicle, err error)
+	// FindByTransmission is a method that returns a map of the vehicles with a transmission
+	FindByTransmission(transmission string) (v map[int]Vehicle, err error)
+	// FindByDimensions is a method that returns a map of the vehicles whose length and width are within a range, bounds included
+	FindByDimensions(minLength float64, maxLength float64, minWidth float64, maxWidth float64) (v map[int]Vehicle, err error)
+	// AverageMaxSpeedByBrand is a method that returns the average maximum speed of the vehicles of a brand
+	AverageMaxSpeedByBrand(brand string) (average float64, err error)
+	// AverageCapacityByBrand is a method that returns the average capacity of people of the vehicles of a brand
+	AverageCapacityByBrand(brand string) (average float64, err error)
+	// Save is a method that validates and saves a new vehicle, setting its id
+	Save(v *Vehicle) (err error)
+	// SaveBatch is a method that validates and saves new vehicles, setting their ids; none is saved if any of them fails
+	SaveBatch(v []Vehicle) (err error)
+	// UpdateMaxSpeed is a method that validates and updates the maximum speed of a vehicle
+	UpdateMaxSpeed(id int, maxSpeed float64) (err error)
+	// UpdateFuelType is a method that validates and updates the fuel type of a vehicle
+	UpdateFuelType(id int, fuelType string) (err error)
+	// Delete is a method that deletes a vehicle
+	Delete(id int) (err error)
 }
\ No newline at end of file
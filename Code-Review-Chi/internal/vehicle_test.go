@@ -0,0 +1,126 @@
+package internal_test
+
+import (
+	"app/internal"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// validVehicleAttributes is a function that returns the attributes of a valid vehicle
+func validVehicleAttributes() internal.VehicleAttributes {
+	return internal.VehicleAttributes{
+		Brand:           "Ford",
+		Model:           "Focus",
+		Registration:    "ABC123",
+		Color:           "Red",
+		FabricationYear: 2010,
+		Capacity:        5,
+		MaxSpeed:        180.5,
+		FuelType:        "gasoline",
+		Transmission:    "manual",
+		Weight:          1200,
+		Dimensions:      internal.Dimensions{Height: 1.5, Length: 4.4, Width: 1.8},
+	}
+}
+
+// Tests for VehicleAttributes - Validate
+func TestVehicleAttributes_Validate(t *testing.T) {
+	type input struct {
+		change func(a *internal.VehicleAttributes)
+	}
+	type output struct {
+		err    error
+		errMsg string
+	}
+	type testCase struct {
+		name   string
+		input  input
+		output output
+	}
+
+	// test cases
+	nextYear := time.Now().Year() + 1
+	testCases := []testCase{
+		// success
+		{
+			name:   "success - valid vehicle",
+			input:  input{change: func(a *internal.VehicleAttributes) {}},
+			output: output{},
+		},
+		{
+			name:   "success - fabrication year of the next year",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.FabricationYear = nextYear }},
+			output: output{},
+		},
+		{
+			name:   "success - dimensions not known",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.Weight = 0; a.Dimensions = internal.Dimensions{} }},
+			output: output{},
+		},
+		// failure
+		{
+			name:   "failure - brand is required",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.Brand = "" }},
+			output: output{err: internal.ErrVehicleInvalid, errMsg: "vehicle: invalid attributes: brand is required"},
+		},
+		{
+			name:   "failure - transmission is required",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.Transmission = "" }},
+			output: output{err: internal.ErrVehicleInvalid, errMsg: "vehicle: invalid attributes: transmission is required"},
+		},
+		{
+			name:   "failure - fabrication year too old",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.FabricationYear = 1899 }},
+			output: output{err: internal.ErrVehicleInvalid},
+		},
+		{
+			name:   "failure - fabrication year in the future",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.FabricationYear = nextYear + 1 }},
+			output: output{err: internal.ErrVehicleInvalid},
+		},
+		{
+			name:   "failure - no passengers",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.Capacity = 0 }},
+			output: output{err: internal.ErrVehicleInvalid, errMsg: "vehicle: invalid attributes: passengers must be greater than zero"},
+		},
+		{
+			name:   "failure - max speed not positive",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.MaxSpeed = 0 }},
+			output: output{err: internal.ErrVehicleInvalid, errMsg: "vehicle: invalid attributes: max_speed must be greater than zero"},
+		},
+		{
+			name:   "failure - negative dimension",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.Width = -1 }},
+			output: output{err: internal.ErrVehicleInvalid, errMsg: "vehicle: invalid attributes: weight and dimensions must not be negative"},
+		},
+		{
+			name:   "failure - unknown fuel type",
+			input:  input{change: func(a *internal.VehicleAttributes) { a.FuelType = "electric" }},
+			output: output{err: internal.ErrVehicleInvalid, errMsg: "vehicle: invalid attributes: fuel_type must be one of biodiesel, diesel, gas, gasoline"},
+		},
+	}
+
+	// run test cases
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// arrange
+			a := validVehicleAttributes()
+			tc.input.change(&a)
+
+			// act
+			err := a.Validate()
+
+			// assert
+			if tc.output.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.output.err)
+			if tc.output.errMsg != "" {
+				require.EqualError(t, err, tc.output.errMsg)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/usuario/repositorio/internal/application"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func main() {
+	// env
+	// ...
+
+	// app
+	// - config
+	mysqlCfg := mysql.Config{
+		User:      "melisprint_user",
+		Passwd:    "melisprint_pass",
+		Net:       "tcp",
+		Addr:      "localhost:3306",
+		DBName:    "melisprint",
+		ParseTime: true,
+	}
+	cfg := application.ConfigMigrate{MySQLDSN: mysqlCfg.FormatDSN(), FilePathLocalities: "./docs/db/json/localities.json"}
+	// - migration
+	migrate := application.NewMigrate(cfg)
+	// - run
+	if err := migrate.Run(); err != nil {
+		fmt.Println(err)
+		return
+	}
+}
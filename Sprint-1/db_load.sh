@@ -1,2 +1,4 @@
 # Load data into the database
-mysql -u root < ./docs/db/melisprint_db_data.sql
\ No newline at end of file
+mysql -u root < ./docs/db/melisprint_db_data.sql
+# Load the reference data (localities) into the database
+go run ./cmd/migrate
@@ -5,6 +5,39 @@ CREATE DATABASE `melisprint`;
 
 USE `melisprint`;
 
+-- table `countries`
+CREATE TABLE `countries` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_countries_name` (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `provinces`
+CREATE TABLE `provinces` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    `country_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_provinces_name_country_id` (`name`, `country_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `localities`
+CREATE TABLE `localities` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `name` varchar(255) NOT NULL,
+    `province_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_localities_name_province_id` (`name`, `province_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `product_types`
+CREATE TABLE `product_types` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `description` varchar(255) NOT NULL,
+    PRIMARY KEY (`id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
 -- table `sellers`
 CREATE TABLE `sellers` (
     `id` int(11) NOT NULL AUTO_INCREMENT,
@@ -12,7 +45,14 @@ CREATE TABLE `sellers` (
     `company_name` varchar(255) NOT NULL,
     `address` varchar(255) NOT NULL,
     `telephone` varchar(15) NOT NULL,
-    PRIMARY KEY (`id`)
+    `locality_id` int(11) DEFAULT NULL,
+    `version` int NOT NULL DEFAULT 1,
+    `deleted_at` datetime DEFAULT NULL,
+    -- 1 while the row is not soft-deleted, NULL after, so the unique keys only apply to the rows that are not soft-deleted
+    `live` tinyint(1) AS (IF(`deleted_at` IS NULL, 1, NULL)) STORED,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_sellers_cid` (`cid`, `live`),
+    KEY `idx_sellers_locality_id` (`locality_id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
 -- table `warehouses`
@@ -23,7 +63,13 @@ CREATE TABLE `warehouses` (
     `telephone` varchar(15) NOT NULL,
     `minimum_capacity` int NOT NULL,
     `minimum_temperature` float NOT NULL,
-    PRIMARY KEY (`id`)
+    `locality_id` int(11) DEFAULT NULL,
+    `version` int NOT NULL DEFAULT 1,
+    `deleted_at` datetime DEFAULT NULL,
+    -- 1 while the row is not soft-deleted, NULL after, so the unique keys only apply to the rows that are not soft-deleted
+    `live` tinyint(1) AS (IF(`deleted_at` IS NULL, 1, NULL)) STORED,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_warehouses_warehouse_code` (`warehouse_code`, `live`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
 -- table `sections`
@@ -37,6 +83,8 @@ CREATE TABLE `sections` (
     `maximum_capacity` int NOT NULL,
     `warehouse_id` int(11) NOT NULL,
     `product_type_id` int(11) NOT NULL,
+    `version` int NOT NULL DEFAULT 1,
+    `deleted_at` datetime DEFAULT NULL,
     PRIMARY KEY (`id`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
@@ -54,7 +102,12 @@ CREATE TABLE `products` (
     `recommended_freezing_temperature` float NOT NULL,
     `seller_id` int(11) NOT NULL,
     `product_type_id` int(11) NOT NULL,
-    PRIMARY KEY (`id`)
+    `version` int NOT NULL DEFAULT 1,
+    `deleted_at` datetime DEFAULT NULL,
+    -- 1 while the row is not soft-deleted, NULL after, so the unique keys only apply to the rows that are not soft-deleted
+    `live` tinyint(1) AS (IF(`deleted_at` IS NULL, 1, NULL)) STORED,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_products_product_code` (`product_code`, `live`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
 -- table `employees`
@@ -64,7 +117,12 @@ CREATE TABLE `employees` (
     `first_name` varchar(50) NOT NULL,
     `last_name` varchar(50) NOT NULL,
     `warehouse_id` int(11) NOT NULL,
-    PRIMARY KEY (`id`)
+    `version` int NOT NULL DEFAULT 1,
+    `deleted_at` datetime DEFAULT NULL,
+    -- 1 while the row is not soft-deleted, NULL after, so the unique keys only apply to the rows that are not soft-deleted
+    `live` tinyint(1) AS (IF(`deleted_at` IS NULL, 1, NULL)) STORED,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_employees_card_number_id` (`card_number_id`, `live`)
 ) ENGINE = InnoDB DEFAULT CHARSET = utf8;
 
 -- table `buyers`
@@ -73,5 +131,94 @@ CREATE TABLE `buyers` (
     `card_number_id` varchar(25) NOT NULL,
     `first_name` varchar(50) NOT NULL,
     `last_name` varchar(50) NOT NULL,
-    PRIMARY KEY (`id`)
-) ENGINE = InnoDB DEFAULT CHARSET = utf8;
\ No newline at end of file
+    `version` int NOT NULL DEFAULT 1,
+    `deleted_at` datetime DEFAULT NULL,
+    -- 1 while the row is not soft-deleted, NULL after, so the unique keys only apply to the rows that are not soft-deleted
+    `live` tinyint(1) AS (IF(`deleted_at` IS NULL, 1, NULL)) STORED,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_buyers_card_number_id` (`card_number_id`, `live`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `product_batches`
+CREATE TABLE `product_batches` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `batch_number` int(11) NOT NULL,
+    `current_quantity` int NOT NULL,
+    `initial_quantity` int NOT NULL,
+    `current_temperature` float NOT NULL,
+    `minimum_temperature` float NOT NULL,
+    `manufacturing_date` date NOT NULL,
+    `manufacturing_hour` int NOT NULL,
+    `due_date` date NOT NULL,
+    `product_id` int(11) NOT NULL,
+    `section_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_product_batches_batch_number` (`batch_number`),
+    KEY `idx_product_batches_section_id` (`section_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `carriers`
+CREATE TABLE `carriers` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `cid` varchar(25) NOT NULL,
+    `company_name` varchar(255) NOT NULL,
+    `address` varchar(255) NOT NULL,
+    `telephone` varchar(15) NOT NULL,
+    `locality_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_carriers_cid` (`cid`),
+    KEY `idx_carriers_locality_id` (`locality_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `product_records`
+CREATE TABLE `product_records` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `last_update_date` date NOT NULL,
+    `purchase_price` decimal(19, 2) NOT NULL,
+    `sale_price` decimal(19, 2) NOT NULL,
+    `product_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    KEY `idx_product_records_product_id` (`product_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `purchase_orders`
+CREATE TABLE `purchase_orders` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `order_number` varchar(25) NOT NULL,
+    `order_date` date NOT NULL,
+    `tracking_code` varchar(25) NOT NULL,
+    `buyer_id` int(11) NOT NULL,
+    `product_record_id` int(11) NOT NULL,
+    `carrier_id` int(11) NOT NULL,
+    `status` varchar(15) NOT NULL DEFAULT 'pending',
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_purchase_orders_order_number` (`order_number`),
+    KEY `idx_purchase_orders_buyer_id` (`buyer_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `inbound_orders`
+CREATE TABLE `inbound_orders` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `order_date` date NOT NULL,
+    `order_number` varchar(25) NOT NULL,
+    `employee_id` int(11) NOT NULL,
+    `product_batch_id` int(11) NOT NULL,
+    `warehouse_id` int(11) NOT NULL,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `uq_inbound_orders_order_number` (`order_number`),
+    KEY `idx_inbound_orders_employee_id` (`employee_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
+
+-- table `audit_log`
+CREATE TABLE `audit_log` (
+    `id` int(11) NOT NULL AUTO_INCREMENT,
+    `entity` varchar(50) NOT NULL,
+    `entity_id` int(11) NOT NULL,
+    `operation` varchar(10) NOT NULL,
+    `before` json DEFAULT NULL,
+    `after` json DEFAULT NULL,
+    `request_id` varchar(100) NOT NULL DEFAULT '',
+    `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `idx_audit_log_entity` (`entity`, `entity_id`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8;
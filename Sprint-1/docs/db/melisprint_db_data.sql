@@ -1,13 +1,35 @@
 USE `melisprint`;
 
+TRUNCATE TABLE `countries`;
+TRUNCATE TABLE `provinces`;
+TRUNCATE TABLE `localities`;
+TRUNCATE TABLE `carriers`;
+TRUNCATE TABLE `product_types`;
 TRUNCATE TABLE `sellers`;
 TRUNCATE TABLE `warehouses`;
 TRUNCATE TABLE `sections`;
 TRUNCATE TABLE `products`;
 TRUNCATE TABLE `employees`;
 TRUNCATE TABLE `buyers`;
+TRUNCATE TABLE `product_batches`;
+TRUNCATE TABLE `product_records`;
+TRUNCATE TABLE `purchase_orders`;
+TRUNCATE TABLE `inbound_orders`;
+TRUNCATE TABLE `audit_log`;
 
 -- DML
+INSERT INTO `product_types` (`description`) VALUES
+('Frozen'),
+('Refrigerated'),
+('Dry goods'),
+('Fresh produce'),
+('Dairy'),
+('Meat'),
+('Seafood'),
+('Bakery'),
+('Beverages'),
+('Cleaning supplies');
+
 INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`) VALUES
 (1, 'Company A', '123 Main St', '123-456-7890'),
 (2, 'Company B', '456 Elm St', '123-456-7891'),
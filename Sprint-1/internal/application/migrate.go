@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/loader"
+	"github.com/usuario/repositorio/internal/repository"
+)
+
+// ConfigMigrate is the configuration for the migration of the reference data
+type ConfigMigrate struct {
+	// MySQLDSN is the DSN for the MySQL database
+	MySQLDSN string
+	// FilePathLocalities is the path to the json file of the localities
+	FilePathLocalities string
+}
+
+// NewMigrate creates a new instance of the migration
+func NewMigrate(cfg ConfigMigrate) *Migrate {
+	// default config
+	defaultCfg := ConfigMigrate{
+		FilePathLocalities: "./docs/db/json/localities.json",
+	}
+	if cfg.MySQLDSN != "" {
+		defaultCfg.MySQLDSN = cfg.MySQLDSN
+	}
+	if cfg.FilePathLocalities != "" {
+		defaultCfg.FilePathLocalities = cfg.FilePathLocalities
+	}
+
+	return &Migrate{
+		mysqlDSN:           defaultCfg.MySQLDSN,
+		filePathLocalities: defaultCfg.FilePathLocalities,
+	}
+}
+
+// Migrate loads the reference data, e.g. the localities, into the database
+type Migrate struct {
+	// mysqlDSN is the DSN for the MySQL database
+	mysqlDSN string
+	// filePathLocalities is the path to the json file of the localities
+	filePathLocalities string
+}
+
+// Run runs the migration
+// - the localities that already exist are skipped, so it can be run more than once
+func (m *Migrate) Run() (err error) {
+	// dependencies
+	// - database: connection
+	db, err := sql.Open("mysql", m.mysqlDSN)
+	if err != nil {
+		return
+	}
+	defer db.Close()
+	// - database: ping
+	err = db.Ping()
+	if err != nil {
+		return
+	}
+	// - file: localities
+	file, err := os.Open(m.filePathLocalities)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	// load the localities
+	var ld internal.LoaderLocality = loader.NewLocalitiesJSON(file)
+	localities, err := ld.Load()
+	if err != nil {
+		return
+	}
+
+	// save the localities
+	rp := repository.NewLocalityMysql(db)
+	for _, locality := range localities {
+		err = rp.Save(context.Background(), &locality)
+		if err != nil {
+			if errors.Is(err, internal.ErrLocalityRepositoryDuplicated) {
+				err = nil
+				continue
+			}
+			return
+		}
+	}
+
+	return
+}
@@ -4,6 +4,11 @@ import (
 	"database/sql"
 	"net/http"
 
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 )
@@ -59,24 +64,45 @@ func (s *ServerChi) Run() (err error) {
 		return
 	}
 
+	// - repository: audit log, shared by the audited repositories
+	rpAudit := repository.NewAuditMysql(db)
+
 	// - router
 	router := chi.NewRouter()
 	//   middlewares
+	router.Use(middleware.RequestID)
+	router.Use(requestIDAudit)
 	router.Use(middleware.Logger)
 	router.Use(middleware.Recoverer)
 	//   endpoints
-	//     sellers
-	buildSellersRouter(router)
-	//     warehouses
-	buildWarehousesRouter(router)
-	//     sections
-	buildSectionsRouter(router)
-	//     products
-	buildProductsRouter(router)
-	//     employees
-	buildEmployeesRouter(router)
-	//     buyers
-	buildBuyersRouter(router)
+	router.Route("/api/v1", func(rt chi.Router) {
+		//     sellers
+		buildSellersRouter(rt, db, rpAudit)
+		//     warehouses
+		buildWarehousesRouter(rt, db, rpAudit)
+		//     sections
+		buildSectionsRouter(rt, db, rpAudit)
+		//     products
+		buildProductsRouter(rt, db, rpAudit)
+		//     employees
+		buildEmployeesRouter(rt, db, rpAudit)
+		//     buyers
+		buildBuyersRouter(rt, db, rpAudit)
+		//     product batches
+		buildProductBatchesRouter(rt, db, rpAudit)
+		//     localities
+		buildLocalitiesRouter(rt, db, rpAudit)
+		//     carriers
+		buildCarriersRouter(rt, db, rpAudit)
+		//     product records
+		buildProductRecordsRouter(rt, db, rpAudit)
+		//     purchase orders
+		buildPurchaseOrdersRouter(rt, db, rpAudit)
+		//     inbound orders
+		buildInboundOrdersRouter(rt, db, rpAudit)
+		//     audit
+		buildAuditRouter(rt, rpAudit)
+	})
 
 	// run
 	err = http.ListenAndServe(s.addr, router)
@@ -84,31 +110,317 @@ func (s *ServerChi) Run() (err error) {
 }
 
 // buildSellersRouter builds the router for the sellers endpoints
-func buildSellersRouter(router *chi.Mux) {
-	// ...
+func buildSellersRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewSellerAudit(repository.NewSellerMysql(db), rpAudit)
+	//   handler
+	hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+	// endpoints
+	router.Route("/sellers", func(r chi.Router) {
+		// GET /sellers
+		r.Get("/", hd.GetAll())
+		// GET /sellers/{id}
+		r.Get("/{id}", hd.GetByID())
+		// POST /sellers
+		r.Post("/", hd.Create())
+		// POST /sellers/bulk
+		r.Post("/bulk", hd.CreateBulk())
+		// PUT /sellers/{id}
+		r.Put("/{id}", hd.Update())
+		// PATCH /sellers/{id}
+		r.Patch("/{id}", hd.Patch())
+		// DELETE /sellers/{id}
+		r.Delete("/{id}", hd.Delete())
+		// POST /sellers/{id}/restore
+		r.Post("/{id}/restore", hd.Restore())
+	})
 }
 
 // buildWarehousesRouter builds the router for the warehouses endpoints
-func buildWarehousesRouter(router *chi.Mux) {
-	// ...
+func buildWarehousesRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewWarehouseAudit(repository.NewWarehouseMysql(db), rpAudit)
+	//   sections and employees, read by the warehouse report
+	rpSection := repository.NewSectionMysql(db)
+	rpEmployee := repository.NewEmployeeMysql(db)
+	//   handler
+	hd := handler.NewWarehouseDefault(service.NewWarehouseDefault(rp, rpSection, rpEmployee))
+
+	// endpoints
+	router.Route("/warehouses", func(r chi.Router) {
+		// GET /warehouses
+		r.Get("/", hd.GetAll())
+		// GET /warehouses/{id}
+		r.Get("/{id}", hd.GetByID())
+		// POST /warehouses
+		r.Post("/", hd.Create())
+		// POST /warehouses/bulk
+		r.Post("/bulk", hd.CreateBulk())
+		// PUT /warehouses/{id}
+		r.Put("/{id}", hd.Update())
+		// PATCH /warehouses/{id}
+		r.Patch("/{id}", hd.Patch())
+		// DELETE /warehouses/{id}
+		r.Delete("/{id}", hd.Delete())
+		// POST /warehouses/{id}/restore
+		r.Post("/{id}/restore", hd.Restore())
+		// GET /warehouses/{id}/report
+		r.Get("/{id}/report", hd.Report())
+	})
 }
 
 // buildSectionsRouter builds the router for the sections endpoints
-func buildSectionsRouter(router *chi.Mux) {
-	// ...
+func buildSectionsRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewSectionAudit(repository.NewSectionMysql(db), rpAudit)
+	//   warehouses and product types referenced by the sections
+	rpWarehouse := repository.NewWarehouseMysql(db)
+	rpProductType := repository.NewProductTypeMysql(db)
+	//   handler
+	hd := handler.NewSectionDefault(service.NewSectionDefault(rp, rpWarehouse, rpProductType))
+	//   products stored in the sections
+	rpProductBatch := repository.NewProductBatchAudit(repository.NewProductBatchMysql(db), repository.NewSectionMysql(db), rpAudit)
+	hdProductBatch := handler.NewProductBatchDefault(service.NewProductBatchDefault(rpProductBatch))
+
+	// endpoints
+	router.Route("/sections", func(r chi.Router) {
+		// GET /sections
+		r.Get("/", hd.GetAll())
+		// GET /sections/{id}
+		r.Get("/{id}", hd.GetByID())
+		// POST /sections
+		r.Post("/", hd.Create())
+		// POST /sections/bulk
+		r.Post("/bulk", hd.CreateBulk())
+		// PUT /sections/{id}
+		r.Put("/{id}", hd.Update())
+		// PATCH /sections/{id}
+		r.Patch("/{id}", hd.Patch())
+		// DELETE /sections/{id}
+		r.Delete("/{id}", hd.Delete())
+		// POST /sections/{id}/restore
+		r.Post("/{id}/restore", hd.Restore())
+		// GET /sections/{id}/report-products
+		r.Get("/{id}/report-products", hdProductBatch.ReportProducts())
+	})
 }
 
 // buildProductsRouter builds the router for the products endpoints
-func buildProductsRouter(router *chi.Mux) {
-	// ...
+func buildProductsRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewProductAudit(repository.NewProductMysql(db), rpAudit)
+	//   sellers and product types referenced by the products
+	rpSeller := repository.NewSellerMysql(db)
+	rpProductType := repository.NewProductTypeMysql(db)
+	//   handler
+	hd := handler.NewProductDefault(service.NewProductDefault(rp, rpSeller, rpProductType))
+	//   records of the products
+	rpProductRecord := repository.NewProductRecordMysql(db)
+	hdProductRecord := handler.NewProductRecordDefault(service.NewProductRecordDefault(rpProductRecord))
+
+	// endpoints
+	router.Route("/products", func(r chi.Router) {
+		// GET /products
+		r.Get("/", hd.GetAll())
+		// GET /products/report-records?id=
+		r.Get("/report-records", hdProductRecord.ReportRecords())
+		// GET /products/{id}
+		r.Get("/{id}", hd.GetByID())
+		// POST /products
+		r.Post("/", hd.Create())
+		// POST /products/bulk
+		r.Post("/bulk", hd.CreateBulk())
+		// PUT /products/{id}
+		r.Put("/{id}", hd.Update())
+		// PATCH /products/{id}
+		r.Patch("/{id}", hd.Patch())
+		// DELETE /products/{id}
+		r.Delete("/{id}", hd.Delete())
+		// POST /products/{id}/restore
+		r.Post("/{id}/restore", hd.Restore())
+	})
 }
 
 // buildEmployeesRouter builds the router for the employees endpoints
-func buildEmployeesRouter(router *chi.Mux) {
-	// ...
+func buildEmployeesRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewEmployeeAudit(repository.NewEmployeeMysql(db), rpAudit)
+	//   warehouses the employees work at
+	rpWarehouse := repository.NewWarehouseMysql(db)
+	//   handler
+	hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, rpWarehouse))
+
+	// endpoints
+	router.Route("/employees", func(r chi.Router) {
+		// GET /employees
+		r.Get("/", hd.GetAll())
+		// GET /employees/report-inbound-orders?id=
+		r.Get("/report-inbound-orders", hd.ReportInboundOrders())
+		// GET /employees/{id}
+		r.Get("/{id}", hd.GetByID())
+		// POST /employees
+		r.Post("/", hd.Create())
+		// POST /employees/bulk
+		r.Post("/bulk", hd.CreateBulk())
+		// PUT /employees/{id}
+		r.Put("/{id}", hd.Update())
+		// PATCH /employees/{id}
+		r.Patch("/{id}", hd.Patch())
+		// DELETE /employees/{id}
+		r.Delete("/{id}", hd.Delete())
+		// POST /employees/{id}/restore
+		r.Post("/{id}/restore", hd.Restore())
+	})
 }
 
 // buildBuyersRouter builds the router for the buyers endpoints
-func buildBuyersRouter(router *chi.Mux) {
-	// ...
-}
\ No newline at end of file
+func buildBuyersRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewBuyerAudit(repository.NewBuyerMysql(db), rpAudit)
+	//   handler
+	hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+	//   purchase orders placed by the buyers
+	hdPurchaseOrder := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(repository.NewPurchaseOrderMysql(db)))
+
+	// endpoints
+	router.Route("/buyers", func(r chi.Router) {
+		// GET /buyers
+		r.Get("/", hd.GetAll())
+		// GET /buyers/{id}
+		r.Get("/{id}", hd.GetByID())
+		// POST /buyers
+		r.Post("/", hd.Create())
+		// POST /buyers/bulk
+		r.Post("/bulk", hd.CreateBulk())
+		// PUT /buyers/{id}
+		r.Put("/{id}", hd.Update())
+		// PATCH /buyers/{id}
+		r.Patch("/{id}", hd.Patch())
+		// DELETE /buyers/{id}
+		r.Delete("/{id}", hd.Delete())
+		// POST /buyers/{id}/restore
+		r.Post("/{id}/restore", hd.Restore())
+		// GET /buyers/{id}/report-purchase-orders
+		r.Get("/{id}/report-purchase-orders", hdPurchaseOrder.ReportByBuyer())
+	})
+}
+
+// buildProductBatchesRouter builds the router for the product batches endpoints
+func buildProductBatchesRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	//   the sections the batches are stored in are recorded in the audit log when their capacity changes
+	rp := repository.NewProductBatchAudit(repository.NewProductBatchMysql(db), repository.NewSectionMysql(db), rpAudit)
+	sv := service.NewProductBatchDefault(rp)
+	hd := handler.NewProductBatchDefault(sv)
+
+	// endpoints
+	router.Route("/product-batches", func(r chi.Router) {
+		// POST /product-batches
+		r.Post("/", hd.Create())
+		// GET /product-batches/expiring?days=
+		r.Get("/expiring", hd.GetExpiring())
+	})
+}
+
+// buildLocalitiesRouter builds the router for the localities endpoints
+func buildLocalitiesRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewLocalityAudit(repository.NewLocalityMysql(db), rpAudit)
+	sv := service.NewLocalityDefault(rp)
+	hd := handler.NewLocalityDefault(sv)
+
+	// endpoints
+	router.Route("/localities", func(r chi.Router) {
+		// POST /localities
+		r.Post("/", hd.Create())
+		// GET /localities/{id}
+		r.Get("/{id}", hd.GetByID())
+		// GET /localities/report-sellers?id=
+		r.Get("/report-sellers", hd.ReportSellers())
+		// GET /localities/report-carriers?id=
+		r.Get("/report-carriers", hd.ReportCarriers())
+	})
+}
+
+// buildCarriersRouter builds the router for the carriers endpoints
+func buildCarriersRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewCarrierAudit(repository.NewCarrierMysql(db), rpAudit)
+	sv := service.NewCarrierDefault(rp)
+	hd := handler.NewCarrierDefault(sv)
+
+	// endpoints
+	router.Route("/carriers", func(r chi.Router) {
+		// GET /carriers
+		r.Get("/", hd.GetAll())
+		// POST /carriers
+		r.Post("/", hd.Create())
+	})
+}
+
+// buildProductRecordsRouter builds the router for the product records endpoints
+func buildProductRecordsRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewProductRecordAudit(repository.NewProductRecordMysql(db), rpAudit)
+	sv := service.NewProductRecordDefault(rp)
+	hd := handler.NewProductRecordDefault(sv)
+
+	// endpoints
+	router.Route("/product-records", func(r chi.Router) {
+		// POST /product-records
+		r.Post("/", hd.Create())
+	})
+}
+
+// buildPurchaseOrdersRouter builds the router for the purchase orders endpoints
+func buildPurchaseOrdersRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewPurchaseOrderAudit(repository.NewPurchaseOrderMysql(db), rpAudit)
+	sv := service.NewPurchaseOrderDefault(rp)
+	hd := handler.NewPurchaseOrderDefault(sv)
+
+	// endpoints
+	router.Route("/purchase-orders", func(r chi.Router) {
+		// POST /purchase-orders
+		r.Post("/", hd.Create())
+	})
+}
+
+// buildInboundOrdersRouter builds the router for the inbound orders endpoints
+func buildInboundOrdersRouter(router chi.Router, db *sql.DB, rpAudit internal.AuditRepository) {
+	// dependencies
+	rp := repository.NewInboundOrderAudit(repository.NewInboundOrderMysql(db), rpAudit)
+	rpEmployee := repository.NewEmployeeMysql(db)
+	sv := service.NewInboundOrderDefault(rp, rpEmployee)
+	hd := handler.NewInboundOrderDefault(sv)
+
+	// endpoints
+	router.Route("/inbound-orders", func(r chi.Router) {
+		// GET /inbound-orders
+		r.Get("/", hd.GetAll())
+		// POST /inbound-orders
+		r.Post("/", hd.Create())
+	})
+}
+
+// buildAuditRouter builds the router for the audit endpoints
+func buildAuditRouter(router chi.Router, rpAudit internal.AuditRepository) {
+	// dependencies
+	sv := service.NewAuditDefault(rpAudit)
+	hd := handler.NewAuditDefault(sv)
+
+	// endpoints
+	router.Route("/audit", func(r chi.Router) {
+		// GET /audit?entity=&id=
+		r.Get("/", hd.GetByEntity())
+	})
+}
+
+// requestIDAudit puts the id of the request in its context, so the writes it performs are recorded with it in the audit log
+func requestIDAudit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := internal.ContextWithRequestID(r.Context(), middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
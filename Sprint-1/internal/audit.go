@@ -0,0 +1,69 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+const (
+	// AuditOperationCreate is the operation recorded when an entity is saved
+	AuditOperationCreate = "create"
+	// AuditOperationUpdate is the operation recorded when an entity is updated
+	AuditOperationUpdate = "update"
+	// AuditOperationDelete is the operation recorded when an entity is deleted
+	AuditOperationDelete = "delete"
+	// AuditOperationRestore is the operation recorded when a soft-deleted entity is restored
+	AuditOperationRestore = "restore"
+)
+
+// Audit is a struct that contains the information of a write operation over an entity
+type Audit struct {
+	// ID is the unique identifier of the audit entry
+	ID int
+	// Entity is the name of the audited entity (e.g. sellers)
+	Entity string
+	// EntityID is the unique identifier of the audited entity
+	EntityID int
+	// Operation is the write operation performed over the entity (create, update, delete or restore)
+	Operation string
+	// Before is the JSON of the fields of the entity before the operation (only the changed ones on update)
+	Before json.RawMessage
+	// After is the JSON of the fields of the entity after the operation (only the changed ones on update)
+	After json.RawMessage
+	// RequestID is the identifier of the request that performed the operation
+	RequestID string
+	// CreatedAt is the date and time of the operation
+	CreatedAt time.Time
+}
+
+// AuditRepository is an interface that contains the methods that the audit repository should support
+type AuditRepository interface {
+	// FindByEntity returns the audit entries of the given entity, filtered by its ID if it is not zero
+	FindByEntity(entity string, entityID int) ([]Audit, error)
+	// Save saves the given audit entry, in the transaction of the context if there is one
+	Save(ctx context.Context, audit *Audit) error
+	// Transaction runs fn in a transaction, shared through its context by the repositories of the same database
+	// - the audit entries saved by fn are committed or rolled back together with the changes they record
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AuditService is an interface that contains the methods that the audit service should support
+type AuditService interface {
+	// FindByEntity returns the audit entries of the given entity, filtered by its ID if it is not zero
+	FindByEntity(entity string, entityID int) ([]Audit, error)
+}
+
+// requestIDKey is the key of the request id carried by a context
+type requestIDKey struct{}
+
+// ContextWithRequestID returns a copy of the context that carries the request id recorded in the audit log
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the request id carried by the context, empty if there is none
+func RequestIDFromContext(ctx context.Context) (requestID string) {
+	requestID, _ = ctx.Value(requestIDKey{}).(string)
+	return
+}
@@ -0,0 +1,50 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// BulkRowError is the error of a single row of a bulk import
+type BulkRowError struct {
+	// Row is the position of the row in the import, starting at 1
+	Row int
+	// Err is the error of the row
+	Err error
+}
+
+// Error returns the error of the row prefixed by its position
+func (e BulkRowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+// Unwrap returns the error of the row
+func (e BulkRowError) Unwrap() error {
+	return e.Err
+}
+
+// BulkError is returned when one or more rows of a bulk import fail
+// - a bulk import is applied as a whole, so none of the rows are saved
+type BulkError []BulkRowError
+
+// Error returns the errors of the rows joined by a semicolon
+func (e BulkError) Error() string {
+	msgs := make([]string, len(e))
+	for i, rowErr := range e {
+		msgs[i] = rowErr.Error()
+	}
+	return "bulk: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors of the rows
+func (e BulkError) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, rowErr := range e {
+		errs[i] = rowErr
+	}
+	return errs
+}
+
+// ErrBulkEmpty is returned when a bulk import has no rows
+var ErrBulkEmpty = errors.New("bulk: no rows to import")
@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Buyer is a struct that contains the buyer's information
 type Buyer struct {
@@ -12,6 +16,21 @@ type Buyer struct {
 	FirstName string
 	// LastName is the last name of the buyer
 	LastName string
+	// Version is the number of times the buyer was written, used to detect concurrent updates
+	Version int
+}
+
+// Validate returns ErrBuyerInvalid wrapped with the first field of the buyer that is not valid
+func (b Buyer) Validate() (err error) {
+	switch {
+	case b.CardNumberID <= 0:
+		err = fmt.Errorf("%w: card_number_id must be greater than 0", ErrBuyerInvalid)
+	case b.FirstName == "":
+		err = fmt.Errorf("%w: first_name is required", ErrBuyerInvalid)
+	case b.LastName == "":
+		err = fmt.Errorf("%w: last_name is required", ErrBuyerInvalid)
+	}
+	return
 }
 
 var (
@@ -19,32 +38,60 @@ var (
 	ErrBuyerRepositoryNotFound = errors.New("repository: buyer not found")
 	// ErrBuyerRepositoryDuplicated is returned when the buyer already exists
 	ErrBuyerRepositoryDuplicated = errors.New("repository: buyer already exists")
+	// ErrBuyerCardNumberIDDuplicated is returned when another buyer already has the same card_number_id
+	ErrBuyerCardNumberIDDuplicated = fmt.Errorf("%w: card_number_id already in use", ErrBuyerRepositoryDuplicated)
+	// ErrBuyerHasDependents is returned when the buyer can not be deleted because other entities reference it
+	ErrBuyerHasDependents = errors.New("buyer: has dependents")
+	// ErrBuyerHasPurchaseOrders is returned when the buyer still has purchase orders
+	ErrBuyerHasPurchaseOrders = fmt.Errorf("%w: purchase orders", ErrBuyerHasDependents)
+	// ErrBuyerInvalid is returned when the buyer has invalid fields
+	ErrBuyerInvalid = errors.New("buyer: invalid fields")
 )
 
 // BuyerRepository is an interface that contains the methods that the buyer repository should support
 type BuyerRepository interface {
 	// FindAll returns all the buyers
 	FindAll() ([]Buyer, error)
+	// FindAllWithDeleted returns all the buyers, including the soft-deleted ones
+	FindAllWithDeleted() ([]Buyer, error)
 	// FindByID returns the buyer with the given ID
 	FindByID(id int) (Buyer, error)
+	// FindByIDForUpdate returns the buyer with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (Buyer, error)
+	// FindByCardNumberID returns the buyer with the given card_number_id
+	FindByCardNumberID(cardNumberID int) (Buyer, error)
 	// Save saves the given buyer
-	Save(buyer *Buyer) error
-	// Update updates the given buyer
-	Update(buyer *Buyer) error
-	// Delete deletes the buyer with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, buyer *Buyer) error
+	// SaveAll saves the given buyers in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, buyers []*Buyer) error
+	// Update updates the given buyer, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, buyer *Buyer) error
+	// Delete soft-deletes the buyer with the given ID, ErrBuyerHasPurchaseOrders is returned if it still has purchase orders
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted buyer with the given ID, ErrBuyerCardNumberIDDuplicated is returned if another buyer has its card_number_id
+	Restore(ctx context.Context, id int) error
 }
 
 // BuyerService is an interface that contains the methods that the buyer service should support
 type BuyerService interface {
 	// FindAll returns all the buyers
 	FindAll() ([]Buyer, error)
+	// FindAllWithDeleted returns all the buyers, including the soft-deleted ones
+	FindAllWithDeleted() ([]Buyer, error)
 	// FindByID returns the buyer with the given ID
 	FindByID(id int) (Buyer, error)
+	// FindByCardNumberID returns the buyer with the given card_number_id
+	FindByCardNumberID(cardNumberID int) (Buyer, error)
 	// Save saves the given buyer
-	Save(buyer *Buyer) error
-	// Update updates the given buyer
-	Update(buyer *Buyer) error
-	// Delete deletes the buyer with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, buyer *Buyer) error
+	// SaveAll validates and saves the given buyers in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, buyers []*Buyer) error
+	// Update updates the given buyer, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, buyer *Buyer) error
+	// Patch partially updates the buyer with the given ID, fn receives the current buyer and changes the fields to update
+	Patch(ctx context.Context, id int, fn func(buyer *Buyer) error) (Buyer, error)
+	// Delete soft-deletes the buyer with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted buyer with the given ID
+	Restore(ctx context.Context, id int) error
 }
\ No newline at end of file
@@ -0,0 +1,65 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Carrier is a struct that contains the carrier's information
+type Carrier struct {
+	// ID is the unique identifier of the carrier
+	ID int
+	// CID is the unique code of the carrier
+	CID string
+	// CompanyName is the name of the company
+	CompanyName string
+	// Address is the address of the company
+	Address string
+	// Telephone is the telephone number of the company
+	Telephone string
+	// LocalityID is the unique identifier of the locality the carrier operates in
+	LocalityID int
+}
+
+// Validate returns ErrCarrierInvalid wrapped with the first field of the carrier that is not valid
+func (c Carrier) Validate() (err error) {
+	switch {
+	case c.CID == "":
+		err = fmt.Errorf("%w: cid is required", ErrCarrierInvalid)
+	case c.CompanyName == "":
+		err = fmt.Errorf("%w: company_name is required", ErrCarrierInvalid)
+	case c.Address == "":
+		err = fmt.Errorf("%w: address is required", ErrCarrierInvalid)
+	case c.Telephone == "":
+		err = fmt.Errorf("%w: telephone is required", ErrCarrierInvalid)
+	case c.LocalityID <= 0:
+		err = fmt.Errorf("%w: locality_id must be greater than 0", ErrCarrierInvalid)
+	}
+	return
+}
+
+var (
+	// ErrCarrierRepositoryDuplicated is returned when the carrier already exists
+	ErrCarrierRepositoryDuplicated = errors.New("repository: carrier already exists")
+	// ErrCarrierRepositoryLocalityNotFound is returned when the locality of the carrier is not found
+	ErrCarrierRepositoryLocalityNotFound = errors.New("repository: carrier locality not found")
+	// ErrCarrierInvalid is returned when the carrier has invalid fields
+	ErrCarrierInvalid = errors.New("carrier: invalid fields")
+)
+
+// CarrierRepository is an interface that contains the methods that the carrier repository should support
+type CarrierRepository interface {
+	// FindAll returns all the carriers
+	FindAll() ([]Carrier, error)
+	// Save saves the given carrier
+	Save(ctx context.Context, carrier *Carrier) error
+}
+
+// CarrierService is an interface that contains the methods that the carrier service should support
+type CarrierService interface {
+	// FindAll returns all the carriers
+	FindAll() ([]Carrier, error)
+	// Save saves the given carrier
+	Save(ctx context.Context, carrier *Carrier) error
+}
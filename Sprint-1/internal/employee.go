@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Employee is a struct that contains the employee's information
 type Employee struct {
@@ -14,6 +18,23 @@ type Employee struct {
 	LastName string
 	// WarehouseID is the unique identifier of the warehouse to which the employee belongs
 	WarehouseID int
+	// Version is the number of times the employee was written, used to detect concurrent updates
+	Version int
+}
+
+// Validate returns ErrEmployeeInvalid wrapped with the first field of the employee that is not valid
+func (e Employee) Validate() (err error) {
+	switch {
+	case e.CardNumberID <= 0:
+		err = fmt.Errorf("%w: card_number_id must be greater than 0", ErrEmployeeInvalid)
+	case e.FirstName == "":
+		err = fmt.Errorf("%w: first_name is required", ErrEmployeeInvalid)
+	case e.LastName == "":
+		err = fmt.Errorf("%w: last_name is required", ErrEmployeeInvalid)
+	case e.WarehouseID <= 0:
+		err = fmt.Errorf("%w: warehouse_id must be greater than 0", ErrEmployeeInvalid)
+	}
+	return
 }
 
 var (
@@ -21,32 +42,71 @@ var (
 	ErrEmployeeRepositoryNotFound = errors.New("repository: employee not found")
 	// ErrEmployeeRepositoryDuplicated is returned when the employee already exists
 	ErrEmployeeRepositoryDuplicated = errors.New("repository: employee already exists")
+	// ErrEmployeeCardNumberIDDuplicated is returned when another employee already has the same card_number_id
+	ErrEmployeeCardNumberIDDuplicated = fmt.Errorf("%w: card_number_id already in use", ErrEmployeeRepositoryDuplicated)
+	// ErrEmployeeReferenceNotFound is returned when an entity referenced by the employee does not exist
+	ErrEmployeeReferenceNotFound = errors.New("employee: reference not found")
+	// ErrEmployeeWarehouseNotFound is returned when the warehouse of the employee does not exist
+	ErrEmployeeWarehouseNotFound = fmt.Errorf("%w: warehouse_id", ErrEmployeeReferenceNotFound)
+	// ErrEmployeeHasDependents is returned when the employee can not be deleted because other entities reference it
+	ErrEmployeeHasDependents = errors.New("employee: has dependents")
+	// ErrEmployeeHasInboundOrders is returned when the employee still has inbound orders
+	ErrEmployeeHasInboundOrders = fmt.Errorf("%w: inbound orders", ErrEmployeeHasDependents)
+	// ErrEmployeeInvalid is returned when the employee has invalid fields
+	ErrEmployeeInvalid = errors.New("employee: invalid fields")
 )
 
 // EmployeeRepository is an interface that contains the methods that the employee repository should support
 type EmployeeRepository interface {
 	// FindAll returns all the employees
 	FindAll() ([]Employee, error)
+	// FindAllWithDeleted returns all the employees, including the soft-deleted ones
+	FindAllWithDeleted() ([]Employee, error)
 	// FindByID returns the employee with the given ID
 	FindByID(id int) (Employee, error)
+	// FindByIDForUpdate returns the employee with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (Employee, error)
+	// FindByCardNumberID returns the employee with the given card_number_id
+	FindByCardNumberID(cardNumberID int) (Employee, error)
 	// Save saves the given employee
-	Save(employee *Employee) error
-	// Update updates the given employee
-	Update(employee *Employee) error
-	// Delete deletes the employee with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, employee *Employee) error
+	// SaveAll saves the given employees in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, employees []*Employee) error
+	// Update updates the given employee, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, employee *Employee) error
+	// Delete soft-deletes the employee with the given ID, ErrEmployeeHasInboundOrders is returned if it still has inbound orders
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted employee with the given ID, ErrEmployeeWarehouseNotFound is returned if its warehouse was deleted
+	// and ErrEmployeeCardNumberIDDuplicated if another employee has its card_number_id
+	Restore(ctx context.Context, id int) error
+	// CountByWarehouse returns the number of employees of the warehouse with the given ID
+	CountByWarehouse(warehouseID int) (int, error)
+	// ReportInboundOrders returns the number of inbound orders received by the employee with the given ID, or by every employee if it is zero
+	ReportInboundOrders(id int) ([]EmployeeInboundOrdersReport, error)
 }
 
 // EmployeeService is an interface that contains the methods that the employee service should support
 type EmployeeService interface {
 	// FindAll returns all the employees
 	FindAll() ([]Employee, error)
+	// FindAllWithDeleted returns all the employees, including the soft-deleted ones
+	FindAllWithDeleted() ([]Employee, error)
 	// FindByID returns the employee with the given ID
 	FindByID(id int) (Employee, error)
+	// FindByCardNumberID returns the employee with the given card_number_id
+	FindByCardNumberID(cardNumberID int) (Employee, error)
 	// Save saves the given employee
-	Save(employee *Employee) error
-	// Update updates the given employee
-	Update(employee *Employee) error
-	// Delete deletes the employee with the given ID
-	Delete(id int) error
-}
\ No newline at end of file
+	Save(ctx context.Context, employee *Employee) error
+	// SaveAll validates and saves the given employees in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, employees []*Employee) error
+	// Update updates the given employee, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, employee *Employee) error
+	// Patch partially updates the employee with the given ID, fn receives the current employee and changes the fields to update
+	Patch(ctx context.Context, id int, fn func(employee *Employee) error) (Employee, error)
+	// Delete soft-deletes the employee with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted employee with the given ID
+	Restore(ctx context.Context, id int) error
+	// ReportInboundOrders returns the number of inbound orders received by the employee with the given ID, or by every employee if it is zero
+	ReportInboundOrders(id int) ([]EmployeeInboundOrdersReport, error)
+}
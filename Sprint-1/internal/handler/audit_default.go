@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/response"
+)
+
+// NewAuditDefault creates a new instance of the audit handler
+func NewAuditDefault(sv internal.AuditService) *AuditDefault {
+	return &AuditDefault{
+		sv: sv,
+	}
+}
+
+// AuditDefault is the default implementation of the audit handler
+type AuditDefault struct {
+	// sv is the service used by the handler
+	sv internal.AuditService
+}
+
+// AuditJSON is the JSON representation of an audit entry
+type AuditJSON struct {
+	ID        int             `json:"id"`
+	Entity    string          `json:"entity"`
+	EntityID  int             `json:"entity_id"`
+	Operation string          `json:"operation"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	RequestID string          `json:"request_id"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// GetByEntity returns the audit entries of an entity
+// - query parameter entity is required
+// - query parameter id is optional
+func (h *AuditDefault) GetByEntity() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		entity := r.URL.Query().Get("entity")
+		if entity == "" {
+			response.Error(w, http.StatusBadRequest, "entity is required")
+			return
+		}
+		var id int
+		if r.URL.Query().Has("id") {
+			var err error
+			id, err = strconv.Atoi(r.URL.Query().Get("id"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid id")
+				return
+			}
+		}
+
+		// process
+		audits, err := h.sv.FindByEntity(entity, id)
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		data := make([]AuditJSON, len(audits))
+		for i, a := range audits {
+			data[i] = AuditJSON{
+				ID:        a.ID,
+				Entity:    a.Entity,
+				EntityID:  a.EntityID,
+				Operation: a.Operation,
+				Before:    a.Before,
+				After:     a.After,
+				RequestID: a.RequestID,
+				CreatedAt: a.CreatedAt.Format(time.DateTime),
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+)
+
+// BulkRowJSON is the JSON representation of the result of a row of a bulk import
+type BulkRowJSON struct {
+	Row   int    `json:"row"`
+	ID    int    `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// requestBulk decodes the rows of a bulk import into ptr, from a CSV upload (text/csv) or a JSON array
+// - the rows of a CSV upload that can not be decoded are returned as an internal.BulkError
+func requestBulk(r *http.Request, ptr any) (err error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "text/csv" {
+		err = request.JSON(r, ptr)
+		return
+	}
+
+	err = request.CSV(r, ptr)
+	var rowsErr request.CSVRowsError
+	if errors.As(err, &rowsErr) {
+		bulkErr := make(internal.BulkError, len(rowsErr))
+		for i, rowErr := range rowsErr {
+			bulkErr[i] = internal.BulkRowError{Row: rowErr.Row, Err: rowErr}
+		}
+		err = bulkErr
+	}
+	return
+}
+
+// responseBulkRequest writes the error of a bulk import that could not be decoded
+// - the rows of a CSV upload that can not be decoded are reported on their own, as the rows that fail to import
+func responseBulkRequest(w http.ResponseWriter, rows int, err error) {
+	var bulkErr internal.BulkError
+	if !errors.As(err, &bulkErr) {
+		response.Error(w, http.StatusBadRequest, "invalid body")
+		return
+	}
+	responseBulk(w, make([]int, rows), err, func(err error) (int, string) {
+		return http.StatusUnprocessableEntity, err.Error()
+	})
+}
+
+// responseBulk writes the result of a bulk import
+// - ids are the ids of the imported rows, in the same order as the request
+// - rowError maps the error of a row to its status code and message, the first failed row sets the status code
+// - as the import is applied as a whole, the rows without an error of their own are reported as not imported
+func responseBulk(w http.ResponseWriter, ids []int, err error, rowError func(err error) (int, string)) {
+	// success
+	if err == nil {
+		data := make([]BulkRowJSON, len(ids))
+		for i, id := range ids {
+			data[i] = BulkRowJSON{Row: i + 1, ID: id}
+		}
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+		return
+	}
+
+	// failure of the whole import
+	var bulkErr internal.BulkError
+	switch {
+	case errors.Is(err, internal.ErrBulkEmpty):
+		response.Error(w, http.StatusBadRequest, "no rows to import")
+		return
+	case !errors.As(err, &bulkErr):
+		response.Error(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	// failure of some rows
+	data := make([]BulkRowJSON, len(ids))
+	for i := range data {
+		data[i] = BulkRowJSON{Row: i + 1, Error: "not imported"}
+	}
+	var code int
+	for _, rowErr := range bulkErr {
+		rowCode, msg := rowError(rowErr.Err)
+		if rowCode == http.StatusInternalServerError {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+		if code == 0 {
+			code = rowCode
+		}
+		data[rowErr.Row-1].Error = msg
+	}
+	response.JSON(w, code, map[string]any{
+		"message": "no rows were imported",
+		"data":    data,
+	})
+}
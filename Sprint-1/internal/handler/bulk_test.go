@@ -0,0 +1,259 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSellerBulkMock creates a seller repository mock where no cid is in use and the sellers are saved with consecutive ids
+func newSellerBulkMock() *repository.SellerMock {
+	rp := repository.NewSellerMock()
+	rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+		return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+	}
+	rp.FuncSaveAll = func(ctx context.Context, sellers []*internal.Seller) error {
+		for i, seller := range sellers {
+			(*seller).ID = i + 1
+		}
+		return nil
+	}
+	return rp
+}
+
+// Tests for SellerDefault.CreateBulk, over requestBulk and responseBulk
+func TestSellerDefault_CreateBulk(t *testing.T) {
+	t.Run("case 1: success - imports the rows of a JSON array", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `[{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111"},{"cid":20,"company_name":"Other","address":"Street 2","telephone":"222"}]`
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", body)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":[{"row":1,"id":1},{"row":2,"id":2}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.SaveAll)
+	})
+
+	t.Run("case 2: success - imports the rows of a CSV upload with a charset", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		var saved []internal.Seller
+		rp.FuncSaveAll = func(ctx context.Context, sellers []*internal.Seller) error {
+			for i, seller := range sellers {
+				(*seller).ID = i + 1
+				saved = append(saved, *seller)
+			}
+			return nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := "cid,company_name,address,telephone,locality_id\n10,Acme,Street 1,111,\n20,Other,Street 2,222,1425\n"
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", body)
+		req.Header.Set("Content-Type", "text/csv; charset=utf-8")
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":[{"row":1,"id":1},{"row":2,"id":2}]}`
+		expectedSaved := []internal.Seller{
+			{ID: 1, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111"},
+			{ID: 2, CID: 20, CompanyName: "Other", Address: "Street 2", Telephone: "222", LocalityID: 1425},
+		}
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, expectedSaved, saved)
+	})
+
+	t.Run("case 3: error - a CSV row that can not be decoded is reported on its own row", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := "cid,company_name,address,telephone\n10,Acme,Street 1,111\nabc,Other,Street 2,222\n"
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", body)
+		req.Header.Set("Content-Type", "text/csv")
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"message":"no rows were imported","data":[{"row":1,"error":"not imported"},{"row":2,"error":"line 3, column cid: strconv.ParseInt: parsing \"abc\": invalid syntax"}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.SaveAll)
+	})
+
+	t.Run("case 4: error - the rows that fail are reported with their error, the first one sets the status code", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `[{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111"},{"cid":10,"company_name":"Other","address":"Street 2","telephone":"222"},{"cid":30,"company_name":"","address":"Street 3","telephone":"333"}]`
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", body)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"message":"no rows were imported","data":[{"row":1,"error":"not imported"},{"row":2,"error":"seller cid already exists"},{"row":3,"error":"seller: invalid fields: company_name is required"}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.SaveAll)
+	})
+
+	t.Run("case 5: error - a row the repository fails to save is reported on its row", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		rp.FuncSaveAll = func(ctx context.Context, sellers []*internal.Seller) error {
+			return internal.BulkError{{Row: 2, Err: internal.ErrSellerLocalityNotFound}}
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `[{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111"},{"cid":20,"company_name":"Other","address":"Street 2","telephone":"222","locality_id":99}]`
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", body)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"message":"no rows were imported","data":[{"row":1,"error":"not imported"},{"row":2,"error":"locality not found"}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 6: error - the import has no rows", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", `[]`)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"no rows to import"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.SaveAll)
+	})
+
+	t.Run("case 7: error - the body is neither a JSON array nor a CSV upload", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", `{"cid":10}`)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid body"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.SaveAll)
+	})
+
+	t.Run("case 8: error - the repository fails as a whole", func(t *testing.T) {
+		// arrange
+		rp := newSellerBulkMock()
+		rp.FuncSaveAll = func(ctx context.Context, sellers []*internal.Seller) error {
+			return errors.New("connection refused")
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `[{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111"}]`
+		req := newRequest(http.MethodPost, "/api/v1/sellers/bulk", "", body)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusInternalServerError
+		expectedBody := `{"status":"Internal Server Error","message":"internal server error"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for WarehouseDefault.CreateBulk
+func TestWarehouseDefault_CreateBulk(t *testing.T) {
+	t.Run("case 1: success - imports the rows of a CSV upload", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		var saved []internal.Warehouse
+		rp.FuncSaveAll = func(ctx context.Context, warehouses []*internal.Warehouse) error {
+			for i, warehouse := range warehouses {
+				(*warehouse).ID = i + 1
+				saved = append(saved, *warehouse)
+			}
+			return nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := "warehouse_code,address,telephone,minimum_capacity,minimum_temperature\nW1,Street 1,111,10,-5.5\n"
+		req := newRequest(http.MethodPost, "/api/v1/warehouses/bulk", "", body)
+		req.Header.Set("Content-Type", "text/csv")
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":[{"row":1,"id":1}]}`
+		expectedSaved := []internal.Warehouse{{ID: 1, WarehouseCode: "W1", Address: "Street 1", Telephone: "111", MinimumCapacity: 10, MinimumTemperature: -5.5}}
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, expectedSaved, saved)
+	})
+
+	t.Run("case 2: error - a warehouse_code repeated in the import is reported on its row", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := `[{"warehouse_code":"W1","address":"Street 1","telephone":"111"},{"warehouse_code":"W1","address":"Street 2","telephone":"222"}]`
+		req := newRequest(http.MethodPost, "/api/v1/warehouses/bulk", "", body)
+		res := httptest.NewRecorder()
+		hd.CreateBulk()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"message":"no rows were imported","data":[{"row":1,"error":"not imported"},{"row":2,"error":"warehouse warehouse_code already exists"}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.SaveAll)
+	})
+}
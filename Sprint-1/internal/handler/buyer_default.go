@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewBuyerDefault creates a new instance of the buyer handler
@@ -19,37 +25,406 @@ type BuyerDefault struct {
 	sv internal.BuyerService
 }
 
+// BuyerJSON is the JSON representation of a buyer
+type BuyerJSON struct {
+	ID           int    `json:"id"`
+	CardNumberID int    `json:"card_number_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+}
+
+// newBuyerJSON serializes a buyer into its JSON representation
+func newBuyerJSON(buyer internal.Buyer) BuyerJSON {
+	return BuyerJSON{
+		ID:           buyer.ID,
+		CardNumberID: buyer.CardNumberID,
+		FirstName:    buyer.FirstName,
+		LastName:     buyer.LastName,
+	}
+}
+
+// RequestBodyBuyer is the request body to create or update a buyer
+type RequestBodyBuyer struct {
+	CardNumberID int    `json:"card_number_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+}
+
+// buyerFromRequestBody deserializes the request body into a buyer
+func buyerFromRequestBody(id int, body RequestBodyBuyer) internal.Buyer {
+	return internal.Buyer{
+		ID:           id,
+		CardNumberID: body.CardNumberID,
+		FirstName:    body.FirstName,
+		LastName:     body.LastName,
+	}
+}
+
+// newRequestBodyBuyer serializes a buyer into its request body, so a partial update can be decoded on top of it
+func newRequestBodyBuyer(buyer internal.Buyer) RequestBodyBuyer {
+	return RequestBodyBuyer{
+		CardNumberID: buyer.CardNumberID,
+		FirstName:    buyer.FirstName,
+		LastName:     buyer.LastName,
+	}
+}
+
 // GetAll returns all buyers
 func (h *BuyerDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter card_number_id: look up a single buyer by its card_number_id
+		if r.URL.Query().Has("card_number_id") {
+			h.GetByCardNumberID()(w, r)
+			return
+		}
+		// - query parameter include_deleted: also return the soft-deleted buyers
+		var includeDeleted bool
+		if r.URL.Query().Has("include_deleted") {
+			var err error
+			includeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid include_deleted")
+				return
+			}
+		}
+
+		// process
+		var buyers []internal.Buyer
+		var err error
+		if includeDeleted {
+			buyers, err = h.sv.FindAllWithDeleted()
+		} else {
+			buyers, err = h.sv.FindAll()
+		}
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
 
+		// response
+		data := make([]BuyerJSON, len(buyers))
+		for i, buyer := range buyers {
+			data[i] = newBuyerJSON(buyer)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
 	}
 }
 
 // GetByID returns a buyer
 func (h *BuyerDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		buyer, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrBuyerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, buyer.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newBuyerJSON(buyer),
+		})
+	}
+}
+
+// GetByCardNumberID returns a buyer by its card_number_id, given as the query parameter card_number_id
+func (h *BuyerDefault) GetByCardNumberID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		cardNumberID, err := strconv.Atoi(r.URL.Query().Get("card_number_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid card_number_id")
+			return
+		}
 
+		// process
+		buyer, err := h.sv.FindByCardNumberID(cardNumberID)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrBuyerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, buyer.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newBuyerJSON(buyer),
+		})
 	}
 }
 
 // Create creates a new buyer
 func (h *BuyerDefault) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyBuyer
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		buyer := buyerFromRequestBody(0, body)
+		err = h.sv.Save(r.Context(), &buyer)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrBuyerInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrBuyerCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "buyer card_number_id already exists")
+			case errors.Is(err, internal.ErrBuyerRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "buyer already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, buyer.Version)
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newBuyerJSON(buyer),
+		})
+	}
+}
+
+// CreateBulk creates the buyers of a JSON array or a CSV upload in a single import
+func (h *BuyerDefault) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []RequestBodyBuyer
+		err := requestBulk(r, &body)
+		if err != nil {
+			responseBulkRequest(w, len(body), err)
+			return
+		}
+
+		// process
+		buyers := make([]*internal.Buyer, len(body))
+		for i, row := range body {
+			buyer := buyerFromRequestBody(0, row)
+			buyers[i] = &buyer
+		}
+		err = h.sv.SaveAll(r.Context(), buyers)
+
+		// response
+		ids := make([]int, len(buyers))
+		for i, buyer := range buyers {
+			ids[i] = (*buyer).ID
+		}
+		responseBulk(w, ids, err, buyerRowError)
+	}
+}
+
+// buyerRowError maps the error of a row of a bulk import of buyers to its status code and message
+func buyerRowError(err error) (int, string) {
+	switch {
+	case errors.Is(err, internal.ErrBuyerInvalid):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, internal.ErrBuyerCardNumberIDDuplicated):
+		return http.StatusConflict, "buyer card_number_id already exists"
+	case errors.Is(err, internal.ErrBuyerRepositoryDuplicated):
+		return http.StatusConflict, "buyer already exists"
+	default:
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
 // Update updates a buyer
 func (h *BuyerDefault) Update() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body RequestBodyBuyer
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		// - header If-Match: the version the update is based on, the current one if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		buyer := buyerFromRequestBody(id, body)
+		buyer.Version = version
+		err = h.sv.Update(r.Context(), &buyer)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrBuyerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			case errors.Is(err, internal.ErrBuyerInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrBuyerCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "buyer card_number_id already exists")
+			case errors.Is(err, internal.ErrBuyerRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "buyer already exists")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "buyer was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, buyer.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newBuyerJSON(buyer),
+		})
 	}
 }
 
-// Delete deletes a buyer
+// Patch partially updates a buyer, the fields absent from the body keep their current value
+func (h *BuyerDefault) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - header If-Match: the version the update is based on, the one read by the patch if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		// - the body is decoded on top of the current buyer
+		buyer, err := h.sv.Patch(r.Context(), id, func(buyer *internal.Buyer) (err error) {
+			body := newRequestBodyBuyer(*buyer)
+			err = request.JSON(r, &body)
+			if err != nil {
+				return
+			}
+			patched := buyerFromRequestBody((*buyer).ID, body)
+			patched.Version = (*buyer).Version
+			if version != 0 {
+				patched.Version = version
+			}
+			*buyer = patched
+			return
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, request.ErrRequestContentTypeNotJSON), errors.Is(err, request.ErrRequestJSONInvalid):
+				response.Error(w, http.StatusBadRequest, "invalid body")
+			case errors.Is(err, internal.ErrBuyerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			case errors.Is(err, internal.ErrBuyerInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrBuyerCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "buyer card_number_id already exists")
+			case errors.Is(err, internal.ErrBuyerRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "buyer already exists")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "buyer was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, buyer.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newBuyerJSON(buyer),
+		})
+	}
+}
+
+// Delete soft-deletes a buyer
 func (h *BuyerDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
 
+		// process
+		err = h.sv.Delete(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrBuyerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			case errors.Is(err, internal.ErrBuyerHasPurchaseOrders):
+				response.Error(w, http.StatusConflict, "buyer has purchase orders")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
 	}
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted buyer
+func (h *BuyerDefault) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Restore(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrBuyerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			case errors.Is(err, internal.ErrBuyerCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "buyer card_number_id already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "buyer restored",
+		})
+	}
+}
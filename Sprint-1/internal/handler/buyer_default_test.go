@@ -0,0 +1,256 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for BuyerDefault.GetAll
+func TestBuyerDefault_GetAll(t *testing.T) {
+	t.Run("case 1: success - returns the active buyers", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncFindAll = func() ([]internal.Buyer, error) {
+			return []internal.Buyer{
+				{ID: 1, CardNumberID: 100, FirstName: "Ana", LastName: "Diaz"},
+			}, nil
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"id":1,"card_number_id":100,"first_name":"Ana","last_name":"Diaz"}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.FindAll)
+	})
+
+	t.Run("case 2: error - include_deleted is not a boolean", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers?include_deleted=no", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid include_deleted"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for BuyerDefault.GetByCardNumberID, through the query parameter card_number_id of BuyerDefault.GetAll
+func TestBuyerDefault_GetByCardNumberID(t *testing.T) {
+	t.Run("case 1: success - returns the buyer with the card_number_id and its version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Buyer, error) {
+			return internal.Buyer{ID: 1, CardNumberID: cardNumberID, FirstName: "Ana", LastName: "Diaz", Version: 3}, nil
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers?card_number_id=100", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"card_number_id":100,"first_name":"Ana","last_name":"Diaz"}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"3"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.FindByCardNumberID)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 2: error - the card_number_id is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers?card_number_id=abc", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid card_number_id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByCardNumberID)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 3: error - no buyer has the card_number_id", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Buyer, error) {
+			return internal.Buyer{}, internal.ErrBuyerRepositoryNotFound
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers?card_number_id=100", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"buyer not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for BuyerDefault.Create
+func TestBuyerDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the buyer", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Buyer, error) {
+			return internal.Buyer{}, internal.ErrBuyerRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, buyer *internal.Buyer) error {
+			(*buyer).ID = 1
+			(*buyer).Version = 1
+			return nil
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		body := `{"card_number_id":100,"first_name":"Ana","last_name":"Diaz"}`
+		req := newRequest(http.MethodPost, "/api/v1/buyers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"card_number_id":100,"first_name":"Ana","last_name":"Diaz"}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		body := `{"card_number_id":-1,"first_name":"Ana","last_name":"Diaz"}`
+		req := newRequest(http.MethodPost, "/api/v1/buyers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"buyer: invalid fields: card_number_id must be greater than 0"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the card_number_id is already in use", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Buyer, error) {
+			return internal.Buyer{ID: 2, CardNumberID: cardNumberID}, nil
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		body := `{"card_number_id":100,"first_name":"Ana","last_name":"Diaz"}`
+		req := newRequest(http.MethodPost, "/api/v1/buyers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"buyer card_number_id already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for BuyerDefault.Delete
+func TestBuyerDefault_Delete(t *testing.T) {
+	t.Run("case 1: success - deletes the buyer", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/buyers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNoContent
+		require.Equal(t, expectedCode, res.Code)
+		require.Empty(t, res.Body.String())
+	})
+
+	t.Run("case 2: error - the buyer is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrBuyerRepositoryNotFound
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/buyers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"buyer not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 3: error - the buyer still has purchase orders", func(t *testing.T) {
+		// arrange
+		rp := repository.NewBuyerMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrBuyerHasPurchaseOrders
+		}
+		hd := handler.NewBuyerDefault(service.NewBuyerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/buyers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"buyer has purchase orders"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+)
+
+// NewCarrierDefault creates a new instance of the carrier handler
+func NewCarrierDefault(sv internal.CarrierService) *CarrierDefault {
+	return &CarrierDefault{
+		sv: sv,
+	}
+}
+
+// CarrierDefault is the default implementation of the carrier handler
+type CarrierDefault struct {
+	// sv is the service used by the handler
+	sv internal.CarrierService
+}
+
+// CarrierJSON is the JSON representation of a carrier
+type CarrierJSON struct {
+	ID          int    `json:"id"`
+	CID         string `json:"cid"`
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Telephone   string `json:"telephone"`
+	LocalityID  int    `json:"locality_id"`
+}
+
+// newCarrierJSON serializes a carrier into its JSON representation
+func newCarrierJSON(carrier internal.Carrier) CarrierJSON {
+	return CarrierJSON{
+		ID:          carrier.ID,
+		CID:         carrier.CID,
+		CompanyName: carrier.CompanyName,
+		Address:     carrier.Address,
+		Telephone:   carrier.Telephone,
+		LocalityID:  carrier.LocalityID,
+	}
+}
+
+// RequestBodyCarrier is the request body to create a carrier
+type RequestBodyCarrier struct {
+	CID         string `json:"cid"`
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Telephone   string `json:"telephone"`
+	LocalityID  int    `json:"locality_id"`
+}
+
+// GetAll returns all carriers
+func (h *CarrierDefault) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// process
+		carriers, err := h.sv.FindAll()
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		data := make([]CarrierJSON, len(carriers))
+		for i, carrier := range carriers {
+			data[i] = newCarrierJSON(carrier)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
+
+// Create creates a new carrier
+func (h *CarrierDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyCarrier
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		carrier := internal.Carrier{
+			CID:         body.CID,
+			CompanyName: body.CompanyName,
+			Address:     body.Address,
+			Telephone:   body.Telephone,
+			LocalityID:  body.LocalityID,
+		}
+		err = h.sv.Save(r.Context(), &carrier)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrCarrierInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrCarrierRepositoryLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			case errors.Is(err, internal.ErrCarrierRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "carrier already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newCarrierJSON(carrier),
+		})
+	}
+}
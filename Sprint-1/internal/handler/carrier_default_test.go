@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for CarrierDefault.Create
+func TestCarrierDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the carrier", func(t *testing.T) {
+		// arrange
+		rp := repository.NewCarrierMock()
+		rp.FuncSave = func(ctx context.Context, carrier *internal.Carrier) error {
+			(*carrier).ID = 1
+			return nil
+		}
+		hd := handler.NewCarrierDefault(service.NewCarrierDefault(rp))
+
+		// act
+		body := `{"cid":"CAR-1","company_name":"Fast","address":"Street 1","telephone":"111","locality_id":1425}`
+		req := newRequest(http.MethodPost, "/api/v1/carriers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"cid":"CAR-1","company_name":"Fast","address":"Street 1","telephone":"111","locality_id":1425}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the locality_id is missing", func(t *testing.T) {
+		// arrange
+		rp := repository.NewCarrierMock()
+		hd := handler.NewCarrierDefault(service.NewCarrierDefault(rp))
+
+		// act
+		body := `{"cid":"CAR-1","company_name":"Fast","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPost, "/api/v1/carriers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"carrier: invalid fields: locality_id must be greater than 0"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the locality does not exist or the carrier already exists", func(t *testing.T) {
+		// arrange
+		type testCase struct {
+			err             error
+			expectedMessage string
+		}
+		testCases := []testCase{
+			{err: internal.ErrCarrierRepositoryLocalityNotFound, expectedMessage: "locality not found"},
+			{err: internal.ErrCarrierRepositoryDuplicated, expectedMessage: "carrier already exists"},
+		}
+
+		for _, tc := range testCases {
+			rp := repository.NewCarrierMock()
+			rp.FuncSave = func(ctx context.Context, carrier *internal.Carrier) error {
+				return tc.err
+			}
+			hd := handler.NewCarrierDefault(service.NewCarrierDefault(rp))
+
+			// act
+			body := `{"cid":"CAR-1","company_name":"Fast","address":"Street 1","telephone":"111","locality_id":1425}`
+			req := newRequest(http.MethodPost, "/api/v1/carriers", "", body)
+			res := httptest.NewRecorder()
+			hd.Create()(res, req)
+
+			// assert
+			expectedCode := http.StatusConflict
+			expectedBody := `{"status":"Conflict","message":"` + tc.expectedMessage + `"}`
+			require.Equal(t, expectedCode, res.Code)
+			require.JSONEq(t, expectedBody, res.Body.String())
+		}
+	})
+}
@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewEmployeeDefault creates a new instance of the employee handler
@@ -19,37 +25,477 @@ type EmployeeDefault struct {
 	sv internal.EmployeeService
 }
 
+// EmployeeJSON is the JSON representation of an employee
+type EmployeeJSON struct {
+	ID           int    `json:"id"`
+	CardNumberID int    `json:"card_number_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	WarehouseID  int    `json:"warehouse_id"`
+}
+
+// newEmployeeJSON serializes an employee into its JSON representation
+func newEmployeeJSON(employee internal.Employee) EmployeeJSON {
+	return EmployeeJSON{
+		ID:           employee.ID,
+		CardNumberID: employee.CardNumberID,
+		FirstName:    employee.FirstName,
+		LastName:     employee.LastName,
+		WarehouseID:  employee.WarehouseID,
+	}
+}
+
+// RequestBodyEmployee is the request body to create or update an employee
+type RequestBodyEmployee struct {
+	CardNumberID int    `json:"card_number_id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	WarehouseID  int    `json:"warehouse_id"`
+}
+
+// employeeFromRequestBody deserializes the request body into an employee
+func employeeFromRequestBody(id int, body RequestBodyEmployee) internal.Employee {
+	return internal.Employee{
+		ID:           id,
+		CardNumberID: body.CardNumberID,
+		FirstName:    body.FirstName,
+		LastName:     body.LastName,
+		WarehouseID:  body.WarehouseID,
+	}
+}
+
+// newRequestBodyEmployee serializes an employee into its request body, so a partial update can be decoded on top of it
+func newRequestBodyEmployee(employee internal.Employee) RequestBodyEmployee {
+	return RequestBodyEmployee{
+		CardNumberID: employee.CardNumberID,
+		FirstName:    employee.FirstName,
+		LastName:     employee.LastName,
+		WarehouseID:  employee.WarehouseID,
+	}
+}
+
+// EmployeeInboundOrdersReportJSON is the JSON representation of the number of inbound orders received by an employee
+type EmployeeInboundOrdersReportJSON struct {
+	ID                 int    `json:"id"`
+	CardNumberID       int    `json:"card_number_id"`
+	FirstName          string `json:"first_name"`
+	LastName           string `json:"last_name"`
+	WarehouseID        int    `json:"warehouse_id"`
+	InboundOrdersCount int    `json:"inbound_orders_count"`
+}
+
 // GetAll returns all employees
 func (h *EmployeeDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter card_number_id: look up a single employee by its card_number_id
+		if r.URL.Query().Has("card_number_id") {
+			h.GetByCardNumberID()(w, r)
+			return
+		}
+		// - query parameter include_deleted: also return the soft-deleted employees
+		var includeDeleted bool
+		if r.URL.Query().Has("include_deleted") {
+			var err error
+			includeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid include_deleted")
+				return
+			}
+		}
 
+		// process
+		var employees []internal.Employee
+		var err error
+		if includeDeleted {
+			employees, err = h.sv.FindAllWithDeleted()
+		} else {
+			employees, err = h.sv.FindAll()
+		}
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		data := make([]EmployeeJSON, len(employees))
+		for i, employee := range employees {
+			data[i] = newEmployeeJSON(employee)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
 	}
 }
 
 // GetByID returns a employee
 func (h *EmployeeDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		employee, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, employee.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newEmployeeJSON(employee),
+		})
+	}
+}
+
+// GetByCardNumberID returns an employee by its card_number_id, given as the query parameter card_number_id
+func (h *EmployeeDefault) GetByCardNumberID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		cardNumberID, err := strconv.Atoi(r.URL.Query().Get("card_number_id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid card_number_id")
+			return
+		}
 
+		// process
+		employee, err := h.sv.FindByCardNumberID(cardNumberID)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, employee.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newEmployeeJSON(employee),
+		})
 	}
 }
 
 // Create creates a new employee
 func (h *EmployeeDefault) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyEmployee
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		employee := employeeFromRequestBody(0, body)
+		err = h.sv.Save(r.Context(), &employee)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrEmployeeCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "employee card_number_id already exists")
+			case errors.Is(err, internal.ErrEmployeeRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "employee already exists")
+			case errors.Is(err, internal.ErrEmployeeWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, employee.Version)
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newEmployeeJSON(employee),
+		})
+	}
+}
+
+// CreateBulk creates the employees of a JSON array or a CSV upload in a single import
+func (h *EmployeeDefault) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []RequestBodyEmployee
+		err := requestBulk(r, &body)
+		if err != nil {
+			responseBulkRequest(w, len(body), err)
+			return
+		}
+
+		// process
+		employees := make([]*internal.Employee, len(body))
+		for i, row := range body {
+			employee := employeeFromRequestBody(0, row)
+			employees[i] = &employee
+		}
+		err = h.sv.SaveAll(r.Context(), employees)
+
+		// response
+		ids := make([]int, len(employees))
+		for i, employee := range employees {
+			ids[i] = (*employee).ID
+		}
+		responseBulk(w, ids, err, employeeRowError)
+	}
+}
+
+// employeeRowError maps the error of a row of a bulk import of employees to its status code and message
+func employeeRowError(err error) (int, string) {
+	switch {
+	case errors.Is(err, internal.ErrEmployeeInvalid):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, internal.ErrEmployeeCardNumberIDDuplicated):
+		return http.StatusConflict, "employee card_number_id already exists"
+	case errors.Is(err, internal.ErrEmployeeRepositoryDuplicated):
+		return http.StatusConflict, "employee already exists"
+	case errors.Is(err, internal.ErrEmployeeWarehouseNotFound):
+		return http.StatusConflict, "warehouse not found"
+	default:
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
 // Update updates a employee
 func (h *EmployeeDefault) Update() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body RequestBodyEmployee
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		// - header If-Match: the version the update is based on, the current one if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		employee := employeeFromRequestBody(id, body)
+		employee.Version = version
+		err = h.sv.Update(r.Context(), &employee)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			case errors.Is(err, internal.ErrEmployeeInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrEmployeeCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "employee card_number_id already exists")
+			case errors.Is(err, internal.ErrEmployeeRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "employee already exists")
+			case errors.Is(err, internal.ErrEmployeeWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "employee was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, employee.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newEmployeeJSON(employee),
+		})
+	}
+}
+
+// Patch partially updates an employee, the fields absent from the body keep their current value
+func (h *EmployeeDefault) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - header If-Match: the version the update is based on, the one read by the patch if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		// - the body is decoded on top of the current employee
+		employee, err := h.sv.Patch(r.Context(), id, func(employee *internal.Employee) (err error) {
+			body := newRequestBodyEmployee(*employee)
+			err = request.JSON(r, &body)
+			if err != nil {
+				return
+			}
+			patched := employeeFromRequestBody((*employee).ID, body)
+			patched.Version = (*employee).Version
+			if version != 0 {
+				patched.Version = version
+			}
+			*employee = patched
+			return
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, request.ErrRequestContentTypeNotJSON), errors.Is(err, request.ErrRequestJSONInvalid):
+				response.Error(w, http.StatusBadRequest, "invalid body")
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			case errors.Is(err, internal.ErrEmployeeInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrEmployeeCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "employee card_number_id already exists")
+			case errors.Is(err, internal.ErrEmployeeRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "employee already exists")
+			case errors.Is(err, internal.ErrEmployeeWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "employee was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, employee.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newEmployeeJSON(employee),
+		})
 	}
 }
 
-// Delete deletes a employee
+// Delete soft-deletes a employee
 func (h *EmployeeDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Delete(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			case errors.Is(err, internal.ErrEmployeeHasInboundOrders):
+				response.Error(w, http.StatusConflict, "employee has inbound orders")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
 	}
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted employee
+func (h *EmployeeDefault) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Restore(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			case errors.Is(err, internal.ErrEmployeeCardNumberIDDuplicated):
+				response.Error(w, http.StatusConflict, "employee card_number_id already exists")
+			case errors.Is(err, internal.ErrEmployeeWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "employee restored",
+		})
+	}
+}
+
+// ReportInboundOrders returns the number of inbound orders received by an employee, or by every employee
+func (h *EmployeeDefault) ReportInboundOrders() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter id: the employee to report, every employee if it is not given
+		var id int
+		if r.URL.Query().Has("id") {
+			var err error
+			id, err = strconv.Atoi(r.URL.Query().Get("id"))
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "invalid id")
+				return
+			}
+		}
+
+		// process
+		reports, err := h.sv.ReportInboundOrders(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "employee not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		data := make([]EmployeeInboundOrdersReportJSON, len(reports))
+		for i, report := range reports {
+			data[i] = EmployeeInboundOrdersReportJSON{
+				ID:                 report.ID,
+				CardNumberID:       report.CardNumberID,
+				FirstName:          report.FirstName,
+				LastName:           report.LastName,
+				WarehouseID:        report.WarehouseID,
+				InboundOrdersCount: report.InboundOrdersCount,
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for EmployeeDefault.GetAll
+func TestEmployeeDefault_GetAll(t *testing.T) {
+	t.Run("case 1: success - include_deleted also returns the soft-deleted employees", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncFindAllWithDeleted = func() ([]internal.Employee, error) {
+			return []internal.Employee{
+				{ID: 1, CardNumberID: 100, FirstName: "Ana", LastName: "Diaz", WarehouseID: 1},
+			}, nil
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees?include_deleted=true", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"id":1,"card_number_id":100,"first_name":"Ana","last_name":"Diaz","warehouse_id":1}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 2: error - include_deleted is not a boolean", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees?include_deleted=2", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid include_deleted"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for EmployeeDefault.GetByCardNumberID, through the query parameter card_number_id of EmployeeDefault.GetAll
+func TestEmployeeDefault_GetByCardNumberID(t *testing.T) {
+	t.Run("case 1: success - returns the employee with the card_number_id and its version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Employee, error) {
+			return internal.Employee{ID: 1, CardNumberID: cardNumberID, FirstName: "John", LastName: "Doe", WarehouseID: 1, Version: 3}, nil
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees?card_number_id=1001", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"card_number_id":1001,"first_name":"John","last_name":"Doe","warehouse_id":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"3"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.FindByCardNumberID)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 2: error - the card_number_id is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees?card_number_id=abc", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid card_number_id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByCardNumberID)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 3: error - no employee has the card_number_id", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Employee, error) {
+			return internal.Employee{}, internal.ErrEmployeeRepositoryNotFound
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees?card_number_id=1001", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"employee not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for EmployeeDefault.Create
+func TestEmployeeDefault_Create(t *testing.T) {
+	t.Run("case 1: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		body := `{"card_number_id":100,"first_name":"Ana","last_name":"","warehouse_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/employees", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"employee: invalid fields: last_name is required"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the card_number_id is already in use", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Employee, error) {
+			return internal.Employee{ID: 2, CardNumberID: cardNumberID}, nil
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		body := `{"card_number_id":100,"first_name":"Ana","last_name":"Diaz","warehouse_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/employees", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"employee card_number_id already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the warehouse does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncFindByCardNumberID = func(cardNumberID int) (internal.Employee, error) {
+			return internal.Employee{}, internal.ErrEmployeeRepositoryNotFound
+		}
+		rpWarehouse := repository.NewWarehouseMock()
+		rpWarehouse.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, rpWarehouse))
+
+		// act
+		body := `{"card_number_id":100,"first_name":"Ana","last_name":"Diaz","warehouse_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/employees", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for EmployeeDefault.Delete
+func TestEmployeeDefault_Delete(t *testing.T) {
+	t.Run("case 1: success - deletes the employee", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/employees/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNoContent
+		require.Equal(t, expectedCode, res.Code)
+		require.Empty(t, res.Body.String())
+	})
+
+	t.Run("case 2: error - the employee still has inbound orders", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrEmployeeHasInboundOrders
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/employees/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"employee has inbound orders"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+)
+
+// inboundOrderDateLayout is the layout of the order date of an inbound order in its JSON representation
+const inboundOrderDateLayout = time.DateOnly
+
+// NewInboundOrderDefault creates a new instance of the inbound order handler
+func NewInboundOrderDefault(sv internal.InboundOrderService) *InboundOrderDefault {
+	return &InboundOrderDefault{
+		sv: sv,
+	}
+}
+
+// InboundOrderDefault is the default implementation of the inbound order handler
+type InboundOrderDefault struct {
+	// sv is the service used by the handler
+	sv internal.InboundOrderService
+}
+
+// InboundOrderJSON is the JSON representation of an inbound order
+type InboundOrderJSON struct {
+	ID             int    `json:"id"`
+	OrderDate      string `json:"order_date"`
+	OrderNumber    string `json:"order_number"`
+	EmployeeID     int    `json:"employee_id"`
+	ProductBatchID int    `json:"product_batch_id"`
+	WarehouseID    int    `json:"warehouse_id"`
+}
+
+// newInboundOrderJSON serializes an inbound order into its JSON representation
+func newInboundOrderJSON(inboundOrder internal.InboundOrder) InboundOrderJSON {
+	return InboundOrderJSON{
+		ID:             inboundOrder.ID,
+		OrderDate:      inboundOrder.OrderDate.Format(inboundOrderDateLayout),
+		OrderNumber:    inboundOrder.OrderNumber,
+		EmployeeID:     inboundOrder.EmployeeID,
+		ProductBatchID: inboundOrder.ProductBatchID,
+		WarehouseID:    inboundOrder.WarehouseID,
+	}
+}
+
+// RequestBodyInboundOrder is the request body to create an inbound order
+type RequestBodyInboundOrder struct {
+	OrderDate      string `json:"order_date"`
+	OrderNumber    string `json:"order_number"`
+	EmployeeID     int    `json:"employee_id"`
+	ProductBatchID int    `json:"product_batch_id"`
+	WarehouseID    int    `json:"warehouse_id"`
+}
+
+// GetAll returns all inbound orders
+func (h *InboundOrderDefault) GetAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// process
+		inboundOrders, err := h.sv.FindAll()
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		data := make([]InboundOrderJSON, len(inboundOrders))
+		for i, inboundOrder := range inboundOrders {
+			data[i] = newInboundOrderJSON(inboundOrder)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
+
+// Create creates a new inbound order
+func (h *InboundOrderDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyInboundOrder
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		orderDate, err := time.Parse(inboundOrderDateLayout, body.OrderDate)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "invalid order_date")
+			return
+		}
+
+		// process
+		inboundOrder := internal.InboundOrder{
+			OrderDate:      orderDate,
+			OrderNumber:    body.OrderNumber,
+			EmployeeID:     body.EmployeeID,
+			ProductBatchID: body.ProductBatchID,
+			WarehouseID:    body.WarehouseID,
+		}
+		err = h.sv.Save(r.Context(), &inboundOrder)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrInboundOrderInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrEmployeeRepositoryNotFound):
+				response.Error(w, http.StatusConflict, "employee not found")
+			case errors.Is(err, internal.ErrInboundOrderRepositoryEmployeeNotFound):
+				response.Error(w, http.StatusConflict, "employee not found")
+			case errors.Is(err, internal.ErrInboundOrderRepositoryProductBatchNotFound):
+				response.Error(w, http.StatusConflict, "product batch not found")
+			case errors.Is(err, internal.ErrInboundOrderWarehouseMismatch):
+				response.Error(w, http.StatusConflict, "warehouse does not match the employee warehouse")
+			case errors.Is(err, internal.ErrInboundOrderRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "inbound order already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newInboundOrderJSON(inboundOrder),
+		})
+	}
+}
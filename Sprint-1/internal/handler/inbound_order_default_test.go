@@ -0,0 +1,202 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for InboundOrderDefault.Create
+func TestInboundOrderDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the inbound order", func(t *testing.T) {
+		// arrange
+		rp := repository.NewInboundOrderMock()
+		rp.FuncSave = func(ctx context.Context, inboundOrder *internal.InboundOrder) error {
+			(*inboundOrder).ID = 1
+			return nil
+		}
+		rpEmployee := repository.NewEmployeeMock()
+		rpEmployee.FuncFindByID = func(id int) (internal.Employee, error) {
+			return internal.Employee{ID: id, WarehouseID: 2}, nil
+		}
+		hd := handler.NewInboundOrderDefault(service.NewInboundOrderDefault(rp, rpEmployee))
+
+		// act
+		body := `{"order_date":"2024-01-01","order_number":"IO-1","employee_id":1,"product_batch_id":1,"warehouse_id":2}`
+		req := newRequest(http.MethodPost, "/api/v1/inbound-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"order_date":"2024-01-01","order_number":"IO-1","employee_id":1,"product_batch_id":1,"warehouse_id":2}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewInboundOrderMock()
+		rpEmployee := repository.NewEmployeeMock()
+		hd := handler.NewInboundOrderDefault(service.NewInboundOrderDefault(rp, rpEmployee))
+
+		// act
+		body := `{"order_date":"2024-01-01","order_number":"","employee_id":1,"product_batch_id":1,"warehouse_id":2}`
+		req := newRequest(http.MethodPost, "/api/v1/inbound-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"inbound order: invalid fields: order_number is required"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rpEmployee.Spy.FindByID)
+	})
+
+	t.Run("case 3: error - the employee does not work in the warehouse", func(t *testing.T) {
+		// arrange
+		rp := repository.NewInboundOrderMock()
+		rpEmployee := repository.NewEmployeeMock()
+		rpEmployee.FuncFindByID = func(id int) (internal.Employee, error) {
+			return internal.Employee{ID: id, WarehouseID: 3}, nil
+		}
+		hd := handler.NewInboundOrderDefault(service.NewInboundOrderDefault(rp, rpEmployee))
+
+		// act
+		body := `{"order_date":"2024-01-01","order_number":"IO-1","employee_id":1,"product_batch_id":1,"warehouse_id":2}`
+		req := newRequest(http.MethodPost, "/api/v1/inbound-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse does not match the employee warehouse"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the employee does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewInboundOrderMock()
+		rpEmployee := repository.NewEmployeeMock()
+		rpEmployee.FuncFindByID = func(id int) (internal.Employee, error) {
+			return internal.Employee{}, internal.ErrEmployeeRepositoryNotFound
+		}
+		hd := handler.NewInboundOrderDefault(service.NewInboundOrderDefault(rp, rpEmployee))
+
+		// act
+		body := `{"order_date":"2024-01-01","order_number":"IO-1","employee_id":99,"product_batch_id":1,"warehouse_id":2}`
+		req := newRequest(http.MethodPost, "/api/v1/inbound-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"employee not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 5: error - the product batch does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewInboundOrderMock()
+		rp.FuncSave = func(ctx context.Context, inboundOrder *internal.InboundOrder) error {
+			return internal.ErrInboundOrderRepositoryProductBatchNotFound
+		}
+		rpEmployee := repository.NewEmployeeMock()
+		rpEmployee.FuncFindByID = func(id int) (internal.Employee, error) {
+			return internal.Employee{ID: id, WarehouseID: 2}, nil
+		}
+		hd := handler.NewInboundOrderDefault(service.NewInboundOrderDefault(rp, rpEmployee))
+
+		// act
+		body := `{"order_date":"2024-01-01","order_number":"IO-1","employee_id":1,"product_batch_id":99,"warehouse_id":2}`
+		req := newRequest(http.MethodPost, "/api/v1/inbound-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"product batch not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for EmployeeDefault.ReportInboundOrders
+func TestEmployeeDefault_ReportInboundOrders(t *testing.T) {
+	t.Run("case 1: success - without id every employee is reported", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		var reported int
+		rp.FuncReportInboundOrders = func(id int) ([]internal.EmployeeInboundOrdersReport, error) {
+			reported = id
+			return []internal.EmployeeInboundOrdersReport{
+				{Employee: internal.Employee{ID: 1, CardNumberID: 100, FirstName: "Ana", LastName: "Diaz", WarehouseID: 2}, InboundOrdersCount: 3},
+			}, nil
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees/report-inbound-orders", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportInboundOrders()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"id":1,"card_number_id":100,"first_name":"Ana","last_name":"Diaz","warehouse_id":2,"inbound_orders_count":3}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, reported)
+	})
+
+	t.Run("case 2: error - the id is not a positive number", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees/report-inbound-orders?id=0", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportInboundOrders()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.ReportInboundOrders)
+	})
+
+	t.Run("case 3: error - the employee is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewEmployeeMock()
+		rp.FuncReportInboundOrders = func(id int) ([]internal.EmployeeInboundOrdersReport, error) {
+			return nil, internal.ErrEmployeeRepositoryNotFound
+		}
+		hd := handler.NewEmployeeDefault(service.NewEmployeeDefault(rp, repository.NewWarehouseMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/employees/report-inbound-orders?id=1", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportInboundOrders()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"employee not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NewLocalityDefault creates a new instance of the locality handler
+func NewLocalityDefault(sv internal.LocalityService) *LocalityDefault {
+	return &LocalityDefault{
+		sv: sv,
+	}
+}
+
+// LocalityDefault is the default implementation of the locality handler
+type LocalityDefault struct {
+	// sv is the service used by the handler
+	sv internal.LocalityService
+}
+
+// LocalityJSON is the JSON representation of a locality
+type LocalityJSON struct {
+	ID           int    `json:"id"`
+	LocalityName string `json:"locality_name"`
+	ProvinceName string `json:"province_name"`
+	CountryName  string `json:"country_name"`
+}
+
+// newLocalityJSON serializes a locality into its JSON representation
+func newLocalityJSON(locality internal.Locality) LocalityJSON {
+	return LocalityJSON{
+		ID:           locality.ID,
+		LocalityName: locality.LocalityName,
+		ProvinceName: locality.ProvinceName,
+		CountryName:  locality.CountryName,
+	}
+}
+
+// RequestBodyLocality is the request body to create a locality
+// - id is optional, e.g. the postal code of the locality
+type RequestBodyLocality struct {
+	ID           int    `json:"id"`
+	LocalityName string `json:"locality_name"`
+	ProvinceName string `json:"province_name"`
+	CountryName  string `json:"country_name"`
+}
+
+// LocalitySellersReportJSON is the JSON representation of the number of sellers of a locality
+type LocalitySellersReportJSON struct {
+	LocalityID   int    `json:"locality_id"`
+	LocalityName string `json:"locality_name"`
+	SellersCount int    `json:"sellers_count"`
+}
+
+// LocalityCarriersReportJSON is the JSON representation of the number of carriers of a locality
+type LocalityCarriersReportJSON struct {
+	LocalityID    int    `json:"locality_id"`
+	LocalityName  string `json:"locality_name"`
+	CarriersCount int    `json:"carriers_count"`
+}
+
+// GetByID returns a locality
+func (h *LocalityDefault) GetByID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		locality, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrLocalityRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "locality not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newLocalityJSON(locality),
+		})
+	}
+}
+
+// Create creates a new locality
+func (h *LocalityDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyLocality
+		err := request.JSON(r, &body)
+		if err != nil || body.ID < 0 {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		locality := internal.Locality{
+			ID:           body.ID,
+			LocalityName: body.LocalityName,
+			ProvinceName: body.ProvinceName,
+			CountryName:  body.CountryName,
+		}
+		err = h.sv.Save(r.Context(), &locality)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrLocalityInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrLocalityRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "locality already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newLocalityJSON(locality),
+		})
+	}
+}
+
+// ReportSellers returns the number of sellers of a locality, or of every locality
+func (h *LocalityDefault) ReportSellers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, ok := localityReportID(w, r)
+		if !ok {
+			return
+		}
+
+		// process
+		reports, err := h.sv.ReportSellers(id)
+		if err != nil {
+			localityReportError(w, err)
+			return
+		}
+
+		// response
+		data := make([]LocalitySellersReportJSON, len(reports))
+		for i, report := range reports {
+			data[i] = LocalitySellersReportJSON{
+				LocalityID:   report.LocalityID,
+				LocalityName: report.LocalityName,
+				SellersCount: report.Count,
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
+
+// ReportCarriers returns the number of carriers of a locality, or of every locality
+func (h *LocalityDefault) ReportCarriers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, ok := localityReportID(w, r)
+		if !ok {
+			return
+		}
+
+		// process
+		reports, err := h.sv.ReportCarriers(id)
+		if err != nil {
+			localityReportError(w, err)
+			return
+		}
+
+		// response
+		data := make([]LocalityCarriersReportJSON, len(reports))
+		for i, report := range reports {
+			data[i] = LocalityCarriersReportJSON{
+				LocalityID:    report.LocalityID,
+				LocalityName:  report.LocalityName,
+				CarriersCount: report.Count,
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
+
+// localityReportID reads the optional query parameter id of the locality reports, responding 400 if it is not valid
+func localityReportID(w http.ResponseWriter, r *http.Request) (id int, ok bool) {
+	if !r.URL.Query().Has("id") {
+		return 0, true
+	}
+
+	id, err := strconv.Atoi(r.URL.Query().Get("id"))
+	if err != nil || id <= 0 {
+		response.Error(w, http.StatusBadRequest, "invalid id")
+		return 0, false
+	}
+	return id, true
+}
+
+// localityReportError responds with the status code of an error of the locality reports
+func localityReportError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, internal.ErrLocalityRepositoryNotFound):
+		response.Error(w, http.StatusNotFound, "locality not found")
+	default:
+		response.Error(w, http.StatusInternalServerError, "internal server error")
+	}
+}
@@ -0,0 +1,216 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for LocalityDefault.GetByID
+func TestLocalityDefault_GetByID(t *testing.T) {
+	t.Run("case 1: success - returns the locality", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		rp.FuncFindByID = func(id int) (internal.Locality, error) {
+			return internal.Locality{ID: id, LocalityName: "Palermo", ProvinceName: "Buenos Aires", CountryName: "Argentina"}, nil
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/localities/1425", "1425", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1425,"locality_name":"Palermo","province_name":"Buenos Aires","country_name":"Argentina"}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 2: error - the locality is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		rp.FuncFindByID = func(id int) (internal.Locality, error) {
+			return internal.Locality{}, internal.ErrLocalityRepositoryNotFound
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/localities/1425", "1425", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"locality not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for LocalityDefault.Create
+func TestLocalityDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the locality with the given id", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		rp.FuncSave = func(ctx context.Context, locality *internal.Locality) error {
+			return nil
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		body := `{"id":1425,"locality_name":"Palermo","province_name":"Buenos Aires","country_name":"Argentina"}`
+		req := newRequest(http.MethodPost, "/api/v1/localities", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1425,"locality_name":"Palermo","province_name":"Buenos Aires","country_name":"Argentina"}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		body := `{"locality_name":"Palermo","province_name":"","country_name":"Argentina"}`
+		req := newRequest(http.MethodPost, "/api/v1/localities", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"locality: invalid fields: province_name is required"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the locality already exists", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		rp.FuncSave = func(ctx context.Context, locality *internal.Locality) error {
+			return internal.ErrLocalityRepositoryDuplicated
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		body := `{"id":1425,"locality_name":"Palermo","province_name":"Buenos Aires","country_name":"Argentina"}`
+		req := newRequest(http.MethodPost, "/api/v1/localities", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"locality already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for LocalityDefault.ReportSellers
+func TestLocalityDefault_ReportSellers(t *testing.T) {
+	t.Run("case 1: success - without id returns the sellers of every locality", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		var reportedID int
+		rp.FuncReportSellers = func(id int) ([]internal.LocalityCountReport, error) {
+			reportedID = id
+			return []internal.LocalityCountReport{
+				{LocalityID: 1425, LocalityName: "Palermo", Count: 2},
+				{LocalityID: 1900, LocalityName: "La Plata", Count: 0},
+			}, nil
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/localities/report-sellers", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportSellers()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"locality_id":1425,"locality_name":"Palermo","sellers_count":2},{"locality_id":1900,"locality_name":"La Plata","sellers_count":0}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, reportedID)
+	})
+
+	t.Run("case 2: error - the id is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/localities/report-sellers?id=0", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportSellers()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.ReportSellers)
+	})
+
+	t.Run("case 3: error - the locality is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		rp.FuncReportSellers = func(id int) ([]internal.LocalityCountReport, error) {
+			return nil, internal.ErrLocalityRepositoryNotFound
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/localities/report-sellers?id=1425", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportSellers()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"locality not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for LocalityDefault.ReportCarriers
+func TestLocalityDefault_ReportCarriers(t *testing.T) {
+	t.Run("case 1: success - returns the carriers of the locality", func(t *testing.T) {
+		// arrange
+		rp := repository.NewLocalityMock()
+		var reportedID int
+		rp.FuncReportCarriers = func(id int) ([]internal.LocalityCountReport, error) {
+			reportedID = id
+			return []internal.LocalityCountReport{{LocalityID: id, LocalityName: "Palermo", Count: 3}}, nil
+		}
+		hd := handler.NewLocalityDefault(service.NewLocalityDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/localities/report-carriers?id=1425", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportCarriers()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"locality_id":1425,"locality_name":"Palermo","carriers_count":3}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1425, reportedID)
+	})
+}
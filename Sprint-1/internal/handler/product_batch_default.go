@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// productBatchDateLayout is the layout of the dates of a product batch in its JSON representation
+const productBatchDateLayout = time.DateOnly
+
+// NewProductBatchDefault creates a new instance of the product batch handler
+func NewProductBatchDefault(sv internal.ProductBatchService) *ProductBatchDefault {
+	return &ProductBatchDefault{
+		sv: sv,
+	}
+}
+
+// ProductBatchDefault is the default implementation of the product batch handler
+type ProductBatchDefault struct {
+	// sv is the service used by the handler
+	sv internal.ProductBatchService
+}
+
+// ProductBatchJSON is the JSON representation of a product batch
+type ProductBatchJSON struct {
+	ID                 int     `json:"id"`
+	BatchNumber        int     `json:"batch_number"`
+	CurrentQuantity    int     `json:"current_quantity"`
+	InitialQuantity    int     `json:"initial_quantity"`
+	CurrentTemperature float64 `json:"current_temperature"`
+	MinimumTemperature float64 `json:"minimum_temperature"`
+	ManufacturingDate  string  `json:"manufacturing_date"`
+	ManufacturingHour  int     `json:"manufacturing_hour"`
+	DueDate            string  `json:"due_date"`
+	ProductID          int     `json:"product_id"`
+	SectionID          int     `json:"section_id"`
+}
+
+// newProductBatchJSON serializes a product batch into its JSON representation
+func newProductBatchJSON(productBatch internal.ProductBatch) ProductBatchJSON {
+	return ProductBatchJSON{
+		ID:                 productBatch.ID,
+		BatchNumber:        productBatch.BatchNumber,
+		CurrentQuantity:    productBatch.CurrentQuantity,
+		InitialQuantity:    productBatch.InitialQuantity,
+		CurrentTemperature: productBatch.CurrentTemperature,
+		MinimumTemperature: productBatch.MinimumTemperature,
+		ManufacturingDate:  productBatch.ManufacturingDate.Format(productBatchDateLayout),
+		ManufacturingHour:  productBatch.ManufacturingHour,
+		DueDate:            productBatch.DueDate.Format(productBatchDateLayout),
+		ProductID:          productBatch.ProductID,
+		SectionID:          productBatch.SectionID,
+	}
+}
+
+// RequestBodyProductBatch is the request body to create a product batch
+type RequestBodyProductBatch struct {
+	BatchNumber        int     `json:"batch_number"`
+	CurrentQuantity    int     `json:"current_quantity"`
+	InitialQuantity    int     `json:"initial_quantity"`
+	CurrentTemperature float64 `json:"current_temperature"`
+	MinimumTemperature float64 `json:"minimum_temperature"`
+	ManufacturingDate  string  `json:"manufacturing_date"`
+	ManufacturingHour  int     `json:"manufacturing_hour"`
+	DueDate            string  `json:"due_date"`
+	ProductID          int     `json:"product_id"`
+	SectionID          int     `json:"section_id"`
+}
+
+// SectionProductsReportJSON is the JSON representation of the quantity of products stored in a section
+type SectionProductsReportJSON struct {
+	SectionID     int `json:"section_id"`
+	SectionNumber int `json:"section_number"`
+	ProductsCount int `json:"products_count"`
+}
+
+// Create creates a new product batch
+func (h *ProductBatchDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyProductBatch
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		manufacturingDate, err := time.Parse(productBatchDateLayout, body.ManufacturingDate)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "invalid manufacturing_date")
+			return
+		}
+		dueDate, err := time.Parse(productBatchDateLayout, body.DueDate)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "invalid due_date")
+			return
+		}
+
+		// process
+		productBatch := internal.ProductBatch{
+			BatchNumber:        body.BatchNumber,
+			CurrentQuantity:    body.CurrentQuantity,
+			InitialQuantity:    body.InitialQuantity,
+			CurrentTemperature: body.CurrentTemperature,
+			MinimumTemperature: body.MinimumTemperature,
+			ManufacturingDate:  manufacturingDate,
+			ManufacturingHour:  body.ManufacturingHour,
+			DueDate:            dueDate,
+			ProductID:          body.ProductID,
+			SectionID:          body.SectionID,
+		}
+		err = h.sv.Save(r.Context(), &productBatch)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductBatchInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrProductBatchRepositorySectionNotFound):
+				response.Error(w, http.StatusConflict, "section not found")
+			case errors.Is(err, internal.ErrProductBatchRepositoryProductNotFound):
+				response.Error(w, http.StatusConflict, "product not found")
+			case errors.Is(err, internal.ErrProductBatchRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "product batch already exists")
+			case errors.Is(err, internal.ErrProductBatchRepositoryCapacityExceeded):
+				response.Error(w, http.StatusConflict, "section maximum capacity exceeded")
+			case errors.Is(err, internal.ErrProductBatchRepositoryProductType):
+				response.Error(w, http.StatusUnprocessableEntity, "product type does not match the section")
+			case errors.Is(err, internal.ErrProductBatchRepositoryTemperature):
+				response.Error(w, http.StatusUnprocessableEntity, "section temperature below the product batch minimum")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newProductBatchJSON(productBatch),
+		})
+	}
+}
+
+// GetExpiring returns the batches with products left that expire within the given number of days
+func (h *ProductBatchDefault) GetExpiring() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter days: the number of days from today, required
+		days, err := strconv.Atoi(r.URL.Query().Get("days"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid days")
+			return
+		}
+
+		// process
+		productBatches, err := h.sv.FindExpiring(days)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductBatchInvalidDays):
+				response.Error(w, http.StatusBadRequest, "invalid days")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		data := make([]ProductBatchJSON, len(productBatches))
+		for i, productBatch := range productBatches {
+			data[i] = newProductBatchJSON(productBatch)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
+
+// ReportProducts returns the quantity of products stored in a section
+func (h *ProductBatchDefault) ReportProducts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		report, err := h.sv.ReportProducts(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductBatchRepositorySectionNotFound):
+				response.Error(w, http.StatusNotFound, "section not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data": SectionProductsReportJSON{
+				SectionID:     report.SectionID,
+				SectionNumber: report.SectionNumber,
+				ProductsCount: report.ProductsCount,
+			},
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for ProductBatchDefault.Create
+func TestProductBatchDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the product batch", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		rp.FuncSave = func(ctx context.Context, productBatch *internal.ProductBatch) error {
+			(*productBatch).ID = 1
+			return nil
+		}
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		body := `{"batch_number":1,"current_quantity":60,"initial_quantity":60,"current_temperature":-10,"minimum_temperature":-15,"manufacturing_date":"2024-01-01","manufacturing_hour":8,"due_date":"2024-07-01","product_id":1,"section_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-batches", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"batch_number":1,"current_quantity":60,"initial_quantity":60,"current_temperature":-10,"minimum_temperature":-15,"manufacturing_date":"2024-01-01","manufacturing_hour":8,"due_date":"2024-07-01","product_id":1,"section_id":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the manufacturing_date is not a date", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		body := `{"batch_number":1,"current_quantity":60,"initial_quantity":60,"manufacturing_date":"01/01/2024","due_date":"2024-07-01","product_id":1,"section_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-batches", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"invalid manufacturing_date"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the current quantity exceeds the initial one", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		body := `{"batch_number":1,"current_quantity":61,"initial_quantity":60,"manufacturing_date":"2024-01-01","due_date":"2024-07-01","product_id":1,"section_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-batches", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"product batch: invalid fields: current_quantity must not exceed initial_quantity"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the section can not hold the batch", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		rp.FuncSave = func(ctx context.Context, productBatch *internal.ProductBatch) error {
+			return internal.ErrProductBatchRepositoryCapacityExceeded
+		}
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		body := `{"batch_number":1,"current_quantity":60,"initial_quantity":60,"manufacturing_date":"2024-01-01","due_date":"2024-07-01","product_id":1,"section_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-batches", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"section maximum capacity exceeded"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 5: error - the section does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		rp.FuncSave = func(ctx context.Context, productBatch *internal.ProductBatch) error {
+			return internal.ErrProductBatchRepositorySectionNotFound
+		}
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		body := `{"batch_number":1,"current_quantity":60,"initial_quantity":60,"manufacturing_date":"2024-01-01","due_date":"2024-07-01","product_id":1,"section_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/product-batches", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"section not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for ProductBatchDefault.ReportProducts
+func TestProductBatchDefault_ReportProducts(t *testing.T) {
+	t.Run("case 1: success - returns the products stored in the section", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		rp.FuncReportProducts = func(sectionID int) (internal.SectionProductsReport, error) {
+			return internal.SectionProductsReport{SectionID: sectionID, SectionNumber: 3, ProductsCount: 60}, nil
+		}
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sections/1/report-products", "1", "")
+		res := httptest.NewRecorder()
+		hd.ReportProducts()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"section_id":1,"section_number":3,"products_count":60}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 2: error - the section is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		rp.FuncReportProducts = func(sectionID int) (internal.SectionProductsReport, error) {
+			return internal.SectionProductsReport{}, internal.ErrProductBatchRepositorySectionNotFound
+		}
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sections/1/report-products", "1", "")
+		res := httptest.NewRecorder()
+		hd.ReportProducts()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"section not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for ProductBatchDefault.GetExpiring
+func TestProductBatchDefault_GetExpiring(t *testing.T) {
+	t.Run("case 1: error - days is negative", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductBatchMock()
+		hd := handler.NewProductBatchDefault(service.NewProductBatchDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/product-batches/expiring?days=-1", "", "")
+		res := httptest.NewRecorder()
+		hd.GetExpiring()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid days"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindExpiring)
+	})
+}
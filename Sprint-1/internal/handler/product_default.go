@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewProductDefault creates a new instance of the product handler
@@ -19,37 +25,466 @@ type ProductDefault struct {
 	sv internal.ProductService
 }
 
+// ProductJSON is the JSON representation of a product
+type ProductJSON struct {
+	ID             int     `json:"id"`
+	ProductCode    string  `json:"product_code"`
+	Description    string  `json:"description"`
+	Height         float64 `json:"height"`
+	Length         float64 `json:"length"`
+	Width          float64 `json:"width"`
+	Weight         float64 `json:"weight"`
+	ExpirationRate float64 `json:"expiration_rate"`
+	FreezingRate   float64 `json:"freezing_rate"`
+	RecomFreezTemp float64 `json:"recommended_freezing_temperature"`
+	ProductTypeID  int     `json:"product_type_id"`
+	SellerID       int     `json:"seller_id"`
+}
+
+// newProductJSON serializes a product into its JSON representation
+func newProductJSON(product internal.Product) ProductJSON {
+	return ProductJSON{
+		ID:             product.ID,
+		ProductCode:    product.ProductCode,
+		Description:    product.Description,
+		Height:         product.Height,
+		Length:         product.Length,
+		Width:          product.Width,
+		Weight:         product.Weight,
+		ExpirationRate: product.ExpirationRate,
+		FreezingRate:   product.FreezingRate,
+		RecomFreezTemp: product.RecomFreezTemp,
+		ProductTypeID:  product.ProductTypeID,
+		SellerID:       product.SellerID,
+	}
+}
+
+// RequestBodyProduct is the request body to create or update a product
+type RequestBodyProduct struct {
+	ProductCode    string  `json:"product_code"`
+	Description    string  `json:"description"`
+	Height         float64 `json:"height"`
+	Length         float64 `json:"length"`
+	Width          float64 `json:"width"`
+	Weight         float64 `json:"weight"`
+	ExpirationRate float64 `json:"expiration_rate"`
+	FreezingRate   float64 `json:"freezing_rate"`
+	RecomFreezTemp float64 `json:"recommended_freezing_temperature"`
+	ProductTypeID  int     `json:"product_type_id"`
+	SellerID       int     `json:"seller_id"`
+}
+
+// productFromRequestBody deserializes the request body into a product
+func productFromRequestBody(id int, body RequestBodyProduct) internal.Product {
+	return internal.Product{
+		ID:             id,
+		ProductCode:    body.ProductCode,
+		Description:    body.Description,
+		Height:         body.Height,
+		Length:         body.Length,
+		Width:          body.Width,
+		Weight:         body.Weight,
+		ExpirationRate: body.ExpirationRate,
+		FreezingRate:   body.FreezingRate,
+		RecomFreezTemp: body.RecomFreezTemp,
+		ProductTypeID:  body.ProductTypeID,
+		SellerID:       body.SellerID,
+	}
+}
+
+// newRequestBodyProduct serializes a product into its request body, so a partial update can be decoded on top of it
+func newRequestBodyProduct(product internal.Product) RequestBodyProduct {
+	return RequestBodyProduct{
+		ProductCode:    product.ProductCode,
+		Description:    product.Description,
+		Height:         product.Height,
+		Length:         product.Length,
+		Width:          product.Width,
+		Weight:         product.Weight,
+		ExpirationRate: product.ExpirationRate,
+		FreezingRate:   product.FreezingRate,
+		RecomFreezTemp: product.RecomFreezTemp,
+		ProductTypeID:  product.ProductTypeID,
+		SellerID:       product.SellerID,
+	}
+}
+
 // GetAll returns all products
 func (h *ProductDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter code: look up a single product by its product_code
+		if r.URL.Query().Has("code") {
+			h.GetByCode()(w, r)
+			return
+		}
+		// - query parameter include_deleted: also return the soft-deleted products
+		var includeDeleted bool
+		if r.URL.Query().Has("include_deleted") {
+			var err error
+			includeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid include_deleted")
+				return
+			}
+		}
+
+		// process
+		var products []internal.Product
+		var err error
+		if includeDeleted {
+			products, err = h.sv.FindAllWithDeleted()
+		} else {
+			products, err = h.sv.FindAll()
+		}
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
 
+		// response
+		data := make([]ProductJSON, len(products))
+		for i, product := range products {
+			data[i] = newProductJSON(product)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
 	}
 }
 
 // GetByID returns a product
 func (h *ProductDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		product, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, product.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newProductJSON(product),
+		})
+	}
+}
+
+// GetByCode returns a product by its product_code, given as the query parameter code
+func (h *ProductDefault) GetByCode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			response.Error(w, http.StatusBadRequest, "invalid code")
+			return
+		}
 
+		// process
+		product, err := h.sv.FindByCode(code)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, product.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newProductJSON(product),
+		})
 	}
 }
 
 // Create creates a new product
 func (h *ProductDefault) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyProduct
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		product := productFromRequestBody(0, body)
+		err = h.sv.Save(r.Context(), &product)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrProductCodeDuplicated):
+				response.Error(w, http.StatusConflict, "product product_code already exists")
+			case errors.Is(err, internal.ErrProductRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "product already exists")
+			case errors.Is(err, internal.ErrProductSellerNotFound):
+				response.Error(w, http.StatusConflict, "seller not found")
+			case errors.Is(err, internal.ErrProductProductTypeNotFound):
+				response.Error(w, http.StatusConflict, "product type not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, product.Version)
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newProductJSON(product),
+		})
+	}
+}
+
+// CreateBulk creates the products of a JSON array or a CSV upload in a single import
+func (h *ProductDefault) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []RequestBodyProduct
+		err := requestBulk(r, &body)
+		if err != nil {
+			responseBulkRequest(w, len(body), err)
+			return
+		}
+
+		// process
+		products := make([]*internal.Product, len(body))
+		for i, row := range body {
+			product := productFromRequestBody(0, row)
+			products[i] = &product
+		}
+		err = h.sv.SaveAll(r.Context(), products)
+
+		// response
+		ids := make([]int, len(products))
+		for i, product := range products {
+			ids[i] = (*product).ID
+		}
+		responseBulk(w, ids, err, productRowError)
+	}
+}
+
+// productRowError maps the error of a row of a bulk import of products to its status code and message
+func productRowError(err error) (int, string) {
+	switch {
+	case errors.Is(err, internal.ErrProductInvalid):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, internal.ErrProductCodeDuplicated):
+		return http.StatusConflict, "product product_code already exists"
+	case errors.Is(err, internal.ErrProductRepositoryDuplicated):
+		return http.StatusConflict, "product already exists"
+	case errors.Is(err, internal.ErrProductSellerNotFound):
+		return http.StatusConflict, "seller not found"
+	case errors.Is(err, internal.ErrProductProductTypeNotFound):
+		return http.StatusConflict, "product type not found"
+	default:
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
 // Update updates a product
 func (h *ProductDefault) Update() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body RequestBodyProduct
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		// - header If-Match: the version the update is based on, the current one if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		product := productFromRequestBody(id, body)
+		product.Version = version
+		err = h.sv.Update(r.Context(), &product)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			case errors.Is(err, internal.ErrProductInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrProductCodeDuplicated):
+				response.Error(w, http.StatusConflict, "product product_code already exists")
+			case errors.Is(err, internal.ErrProductRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "product already exists")
+			case errors.Is(err, internal.ErrProductSellerNotFound):
+				response.Error(w, http.StatusConflict, "seller not found")
+			case errors.Is(err, internal.ErrProductProductTypeNotFound):
+				response.Error(w, http.StatusConflict, "product type not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "product was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, product.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newProductJSON(product),
+		})
 	}
 }
 
-// Delete deletes a product
+// Patch partially updates a product, the fields absent from the body keep their current value
+func (h *ProductDefault) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - header If-Match: the version the update is based on, the one read by the patch if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		// - the body is decoded on top of the current product
+		product, err := h.sv.Patch(r.Context(), id, func(product *internal.Product) (err error) {
+			body := newRequestBodyProduct(*product)
+			err = request.JSON(r, &body)
+			if err != nil {
+				return
+			}
+			patched := productFromRequestBody((*product).ID, body)
+			patched.Version = (*product).Version
+			if version != 0 {
+				patched.Version = version
+			}
+			*product = patched
+			return
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, request.ErrRequestContentTypeNotJSON), errors.Is(err, request.ErrRequestJSONInvalid):
+				response.Error(w, http.StatusBadRequest, "invalid body")
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			case errors.Is(err, internal.ErrProductInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrProductCodeDuplicated):
+				response.Error(w, http.StatusConflict, "product product_code already exists")
+			case errors.Is(err, internal.ErrProductRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "product already exists")
+			case errors.Is(err, internal.ErrProductSellerNotFound):
+				response.Error(w, http.StatusConflict, "seller not found")
+			case errors.Is(err, internal.ErrProductProductTypeNotFound):
+				response.Error(w, http.StatusConflict, "product type not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "product was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, product.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newProductJSON(product),
+		})
+	}
+}
+
+// Delete soft-deletes a product
 func (h *ProductDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
 
+		// process
+		err = h.sv.Delete(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			case errors.Is(err, internal.ErrProductHasProductBatches):
+				response.Error(w, http.StatusConflict, "product has product batches")
+			case errors.Is(err, internal.ErrProductHasProductRecords):
+				response.Error(w, http.StatusConflict, "product has product records")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
 	}
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted product
+func (h *ProductDefault) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Restore(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			case errors.Is(err, internal.ErrProductCodeDuplicated):
+				response.Error(w, http.StatusConflict, "product product_code already exists")
+			case errors.Is(err, internal.ErrProductSellerNotFound):
+				response.Error(w, http.StatusConflict, "seller not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "product restored",
+		})
+	}
+}
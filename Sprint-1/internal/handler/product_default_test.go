@@ -0,0 +1,269 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for ProductDefault.GetAll
+func TestProductDefault_GetAll(t *testing.T) {
+	t.Run("case 1: success - returns the active products", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncFindAll = func() ([]internal.Product, error) {
+			return []internal.Product{}, nil
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.FindAll)
+		require.Equal(t, 0, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 2: error - include_deleted is not a boolean", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products?include_deleted=all", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid include_deleted"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for ProductDefault.GetByCode, through the query parameter code of ProductDefault.GetAll
+func TestProductDefault_GetByCode(t *testing.T) {
+	t.Run("case 1: success - returns the product with the code and its version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncFindByCode = func(code string) (internal.Product, error) {
+			return internal.Product{ID: 1, ProductCode: code, Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: 1, Version: 3}, nil
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products?code=P1", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"product_code":"P1","description":"Frozen peas","height":10,"length":20,"width":5,"weight":1.5,"expiration_rate":0.5,"freezing_rate":0.25,"recommended_freezing_temperature":-18,"product_type_id":1,"seller_id":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"3"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.FindByCode)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 2: error - the code is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products?code=", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid code"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByCode)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 3: error - no product has the code", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncFindByCode = func(code string) (internal.Product, error) {
+			return internal.Product{}, internal.ErrProductRepositoryNotFound
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products?code=P1", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"product not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for ProductDefault.Create
+func TestProductDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the product", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncFindByCode = func(code string) (internal.Product, error) {
+			return internal.Product{}, internal.ErrProductRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, product *internal.Product) error {
+			(*product).ID = 1
+			(*product).Version = 1
+			return nil
+		}
+		rpSeller := repository.NewSellerMock()
+		rpSeller.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id}, nil
+		}
+		rpProductType := repository.NewProductTypeMock()
+		rpProductType.FuncFindByID = func(id int) (internal.ProductType, error) {
+			return internal.ProductType{ID: id}, nil
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, rpSeller, rpProductType))
+
+		// act
+		body := `{"product_code":"P1","description":"Milk","height":1,"length":2,"width":3,"weight":4,"expiration_rate":0.5,"freezing_rate":0.2,"recommended_freezing_temperature":-18,"product_type_id":1,"seller_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/products", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"product_code":"P1","description":"Milk","height":1,"length":2,"width":3,"weight":4,"expiration_rate":0.5,"freezing_rate":0.2,"recommended_freezing_temperature":-18,"product_type_id":1,"seller_id":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		body := `{"product_code":"P1","description":"Milk","height":0,"length":2,"width":3,"weight":4,"product_type_id":1,"seller_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/products", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"product: invalid fields: height must be greater than 0"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the product_code is already in use", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncFindByCode = func(code string) (internal.Product, error) {
+			return internal.Product{ID: 2, ProductCode: code}, nil
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		body := `{"product_code":"P1","description":"Milk","height":1,"length":2,"width":3,"weight":4,"product_type_id":1,"seller_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/products", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"product product_code already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the seller does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncFindByCode = func(code string) (internal.Product, error) {
+			return internal.Product{}, internal.ErrProductRepositoryNotFound
+		}
+		rpSeller := repository.NewSellerMock()
+		rpSeller.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, rpSeller, repository.NewProductTypeMock()))
+
+		// act
+		body := `{"product_code":"P1","description":"Milk","height":1,"length":2,"width":3,"weight":4,"product_type_id":1,"seller_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/products", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"seller not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for ProductDefault.Delete
+func TestProductDefault_Delete(t *testing.T) {
+	t.Run("case 1: success - deletes the product", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/products/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNoContent
+		require.Equal(t, expectedCode, res.Code)
+		require.Empty(t, res.Body.String())
+	})
+
+	t.Run("case 2: error - the product still has product records", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrProductHasProductRecords
+		}
+		hd := handler.NewProductDefault(service.NewProductDefault(rp, repository.NewSellerMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/products/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"product has product records"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
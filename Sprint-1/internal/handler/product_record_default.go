@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+)
+
+// productRecordDateLayout is the layout of the last update date of a product record in its JSON representation
+const productRecordDateLayout = time.DateOnly
+
+// NewProductRecordDefault creates a new instance of the product record handler
+func NewProductRecordDefault(sv internal.ProductRecordService) *ProductRecordDefault {
+	return &ProductRecordDefault{
+		sv: sv,
+	}
+}
+
+// ProductRecordDefault is the default implementation of the product record handler
+type ProductRecordDefault struct {
+	// sv is the service used by the handler
+	sv internal.ProductRecordService
+}
+
+// ProductRecordJSON is the JSON representation of a product record
+type ProductRecordJSON struct {
+	ID             int     `json:"id"`
+	LastUpdateDate string  `json:"last_update_date"`
+	PurchasePrice  float64 `json:"purchase_price"`
+	SalePrice      float64 `json:"sale_price"`
+	ProductID      int     `json:"product_id"`
+}
+
+// RequestBodyProductRecord is the request body to create a product record
+type RequestBodyProductRecord struct {
+	LastUpdateDate string  `json:"last_update_date"`
+	PurchasePrice  float64 `json:"purchase_price"`
+	SalePrice      float64 `json:"sale_price"`
+	ProductID      int     `json:"product_id"`
+}
+
+// ProductRecordsReportJSON is the JSON representation of the number of records of a product
+type ProductRecordsReportJSON struct {
+	ProductID    int    `json:"product_id"`
+	Description  string `json:"description"`
+	RecordsCount int    `json:"records_count"`
+}
+
+// Create creates a new product record
+func (h *ProductRecordDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyProductRecord
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		lastUpdateDate, err := time.Parse(productRecordDateLayout, body.LastUpdateDate)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "invalid last_update_date")
+			return
+		}
+
+		// process
+		productRecord := internal.ProductRecord{
+			LastUpdateDate: lastUpdateDate,
+			PurchasePrice:  body.PurchasePrice,
+			SalePrice:      body.SalePrice,
+			ProductID:      body.ProductID,
+		}
+		err = h.sv.Save(r.Context(), &productRecord)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRecordInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrProductRecordRepositoryProductNotFound):
+				response.Error(w, http.StatusConflict, "product not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data": ProductRecordJSON{
+				ID:             productRecord.ID,
+				LastUpdateDate: productRecord.LastUpdateDate.Format(productRecordDateLayout),
+				PurchasePrice:  productRecord.PurchasePrice,
+				SalePrice:      productRecord.SalePrice,
+				ProductID:      productRecord.ProductID,
+			},
+		})
+	}
+}
+
+// ReportRecords returns the number of records of a product, or of every product
+func (h *ProductRecordDefault) ReportRecords() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter id: the product to report, every product if it is not given
+		var id int
+		if r.URL.Query().Has("id") {
+			var err error
+			id, err = strconv.Atoi(r.URL.Query().Get("id"))
+			if err != nil || id <= 0 {
+				response.Error(w, http.StatusBadRequest, "invalid id")
+				return
+			}
+		}
+
+		// process
+		reports, err := h.sv.ReportRecords(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrProductRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "product not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		data := make([]ProductRecordsReportJSON, len(reports))
+		for i, report := range reports {
+			data[i] = ProductRecordsReportJSON{
+				ProductID:    report.ProductID,
+				Description:  report.Description,
+				RecordsCount: report.RecordsCount,
+			}
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for ProductRecordDefault.Create
+func TestProductRecordDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the product record", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		rp.FuncSave = func(ctx context.Context, productRecord *internal.ProductRecord) error {
+			(*productRecord).ID = 1
+			return nil
+		}
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		body := `{"last_update_date":"2024-01-01","purchase_price":10.5,"sale_price":15,"product_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-records", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"last_update_date":"2024-01-01","purchase_price":10.5,"sale_price":15,"product_id":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the last_update_date is not a date", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		body := `{"last_update_date":"today","purchase_price":10.5,"sale_price":15,"product_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-records", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"invalid last_update_date"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - a price is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		body := `{"last_update_date":"2024-01-01","purchase_price":10.5,"sale_price":0,"product_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/product-records", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"product record: invalid fields: sale_price must be greater than 0"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the product does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		rp.FuncSave = func(ctx context.Context, productRecord *internal.ProductRecord) error {
+			return internal.ErrProductRecordRepositoryProductNotFound
+		}
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		body := `{"last_update_date":"2024-01-01","purchase_price":10.5,"sale_price":15,"product_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/product-records", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"product not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for ProductRecordDefault.ReportRecords
+func TestProductRecordDefault_ReportRecords(t *testing.T) {
+	t.Run("case 1: success - without id returns the records of every product", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		var reportedID int
+		rp.FuncReportRecords = func(productID int) ([]internal.ProductRecordsReport, error) {
+			reportedID = productID
+			return []internal.ProductRecordsReport{
+				{ProductID: 1, Description: "Frozen peas", RecordsCount: 2},
+				{ProductID: 2, Description: "Ice cream", RecordsCount: 0},
+			}, nil
+		}
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products/report-records", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportRecords()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"product_id":1,"description":"Frozen peas","records_count":2},{"product_id":2,"description":"Ice cream","records_count":0}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, reportedID)
+	})
+
+	t.Run("case 2: error - the id is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products/report-records?id=abc", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportRecords()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.ReportRecords)
+	})
+
+	t.Run("case 3: error - the product is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewProductRecordMock()
+		rp.FuncReportRecords = func(productID int) ([]internal.ProductRecordsReport, error) {
+			return nil, internal.ErrProductRepositoryNotFound
+		}
+		hd := handler.NewProductRecordDefault(service.NewProductRecordDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/products/report-records?id=99", "", "")
+		res := httptest.NewRecorder()
+		hd.ReportRecords()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"product not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
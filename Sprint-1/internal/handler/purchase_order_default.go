@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// purchaseOrderDateLayout is the layout of the order date of a purchase order in its JSON representation
+const purchaseOrderDateLayout = time.DateOnly
+
+// NewPurchaseOrderDefault creates a new instance of the purchase order handler
+func NewPurchaseOrderDefault(sv internal.PurchaseOrderService) *PurchaseOrderDefault {
+	return &PurchaseOrderDefault{
+		sv: sv,
+	}
+}
+
+// PurchaseOrderDefault is the default implementation of the purchase order handler
+type PurchaseOrderDefault struct {
+	// sv is the service used by the handler
+	sv internal.PurchaseOrderService
+}
+
+// PurchaseOrderJSON is the JSON representation of a purchase order
+type PurchaseOrderJSON struct {
+	ID              int    `json:"id"`
+	OrderNumber     string `json:"order_number"`
+	OrderDate       string `json:"order_date"`
+	TrackingCode    string `json:"tracking_code"`
+	BuyerID         int    `json:"buyer_id"`
+	ProductRecordID int    `json:"product_record_id"`
+	CarrierID       int    `json:"carrier_id"`
+	Status          string `json:"status"`
+}
+
+// newPurchaseOrderJSON serializes a purchase order into its JSON representation
+func newPurchaseOrderJSON(purchaseOrder internal.PurchaseOrder) PurchaseOrderJSON {
+	return PurchaseOrderJSON{
+		ID:              purchaseOrder.ID,
+		OrderNumber:     purchaseOrder.OrderNumber,
+		OrderDate:       purchaseOrder.OrderDate.Format(purchaseOrderDateLayout),
+		TrackingCode:    purchaseOrder.TrackingCode,
+		BuyerID:         purchaseOrder.BuyerID,
+		ProductRecordID: purchaseOrder.ProductRecordID,
+		CarrierID:       purchaseOrder.CarrierID,
+		Status:          purchaseOrder.Status,
+	}
+}
+
+// RequestBodyPurchaseOrder is the request body to create a purchase order
+type RequestBodyPurchaseOrder struct {
+	OrderNumber     string `json:"order_number"`
+	OrderDate       string `json:"order_date"`
+	TrackingCode    string `json:"tracking_code"`
+	BuyerID         int    `json:"buyer_id"`
+	ProductRecordID int    `json:"product_record_id"`
+	CarrierID       int    `json:"carrier_id"`
+	Status          string `json:"status"`
+}
+
+// BuyerPurchaseOrdersReportJSON is the JSON representation of the number of purchase orders placed by a buyer
+type BuyerPurchaseOrdersReportJSON struct {
+	ID                  int    `json:"id"`
+	CardNumberID        int    `json:"card_number_id"`
+	FirstName           string `json:"first_name"`
+	LastName            string `json:"last_name"`
+	PurchaseOrdersCount int    `json:"purchase_orders_count"`
+}
+
+// Create creates a new purchase order
+func (h *PurchaseOrderDefault) Create() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyPurchaseOrder
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		orderDate, err := time.Parse(purchaseOrderDateLayout, body.OrderDate)
+		if err != nil {
+			response.Error(w, http.StatusUnprocessableEntity, "invalid order_date")
+			return
+		}
+
+		// process
+		purchaseOrder := internal.PurchaseOrder{
+			OrderNumber:     body.OrderNumber,
+			OrderDate:       orderDate,
+			TrackingCode:    body.TrackingCode,
+			BuyerID:         body.BuyerID,
+			ProductRecordID: body.ProductRecordID,
+			CarrierID:       body.CarrierID,
+			Status:          body.Status,
+		}
+		err = h.sv.Save(r.Context(), &purchaseOrder)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrPurchaseOrderInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrPurchaseOrderRepositoryBuyerNotFound):
+				response.Error(w, http.StatusConflict, "buyer not found")
+			case errors.Is(err, internal.ErrPurchaseOrderRepositoryCarrierNotFound):
+				response.Error(w, http.StatusConflict, "carrier not found")
+			case errors.Is(err, internal.ErrPurchaseOrderRepositoryProductRecordNotFound):
+				response.Error(w, http.StatusConflict, "product record not found")
+			case errors.Is(err, internal.ErrPurchaseOrderRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "purchase order already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newPurchaseOrderJSON(purchaseOrder),
+		})
+	}
+}
+
+// ReportByBuyer returns the number of purchase orders placed by a buyer
+func (h *PurchaseOrderDefault) ReportByBuyer() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		report, err := h.sv.ReportByBuyer(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrPurchaseOrderRepositoryBuyerNotFound):
+				response.Error(w, http.StatusNotFound, "buyer not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data": BuyerPurchaseOrdersReportJSON{
+				ID:                  report.ID,
+				CardNumberID:        report.CardNumberID,
+				FirstName:           report.FirstName,
+				LastName:            report.LastName,
+				PurchaseOrdersCount: report.PurchaseOrdersCount,
+			},
+		})
+	}
+}
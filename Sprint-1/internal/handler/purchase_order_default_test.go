@@ -0,0 +1,156 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for PurchaseOrderDefault.Create
+func TestPurchaseOrderDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the purchase order as pending", func(t *testing.T) {
+		// arrange
+		rp := repository.NewPurchaseOrderMock()
+		rp.FuncSave = func(ctx context.Context, purchaseOrder *internal.PurchaseOrder) error {
+			(*purchaseOrder).ID = 1
+			return nil
+		}
+		hd := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(rp))
+
+		// act
+		body := `{"order_number":"PO-1","order_date":"2024-01-01","tracking_code":"TRK-1","buyer_id":1,"product_record_id":1,"carrier_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/purchase-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"order_number":"PO-1","order_date":"2024-01-01","tracking_code":"TRK-1","buyer_id":1,"product_record_id":1,"carrier_id":1,"status":"pending"}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the order_date is not a date", func(t *testing.T) {
+		// arrange
+		rp := repository.NewPurchaseOrderMock()
+		hd := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(rp))
+
+		// act
+		body := `{"order_number":"PO-1","order_date":"yesterday","tracking_code":"TRK-1","buyer_id":1,"product_record_id":1,"carrier_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/purchase-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"invalid order_date"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the status is not known", func(t *testing.T) {
+		// arrange
+		rp := repository.NewPurchaseOrderMock()
+		hd := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(rp))
+
+		// act
+		body := `{"order_number":"PO-1","order_date":"2024-01-01","tracking_code":"TRK-1","buyer_id":1,"product_record_id":1,"carrier_id":1,"status":"lost"}`
+		req := newRequest(http.MethodPost, "/api/v1/purchase-orders", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"purchase order: invalid fields: status must be one of pending, shipped, delivered or cancelled"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - a reference does not exist", func(t *testing.T) {
+		// arrange
+		type testCase struct {
+			err             error
+			expectedMessage string
+		}
+		testCases := []testCase{
+			{err: internal.ErrPurchaseOrderRepositoryBuyerNotFound, expectedMessage: "buyer not found"},
+			{err: internal.ErrPurchaseOrderRepositoryCarrierNotFound, expectedMessage: "carrier not found"},
+			{err: internal.ErrPurchaseOrderRepositoryProductRecordNotFound, expectedMessage: "product record not found"},
+			{err: internal.ErrPurchaseOrderRepositoryDuplicated, expectedMessage: "purchase order already exists"},
+		}
+
+		for _, tc := range testCases {
+			rp := repository.NewPurchaseOrderMock()
+			rp.FuncSave = func(ctx context.Context, purchaseOrder *internal.PurchaseOrder) error {
+				return tc.err
+			}
+			hd := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(rp))
+
+			// act
+			body := `{"order_number":"PO-1","order_date":"2024-01-01","tracking_code":"TRK-1","buyer_id":1,"product_record_id":1,"carrier_id":1}`
+			req := newRequest(http.MethodPost, "/api/v1/purchase-orders", "", body)
+			res := httptest.NewRecorder()
+			hd.Create()(res, req)
+
+			// assert
+			expectedCode := http.StatusConflict
+			expectedBody := `{"status":"Conflict","message":"` + tc.expectedMessage + `"}`
+			require.Equal(t, expectedCode, res.Code)
+			require.JSONEq(t, expectedBody, res.Body.String())
+		}
+	})
+}
+
+// Tests for PurchaseOrderDefault.ReportByBuyer
+func TestPurchaseOrderDefault_ReportByBuyer(t *testing.T) {
+	t.Run("case 1: success - returns the number of purchase orders of the buyer", func(t *testing.T) {
+		// arrange
+		rp := repository.NewPurchaseOrderMock()
+		rp.FuncReportByBuyer = func(buyerID int) (internal.BuyerPurchaseOrdersReport, error) {
+			return internal.BuyerPurchaseOrdersReport{Buyer: internal.Buyer{ID: buyerID, CardNumberID: 100, FirstName: "Ana", LastName: "Diaz"}, PurchaseOrdersCount: 2}, nil
+		}
+		hd := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers/1/report-purchase-orders", "1", "")
+		res := httptest.NewRecorder()
+		hd.ReportByBuyer()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"card_number_id":100,"first_name":"Ana","last_name":"Diaz","purchase_orders_count":2}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 2: error - the buyer is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewPurchaseOrderMock()
+		rp.FuncReportByBuyer = func(buyerID int) (internal.BuyerPurchaseOrdersReport, error) {
+			return internal.BuyerPurchaseOrdersReport{}, internal.ErrPurchaseOrderRepositoryBuyerNotFound
+		}
+		hd := handler.NewPurchaseOrderDefault(service.NewPurchaseOrderDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/buyers/1/report-purchase-orders", "1", "")
+		res := httptest.NewRecorder()
+		hd.ReportByBuyer()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"buyer not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewSectionDefault creates a new instance of the section handler
@@ -19,37 +25,404 @@ type SectionDefault struct {
 	sv internal.SectionService
 }
 
+// SectionJSON is the JSON representation of a section
+type SectionJSON struct {
+	ID                 int     `json:"id"`
+	SectionNumber      int     `json:"section_number"`
+	CurrentTemperature float64 `json:"current_temperature"`
+	MinimumTemperature float64 `json:"minimum_temperature"`
+	CurrentCapacity    int     `json:"current_capacity"`
+	MinimumCapacity    int     `json:"minimum_capacity"`
+	MaximumCapacity    int     `json:"maximum_capacity"`
+	WarehouseID        int     `json:"warehouse_id"`
+	ProductTypeID      int     `json:"product_type_id"`
+}
+
+// newSectionJSON serializes a section into its JSON representation
+func newSectionJSON(section internal.Section) SectionJSON {
+	return SectionJSON{
+		ID:                 section.ID,
+		SectionNumber:      section.SectionNumber,
+		CurrentTemperature: section.CurrentTemperature,
+		MinimumTemperature: section.MinimumTemperature,
+		CurrentCapacity:    section.CurrentCapacity,
+		MinimumCapacity:    section.MinimumCapacity,
+		MaximumCapacity:    section.MaximumCapacity,
+		WarehouseID:        section.WarehouseID,
+		ProductTypeID:      section.ProductTypeID,
+	}
+}
+
+// RequestBodySection is the request body to create or update a section
+// - current_capacity is only taken on creation, an update keeps the capacity used by the product batches
+type RequestBodySection struct {
+	SectionNumber      int     `json:"section_number"`
+	CurrentTemperature float64 `json:"current_temperature"`
+	MinimumTemperature float64 `json:"minimum_temperature"`
+	CurrentCapacity    int     `json:"current_capacity"`
+	MinimumCapacity    int     `json:"minimum_capacity"`
+	MaximumCapacity    int     `json:"maximum_capacity"`
+	WarehouseID        int     `json:"warehouse_id"`
+	ProductTypeID      int     `json:"product_type_id"`
+}
+
+// sectionFromRequestBody deserializes the request body into a section
+func sectionFromRequestBody(id int, body RequestBodySection) internal.Section {
+	return internal.Section{
+		ID:                 id,
+		SectionNumber:      body.SectionNumber,
+		CurrentTemperature: body.CurrentTemperature,
+		MinimumTemperature: body.MinimumTemperature,
+		CurrentCapacity:    body.CurrentCapacity,
+		MinimumCapacity:    body.MinimumCapacity,
+		MaximumCapacity:    body.MaximumCapacity,
+		WarehouseID:        body.WarehouseID,
+		ProductTypeID:      body.ProductTypeID,
+	}
+}
+
+// newRequestBodySection serializes a section into its request body, so a partial update can be decoded on top of it
+func newRequestBodySection(section internal.Section) RequestBodySection {
+	return RequestBodySection{
+		SectionNumber:      section.SectionNumber,
+		CurrentTemperature: section.CurrentTemperature,
+		MinimumTemperature: section.MinimumTemperature,
+		CurrentCapacity:    section.CurrentCapacity,
+		MinimumCapacity:    section.MinimumCapacity,
+		MaximumCapacity:    section.MaximumCapacity,
+		WarehouseID:        section.WarehouseID,
+		ProductTypeID:      section.ProductTypeID,
+	}
+}
+
 // GetAll returns all sections
 func (h *SectionDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter include_deleted: also return the soft-deleted sections
+		var includeDeleted bool
+		if r.URL.Query().Has("include_deleted") {
+			var err error
+			includeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid include_deleted")
+				return
+			}
+		}
 
+		// process
+		var sections []internal.Section
+		var err error
+		if includeDeleted {
+			sections, err = h.sv.FindAllWithDeleted()
+		} else {
+			sections, err = h.sv.FindAll()
+		}
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		data := make([]SectionJSON, len(sections))
+		for i, section := range sections {
+			data[i] = newSectionJSON(section)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
 	}
 }
 
 // GetByID returns a section
 func (h *SectionDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		section, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSectionRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "section not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, section.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSectionJSON(section),
+		})
 	}
 }
 
 // Create creates a new section
 func (h *SectionDefault) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodySection
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
 
+		// process
+		section := sectionFromRequestBody(0, body)
+		err = h.sv.Save(r.Context(), &section)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSectionInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrSectionRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "section already exists")
+			case errors.Is(err, internal.ErrSectionWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			case errors.Is(err, internal.ErrSectionProductTypeNotFound):
+				response.Error(w, http.StatusConflict, "product type not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, section.Version)
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newSectionJSON(section),
+		})
+	}
+}
+
+// CreateBulk creates the sections of a JSON array or a CSV upload in a single import
+func (h *SectionDefault) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []RequestBodySection
+		err := requestBulk(r, &body)
+		if err != nil {
+			responseBulkRequest(w, len(body), err)
+			return
+		}
+
+		// process
+		sections := make([]*internal.Section, len(body))
+		for i, row := range body {
+			section := sectionFromRequestBody(0, row)
+			sections[i] = &section
+		}
+		err = h.sv.SaveAll(r.Context(), sections)
+
+		// response
+		ids := make([]int, len(sections))
+		for i, section := range sections {
+			ids[i] = (*section).ID
+		}
+		responseBulk(w, ids, err, sectionRowError)
+	}
+}
+
+// sectionRowError maps the error of a row of a bulk import of sections to its status code and message
+func sectionRowError(err error) (int, string) {
+	switch {
+	case errors.Is(err, internal.ErrSectionInvalid):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, internal.ErrSectionRepositoryDuplicated):
+		return http.StatusConflict, "section already exists"
+	case errors.Is(err, internal.ErrSectionWarehouseNotFound):
+		return http.StatusConflict, "warehouse not found"
+	case errors.Is(err, internal.ErrSectionProductTypeNotFound):
+		return http.StatusConflict, "product type not found"
+	default:
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
 // Update updates a section
 func (h *SectionDefault) Update() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body RequestBodySection
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		// - header If-Match: the version the update is based on, the current one if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		section := sectionFromRequestBody(id, body)
+		section.Version = version
+		err = h.sv.Update(r.Context(), &section)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSectionRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "section not found")
+			case errors.Is(err, internal.ErrSectionInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrSectionRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "section already exists")
+			case errors.Is(err, internal.ErrSectionWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			case errors.Is(err, internal.ErrSectionProductTypeNotFound):
+				response.Error(w, http.StatusConflict, "product type not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "section was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, section.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSectionJSON(section),
+		})
+	}
+}
+
+// Patch partially updates a section, the fields absent from the body keep their current value
+func (h *SectionDefault) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - header If-Match: the version the update is based on, the one read by the patch if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		// - the body is decoded on top of the current section
+		section, err := h.sv.Patch(r.Context(), id, func(section *internal.Section) (err error) {
+			body := newRequestBodySection(*section)
+			err = request.JSON(r, &body)
+			if err != nil {
+				return
+			}
+			patched := sectionFromRequestBody((*section).ID, body)
+			patched.Version = (*section).Version
+			if version != 0 {
+				patched.Version = version
+			}
+			*section = patched
+			return
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, request.ErrRequestContentTypeNotJSON), errors.Is(err, request.ErrRequestJSONInvalid):
+				response.Error(w, http.StatusBadRequest, "invalid body")
+			case errors.Is(err, internal.ErrSectionRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "section not found")
+			case errors.Is(err, internal.ErrSectionInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrSectionRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "section already exists")
+			case errors.Is(err, internal.ErrSectionWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			case errors.Is(err, internal.ErrSectionProductTypeNotFound):
+				response.Error(w, http.StatusConflict, "product type not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "section was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, section.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSectionJSON(section),
+		})
 	}
 }
 
-// Delete deletes a section
+// Delete soft-deletes a section
 func (h *SectionDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Delete(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSectionRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "section not found")
+			case errors.Is(err, internal.ErrSectionHasProductBatches):
+				response.Error(w, http.StatusConflict, "section has product batches")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
+	}
+}
+
+// Restore restores a soft-deleted section
+func (h *SectionDefault) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
 
+		// process
+		err = h.sv.Restore(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSectionRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "section not found")
+			case errors.Is(err, internal.ErrSectionWarehouseNotFound):
+				response.Error(w, http.StatusConflict, "warehouse not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "section restored",
+		})
 	}
-}
\ No newline at end of file
+}
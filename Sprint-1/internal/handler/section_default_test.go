@@ -0,0 +1,246 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for SectionDefault.GetAll
+func TestSectionDefault_GetAll(t *testing.T) {
+	t.Run("case 1: success - include_deleted also returns the soft-deleted sections", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncFindAllWithDeleted = func() ([]internal.Section, error) {
+			return []internal.Section{
+				{ID: 1, SectionNumber: 1, CurrentTemperature: 2, MinimumTemperature: -5, CurrentCapacity: 5, MinimumCapacity: 1, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 1},
+			}, nil
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sections?include_deleted=true", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"id":1,"section_number":1,"current_temperature":2,"minimum_temperature":-5,"current_capacity":5,"minimum_capacity":1,"maximum_capacity":10,"warehouse_id":1,"product_type_id":1}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindAll)
+		require.Equal(t, 1, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 2: error - include_deleted is not a boolean", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sections?include_deleted=yes", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid include_deleted"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SectionDefault.Create
+func TestSectionDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the section", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncSave = func(ctx context.Context, section *internal.Section) error {
+			(*section).ID = 1
+			(*section).Version = 1
+			return nil
+		}
+		rpWarehouse := repository.NewWarehouseMock()
+		rpWarehouse.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: id}, nil
+		}
+		rpProductType := repository.NewProductTypeMock()
+		rpProductType.FuncFindByID = func(id int) (internal.ProductType, error) {
+			return internal.ProductType{ID: id}, nil
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, rpWarehouse, rpProductType))
+
+		// act
+		body := `{"section_number":1,"current_temperature":2,"minimum_temperature":-5,"current_capacity":5,"minimum_capacity":1,"maximum_capacity":10,"warehouse_id":1,"product_type_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/sections", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"section_number":1,"current_temperature":2,"minimum_temperature":-5,"current_capacity":5,"minimum_capacity":1,"maximum_capacity":10,"warehouse_id":1,"product_type_id":1}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the current capacity exceeds the maximum one", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		body := `{"section_number":1,"current_capacity":11,"minimum_capacity":1,"maximum_capacity":10,"warehouse_id":1,"product_type_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/sections", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"section: invalid fields: current_capacity must not exceed maximum_capacity"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the warehouse does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rpWarehouse := repository.NewWarehouseMock()
+		rpWarehouse.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, rpWarehouse, repository.NewProductTypeMock()))
+
+		// act
+		body := `{"section_number":1,"current_capacity":5,"minimum_capacity":1,"maximum_capacity":10,"warehouse_id":99,"product_type_id":1}`
+		req := newRequest(http.MethodPost, "/api/v1/sections", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the product type does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rpWarehouse := repository.NewWarehouseMock()
+		rpWarehouse.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: id}, nil
+		}
+		rpProductType := repository.NewProductTypeMock()
+		rpProductType.FuncFindByID = func(id int) (internal.ProductType, error) {
+			return internal.ProductType{}, internal.ErrProductTypeRepositoryNotFound
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, rpWarehouse, rpProductType))
+
+		// act
+		body := `{"section_number":1,"current_capacity":5,"minimum_capacity":1,"maximum_capacity":10,"warehouse_id":1,"product_type_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/sections", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"product type not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for SectionDefault.Delete
+func TestSectionDefault_Delete(t *testing.T) {
+	t.Run("case 1: success - deletes the section", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/sections/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNoContent
+		require.Equal(t, expectedCode, res.Code)
+		require.Empty(t, res.Body.String())
+	})
+
+	t.Run("case 2: error - the id is not a number", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/sections/abc", "abc", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Delete)
+	})
+
+	t.Run("case 3: error - the section still has product batches", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrSectionHasProductBatches
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/sections/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"section has product batches"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SectionDefault.Restore
+func TestSectionDefault_Restore(t *testing.T) {
+	t.Run("case 1: error - the warehouse of the section is soft-deleted", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncRestore = func(ctx context.Context, id int) error {
+			return internal.ErrSectionWarehouseNotFound
+		}
+		hd := handler.NewSectionDefault(service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock()))
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/sections/1/restore", "1", "")
+		res := httptest.NewRecorder()
+		hd.Restore()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
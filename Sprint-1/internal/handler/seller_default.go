@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewSellerDefault creates a new instance of the seller handler
@@ -19,37 +25,424 @@ type SellerDefault struct {
 	sv internal.SellerService
 }
 
+// SellerJSON is the JSON representation of a seller
+type SellerJSON struct {
+	ID          int    `json:"id"`
+	CID         int    `json:"cid"`
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Telephone   string `json:"telephone"`
+	LocalityID  int    `json:"locality_id"`
+}
+
+// newSellerJSON serializes a seller into its JSON representation
+func newSellerJSON(seller internal.Seller) SellerJSON {
+	return SellerJSON{
+		ID:          seller.ID,
+		CID:         seller.CID,
+		CompanyName: seller.CompanyName,
+		Address:     seller.Address,
+		Telephone:   seller.Telephone,
+		LocalityID:  seller.LocalityID,
+	}
+}
+
+// RequestBodySeller is the request body to create or update a seller
+type RequestBodySeller struct {
+	CID         int    `json:"cid"`
+	CompanyName string `json:"company_name"`
+	Address     string `json:"address"`
+	Telephone   string `json:"telephone"`
+	LocalityID  int    `json:"locality_id"`
+}
+
+// sellerFromRequestBody deserializes the request body into a seller
+func sellerFromRequestBody(id int, body RequestBodySeller) internal.Seller {
+	return internal.Seller{
+		ID:          id,
+		CID:         body.CID,
+		CompanyName: body.CompanyName,
+		Address:     body.Address,
+		Telephone:   body.Telephone,
+		LocalityID:  body.LocalityID,
+	}
+}
+
+// newRequestBodySeller serializes a seller into its request body, so a partial update can be decoded on top of it
+func newRequestBodySeller(seller internal.Seller) RequestBodySeller {
+	return RequestBodySeller{
+		CID:         seller.CID,
+		CompanyName: seller.CompanyName,
+		Address:     seller.Address,
+		Telephone:   seller.Telephone,
+		LocalityID:  seller.LocalityID,
+	}
+}
+
 // GetAll returns all sellers
 func (h *SellerDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter cid: look up a single seller by its cid
+		if r.URL.Query().Has("cid") {
+			h.GetByCID()(w, r)
+			return
+		}
+		// - query parameter include_deleted: also return the soft-deleted sellers
+		var includeDeleted bool
+		if r.URL.Query().Has("include_deleted") {
+			var err error
+			includeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid include_deleted")
+				return
+			}
+		}
+
+		// process
+		var sellers []internal.Seller
+		var err error
+		if includeDeleted {
+			sellers, err = h.sv.FindAllWithDeleted()
+		} else {
+			sellers, err = h.sv.FindAll()
+		}
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
 
+		// response
+		data := make([]SellerJSON, len(sellers))
+		for i, seller := range sellers {
+			data[i] = newSellerJSON(seller)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
 	}
 }
 
 // GetByID returns a seller
 func (h *SellerDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		seller, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSellerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "seller not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, seller.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSellerJSON(seller),
+		})
+	}
+}
+
+// GetByCID returns a seller by its cid, given as the query parameter cid
+func (h *SellerDefault) GetByCID() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		cid, err := strconv.Atoi(r.URL.Query().Get("cid"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid cid")
+			return
+		}
 
+		// process
+		seller, err := h.sv.FindByCID(cid)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSellerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "seller not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, seller.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSellerJSON(seller),
+		})
 	}
 }
 
 // Create creates a new seller
 func (h *SellerDefault) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodySeller
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		seller := sellerFromRequestBody(0, body)
+		err = h.sv.Save(r.Context(), &seller)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSellerInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrSellerCIDDuplicated):
+				response.Error(w, http.StatusConflict, "seller cid already exists")
+			case errors.Is(err, internal.ErrSellerRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "seller already exists")
+			case errors.Is(err, internal.ErrSellerLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, seller.Version)
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newSellerJSON(seller),
+		})
+	}
+}
+
+// CreateBulk creates the sellers of a JSON array or a CSV upload in a single import
+func (h *SellerDefault) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []RequestBodySeller
+		err := requestBulk(r, &body)
+		if err != nil {
+			responseBulkRequest(w, len(body), err)
+			return
+		}
+
+		// process
+		sellers := make([]*internal.Seller, len(body))
+		for i, row := range body {
+			seller := sellerFromRequestBody(0, row)
+			sellers[i] = &seller
+		}
+		err = h.sv.SaveAll(r.Context(), sellers)
+
+		// response
+		ids := make([]int, len(sellers))
+		for i, seller := range sellers {
+			ids[i] = (*seller).ID
+		}
+		responseBulk(w, ids, err, sellerRowError)
+	}
+}
+
+// sellerRowError maps the error of a row of a bulk import of sellers to its status code and message
+func sellerRowError(err error) (int, string) {
+	switch {
+	case errors.Is(err, internal.ErrSellerInvalid):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, internal.ErrSellerCIDDuplicated):
+		return http.StatusConflict, "seller cid already exists"
+	case errors.Is(err, internal.ErrSellerRepositoryDuplicated):
+		return http.StatusConflict, "seller already exists"
+	case errors.Is(err, internal.ErrSellerLocalityNotFound):
+		return http.StatusConflict, "locality not found"
+	default:
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
 // Update updates a seller
 func (h *SellerDefault) Update() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body RequestBodySeller
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		// - header If-Match: the version the update is based on, the current one if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		seller := sellerFromRequestBody(id, body)
+		seller.Version = version
+		err = h.sv.Update(r.Context(), &seller)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSellerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "seller not found")
+			case errors.Is(err, internal.ErrSellerInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrSellerCIDDuplicated):
+				response.Error(w, http.StatusConflict, "seller cid already exists")
+			case errors.Is(err, internal.ErrSellerRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "seller already exists")
+			case errors.Is(err, internal.ErrSellerLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "seller was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, seller.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSellerJSON(seller),
+		})
 	}
 }
 
-// Delete deletes a seller
+// Patch partially updates a seller, the fields absent from the body keep their current value
+func (h *SellerDefault) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - header If-Match: the version the update is based on, the one read by the patch if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		// - the body is decoded on top of the current seller
+		seller, err := h.sv.Patch(r.Context(), id, func(seller *internal.Seller) (err error) {
+			body := newRequestBodySeller(*seller)
+			err = request.JSON(r, &body)
+			if err != nil {
+				return
+			}
+			patched := sellerFromRequestBody((*seller).ID, body)
+			patched.Version = (*seller).Version
+			if version != 0 {
+				patched.Version = version
+			}
+			*seller = patched
+			return
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, request.ErrRequestContentTypeNotJSON), errors.Is(err, request.ErrRequestJSONInvalid):
+				response.Error(w, http.StatusBadRequest, "invalid body")
+			case errors.Is(err, internal.ErrSellerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "seller not found")
+			case errors.Is(err, internal.ErrSellerInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrSellerCIDDuplicated):
+				response.Error(w, http.StatusConflict, "seller cid already exists")
+			case errors.Is(err, internal.ErrSellerRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "seller already exists")
+			case errors.Is(err, internal.ErrSellerLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "seller was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, seller.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newSellerJSON(seller),
+		})
+	}
+}
+
+// Delete soft-deletes a seller
 func (h *SellerDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
 
+		// process
+		err = h.sv.Delete(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSellerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "seller not found")
+			case errors.Is(err, internal.ErrSellerHasProducts):
+				response.Error(w, http.StatusConflict, "seller has products")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
 	}
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted seller
+func (h *SellerDefault) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Restore(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrSellerRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "seller not found")
+			case errors.Is(err, internal.ErrSellerCIDDuplicated):
+				response.Error(w, http.StatusConflict, "seller cid already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "seller restored",
+		})
+	}
+}
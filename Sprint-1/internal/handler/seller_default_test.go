@@ -0,0 +1,556 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for SellerDefault.GetAll
+func TestSellerDefault_GetAll(t *testing.T) {
+	t.Run("case 1: success - returns the sellers", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindAll = func() ([]internal.Seller, error) {
+			return []internal.Seller{
+				{ID: 1, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", LocalityID: 2},
+			}, nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"id":1,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":2}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.FindAll)
+		require.Equal(t, 0, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 2: success - include_deleted also returns the soft-deleted sellers", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindAllWithDeleted = func() ([]internal.Seller, error) {
+			return []internal.Seller{}, nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers?include_deleted=true", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindAll)
+		require.Equal(t, 1, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 3: error - include_deleted is not a boolean", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers?include_deleted=maybe", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid include_deleted"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindAll)
+		require.Equal(t, 0, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 4: error - the repository fails", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindAll = func() ([]internal.Seller, error) {
+			return nil, errors.New("connection refused")
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusInternalServerError
+		expectedBody := `{"status":"Internal Server Error","message":"internal server error"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SellerDefault.GetByID
+func TestSellerDefault_GetByID(t *testing.T) {
+	t.Run("case 1: success - returns the seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 3}, nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":0}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"3"`, res.Header().Get("ETag"))
+	})
+
+	t.Run("case 2: error - the id is not a number", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers/abc", "abc", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByID)
+	})
+
+	t.Run("case 3: error - the seller is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"seller not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SellerDefault.GetByCID, through the query parameter cid of SellerDefault.GetAll
+func TestSellerDefault_GetByCID(t *testing.T) {
+	t.Run("case 1: success - returns the seller with the cid and its version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 1, CID: cid, CompanyName: "Acme", Address: "Street 1", Telephone: "111", LocalityID: 2, Version: 3}, nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers?cid=10", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":2}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"3"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.FindByCID)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 2: error - the cid is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers?cid=abc", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid cid"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByCID)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 3: error - no seller has the cid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers?cid=10", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"seller not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SellerDefault.Create
+func TestSellerDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, seller *internal.Seller) error {
+			(*seller).ID = 1
+			(*seller).Version = 1
+			return nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":2}`
+		req := newRequest(http.MethodPost, "/api/v1/sellers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":2}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"1"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the body is not JSON", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/sellers", "", `{"cid":`)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid body"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":0,"company_name":"Acme","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPost, "/api/v1/sellers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"seller: invalid fields: cid must be greater than 0"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the cid is already in use", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 2, CID: cid}, nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPost, "/api/v1/sellers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"seller cid already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 5: error - the locality does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, seller *internal.Seller) error {
+			return internal.ErrSellerLocalityNotFound
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/sellers", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"locality not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+}
+
+// Tests for SellerDefault.Update
+func TestSellerDefault_Update(t *testing.T) {
+	t.Run("case 1: success - updates the seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 1}, nil
+		}
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 1, CID: cid}, nil
+		}
+		rp.FuncUpdate = func(ctx context.Context, seller *internal.Seller) error {
+			(*seller).Version++
+			return nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme Inc","address":"Street 2","telephone":"222"}`
+		req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+		res := httptest.NewRecorder()
+		hd.Update()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"cid":10,"company_name":"Acme Inc","address":"Street 2","telephone":"222","locality_id":0}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"2"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.Update)
+	})
+
+	t.Run("case 2: error - the seller is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+		res := httptest.NewRecorder()
+		hd.Update()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"seller not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 3: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 1}, nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+		res := httptest.NewRecorder()
+		hd.Update()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"seller: invalid fields: company_name is required"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 4: error - the locality does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 1}, nil
+		}
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 1, CID: cid}, nil
+		}
+		rp.FuncUpdate = func(ctx context.Context, seller *internal.Seller) error {
+			return internal.ErrSellerLocalityNotFound
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":99}`
+		req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+		res := httptest.NewRecorder()
+		hd.Update()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"locality not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SellerDefault.Delete
+func TestSellerDefault_Delete(t *testing.T) {
+	t.Run("case 1: success - deletes the seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/sellers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNoContent
+		require.Equal(t, expectedCode, res.Code)
+		require.Empty(t, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Delete)
+	})
+
+	t.Run("case 2: error - the seller is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrSellerRepositoryNotFound
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/sellers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"seller not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 3: error - the seller still has products", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrSellerHasProducts
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/sellers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"seller has products"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SellerDefault.Restore
+func TestSellerDefault_Restore(t *testing.T) {
+	t.Run("case 1: success - restores the seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncRestore = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/sellers/1/restore", "1", "")
+		res := httptest.NewRecorder()
+		hd.Restore()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"seller restored"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 2: error - another seller has its cid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncRestore = func(ctx context.Context, id int) error {
+			return internal.ErrSellerCIDDuplicated
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/sellers/1/restore", "1", "")
+		res := httptest.NewRecorder()
+		hd.Restore()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"seller cid already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
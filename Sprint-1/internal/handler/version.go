@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// errVersionInvalid is returned when the If-Match header of a request is not a valid version
+var errVersionInvalid = errors.New("handler: invalid If-Match version")
+
+// requestVersion returns the version of the entity sent in the If-Match header, zero if the header is not given
+// - the version is the ETag of the entity, with or without quotes
+func requestVersion(r *http.Request) (version int, err error) {
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		return
+	}
+
+	version, err = strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil || version <= 0 {
+		version, err = 0, errVersionInvalid
+	}
+	return
+}
+
+// responseVersion sets the version of the entity as the ETag of the response
+func responseVersion(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", strconv.Quote(strconv.Itoa(version)))
+}
@@ -0,0 +1,291 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newSellerVersionMock creates a seller repository mock with the seller 1 at version 4
+// - updated is set with the seller given to Update, which moves it to its next version
+func newSellerVersionMock(updated *internal.Seller) *repository.SellerMock {
+	rp := repository.NewSellerMock()
+	rp.FuncFindByID = func(id int) (internal.Seller, error) {
+		return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", LocalityID: 2, Version: 4}, nil
+	}
+	rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+		return internal.Seller{ID: 1, CID: cid}, nil
+	}
+	rp.FuncUpdate = func(ctx context.Context, seller *internal.Seller) error {
+		*updated = *seller
+		(*seller).Version++
+		return nil
+	}
+	return rp
+}
+
+// Tests for requestVersion and responseVersion, through SellerDefault.GetByID and SellerDefault.Update
+func TestSellerDefault_Version(t *testing.T) {
+	t.Run("case 1: success - GET sets the version as the ETag", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/sellers/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedETag := `"4"`
+		require.Equal(t, expectedCode, res.Code)
+		require.Equal(t, expectedETag, res.Header().Get("ETag"))
+	})
+
+	t.Run("case 2: success - PUT with the current version, quoted or not, sets the next one as the ETag", func(t *testing.T) {
+		for _, ifMatch := range []string{`"4"`, `4`} {
+			// arrange
+			var updated internal.Seller
+			rp := newSellerVersionMock(&updated)
+			hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+			// act
+			body := `{"cid":10,"company_name":"Acme Inc","address":"Street 1","telephone":"111","locality_id":2}`
+			req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+			req.Header.Set("If-Match", ifMatch)
+			res := httptest.NewRecorder()
+			hd.Update()(res, req)
+
+			// assert
+			expectedCode := http.StatusOK
+			expectedETag := `"5"`
+			require.Equal(t, expectedCode, res.Code)
+			require.Equal(t, expectedETag, res.Header().Get("ETag"))
+			require.Equal(t, 4, updated.Version)
+		}
+	})
+
+	t.Run("case 3: error - PUT with a malformed If-Match", func(t *testing.T) {
+		for _, ifMatch := range []string{`"abc"`, `"0"`, `-1`, `W/"4"`} {
+			// arrange
+			var updated internal.Seller
+			rp := newSellerVersionMock(&updated)
+			hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+			// act
+			body := `{"cid":10,"company_name":"Acme Inc","address":"Street 1","telephone":"111"}`
+			req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+			req.Header.Set("If-Match", ifMatch)
+			res := httptest.NewRecorder()
+			hd.Update()(res, req)
+
+			// assert
+			expectedCode := http.StatusBadRequest
+			expectedBody := `{"status":"Bad Request","message":"invalid If-Match"}`
+			require.Equal(t, expectedCode, res.Code, ifMatch)
+			require.JSONEq(t, expectedBody, res.Body.String())
+			require.Equal(t, 0, rp.Spy.FindByID)
+			require.Equal(t, 0, rp.Spy.Update)
+		}
+	})
+
+	t.Run("case 4: error - PUT with a stale version", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme Inc","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+		req.Header.Set("If-Match", `"3"`)
+		res := httptest.NewRecorder()
+		hd.Update()(res, req)
+
+		// assert
+		expectedCode := http.StatusPreconditionFailed
+		expectedBody := `{"status":"Precondition Failed","message":"seller was modified by another request"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Empty(t, res.Header().Get("ETag"))
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 5: error - PUT that loses a concurrent write in the repository", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		rp.FuncUpdate = func(ctx context.Context, seller *internal.Seller) error {
+			return internal.ErrVersionConflict
+		}
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"cid":10,"company_name":"Acme Inc","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPut, "/api/v1/sellers/1", "1", body)
+		req.Header.Set("If-Match", `"4"`)
+		res := httptest.NewRecorder()
+		hd.Update()(res, req)
+
+		// assert
+		expectedCode := http.StatusPreconditionFailed
+		expectedBody := `{"status":"Precondition Failed","message":"seller was modified by another request"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for SellerDefault.Patch
+func TestSellerDefault_Patch(t *testing.T) {
+	t.Run("case 1: success - a field set to its zero value is updated, the others keep their current value", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"locality_id":0}`
+		req := newRequest(http.MethodPatch, "/api/v1/sellers/1", "1", body)
+		res := httptest.NewRecorder()
+		hd.Patch()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"cid":10,"company_name":"Acme","address":"Street 1","telephone":"111","locality_id":0}}`
+		expectedETag := `"5"`
+		expectedUpdated := internal.Seller{ID: 1, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 4}
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, expectedETag, res.Header().Get("ETag"))
+		require.Equal(t, expectedUpdated, updated)
+	})
+
+	t.Run("case 2: error - a string field set to empty is updated, so it is validated", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"address":""}`
+		req := newRequest(http.MethodPatch, "/api/v1/sellers/1", "1", body)
+		res := httptest.NewRecorder()
+		hd.Patch()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"seller: invalid fields: address is required"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 3: error - a stale If-Match", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"telephone":"222"}`
+		req := newRequest(http.MethodPatch, "/api/v1/sellers/1", "1", body)
+		req.Header.Set("If-Match", `"3"`)
+		res := httptest.NewRecorder()
+		hd.Patch()(res, req)
+
+		// assert
+		expectedCode := http.StatusPreconditionFailed
+		expectedBody := `{"status":"Precondition Failed","message":"seller was modified by another request"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 4: error - a malformed If-Match", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		body := `{"telephone":"222"}`
+		req := newRequest(http.MethodPatch, "/api/v1/sellers/1", "1", body)
+		req.Header.Set("If-Match", `"four"`)
+		res := httptest.NewRecorder()
+		hd.Patch()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid If-Match"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByID)
+	})
+
+	t.Run("case 5: error - the body is not JSON", func(t *testing.T) {
+		// arrange
+		var updated internal.Seller
+		rp := newSellerVersionMock(&updated)
+		hd := handler.NewSellerDefault(service.NewSellerDefault(rp))
+
+		// act
+		req := newRequest(http.MethodPatch, "/api/v1/sellers/1", "1", `{"telephone":`)
+		res := httptest.NewRecorder()
+		hd.Patch()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid body"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+}
+
+// Tests for WarehouseDefault.Patch
+func TestWarehouseDefault_Patch(t *testing.T) {
+	t.Run("case 1: success - numeric fields set to zero are updated, the others keep their current value", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: id, WarehouseCode: "W1", Address: "Street 1", Telephone: "111", MinimumCapacity: 10, MinimumTemperature: -5, LocalityID: 2, Version: 4}, nil
+		}
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: 1, WarehouseCode: code}, nil
+		}
+		var updated internal.Warehouse
+		rp.FuncUpdate = func(ctx context.Context, warehouse *internal.Warehouse) error {
+			updated = *warehouse
+			(*warehouse).Version++
+			return nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := `{"minimum_capacity":0,"minimum_temperature":0}`
+		req := newRequest(http.MethodPatch, "/api/v1/warehouses/1", "1", body)
+		req.Header.Set("If-Match", `"4"`)
+		res := httptest.NewRecorder()
+		hd.Patch()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"warehouse_code":"W1","address":"Street 1","telephone":"111","minimum_capacity":0,"minimum_temperature":0,"locality_id":2}}`
+		expectedETag := `"5"`
+		expectedUpdated := internal.Warehouse{ID: 1, WarehouseCode: "W1", Address: "Street 1", Telephone: "111", LocalityID: 2, Version: 4}
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, expectedETag, res.Header().Get("ETag"))
+		require.Equal(t, expectedUpdated, updated)
+	})
+}
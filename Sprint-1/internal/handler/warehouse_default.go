@@ -1,9 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/platform/web/request"
+	"github.com/usuario/repositorio/platform/web/response"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // NewWarehouseDefault creates a new instance of the warehouse handler
@@ -19,37 +25,482 @@ type WarehouseDefault struct {
 	sv internal.WarehouseService
 }
 
+// WarehouseJSON is the JSON representation of a warehouse
+type WarehouseJSON struct {
+	ID                 int     `json:"id"`
+	WarehouseCode      string  `json:"warehouse_code"`
+	Address            string  `json:"address"`
+	Telephone          string  `json:"telephone"`
+	MinimumCapacity    int     `json:"minimum_capacity"`
+	MinimumTemperature float64 `json:"minimum_temperature"`
+	LocalityID         int     `json:"locality_id"`
+}
+
+// newWarehouseJSON serializes a warehouse into its JSON representation
+func newWarehouseJSON(warehouse internal.Warehouse) WarehouseJSON {
+	return WarehouseJSON{
+		ID:                 warehouse.ID,
+		WarehouseCode:      warehouse.WarehouseCode,
+		Address:            warehouse.Address,
+		Telephone:          warehouse.Telephone,
+		MinimumCapacity:    warehouse.MinimumCapacity,
+		MinimumTemperature: warehouse.MinimumTemperature,
+		LocalityID:         warehouse.LocalityID,
+	}
+}
+
+// RequestBodyWarehouse is the request body to create or update a warehouse
+type RequestBodyWarehouse struct {
+	WarehouseCode      string  `json:"warehouse_code"`
+	Address            string  `json:"address"`
+	Telephone          string  `json:"telephone"`
+	MinimumCapacity    int     `json:"minimum_capacity"`
+	MinimumTemperature float64 `json:"minimum_temperature"`
+	LocalityID         int     `json:"locality_id"`
+}
+
+// warehouseFromRequestBody deserializes the request body into a warehouse
+func warehouseFromRequestBody(id int, body RequestBodyWarehouse) internal.Warehouse {
+	return internal.Warehouse{
+		ID:                 id,
+		WarehouseCode:      body.WarehouseCode,
+		Address:            body.Address,
+		Telephone:          body.Telephone,
+		MinimumCapacity:    body.MinimumCapacity,
+		MinimumTemperature: body.MinimumTemperature,
+		LocalityID:         body.LocalityID,
+	}
+}
+
+// newRequestBodyWarehouse serializes a warehouse into its request body, so a partial update can be decoded on top of it
+func newRequestBodyWarehouse(warehouse internal.Warehouse) RequestBodyWarehouse {
+	return RequestBodyWarehouse{
+		WarehouseCode:      warehouse.WarehouseCode,
+		Address:            warehouse.Address,
+		Telephone:          warehouse.Telephone,
+		MinimumCapacity:    warehouse.MinimumCapacity,
+		MinimumTemperature: warehouse.MinimumTemperature,
+		LocalityID:         warehouse.LocalityID,
+	}
+}
+
+// WarehouseReportJSON is the JSON representation of the aggregates of a warehouse
+type WarehouseReportJSON struct {
+	WarehouseID                     int    `json:"warehouse_id"`
+	WarehouseCode                   string `json:"warehouse_code"`
+	SectionsCount                   int    `json:"sections_count"`
+	TotalCapacity                   int    `json:"total_capacity"`
+	UsedCapacity                    int    `json:"used_capacity"`
+	SectionsBelowMinimumTemperature int    `json:"sections_below_minimum_temperature"`
+	EmployeesCount                  int    `json:"employees_count"`
+	Compliant                       bool   `json:"compliant"`
+}
+
 // GetAll returns all warehouses
 func (h *WarehouseDefault) GetAll() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		// - query parameter code: look up a single warehouse by its warehouse_code
+		if r.URL.Query().Has("code") {
+			h.GetByCode()(w, r)
+			return
+		}
+		// - query parameter include_deleted: also return the soft-deleted warehouses
+		var includeDeleted bool
+		if r.URL.Query().Has("include_deleted") {
+			var err error
+			includeDeleted, err = strconv.ParseBool(r.URL.Query().Get("include_deleted"))
+			if err != nil {
+				response.Error(w, http.StatusBadRequest, "invalid include_deleted")
+				return
+			}
+		}
 
+		// process
+		var warehouses []internal.Warehouse
+		var err error
+		if includeDeleted {
+			warehouses, err = h.sv.FindAllWithDeleted()
+		} else {
+			warehouses, err = h.sv.FindAll()
+		}
+		if err != nil {
+			response.Error(w, http.StatusInternalServerError, "internal server error")
+			return
+		}
+
+		// response
+		data := make([]WarehouseJSON, len(warehouses))
+		for i, warehouse := range warehouses {
+			data[i] = newWarehouseJSON(warehouse)
+		}
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    data,
+		})
 	}
 }
 
 // GetByID returns a warehouse
 func (h *WarehouseDefault) GetByID() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		warehouse, err := h.sv.FindByID(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, warehouse.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newWarehouseJSON(warehouse),
+		})
+	}
+}
+
+// GetByCode returns a warehouse by its warehouse_code, given as the query parameter code
+func (h *WarehouseDefault) GetByCode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			response.Error(w, http.StatusBadRequest, "invalid code")
+			return
+		}
 
+		// process
+		warehouse, err := h.sv.FindByCode(code)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, warehouse.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newWarehouseJSON(warehouse),
+		})
 	}
 }
 
 // Create creates a new warehouse
 func (h *WarehouseDefault) Create() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body RequestBodyWarehouse
+		err := request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+
+		// process
+		warehouse := warehouseFromRequestBody(0, body)
+		err = h.sv.Save(r.Context(), &warehouse)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrWarehouseCodeDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse warehouse_code already exists")
+			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse already exists")
+			case errors.Is(err, internal.ErrWarehouseLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, warehouse.Version)
+		response.JSON(w, http.StatusCreated, map[string]any{
+			"message": "success",
+			"data":    newWarehouseJSON(warehouse),
+		})
+	}
+}
+
+// CreateBulk creates the warehouses of a JSON array or a CSV upload in a single import
+func (h *WarehouseDefault) CreateBulk() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		var body []RequestBodyWarehouse
+		err := requestBulk(r, &body)
+		if err != nil {
+			responseBulkRequest(w, len(body), err)
+			return
+		}
+
+		// process
+		warehouses := make([]*internal.Warehouse, len(body))
+		for i, row := range body {
+			warehouse := warehouseFromRequestBody(0, row)
+			warehouses[i] = &warehouse
+		}
+		err = h.sv.SaveAll(r.Context(), warehouses)
+
+		// response
+		ids := make([]int, len(warehouses))
+		for i, warehouse := range warehouses {
+			ids[i] = (*warehouse).ID
+		}
+		responseBulk(w, ids, err, warehouseRowError)
+	}
+}
+
+// warehouseRowError maps the error of a row of a bulk import of warehouses to its status code and message
+func warehouseRowError(err error) (int, string) {
+	switch {
+	case errors.Is(err, internal.ErrWarehouseInvalid):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, internal.ErrWarehouseCodeDuplicated):
+		return http.StatusConflict, "warehouse warehouse_code already exists"
+	case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated):
+		return http.StatusConflict, "warehouse already exists"
+	case errors.Is(err, internal.ErrWarehouseLocalityNotFound):
+		return http.StatusConflict, "locality not found"
+	default:
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
 // Update updates a warehouse
 func (h *WarehouseDefault) Update() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		var body RequestBodyWarehouse
+		err = request.JSON(r, &body)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid body")
+			return
+		}
+		// - header If-Match: the version the update is based on, the current one if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		warehouse := warehouseFromRequestBody(id, body)
+		warehouse.Version = version
+		err = h.sv.Update(r.Context(), &warehouse)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			case errors.Is(err, internal.ErrWarehouseInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrWarehouseCodeDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse warehouse_code already exists")
+			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse already exists")
+			case errors.Is(err, internal.ErrWarehouseLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "warehouse was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		responseVersion(w, warehouse.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newWarehouseJSON(warehouse),
+		})
+	}
+}
+
+// Patch partially updates a warehouse, the fields absent from the body keep their current value
+func (h *WarehouseDefault) Patch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+		// - header If-Match: the version the update is based on, the one read by the patch if it is not given
+		version, err := requestVersion(r)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid If-Match")
+			return
+		}
+
+		// process
+		// - the body is decoded on top of the current warehouse
+		warehouse, err := h.sv.Patch(r.Context(), id, func(warehouse *internal.Warehouse) (err error) {
+			body := newRequestBodyWarehouse(*warehouse)
+			err = request.JSON(r, &body)
+			if err != nil {
+				return
+			}
+			patched := warehouseFromRequestBody((*warehouse).ID, body)
+			patched.Version = (*warehouse).Version
+			if version != 0 {
+				patched.Version = version
+			}
+			*warehouse = patched
+			return
+		})
+		if err != nil {
+			switch {
+			case errors.Is(err, request.ErrRequestContentTypeNotJSON), errors.Is(err, request.ErrRequestJSONInvalid):
+				response.Error(w, http.StatusBadRequest, "invalid body")
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			case errors.Is(err, internal.ErrWarehouseInvalid):
+				response.Error(w, http.StatusUnprocessableEntity, err.Error())
+			case errors.Is(err, internal.ErrWarehouseCodeDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse warehouse_code already exists")
+			case errors.Is(err, internal.ErrWarehouseRepositoryDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse already exists")
+			case errors.Is(err, internal.ErrWarehouseLocalityNotFound):
+				response.Error(w, http.StatusConflict, "locality not found")
+			case errors.Is(err, internal.ErrVersionConflict):
+				response.Error(w, http.StatusPreconditionFailed, "warehouse was modified by another request")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		responseVersion(w, warehouse.Version)
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data":    newWarehouseJSON(warehouse),
+		})
 	}
 }
 
-// Delete deletes a warehouse
+// Delete soft-deletes a warehouse
 func (h *WarehouseDefault) Delete() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Delete(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			case errors.Is(err, internal.ErrWarehouseHasSections):
+				response.Error(w, http.StatusConflict, "warehouse has sections")
+			case errors.Is(err, internal.ErrWarehouseHasEmployees):
+				response.Error(w, http.StatusConflict, "warehouse has employees")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
 
+		// response
+		response.JSON(w, http.StatusNoContent, nil)
 	}
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted warehouse
+func (h *WarehouseDefault) Restore() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		err = h.sv.Restore(r.Context(), id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			case errors.Is(err, internal.ErrWarehouseCodeDuplicated):
+				response.Error(w, http.StatusConflict, "warehouse warehouse_code already exists")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "warehouse restored",
+		})
+	}
+}
+
+// Report returns the aggregates of a warehouse across its sections and employees
+func (h *WarehouseDefault) Report() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// request
+		id, err := strconv.Atoi(chi.URLParam(r, "id"))
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "invalid id")
+			return
+		}
+
+		// process
+		report, err := h.sv.Report(id)
+		if err != nil {
+			switch {
+			case errors.Is(err, internal.ErrWarehouseRepositoryNotFound):
+				response.Error(w, http.StatusNotFound, "warehouse not found")
+			default:
+				response.Error(w, http.StatusInternalServerError, "internal server error")
+			}
+			return
+		}
+
+		// response
+		response.JSON(w, http.StatusOK, map[string]any{
+			"message": "success",
+			"data": WarehouseReportJSON{
+				WarehouseID:                     report.WarehouseID,
+				WarehouseCode:                   report.WarehouseCode,
+				SectionsCount:                   report.SectionsCount,
+				TotalCapacity:                   report.TotalCapacity,
+				UsedCapacity:                    report.UsedCapacity,
+				SectionsBelowMinimumTemperature: report.SectionsBelowMinimumTemperature,
+				EmployeesCount:                  report.EmployeesCount,
+				Compliant:                       report.Compliant,
+			},
+		})
+	}
+}
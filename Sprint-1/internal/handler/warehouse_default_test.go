@@ -0,0 +1,445 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/handler"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newWarehouseHandler creates the warehouse handler over the given repository mock
+func newWarehouseHandler(rp *repository.WarehouseMock) *handler.WarehouseDefault {
+	return handler.NewWarehouseDefault(service.NewWarehouseDefault(rp, repository.NewSectionMock(), repository.NewEmployeeMock()))
+}
+
+// Tests for WarehouseDefault.GetAll
+func TestWarehouseDefault_GetAll(t *testing.T) {
+	t.Run("case 1: success - include_deleted=false returns the active warehouses", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindAll = func() ([]internal.Warehouse, error) {
+			return []internal.Warehouse{
+				{ID: 1, WarehouseCode: "W1", Address: "Street 1", Telephone: "111", MinimumCapacity: 10, MinimumTemperature: -5},
+			}, nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses?include_deleted=false", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[{"id":1,"warehouse_code":"W1","address":"Street 1","telephone":"111","minimum_capacity":10,"minimum_temperature":-5,"locality_id":0}]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.FindAll)
+		require.Equal(t, 0, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 2: success - include_deleted=1 also returns the soft-deleted warehouses", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindAllWithDeleted = func() ([]internal.Warehouse, error) {
+			return []internal.Warehouse{}, nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses?include_deleted=1", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":[]}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.FindAllWithDeleted)
+	})
+
+	t.Run("case 3: error - include_deleted is not a boolean", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses?include_deleted=", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid include_deleted"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for WarehouseDefault.GetByID
+func TestWarehouseDefault_GetByID(t *testing.T) {
+	t.Run("case 1: error - the warehouse is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.GetByID()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for WarehouseDefault.GetByCode, through the query parameter code of WarehouseDefault.GetAll
+func TestWarehouseDefault_GetByCode(t *testing.T) {
+	t.Run("case 1: success - returns the warehouse with the code and its version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: 1, WarehouseCode: code, Address: "Street 1", Telephone: "111", MinimumCapacity: 10, MinimumTemperature: -5, Version: 3}, nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses?code=W1", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"id":1,"warehouse_code":"W1","address":"Street 1","telephone":"111","minimum_capacity":10,"minimum_temperature":-5,"locality_id":0}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, `"3"`, res.Header().Get("ETag"))
+		require.Equal(t, 1, rp.Spy.FindByCode)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 2: error - the code is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses?code=", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid code"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByCode)
+		require.Equal(t, 0, rp.Spy.FindAll)
+	})
+
+	t.Run("case 3: error - no warehouse has the code", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses?code=W1", "", "")
+		res := httptest.NewRecorder()
+		hd.GetAll()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for WarehouseDefault.Create
+func TestWarehouseDefault_Create(t *testing.T) {
+	t.Run("case 1: success - creates the warehouse", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, warehouse *internal.Warehouse) error {
+			(*warehouse).ID = 1
+			(*warehouse).Version = 1
+			return nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := `{"warehouse_code":"W1","address":"Street 1","telephone":"111","minimum_capacity":10,"minimum_temperature":-5}`
+		req := newRequest(http.MethodPost, "/api/v1/warehouses", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusCreated
+		expectedBody := `{"message":"success","data":{"id":1,"warehouse_code":"W1","address":"Street 1","telephone":"111","minimum_capacity":10,"minimum_temperature":-5,"locality_id":0}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - a field is not valid", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := `{"warehouse_code":"W1","address":"Street 1","telephone":"111","minimum_capacity":-1}`
+		req := newRequest(http.MethodPost, "/api/v1/warehouses", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusUnprocessableEntity
+		expectedBody := `{"status":"Unprocessable Entity","message":"warehouse: invalid fields: minimum_capacity must not be negative"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the warehouse_code is already in use", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: 2, WarehouseCode: code}, nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := `{"warehouse_code":"W1","address":"Street 1","telephone":"111"}`
+		req := newRequest(http.MethodPost, "/api/v1/warehouses", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse warehouse_code already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 4: error - the locality does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByCode = func(code string) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, warehouse *internal.Warehouse) error {
+			return internal.ErrWarehouseLocalityNotFound
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		body := `{"warehouse_code":"W1","address":"Street 1","telephone":"111","locality_id":99}`
+		req := newRequest(http.MethodPost, "/api/v1/warehouses", "", body)
+		res := httptest.NewRecorder()
+		hd.Create()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"locality not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+}
+
+// Tests for WarehouseDefault.Delete
+func TestWarehouseDefault_Delete(t *testing.T) {
+	t.Run("case 1: success - deletes the warehouse", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return nil
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/warehouses/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusNoContent
+		require.Equal(t, expectedCode, res.Code)
+		require.Empty(t, res.Body.String())
+	})
+
+	t.Run("case 2: error - the warehouse still has sections", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrWarehouseHasSections
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/warehouses/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse has sections"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 3: error - the warehouse still has employees", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncDelete = func(ctx context.Context, id int) error {
+			return internal.ErrWarehouseHasEmployees
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodDelete, "/api/v1/warehouses/1", "1", "")
+		res := httptest.NewRecorder()
+		hd.Delete()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse has employees"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for WarehouseDefault.Restore
+func TestWarehouseDefault_Restore(t *testing.T) {
+	t.Run("case 1: error - the warehouse is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncRestore = func(ctx context.Context, id int) error {
+			return internal.ErrWarehouseRepositoryNotFound
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/warehouses/1/restore", "1", "")
+		res := httptest.NewRecorder()
+		hd.Restore()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 2: error - another warehouse has its warehouse_code", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncRestore = func(ctx context.Context, id int) error {
+			return internal.ErrWarehouseCodeDuplicated
+		}
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodPost, "/api/v1/warehouses/1/restore", "1", "")
+		res := httptest.NewRecorder()
+		hd.Restore()(res, req)
+
+		// assert
+		expectedCode := http.StatusConflict
+		expectedBody := `{"status":"Conflict","message":"warehouse warehouse_code already exists"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+}
+
+// Tests for WarehouseDefault.Report
+func TestWarehouseDefault_Report(t *testing.T) {
+	t.Run("case 1: success - returns the aggregates of the warehouse", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: id, WarehouseCode: "W1", MinimumCapacity: 100, MinimumTemperature: -5}, nil
+		}
+		rpSection := repository.NewSectionMock()
+		rpSection.FuncReportByWarehouse = func(warehouseID int) (internal.SectionWarehouseReport, error) {
+			return internal.SectionWarehouseReport{SectionsCount: 2, TotalCapacity: 150, UsedCapacity: 40, SectionsBelowMinimumTemperature: 0}, nil
+		}
+		rpEmployee := repository.NewEmployeeMock()
+		rpEmployee.FuncCountByWarehouse = func(warehouseID int) (int, error) {
+			return 3, nil
+		}
+		hd := handler.NewWarehouseDefault(service.NewWarehouseDefault(rp, rpSection, rpEmployee))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses/1/report", "1", "")
+		res := httptest.NewRecorder()
+		hd.Report()(res, req)
+
+		// assert
+		expectedCode := http.StatusOK
+		expectedBody := `{"message":"success","data":{"warehouse_id":1,"warehouse_code":"W1","sections_count":2,"total_capacity":150,"used_capacity":40,"sections_below_minimum_temperature":0,"employees_count":3,"compliant":true}}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+	})
+
+	t.Run("case 2: error - the id is not a number", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		hd := newWarehouseHandler(rp)
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses/abc/report", "abc", "")
+		res := httptest.NewRecorder()
+		hd.Report()(res, req)
+
+		// assert
+		expectedCode := http.StatusBadRequest
+		expectedBody := `{"status":"Bad Request","message":"invalid id"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rp.Spy.FindByID)
+	})
+
+	t.Run("case 3: error - the warehouse is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		rpSection := repository.NewSectionMock()
+		hd := handler.NewWarehouseDefault(service.NewWarehouseDefault(rp, rpSection, repository.NewEmployeeMock()))
+
+		// act
+		req := newRequest(http.MethodGet, "/api/v1/warehouses/1/report", "1", "")
+		res := httptest.NewRecorder()
+		hd.Report()(res, req)
+
+		// assert
+		expectedCode := http.StatusNotFound
+		expectedBody := `{"status":"Not Found","message":"warehouse not found"}`
+		require.Equal(t, expectedCode, res.Code)
+		require.JSONEq(t, expectedBody, res.Body.String())
+		require.Equal(t, 0, rpSection.Spy.ReportByWarehouse)
+	})
+}
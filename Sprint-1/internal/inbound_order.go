@@ -0,0 +1,78 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// InboundOrder is a struct that contains the inbound order's information
+type InboundOrder struct {
+	// ID is the unique identifier of the inbound order
+	ID int
+	// OrderDate is the date the inbound order was received
+	OrderDate time.Time
+	// OrderNumber is the unique number of the inbound order
+	OrderNumber string
+	// EmployeeID is the unique identifier of the employee that received the inbound order
+	EmployeeID int
+	// ProductBatchID is the unique identifier of the product batch that was received
+	ProductBatchID int
+	// WarehouseID is the unique identifier of the warehouse where the inbound order was received
+	WarehouseID int
+}
+
+// Validate returns ErrInboundOrderInvalid wrapped with the first field of the inbound order that is not valid
+func (i InboundOrder) Validate() (err error) {
+	switch {
+	case i.OrderDate.IsZero():
+		err = fmt.Errorf("%w: order_date is required", ErrInboundOrderInvalid)
+	case i.OrderNumber == "":
+		err = fmt.Errorf("%w: order_number is required", ErrInboundOrderInvalid)
+	case i.EmployeeID <= 0:
+		err = fmt.Errorf("%w: employee_id must be greater than 0", ErrInboundOrderInvalid)
+	case i.ProductBatchID <= 0:
+		err = fmt.Errorf("%w: product_batch_id must be greater than 0", ErrInboundOrderInvalid)
+	case i.WarehouseID <= 0:
+		err = fmt.Errorf("%w: warehouse_id must be greater than 0", ErrInboundOrderInvalid)
+	}
+	return
+}
+
+// EmployeeInboundOrdersReport is a struct that contains the number of inbound orders received by an employee
+type EmployeeInboundOrdersReport struct {
+	// Employee is the employee that received the inbound orders
+	Employee
+	// InboundOrdersCount is the number of inbound orders received by the employee
+	InboundOrdersCount int
+}
+
+var (
+	// ErrInboundOrderRepositoryDuplicated is returned when the inbound order already exists
+	ErrInboundOrderRepositoryDuplicated = errors.New("repository: inbound order already exists")
+	// ErrInboundOrderRepositoryProductBatchNotFound is returned when the product batch of the inbound order is not found
+	ErrInboundOrderRepositoryProductBatchNotFound = errors.New("repository: inbound order product batch not found")
+	// ErrInboundOrderRepositoryEmployeeNotFound is returned when the employee of the inbound order is not found
+	ErrInboundOrderRepositoryEmployeeNotFound = errors.New("repository: inbound order employee not found")
+	// ErrInboundOrderWarehouseMismatch is returned when the warehouse of the inbound order is not the one of its employee
+	ErrInboundOrderWarehouseMismatch = errors.New("service: inbound order warehouse does not match the employee warehouse")
+	// ErrInboundOrderInvalid is returned when the inbound order has invalid fields
+	ErrInboundOrderInvalid = errors.New("inbound order: invalid fields")
+)
+
+// InboundOrderRepository is an interface that contains the methods that the inbound order repository should support
+type InboundOrderRepository interface {
+	// FindAll returns all the inbound orders
+	FindAll() ([]InboundOrder, error)
+	// Save saves the given inbound order
+	Save(ctx context.Context, inboundOrder *InboundOrder) error
+}
+
+// InboundOrderService is an interface that contains the methods that the inbound order service should support
+type InboundOrderService interface {
+	// FindAll returns all the inbound orders
+	FindAll() ([]InboundOrder, error)
+	// Save saves the given inbound order, received by an employee of its warehouse
+	Save(ctx context.Context, inboundOrder *InboundOrder) error
+}
@@ -0,0 +1,49 @@
+package loader
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewLocalitiesJSON creates a new instance of the localities loader
+func NewLocalitiesJSON(file *os.File) *LocalitiesJSON {
+	return &LocalitiesJSON{file: file}
+}
+
+// LocalitiesJSON is the json implementation of the locality loader
+type LocalitiesJSON struct {
+	// file is the file the localities are read from
+	file *os.File
+}
+
+// LocalityJSON is the locality data in the json file
+type LocalityJSON struct {
+	ID           int    `json:"id"`
+	LocalityName string `json:"locality_name"`
+	ProvinceName string `json:"province_name"`
+	CountryName  string `json:"country_name"`
+}
+
+// Load loads the localities from the json file
+func (l *LocalitiesJSON) Load() (localities []internal.Locality, err error) {
+	// decode the json file
+	var ls []LocalityJSON
+	err = json.NewDecoder(l.file).Decode(&ls)
+	if err != nil {
+		return
+	}
+
+	// serialize the locality data
+	for _, v := range ls {
+		localities = append(localities, internal.Locality{
+			ID:           v.ID,
+			LocalityName: v.LocalityName,
+			ProvinceName: v.ProvinceName,
+			CountryName:  v.CountryName,
+		})
+	}
+
+	return
+}
@@ -0,0 +1,82 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Locality is a struct that contains the locality's information
+// - the province and country are reference data shared by the localities, identified by their names
+type Locality struct {
+	// ID is the unique identifier of the locality
+	ID int
+	// LocalityName is the name of the locality
+	LocalityName string
+	// ProvinceName is the name of the province of the locality
+	ProvinceName string
+	// CountryName is the name of the country of the province
+	CountryName string
+}
+
+// Validate returns ErrLocalityInvalid wrapped with the first field of the locality that is not valid
+func (l Locality) Validate() (err error) {
+	switch {
+	case l.LocalityName == "":
+		err = fmt.Errorf("%w: locality_name is required", ErrLocalityInvalid)
+	case l.ProvinceName == "":
+		err = fmt.Errorf("%w: province_name is required", ErrLocalityInvalid)
+	case l.CountryName == "":
+		err = fmt.Errorf("%w: country_name is required", ErrLocalityInvalid)
+	}
+	return
+}
+
+// LocalityCountReport is a struct that contains the number of entities, e.g. sellers or carriers, of a locality
+type LocalityCountReport struct {
+	// LocalityID is the unique identifier of the locality
+	LocalityID int
+	// LocalityName is the name of the locality
+	LocalityName string
+	// Count is the number of entities of the locality
+	Count int
+}
+
+var (
+	// ErrLocalityRepositoryNotFound is returned when the locality is not found
+	ErrLocalityRepositoryNotFound = errors.New("repository: locality not found")
+	// ErrLocalityRepositoryDuplicated is returned when the locality already exists
+	ErrLocalityRepositoryDuplicated = errors.New("repository: locality already exists")
+	// ErrLocalityInvalid is returned when the locality has invalid fields
+	ErrLocalityInvalid = errors.New("locality: invalid fields")
+)
+
+// LocalityRepository is an interface that contains the methods that the locality repository should support
+type LocalityRepository interface {
+	// FindByID returns the locality with the given ID
+	FindByID(id int) (Locality, error)
+	// Save saves the given locality, creating its province and country if they do not exist
+	Save(ctx context.Context, locality *Locality) error
+	// ReportSellers returns the number of sellers of the locality with the given ID, or of every locality if it is zero
+	ReportSellers(id int) ([]LocalityCountReport, error)
+	// ReportCarriers returns the number of carriers of the locality with the given ID, or of every locality if it is zero
+	ReportCarriers(id int) ([]LocalityCountReport, error)
+}
+
+// LocalityService is an interface that contains the methods that the locality service should support
+type LocalityService interface {
+	// FindByID returns the locality with the given ID
+	FindByID(id int) (Locality, error)
+	// Save saves the given locality
+	Save(ctx context.Context, locality *Locality) error
+	// ReportSellers returns the number of sellers of the locality with the given ID, or of every locality if it is zero
+	ReportSellers(id int) ([]LocalityCountReport, error)
+	// ReportCarriers returns the number of carriers of the locality with the given ID, or of every locality if it is zero
+	ReportCarriers(id int) ([]LocalityCountReport, error)
+}
+
+// LoaderLocality is the interface that wraps the basic Load method
+type LoaderLocality interface {
+	// Load loads the localities from the source
+	Load() ([]Locality, error)
+}
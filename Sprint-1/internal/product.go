@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Product is a struct that contains the product's information
 type Product struct {
@@ -28,6 +32,35 @@ type Product struct {
 	ProductTypeID int
 	// SellerID is the unique identifier of the seller
 	SellerID int
+	// Version is the number of times the product was written, used to detect concurrent updates
+	Version int
+}
+
+// Validate returns ErrProductInvalid wrapped with the first field of the product that is not valid
+func (p Product) Validate() (err error) {
+	switch {
+	case p.ProductCode == "":
+		err = fmt.Errorf("%w: product_code is required", ErrProductInvalid)
+	case p.Description == "":
+		err = fmt.Errorf("%w: description is required", ErrProductInvalid)
+	case p.Height <= 0:
+		err = fmt.Errorf("%w: height must be greater than 0", ErrProductInvalid)
+	case p.Length <= 0:
+		err = fmt.Errorf("%w: length must be greater than 0", ErrProductInvalid)
+	case p.Width <= 0:
+		err = fmt.Errorf("%w: width must be greater than 0", ErrProductInvalid)
+	case p.Weight <= 0:
+		err = fmt.Errorf("%w: weight must be greater than 0", ErrProductInvalid)
+	case p.ExpirationRate < 0:
+		err = fmt.Errorf("%w: expiration_rate must not be negative", ErrProductInvalid)
+	case p.FreezingRate < 0:
+		err = fmt.Errorf("%w: freezing_rate must not be negative", ErrProductInvalid)
+	case p.ProductTypeID <= 0:
+		err = fmt.Errorf("%w: product_type_id must be greater than 0", ErrProductInvalid)
+	case p.SellerID <= 0:
+		err = fmt.Errorf("%w: seller_id must be greater than 0", ErrProductInvalid)
+	}
+	return
 }
 
 var (
@@ -35,32 +68,69 @@ var (
 	ErrProductRepositoryNotFound = errors.New("repository: product not found")
 	// ErrProductRepositoryDuplicated is returned when the product already exists
 	ErrProductRepositoryDuplicated = errors.New("repository: product already exists")
+	// ErrProductCodeDuplicated is returned when another product already has the same product_code
+	ErrProductCodeDuplicated = fmt.Errorf("%w: product_code already in use", ErrProductRepositoryDuplicated)
+	// ErrProductReferenceNotFound is returned when an entity referenced by the product does not exist
+	ErrProductReferenceNotFound = errors.New("product: reference not found")
+	// ErrProductSellerNotFound is returned when the seller of the product does not exist
+	ErrProductSellerNotFound = fmt.Errorf("%w: seller_id", ErrProductReferenceNotFound)
+	// ErrProductProductTypeNotFound is returned when the product type of the product does not exist
+	ErrProductProductTypeNotFound = fmt.Errorf("%w: product_type_id", ErrProductReferenceNotFound)
+	// ErrProductHasDependents is returned when the product can not be deleted because other entities reference it
+	ErrProductHasDependents = errors.New("product: has dependents")
+	// ErrProductHasProductBatches is returned when the product still has product batches
+	ErrProductHasProductBatches = fmt.Errorf("%w: product batches", ErrProductHasDependents)
+	// ErrProductHasProductRecords is returned when the product still has product records
+	ErrProductHasProductRecords = fmt.Errorf("%w: product records", ErrProductHasDependents)
+	// ErrProductInvalid is returned when the product has invalid fields
+	ErrProductInvalid = errors.New("product: invalid fields")
 )
 
 // ProductRepository is an interface that contains the methods that the product repository should support
 type ProductRepository interface {
 	// FindAll returns all the products
 	FindAll() ([]Product, error)
+	// FindAllWithDeleted returns all the products, including the soft-deleted ones
+	FindAllWithDeleted() ([]Product, error)
 	// FindByID returns the product with the given ID
 	FindByID(id int) (Product, error)
+	// FindByIDForUpdate returns the product with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (Product, error)
+	// FindByCode returns the product with the given product_code
+	FindByCode(code string) (Product, error)
 	// Save saves the given product
-	Save(product *Product) error
-	// Update updates the given product
-	Update(product *Product) error
-	// Delete deletes the product with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, product *Product) error
+	// SaveAll saves the given products in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, products []*Product) error
+	// Update updates the given product, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, product *Product) error
+	// Delete soft-deletes the product with the given ID, ErrProductHasProductBatches or ErrProductHasProductRecords is returned if it still has them
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted product with the given ID, ErrProductSellerNotFound is returned if its seller was deleted
+	// and ErrProductCodeDuplicated if another product has its product_code
+	Restore(ctx context.Context, id int) error
 }
 
 // ProductService is an interface that contains the methods that the product service should support
 type ProductService interface {
 	// FindAll returns all the products
 	FindAll() ([]Product, error)
+	// FindAllWithDeleted returns all the products, including the soft-deleted ones
+	FindAllWithDeleted() ([]Product, error)
 	// FindByID returns the product with the given ID
 	FindByID(id int) (Product, error)
+	// FindByCode returns the product with the given product_code
+	FindByCode(code string) (Product, error)
 	// Save saves the given product
-	Save(product *Product) error
-	// Update updates the given product
-	Update(product *Product) error
-	// Delete deletes the product with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, product *Product) error
+	// SaveAll validates and saves the given products in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, products []*Product) error
+	// Update updates the given product, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, product *Product) error
+	// Patch partially updates the product with the given ID, fn receives the current product and changes the fields to update
+	Patch(ctx context.Context, id int, fn func(product *Product) error) (Product, error)
+	// Delete soft-deletes the product with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted product with the given ID
+	Restore(ctx context.Context, id int) error
 }
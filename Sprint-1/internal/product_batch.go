@@ -0,0 +1,119 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ProductBatch is a struct that contains the product batch's information
+type ProductBatch struct {
+	// ID is the unique identifier of the product batch
+	ID int
+	// BatchNumber is the unique number of the product batch
+	BatchNumber int
+	// CurrentQuantity is the quantity of products currently in the batch
+	CurrentQuantity int
+	// InitialQuantity is the quantity of products the batch was stored with
+	InitialQuantity int
+	// CurrentTemperature is the current temperature of the batch
+	CurrentTemperature float64
+	// MinimumTemperature is the lowest temperature the batch tolerates
+	MinimumTemperature float64
+	// ManufacturingDate is the date the batch was manufactured
+	ManufacturingDate time.Time
+	// ManufacturingHour is the hour of the day the batch was manufactured
+	ManufacturingHour int
+	// DueDate is the date the batch expires
+	DueDate time.Time
+	// ProductID is the unique identifier of the product of the batch
+	ProductID int
+	// SectionID is the unique identifier of the section where the batch is stored
+	SectionID int
+}
+
+// Validate returns ErrProductBatchInvalid wrapped with the first field of the product batch that is not valid
+func (p ProductBatch) Validate() (err error) {
+	switch {
+	case p.BatchNumber <= 0:
+		err = fmt.Errorf("%w: batch_number must be greater than 0", ErrProductBatchInvalid)
+	case p.InitialQuantity <= 0:
+		err = fmt.Errorf("%w: initial_quantity must be greater than 0", ErrProductBatchInvalid)
+	case p.CurrentQuantity < 0:
+		err = fmt.Errorf("%w: current_quantity must not be negative", ErrProductBatchInvalid)
+	case p.CurrentQuantity > p.InitialQuantity:
+		err = fmt.Errorf("%w: current_quantity must not exceed initial_quantity", ErrProductBatchInvalid)
+	case p.ManufacturingDate.IsZero():
+		err = fmt.Errorf("%w: manufacturing_date is required", ErrProductBatchInvalid)
+	case p.ManufacturingHour < 0 || p.ManufacturingHour > 23:
+		err = fmt.Errorf("%w: manufacturing_hour must be between 0 and 23", ErrProductBatchInvalid)
+	case p.DueDate.IsZero():
+		err = fmt.Errorf("%w: due_date is required", ErrProductBatchInvalid)
+	case p.DueDate.Before(p.ManufacturingDate):
+		err = fmt.Errorf("%w: due_date must not be before manufacturing_date", ErrProductBatchInvalid)
+	case p.CurrentTemperature < p.MinimumTemperature:
+		err = fmt.Errorf("%w: current_temperature must not be below minimum_temperature", ErrProductBatchInvalid)
+	case p.ProductID <= 0:
+		err = fmt.Errorf("%w: product_id must be greater than 0", ErrProductBatchInvalid)
+	case p.SectionID <= 0:
+		err = fmt.Errorf("%w: section_id must be greater than 0", ErrProductBatchInvalid)
+	}
+	return
+}
+
+// SectionProductsReport is a struct that contains the quantity of products stored in a section
+type SectionProductsReport struct {
+	// SectionID is the unique identifier of the section
+	SectionID int
+	// SectionNumber is the number of the section
+	SectionNumber int
+	// ProductsCount is the sum of the current quantity of the batches stored in the section
+	ProductsCount int
+}
+
+var (
+	// ErrProductBatchRepositoryNotFound is returned when the product batch is not found
+	ErrProductBatchRepositoryNotFound = errors.New("repository: product batch not found")
+	// ErrProductBatchRepositoryDuplicated is returned when the product batch already exists
+	ErrProductBatchRepositoryDuplicated = errors.New("repository: product batch already exists")
+	// ErrProductBatchRepositorySectionNotFound is returned when the section of the product batch is not found
+	ErrProductBatchRepositorySectionNotFound = errors.New("repository: product batch section not found")
+	// ErrProductBatchRepositoryProductNotFound is returned when the product of the product batch is not found
+	ErrProductBatchRepositoryProductNotFound = errors.New("repository: product batch product not found")
+	// ErrProductBatchRepositoryProductType is returned when the product type does not match the one of the section
+	ErrProductBatchRepositoryProductType = errors.New("repository: product type does not match the section")
+	// ErrProductBatchRepositoryCapacityExceeded is returned when the section can not hold the product batch
+	ErrProductBatchRepositoryCapacityExceeded = errors.New("repository: section maximum capacity exceeded")
+	// ErrProductBatchRepositoryTemperature is returned when the section is colder than the minimum temperature of the product batch
+	ErrProductBatchRepositoryTemperature = errors.New("repository: section temperature below the product batch minimum")
+	// ErrProductBatchInvalidDays is returned when the number of days to look for expiring batches is negative
+	ErrProductBatchInvalidDays = errors.New("product batch: days must not be negative")
+	// ErrProductBatchInvalid is returned when the product batch has invalid fields
+	ErrProductBatchInvalid = errors.New("product batch: invalid fields")
+)
+
+// ProductBatchRepository is an interface that contains the methods that the product batch repository should support
+type ProductBatchRepository interface {
+	// FindByID returns the product batch with the given ID
+	FindByID(id int) (ProductBatch, error)
+	// Save saves the given product batch, adding its quantity to the current capacity of its section
+	Save(ctx context.Context, productBatch *ProductBatch) error
+	// ReportProducts returns the quantity of products stored in the section with the given ID
+	ReportProducts(sectionID int) (SectionProductsReport, error)
+	// FindExpiring returns the batches with products left that expire within the given number of days
+	// - a batch expires ExpirationRate days, rounded up, before its due date, so it can still be sold before it perishes
+	FindExpiring(days int) ([]ProductBatch, error)
+}
+
+// ProductBatchService is an interface that contains the methods that the product batch service should support
+type ProductBatchService interface {
+	// FindByID returns the product batch with the given ID
+	FindByID(id int) (ProductBatch, error)
+	// Save saves the given product batch
+	Save(ctx context.Context, productBatch *ProductBatch) error
+	// ReportProducts returns the quantity of products stored in the section with the given ID
+	ReportProducts(sectionID int) (SectionProductsReport, error)
+	// FindExpiring returns the batches with products left that expire within the given number of days
+	FindExpiring(days int) ([]ProductBatch, error)
+}
@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ProductRecord is a struct that contains the prices of a product at a point in time
+type ProductRecord struct {
+	// ID is the unique identifier of the product record
+	ID int
+	// LastUpdateDate is the date the prices were updated
+	LastUpdateDate time.Time
+	// PurchasePrice is the price the product is purchased at
+	PurchasePrice float64
+	// SalePrice is the price the product is sold at
+	SalePrice float64
+	// ProductID is the unique identifier of the product
+	ProductID int
+}
+
+// Validate returns ErrProductRecordInvalid wrapped with the first field of the product record that is not valid
+func (p ProductRecord) Validate() (err error) {
+	switch {
+	case p.LastUpdateDate.IsZero():
+		err = fmt.Errorf("%w: last_update_date is required", ErrProductRecordInvalid)
+	case p.PurchasePrice <= 0:
+		err = fmt.Errorf("%w: purchase_price must be greater than 0", ErrProductRecordInvalid)
+	case p.SalePrice <= 0:
+		err = fmt.Errorf("%w: sale_price must be greater than 0", ErrProductRecordInvalid)
+	case p.ProductID <= 0:
+		err = fmt.Errorf("%w: product_id must be greater than 0", ErrProductRecordInvalid)
+	}
+	return
+}
+
+// ProductRecordsReport is a struct that contains the number of records of a product
+type ProductRecordsReport struct {
+	// ProductID is the unique identifier of the product
+	ProductID int
+	// Description is the description of the product
+	Description string
+	// RecordsCount is the number of records of the product
+	RecordsCount int
+}
+
+var (
+	// ErrProductRecordRepositoryProductNotFound is returned when the product of the product record is not found
+	ErrProductRecordRepositoryProductNotFound = errors.New("repository: product record product not found")
+	// ErrProductRecordInvalid is returned when the product record has invalid fields
+	ErrProductRecordInvalid = errors.New("product record: invalid fields")
+)
+
+// ProductRecordRepository is an interface that contains the methods that the product record repository should support
+type ProductRecordRepository interface {
+	// Save saves the given product record
+	Save(ctx context.Context, productRecord *ProductRecord) error
+	// ReportRecords returns the number of records of the product with the given ID, or of every product if it is zero
+	ReportRecords(productID int) ([]ProductRecordsReport, error)
+}
+
+// ProductRecordService is an interface that contains the methods that the product record service should support
+type ProductRecordService interface {
+	// Save saves the given product record
+	Save(ctx context.Context, productRecord *ProductRecord) error
+	// ReportRecords returns the number of records of the product with the given ID, or of every product if it is zero
+	ReportRecords(productID int) ([]ProductRecordsReport, error)
+}
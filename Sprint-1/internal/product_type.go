@@ -0,0 +1,22 @@
+package internal
+
+import "errors"
+
+// ProductType is a struct that contains the product type's information
+type ProductType struct {
+	// ID is the unique identifier of the product type
+	ID int
+	// Description is the description of the product type
+	Description string
+}
+
+var (
+	// ErrProductTypeRepositoryNotFound is returned when the product type is not found
+	ErrProductTypeRepositoryNotFound = errors.New("repository: product type not found")
+)
+
+// ProductTypeRepository is an interface that contains the methods that the product type repository should support
+type ProductTypeRepository interface {
+	// FindByID returns the product type with the given ID
+	FindByID(id int) (ProductType, error)
+}
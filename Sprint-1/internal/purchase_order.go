@@ -0,0 +1,103 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// PurchaseOrderStatusPending is the status of a purchase order that has not been shipped yet
+	PurchaseOrderStatusPending = "pending"
+	// PurchaseOrderStatusShipped is the status of a purchase order handed to the carrier
+	PurchaseOrderStatusShipped = "shipped"
+	// PurchaseOrderStatusDelivered is the status of a purchase order received by the buyer
+	PurchaseOrderStatusDelivered = "delivered"
+	// PurchaseOrderStatusCancelled is the status of a purchase order that will not be delivered
+	PurchaseOrderStatusCancelled = "cancelled"
+)
+
+// PurchaseOrder is a struct that contains the purchase order's information
+type PurchaseOrder struct {
+	// ID is the unique identifier of the purchase order
+	ID int
+	// OrderNumber is the unique number of the purchase order
+	OrderNumber string
+	// OrderDate is the date the purchase order was placed
+	OrderDate time.Time
+	// TrackingCode is the code to track the shipment of the purchase order
+	TrackingCode string
+	// BuyerID is the unique identifier of the buyer that placed the purchase order
+	BuyerID int
+	// ProductRecordID is the unique identifier of the product record that was purchased
+	ProductRecordID int
+	// CarrierID is the unique identifier of the carrier that ships the purchase order
+	CarrierID int
+	// Status is the status of the purchase order
+	Status string
+}
+
+// Validate returns ErrPurchaseOrderInvalid wrapped with the first field of the purchase order that is not valid
+func (p PurchaseOrder) Validate() (err error) {
+	switch {
+	case p.OrderNumber == "":
+		err = fmt.Errorf("%w: order_number is required", ErrPurchaseOrderInvalid)
+	case p.OrderDate.IsZero():
+		err = fmt.Errorf("%w: order_date is required", ErrPurchaseOrderInvalid)
+	case p.TrackingCode == "":
+		err = fmt.Errorf("%w: tracking_code is required", ErrPurchaseOrderInvalid)
+	case p.BuyerID <= 0:
+		err = fmt.Errorf("%w: buyer_id must be greater than 0", ErrPurchaseOrderInvalid)
+	case p.ProductRecordID <= 0:
+		err = fmt.Errorf("%w: product_record_id must be greater than 0", ErrPurchaseOrderInvalid)
+	case p.CarrierID <= 0:
+		err = fmt.Errorf("%w: carrier_id must be greater than 0", ErrPurchaseOrderInvalid)
+	case p.Status != PurchaseOrderStatusPending && p.Status != PurchaseOrderStatusShipped && p.Status != PurchaseOrderStatusDelivered && p.Status != PurchaseOrderStatusCancelled:
+		err = fmt.Errorf("%w: status must be one of pending, shipped, delivered or cancelled", ErrPurchaseOrderInvalid)
+	}
+	return
+}
+
+// BuyerPurchaseOrdersReport is a struct that contains the number of purchase orders placed by a buyer
+type BuyerPurchaseOrdersReport struct {
+	// Buyer is the buyer that placed the purchase orders
+	Buyer
+	// PurchaseOrdersCount is the number of purchase orders placed by the buyer
+	PurchaseOrdersCount int
+}
+
+var (
+	// ErrPurchaseOrderRepositoryNotFound is returned when the purchase order is not found
+	ErrPurchaseOrderRepositoryNotFound = errors.New("repository: purchase order not found")
+	// ErrPurchaseOrderRepositoryDuplicated is returned when the purchase order already exists
+	ErrPurchaseOrderRepositoryDuplicated = errors.New("repository: purchase order already exists")
+	// ErrPurchaseOrderRepositoryBuyerNotFound is returned when the buyer of the purchase order is not found
+	ErrPurchaseOrderRepositoryBuyerNotFound = errors.New("repository: purchase order buyer not found")
+	// ErrPurchaseOrderRepositoryCarrierNotFound is returned when the carrier of the purchase order is not found
+	ErrPurchaseOrderRepositoryCarrierNotFound = errors.New("repository: purchase order carrier not found")
+	// ErrPurchaseOrderRepositoryProductRecordNotFound is returned when the product record of the purchase order is not found
+	ErrPurchaseOrderRepositoryProductRecordNotFound = errors.New("repository: purchase order product record not found")
+	// ErrPurchaseOrderInvalid is returned when the purchase order has invalid fields
+	ErrPurchaseOrderInvalid = errors.New("purchase order: invalid fields")
+)
+
+// PurchaseOrderRepository is an interface that contains the methods that the purchase order repository should support
+type PurchaseOrderRepository interface {
+	// FindByID returns the purchase order with the given ID
+	FindByID(id int) (PurchaseOrder, error)
+	// Save saves the given purchase order
+	Save(ctx context.Context, purchaseOrder *PurchaseOrder) error
+	// ReportByBuyer returns the number of purchase orders placed by the buyer with the given ID
+	ReportByBuyer(buyerID int) (BuyerPurchaseOrdersReport, error)
+}
+
+// PurchaseOrderService is an interface that contains the methods that the purchase order service should support
+type PurchaseOrderService interface {
+	// FindByID returns the purchase order with the given ID
+	FindByID(id int) (PurchaseOrder, error)
+	// Save saves the given purchase order
+	Save(ctx context.Context, purchaseOrder *PurchaseOrder) error
+	// ReportByBuyer returns the number of purchase orders placed by the buyer with the given ID
+	ReportByBuyer(buyerID int) (BuyerPurchaseOrdersReport, error)
+}
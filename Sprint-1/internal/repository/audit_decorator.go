@@ -0,0 +1,270 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// AuditedRepository is the set of methods shared by the repositories that can be audited
+type AuditedRepository[T any] interface {
+	// FindAll returns all the entities
+	FindAll() ([]T, error)
+	// FindAllWithDeleted returns all the entities, including the soft-deleted ones
+	FindAllWithDeleted() ([]T, error)
+	// FindByID returns the entity with the given ID
+	FindByID(id int) (T, error)
+	// FindByIDForUpdate returns the entity with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (T, error)
+	// Save saves the given entity
+	Save(ctx context.Context, entity *T) error
+	// SaveAll saves the given entities in a single transaction
+	SaveAll(ctx context.Context, entities []*T) error
+	// Update updates the given entity
+	Update(ctx context.Context, entity *T) error
+	// Delete deletes the entity with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted entity with the given ID
+	Restore(ctx context.Context, id int) error
+}
+
+// SavedRepository is the method of the repositories whose entities are only created, never changed
+type SavedRepository[T any] interface {
+	// Save saves the given entity
+	Save(ctx context.Context, entity *T) error
+}
+
+// NewAuditSaveDecorator creates a new instance of the audit decorator of a repository whose entities are only created
+// - entity is the name recorded in the audit log (e.g. carriers)
+// - id returns the unique identifier of an entity
+func NewAuditSaveDecorator[T any](rp SavedRepository[T], au internal.AuditRepository, entity string, id func(T) int) *AuditSaveDecorator[T] {
+	return &AuditSaveDecorator[T]{
+		rp:     rp,
+		au:     au,
+		entity: entity,
+		id:     id,
+	}
+}
+
+// AuditSaveDecorator is a repository decorator that records the creation of the entities in the audit log
+// - the entity and its audit entry are saved in the same transaction, so neither is kept without the other
+// - the request id recorded with the entries is the one carried by the context of the operation
+type AuditSaveDecorator[T any] struct {
+	// rp is the decorated repository
+	rp SavedRepository[T]
+	// au is the repository where the audit entries are saved
+	au internal.AuditRepository
+	// entity is the name of the audited entity
+	entity string
+	// id returns the unique identifier of an entity
+	id func(T) int
+}
+
+// Save saves the given entity and records its creation
+func (r *AuditSaveDecorator[T]) Save(ctx context.Context, entity *T) (err error) {
+	err = r.au.Transaction(ctx, func(ctx context.Context) (err error) {
+		// save the entity
+		err = r.rp.Save(ctx, entity)
+		if err != nil {
+			return
+		}
+
+		// record the operation
+		err = r.recordCreate(ctx, *entity)
+		return
+	})
+	return
+}
+
+// recordCreate saves the audit entry of a created entity
+func (r *AuditSaveDecorator[T]) recordCreate(ctx context.Context, entity T) (err error) {
+	after, err := json.Marshal(entity)
+	if err != nil {
+		return
+	}
+	err = r.record(ctx, r.id(entity), internal.AuditOperationCreate, nil, after)
+	return
+}
+
+// recordUpdate saves the audit entry of an updated entity, with the fields that changed between prev and next
+func (r *AuditSaveDecorator[T]) recordUpdate(ctx context.Context, prev, next T) (err error) {
+	changedBefore, changedAfter := changes(prev, next)
+	before, err := json.Marshal(changedBefore)
+	if err != nil {
+		return
+	}
+	after, err := json.Marshal(changedAfter)
+	if err != nil {
+		return
+	}
+	err = r.record(ctx, r.id(next), internal.AuditOperationUpdate, before, after)
+	return
+}
+
+// record saves an audit entry for the entity, with the request id carried by the context
+func (r *AuditSaveDecorator[T]) record(ctx context.Context, id int, operation string, before, after json.RawMessage) (err error) {
+	audit := internal.Audit{
+		Entity:    r.entity,
+		EntityID:  id,
+		Operation: operation,
+		Before:    before,
+		After:     after,
+		RequestID: internal.RequestIDFromContext(ctx),
+		CreatedAt: time.Now(),
+	}
+	err = r.au.Save(ctx, &audit)
+	return
+}
+
+// NewAuditDecorator creates a new instance of the audit decorator
+// - entity is the name recorded in the audit log (e.g. sellers)
+// - id returns the unique identifier of an entity
+func NewAuditDecorator[T any](rp AuditedRepository[T], au internal.AuditRepository, entity string, id func(T) int) *AuditDecorator[T] {
+	return &AuditDecorator[T]{
+		AuditSaveDecorator: NewAuditSaveDecorator[T](rp, au, entity, id),
+		rp:                 rp,
+	}
+}
+
+// AuditDecorator is a repository decorator that records every write operation in the audit log
+// - each operation and its audit entry run in the same transaction, so neither is kept without the other
+// - the creation is recorded by the embedded decorator, the rest of the operations by this one
+type AuditDecorator[T any] struct {
+	*AuditSaveDecorator[T]
+	// rp is the decorated repository
+	rp AuditedRepository[T]
+}
+
+// FindAll returns all the entities
+func (r *AuditDecorator[T]) FindAll() ([]T, error) {
+	return r.rp.FindAll()
+}
+
+// FindAllWithDeleted returns all the entities, including the soft-deleted ones
+func (r *AuditDecorator[T]) FindAllWithDeleted() ([]T, error) {
+	return r.rp.FindAllWithDeleted()
+}
+
+// FindByID returns the entity with the given ID
+func (r *AuditDecorator[T]) FindByID(id int) (T, error) {
+	return r.rp.FindByID(id)
+}
+
+// FindByIDForUpdate returns the entity with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *AuditDecorator[T]) FindByIDForUpdate(ctx context.Context, id int) (T, error) {
+	return r.rp.FindByIDForUpdate(ctx, id)
+}
+
+// SaveAll saves the given entities and records the creation of each of them
+// - the entities and their entries are saved in a single transaction, so a failed import records nothing
+func (r *AuditDecorator[T]) SaveAll(ctx context.Context, entities []*T) (err error) {
+	err = r.au.Transaction(ctx, func(ctx context.Context) (err error) {
+		// save the entities
+		err = r.rp.SaveAll(ctx, entities)
+		if err != nil {
+			return
+		}
+
+		// record the operations
+		for _, entity := range entities {
+			err = r.recordCreate(ctx, *entity)
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// Update updates the given entity and records the fields that changed
+func (r *AuditDecorator[T]) Update(ctx context.Context, entity *T) (err error) {
+	err = r.au.Transaction(ctx, func(ctx context.Context) (err error) {
+		// get the entity before the update, locked until the entry is recorded
+		prev, err := r.rp.FindByIDForUpdate(ctx, r.id(*entity))
+		if err != nil {
+			return
+		}
+
+		// update the entity
+		err = r.rp.Update(ctx, entity)
+		if err != nil {
+			return
+		}
+
+		// record the fields that changed
+		err = r.recordUpdate(ctx, prev, *entity)
+		return
+	})
+	return
+}
+
+// Delete deletes the entity with the given ID and records its last state
+func (r *AuditDecorator[T]) Delete(ctx context.Context, id int) (err error) {
+	err = r.au.Transaction(ctx, func(ctx context.Context) (err error) {
+		// get the entity before the delete, locked until the entry is recorded
+		prev, err := r.rp.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return
+		}
+
+		// delete the entity
+		err = r.rp.Delete(ctx, id)
+		if err != nil {
+			return
+		}
+
+		// record the operation
+		before, err := json.Marshal(prev)
+		if err != nil {
+			return
+		}
+		err = r.record(ctx, id, internal.AuditOperationDelete, before, nil)
+		return
+	})
+	return
+}
+
+// Restore restores the soft-deleted entity with the given ID and records its restored state
+func (r *AuditDecorator[T]) Restore(ctx context.Context, id int) (err error) {
+	err = r.au.Transaction(ctx, func(ctx context.Context) (err error) {
+		// restore the entity
+		err = r.rp.Restore(ctx, id)
+		if err != nil {
+			return
+		}
+
+		// record the operation
+		next, err := r.rp.FindByIDForUpdate(ctx, id)
+		if err != nil {
+			return
+		}
+		after, err := json.Marshal(next)
+		if err != nil {
+			return
+		}
+		err = r.record(ctx, id, internal.AuditOperationRestore, nil, after)
+		return
+	})
+	return
+}
+
+// changes returns the fields that differ between two versions of an entity, keyed by field name
+// - the entities are flat structs, so their fields are compared one by one by value
+func changes[T any](prev, next T) (before, after map[string]any) {
+	before, after = make(map[string]any), make(map[string]any)
+	prevValue, nextValue := reflect.ValueOf(prev), reflect.ValueOf(next)
+	for i := 0; i < prevValue.NumField(); i++ {
+		prevField, nextField := prevValue.Field(i).Interface(), nextValue.Field(i).Interface()
+		if prevField == nextField {
+			continue
+		}
+		name := prevValue.Type().Field(i).Name
+		before[name] = prevField
+		after[name] = nextField
+	}
+	return
+}
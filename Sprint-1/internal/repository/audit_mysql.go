@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewAuditMysql creates a new instance of the audit repository
+func NewAuditMysql(db *sql.DB) *AuditMysql {
+	return &AuditMysql{db}
+}
+
+// AuditMysql is the mysql implementation of the audit repository
+type AuditMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByEntity returns the audit entries of an entity from the database, filtered by its id if it is not zero
+func (r *AuditMysql) FindByEntity(entity string, entityID int) (audits []internal.Audit, err error) {
+	// build the query
+	query := "SELECT `id`, `entity`, `entity_id`, `operation`, `before`, `after`, `request_id`, `created_at` FROM `audit_log` WHERE `entity` = ?"
+	args := []any{entity}
+	if entityID != 0 {
+		query += " AND `entity_id` = ?"
+		args = append(args, entityID)
+	}
+	query += " ORDER BY `id`"
+
+	// execute the query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		// create a new audit entry
+		var audit internal.Audit
+		var before, after []byte
+		err = rows.Scan(&audit.ID, &audit.Entity, &audit.EntityID, &audit.Operation, &before, &after, &audit.RequestID, &audit.CreatedAt)
+		if err != nil {
+			return
+		}
+		audit.Before = before
+		audit.After = after
+
+		// append the audit entry to the slice
+		audits = append(audits, audit)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Save saves an audit entry into the database, in the transaction of the context if there is one
+func (r *AuditMysql) Save(ctx context.Context, audit *internal.Audit) (err error) {
+	// null json columns when there is nothing to record
+	var before, after any
+	if len((*audit).Before) > 0 {
+		before = string((*audit).Before)
+	}
+	if len((*audit).After) > 0 {
+		after = string((*audit).After)
+	}
+
+	// execute the query
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"INSERT INTO `audit_log` (`entity`, `entity_id`, `operation`, `before`, `after`, `request_id`, `created_at`) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		(*audit).Entity, (*audit).EntityID, (*audit).Operation, before, after, (*audit).RequestID, (*audit).CreatedAt,
+	)
+	if err != nil {
+		return
+	}
+
+	// get the id of the inserted audit entry
+	id, err := result.LastInsertId()
+	if err != nil {
+		return
+	}
+
+	// set the id of the audit entry
+	(*audit).ID = int(id)
+
+	return
+}
+
+// Transaction runs fn in a transaction, shared through its context by the mysql repositories of the same database
+func (r *AuditMysql) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	err = transaction(ctx, r.db, fn)
+	return
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewBuyerAudit creates a new instance of the buyer audit decorator
+func NewBuyerAudit(rp internal.BuyerRepository, au internal.AuditRepository) *BuyerAudit {
+	return &BuyerAudit{
+		AuditDecorator: NewAuditDecorator[internal.Buyer](rp, au, "buyers", func(buyer internal.Buyer) int { return buyer.ID }),
+		rp:             rp,
+	}
+}
+
+// BuyerAudit is the audit decorator of the buyer repository
+// - the write operations are recorded by the embedded decorator, the buyer specific reads go straight to rp
+type BuyerAudit struct {
+	*AuditDecorator[internal.Buyer]
+	// rp is the decorated repository
+	rp internal.BuyerRepository
+}
+
+// FindByCardNumberID returns a buyer by its card_number_id
+func (r *BuyerAudit) FindByCardNumberID(cardNumberID int) (internal.Buyer, error) {
+	return r.rp.FindByCardNumberID(cardNumberID)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewBuyerMock creates a new instance of the buyer repository mock
+func NewBuyerMock() *BuyerMock {
+	return &BuyerMock{}
+}
+
+// BuyerMock is a mock of the buyer repository
+// - each method calls its Func field and counts the call in Spy
+type BuyerMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Buyer, error)
+	// FuncFindAllWithDeleted is the function called by FindAllWithDeleted
+	FuncFindAllWithDeleted func() ([]internal.Buyer, error)
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Buyer, error)
+	// FuncFindByIDForUpdate is the function called by FindByIDForUpdate
+	FuncFindByIDForUpdate func(ctx context.Context, id int) (internal.Buyer, error)
+	// FuncFindByCardNumberID is the function called by FindByCardNumberID
+	FuncFindByCardNumberID func(cardNumberID int) (internal.Buyer, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, buyer *internal.Buyer) error
+	// FuncSaveAll is the function called by SaveAll
+	FuncSaveAll func(ctx context.Context, buyers []*internal.Buyer) error
+	// FuncUpdate is the function called by Update
+	FuncUpdate func(ctx context.Context, buyer *internal.Buyer) error
+	// FuncDelete is the function called by Delete
+	FuncDelete func(ctx context.Context, id int) error
+	// FuncRestore is the function called by Restore
+	FuncRestore func(ctx context.Context, id int) error
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// FindAllWithDeleted is the number of times FindAllWithDeleted was called
+		FindAllWithDeleted int
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// FindByIDForUpdate is the number of times FindByIDForUpdate was called
+		FindByIDForUpdate int
+		// FindByCardNumberID is the number of times FindByCardNumberID was called
+		FindByCardNumberID int
+		// Save is the number of times Save was called
+		Save int
+		// SaveAll is the number of times SaveAll was called
+		SaveAll int
+		// Update is the number of times Update was called
+		Update int
+		// Delete is the number of times Delete was called
+		Delete int
+		// Restore is the number of times Restore was called
+		Restore int
+	}
+}
+
+// FindAll returns all the buyers
+func (r *BuyerMock) FindAll() ([]internal.Buyer, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// FindAllWithDeleted returns all the buyers, including the soft-deleted ones
+func (r *BuyerMock) FindAllWithDeleted() ([]internal.Buyer, error) {
+	// spy
+	r.Spy.FindAllWithDeleted++
+
+	// mock
+	return r.FuncFindAllWithDeleted()
+}
+
+// FindByID returns the buyer with the given ID
+func (r *BuyerMock) FindByID(id int) (internal.Buyer, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// FindByIDForUpdate returns the buyer with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *BuyerMock) FindByIDForUpdate(ctx context.Context, id int) (internal.Buyer, error) {
+	// spy
+	r.Spy.FindByIDForUpdate++
+
+	// mock
+	return r.FuncFindByIDForUpdate(ctx, id)
+}
+
+// FindByCardNumberID returns the buyer with the given card_number_id
+func (r *BuyerMock) FindByCardNumberID(cardNumberID int) (internal.Buyer, error) {
+	// spy
+	r.Spy.FindByCardNumberID++
+
+	// mock
+	return r.FuncFindByCardNumberID(cardNumberID)
+}
+
+// Save saves the given buyer
+func (r *BuyerMock) Save(ctx context.Context, buyer *internal.Buyer) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, buyer)
+}
+
+// SaveAll saves the given buyers in a single transaction, none of them are saved if one fails
+func (r *BuyerMock) SaveAll(ctx context.Context, buyers []*internal.Buyer) error {
+	// spy
+	r.Spy.SaveAll++
+
+	// mock
+	return r.FuncSaveAll(ctx, buyers)
+}
+
+// Update updates the given buyer, ErrVersionConflict is returned if its version is not the current one
+func (r *BuyerMock) Update(ctx context.Context, buyer *internal.Buyer) error {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	return r.FuncUpdate(ctx, buyer)
+}
+
+// Delete soft-deletes the buyer with the given ID
+func (r *BuyerMock) Delete(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	return r.FuncDelete(ctx, id)
+}
+
+// Restore restores the soft-deleted buyer with the given ID, ErrBuyerCardNumberIDDuplicated is returned if another buyer has its card_number_id
+func (r *BuyerMock) Restore(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Restore++
+
+	// mock
+	return r.FuncRestore(ctx, id)
+}
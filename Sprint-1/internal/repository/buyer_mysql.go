@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -20,10 +21,28 @@ type BuyerMysql struct {
 	db *sql.DB
 }
 
-// FindAll returns all buyers from the database
+// FindAll returns all buyers from the database, except the soft-deleted ones
 func (r *BuyerMysql) FindAll() (buyers []internal.Buyer, err error) {
+	buyers, err = r.findAll(false)
+	return
+}
+
+// FindAllWithDeleted returns all buyers from the database, including the soft-deleted ones
+func (r *BuyerMysql) FindAllWithDeleted() (buyers []internal.Buyer, err error) {
+	buyers, err = r.findAll(true)
+	return
+}
+
+// findAll returns the buyers from the database, including the soft-deleted ones if requested
+func (r *BuyerMysql) findAll(includeDeleted bool) (buyers []internal.Buyer, err error) {
+	// build the query
+	query := "SELECT `b`.`id`, `b`.`card_number_id`, `b`.`first_name`, `b`.`last_name`, `b`.`version` FROM `buyers` AS `b`"
+	if !includeDeleted {
+		query += " WHERE `b`.`deleted_at` IS NULL"
+	}
+
 	// execute the query
-	rows, err := r.db.Query("SELECT `b.id`, `b.card_number_id`, `b.first_name`, `b.last_name` FROM `buyers` AS `b`")
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return
 	}
@@ -32,7 +51,7 @@ func (r *BuyerMysql) FindAll() (buyers []internal.Buyer, err error) {
 	for rows.Next() {
 		// create a new buyer
 		var buyer internal.Buyer
-		err = rows.Scan(&buyer.ID, &buyer.CardNumberID, &buyer.FirstName, &buyer.LastName)
+		err = rows.Scan(&buyer.ID, &buyer.CardNumberID, &buyer.FirstName, &buyer.LastName, &buyer.Version)
 		if err != nil {
 			return
 		}
@@ -53,10 +72,44 @@ func (r *BuyerMysql) FindAll() (buyers []internal.Buyer, err error) {
 // FindByID returns a buyer from the database by its id
 func (r *BuyerMysql) FindByID(id int) (buyer internal.Buyer, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `b.id`, `b.card_number_id`, `b.first_name`, `b.last_name` FROM `buyers` AS `b` WHERE `b.id` = ?", id)
+	row := r.db.QueryRow("SELECT `b`.`id`, `b`.`card_number_id`, `b`.`first_name`, `b`.`last_name`, `b`.`version` FROM `buyers` AS `b` WHERE `b`.`id` = ? AND `b`.`deleted_at` IS NULL", id)
 
 	// scan the row into the buyer
-	err = row.Scan(&buyer.ID, &buyer.CardNumberID, &buyer.FirstName, &buyer.LastName)
+	err = row.Scan(&buyer.ID, &buyer.CardNumberID, &buyer.FirstName, &buyer.LastName, &buyer.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrBuyerRepositoryNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// FindByIDForUpdate returns a buyer from the database by its id, also if it is soft-deleted, locking its row in the transaction of the context
+func (r *BuyerMysql) FindByIDForUpdate(ctx context.Context, id int) (buyer internal.Buyer, err error) {
+	// execute the query
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT `b`.`id`, `b`.`card_number_id`, `b`.`first_name`, `b`.`last_name`, `b`.`version` FROM `buyers` AS `b` WHERE `b`.`id` = ? FOR UPDATE", id)
+
+	// scan the row into the buyer
+	err = row.Scan(&buyer.ID, &buyer.CardNumberID, &buyer.FirstName, &buyer.LastName, &buyer.Version)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrBuyerRepositoryNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// FindByCardNumberID returns a buyer from the database by its card_number_id
+func (r *BuyerMysql) FindByCardNumberID(cardNumberID int) (buyer internal.Buyer, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `b`.`id`, `b`.`card_number_id`, `b`.`first_name`, `b`.`last_name`, `b`.`version` FROM `buyers` AS `b` WHERE `b`.`card_number_id` = ? AND `b`.`deleted_at` IS NULL", cardNumberID)
+
+	// scan the row into the buyer
+	err = row.Scan(&buyer.ID, &buyer.CardNumberID, &buyer.FirstName, &buyer.LastName, &buyer.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrBuyerRepositoryNotFound
@@ -68,9 +121,33 @@ func (r *BuyerMysql) FindByID(id int) (buyer internal.Buyer, err error) {
 }
 
 // Save saves the given buyer in the database
-func (r *BuyerMysql) Save(buyer *internal.Buyer) (err error) {
+func (r *BuyerMysql) Save(ctx context.Context, buyer *internal.Buyer) (err error) {
+	err = r.save(ctx, buyer)
+	return
+}
+
+// SaveAll saves the given buyers into the database in a single transaction
+// - if a buyer fails, none of them are saved and the error is returned as an internal.BulkError with its row
+func (r *BuyerMysql) SaveAll(ctx context.Context, buyers []*internal.Buyer) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// save the buyers
+		for i, buyer := range buyers {
+			err = r.save(ctx, buyer)
+			if err != nil {
+				err = internal.BulkError{{Row: i + 1, Err: err}}
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// save inserts a buyer, in the transaction of the context if there is one, and sets its id
+func (r *BuyerMysql) save(ctx context.Context, buyer *internal.Buyer) (err error) {
 	// execute the query
-	result, err := r.db.Exec(
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
 		"INSERT INTO `buyers` (`card_number_id`, `first_name`, `last_name`) VALUES (?, ?, ?)",
 		(*buyer).CardNumberID, (*buyer).FirstName, (*buyer).LastName,
 	)
@@ -97,16 +174,20 @@ func (r *BuyerMysql) Save(buyer *internal.Buyer) (err error) {
 
 	// set the id of the buyer
 	(*buyer).ID = int(id)
+	// a new buyer starts at its first version
+	(*buyer).Version = 1
 
 	return
 }
 
 // Update updates the given buyer in the database
-func (r *BuyerMysql) Update(buyer *internal.Buyer) (err error) {
+// - a soft-deleted buyer is not updated, internal.ErrBuyerRepositoryNotFound is returned
+func (r *BuyerMysql) Update(ctx context.Context, buyer *internal.Buyer) (err error) {
 	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `buyers` SET `card_number_id` = ?, `first_name` = ?, `last_name` = ? WHERE `id` = ?",
-		(*buyer).CardNumberID, (*buyer).FirstName, (*buyer).LastName, (*buyer).ID,
+	result, err := conn(ctx, r.db).ExecContext(
+		ctx,
+		"UPDATE `buyers` SET `card_number_id` = ?, `first_name` = ?, `last_name` = ?, `version` = `version` + 1 WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL",
+		(*buyer).CardNumberID, (*buyer).FirstName, (*buyer).LastName, (*buyer).ID, (*buyer).Version,
 	)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
@@ -119,16 +200,72 @@ func (r *BuyerMysql) Update(buyer *internal.Buyer) (err error) {
 			}
 			return
 		}
+
+		return
+	}
+
+	// check the version was the current one, of a buyer not deleted
+	err = checkUpdated(ctx, r.db, result, "buyers", (*buyer).ID, internal.ErrBuyerRepositoryNotFound)
+	if err != nil {
+		return
 	}
 
+	// the buyer is now at its next version
+	(*buyer).Version++
+
 	return
 }
 
-// Delete deletes a buyer from the database by its id
-func (r *BuyerMysql) Delete(id int) (err error) {
+// Delete soft-deletes the buyer with the given id, keeping its row for the entities that reference it
+// - a buyer that still has purchase orders is not deleted, internal.ErrBuyerHasPurchaseOrders is returned
+func (r *BuyerMysql) Delete(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the buyer, so no purchase order is saved for it while it is deleted
+		err = lock(ctx, r.db, "buyers", id, internal.ErrBuyerRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// check the buyer has no purchase orders
+		err = checkNone(ctx, r.db, "SELECT COUNT(`po`.`id`) FROM `purchase_orders` AS `po` WHERE `po`.`buyer_id` = ?", id, internal.ErrBuyerHasPurchaseOrders)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `buyers` SET `deleted_at` = NOW() WHERE `id` = ?", id)
+		return
+	})
+	return
+}
+
+// Restore restores the soft-deleted buyer with the given id
+// - internal.ErrBuyerCardNumberIDDuplicated is returned if a buyer saved since it was deleted has its card_number_id
+func (r *BuyerMysql) Restore(ctx context.Context, id int) (err error) {
 	// execute the query
-	_, err = r.db.Exec("DELETE FROM `buyers` WHERE `id` = ?", id)
+	result, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE `buyers` SET `deleted_at` = NULL WHERE `id` = ? AND `deleted_at` IS NOT NULL", id)
 	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) {
+			switch mysqlErr.Number {
+			case 1062:
+				err = internal.ErrBuyerCardNumberIDDuplicated
+			default:
+				// ...
+			}
+			return
+		}
+
+		return
+	}
+
+	// check the affected rows
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected == 0 {
+		err = internal.ErrBuyerRepositoryNotFound
 		return
 	}
 
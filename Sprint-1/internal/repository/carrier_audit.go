@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewCarrierAudit creates a new instance of the carrier audit decorator
+func NewCarrierAudit(rp internal.CarrierRepository, au internal.AuditRepository) *CarrierAudit {
+	return &CarrierAudit{
+		AuditSaveDecorator: NewAuditSaveDecorator[internal.Carrier](rp, au, "carriers", func(carrier internal.Carrier) int { return carrier.ID }),
+		rp:                 rp,
+	}
+}
+
+// CarrierAudit is the audit decorator of the carrier repository
+// - the carriers are only created, their creation is recorded by the embedded decorator and the reads go straight to rp
+type CarrierAudit struct {
+	*AuditSaveDecorator[internal.Carrier]
+	// rp is the decorated repository
+	rp internal.CarrierRepository
+}
+
+// FindAll returns all the carriers
+func (r *CarrierAudit) FindAll() ([]internal.Carrier, error) {
+	return r.rp.FindAll()
+}
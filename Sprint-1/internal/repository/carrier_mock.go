@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewCarrierMock creates a new instance of the carrier repository mock
+func NewCarrierMock() *CarrierMock {
+	return &CarrierMock{}
+}
+
+// CarrierMock is a mock of the carrier repository
+// - each method calls its Func field and counts the call in Spy
+type CarrierMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Carrier, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, carrier *internal.Carrier) error
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// Save is the number of times Save was called
+		Save int
+	}
+}
+
+// FindAll returns all the carriers
+func (r *CarrierMock) FindAll() ([]internal.Carrier, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// Save saves the given carrier
+func (r *CarrierMock) Save(ctx context.Context, carrier *internal.Carrier) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, carrier)
+}
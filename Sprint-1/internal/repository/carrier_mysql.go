@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NewCarrierMysql creates a new instance of the carrier repository
+func NewCarrierMysql(db *sql.DB) *CarrierMysql {
+	return &CarrierMysql{db}
+}
+
+// CarrierMysql is the mysql implementation of the carrier repository
+type CarrierMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns all carriers from the database
+func (r *CarrierMysql) FindAll() (carriers []internal.Carrier, err error) {
+	// execute the query
+	rows, err := r.db.Query("SELECT `c`.`id`, `c`.`cid`, `c`.`company_name`, `c`.`address`, `c`.`telephone`, `c`.`locality_id` FROM `carriers` AS `c`")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		// create a new carrier
+		var carrier internal.Carrier
+		err = rows.Scan(&carrier.ID, &carrier.CID, &carrier.CompanyName, &carrier.Address, &carrier.Telephone, &carrier.LocalityID)
+		if err != nil {
+			return
+		}
+
+		// append the carrier to the slice
+		carriers = append(carriers, carrier)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Save saves a carrier into the database
+func (r *CarrierMysql) Save(ctx context.Context, carrier *internal.Carrier) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the locality, so it is checked to exist while the carrier is saved
+		err = lockRow(ctx, r.db, "localities", (*carrier).LocalityID, internal.ErrCarrierRepositoryLocalityNotFound)
+		if err != nil {
+			return
+		}
+
+		// insert the carrier
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `carriers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES (?, ?, ?, ?, ?)",
+			(*carrier).CID, (*carrier).CompanyName, (*carrier).Address, (*carrier).Telephone, (*carrier).LocalityID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrCarrierRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the id of the inserted carrier
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the carrier
+		(*carrier).ID = int(id)
+
+		return
+	})
+	return
+}
@@ -0,0 +1,932 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/repository"
+
+	"github.com/DATA-DOG/go-txdb"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// schemaPath is the DDL the repositories must conform to
+	schemaPath = "../../docs/db/melisprint_db.sql"
+	// schemaDBName is the database name used in the DDL
+	schemaDBName = "melisprint"
+	// testDBName is the database where the DDL is booted, so the development database is left untouched
+	testDBName = "melisprint_conformance"
+)
+
+// skipReason is set when the schema could not be booted, e.g. there is no local mysql server
+var skipReason string
+
+// TestMain boots the schema from docs/db into a local mysql server and registers txdb against it
+func TestMain(m *testing.M) {
+	// db connection
+	cfg := mysql.Config{
+		User:            "root",
+		Passwd:          os.Getenv("MYSQL_ROOT_PASSWORD"),
+		Net:             "tcp",
+		Addr:            "127.0.0.1:3306",
+		ParseTime:       true,
+		MultiStatements: true,
+	}
+	if addr := os.Getenv("MYSQL_ADDR"); addr != "" {
+		cfg.Addr = addr
+	}
+
+	// boot the schema
+	if err := bootSchema(cfg); err != nil {
+		skipReason = fmt.Sprintf("schema could not be booted: %s", err)
+	} else {
+		// register txdb
+		cfg.DBName = testDBName
+		cfg.MultiStatements = false
+		txdb.Register("txdb", "mysql", cfg.FormatDSN())
+	}
+
+	os.Exit(m.Run())
+}
+
+// bootSchema recreates the test database from the DDL in docs/db
+func bootSchema(cfg mysql.Config) (err error) {
+	ddl, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return
+	}
+
+	db, err := sql.Open("mysql", cfg.FormatDSN())
+	if err != nil {
+		return
+	}
+	defer db.Close()
+	err = db.Ping()
+	if err != nil {
+		return
+	}
+
+	_, err = db.Exec(strings.ReplaceAll(string(ddl), "`"+schemaDBName+"`", "`"+testDBName+"`"))
+	return
+}
+
+// openTxdb opens a connection whose changes are rolled back when it is closed
+func openTxdb(t *testing.T) *sql.DB {
+	t.Helper()
+	if skipReason != "" {
+		t.Skip(skipReason)
+	}
+
+	db, err := sql.Open("txdb", t.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// saveWarehouse saves a warehouse for the entities that reference one and returns its id
+func saveWarehouse(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	warehouse := internal.Warehouse{WarehouseCode: "WH99", Address: "900 Parent Rd", Telephone: "999-999-9999", MinimumCapacity: 10, MinimumTemperature: -30}
+	require.NoError(t, repository.NewWarehouseMysql(db).Save(context.Background(), &warehouse))
+	return warehouse.ID
+}
+
+// saveSeller saves a seller for the products that reference one and returns its id
+func saveSeller(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	seller := internal.Seller{CID: 99, CompanyName: "Parent Company", Address: "900 Parent St", Telephone: "999-999-9999"}
+	require.NoError(t, repository.NewSellerMysql(db).Save(context.Background(), &seller))
+	return seller.ID
+}
+
+// conformance exercises every method of a repository against the booted schema
+// - entity is saved, then replaced by update(entity) and finally soft-deleted and restored
+// - updating from the version saved once it was replaced must fail, so concurrent updates are not lost
+// - updating it once soft-deleted must fail as not found, whatever its version
+func conformance[T any](t *testing.T, rp repository.AuditedRepository[T], entity T, update func(T) T, id func(T) int) {
+	t.Helper()
+
+	// save
+	err := rp.Save(context.Background(), &entity)
+	require.NoError(t, err)
+	require.NotZero(t, id(entity))
+
+	// find by id
+	found, err := rp.FindByID(id(entity))
+	require.NoError(t, err)
+	require.Equal(t, entity, found)
+
+	// find all
+	all, err := rp.FindAll()
+	require.NoError(t, err)
+	require.Contains(t, all, entity)
+
+	// update
+	updated := update(entity)
+	err = rp.Update(context.Background(), &updated)
+	require.NoError(t, err)
+	found, err = rp.FindByID(id(entity))
+	require.NoError(t, err)
+	require.Equal(t, updated, found)
+
+	// update from a stale version
+	stale := update(entity)
+	err = rp.Update(context.Background(), &stale)
+	require.ErrorIs(t, err, internal.ErrVersionConflict)
+
+	// delete
+	err = rp.Delete(context.Background(), id(entity))
+	require.NoError(t, err)
+	_, err = rp.FindByID(id(entity))
+	require.Error(t, err)
+	all, err = rp.FindAll()
+	require.NoError(t, err)
+	require.NotContains(t, all, updated)
+	all, err = rp.FindAllWithDeleted()
+	require.NoError(t, err)
+	require.Contains(t, all, updated)
+	err = rp.Delete(context.Background(), id(entity))
+	require.Error(t, err)
+	found, err = rp.FindByIDForUpdate(context.Background(), id(entity))
+	require.NoError(t, err)
+	require.Equal(t, updated, found)
+
+	// update once deleted, at its current version it is not found rather than in conflict
+	deleted := update(found)
+	err = rp.Update(context.Background(), &deleted)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, internal.ErrVersionConflict)
+	found, err = rp.FindByIDForUpdate(context.Background(), id(entity))
+	require.NoError(t, err)
+	require.Equal(t, updated, found)
+
+	// restore
+	err = rp.Restore(context.Background(), id(entity))
+	require.NoError(t, err)
+	found, err = rp.FindByID(id(entity))
+	require.NoError(t, err)
+	require.Equal(t, updated, found)
+	err = rp.Restore(context.Background(), id(entity))
+	require.Error(t, err)
+}
+
+// TestSellerMysql_Conformance tests the seller repository against the schema
+func TestSellerMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewSellerMysql(db)
+
+	conformance[internal.Seller](t, rp,
+		internal.Seller{CID: 1, CompanyName: "Company A", Address: "123 Main St", Telephone: "123-456-7890"},
+		func(s internal.Seller) internal.Seller { s.CompanyName = "Company B"; return s },
+		func(s internal.Seller) int { return s.ID },
+	)
+}
+
+// TestWarehouseMysql_Conformance tests the warehouse repository against the schema
+func TestWarehouseMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewWarehouseMysql(db)
+
+	conformance[internal.Warehouse](t, rp,
+		internal.Warehouse{WarehouseCode: "WH01", Address: "200 Warehouse Rd", Telephone: "234-567-8901", MinimumCapacity: 100, MinimumTemperature: -5},
+		func(w internal.Warehouse) internal.Warehouse { w.MinimumCapacity = 150; return w },
+		func(w internal.Warehouse) int { return w.ID },
+	)
+}
+
+// TestSectionMysql_Conformance tests the section repository against the schema
+func TestSectionMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewSectionMysql(db)
+	warehouseID := saveWarehouse(t, db)
+
+	conformance[internal.Section](t, rp,
+		internal.Section{SectionNumber: 1, CurrentTemperature: 0, MinimumTemperature: -5, CurrentCapacity: 50, MinimumCapacity: 20, MaximumCapacity: 100, WarehouseID: warehouseID, ProductTypeID: 1},
+		func(s internal.Section) internal.Section { s.MaximumCapacity = 120; return s },
+		func(s internal.Section) int { return s.ID },
+	)
+}
+
+// TestProductMysql_Conformance tests the product repository against the schema
+func TestProductMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewProductMysql(db)
+	sellerID := saveSeller(t, db)
+
+	conformance[internal.Product](t, rp,
+		internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID},
+		func(p internal.Product) internal.Product { p.Length = 25; p.RecomFreezTemp = -20; return p },
+		func(p internal.Product) int { return p.ID },
+	)
+}
+
+// TestEmployeeMysql_Conformance tests the employee repository against the schema
+func TestEmployeeMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewEmployeeMysql(db)
+	warehouseID := saveWarehouse(t, db)
+
+	conformance[internal.Employee](t, rp,
+		internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouseID},
+		func(e internal.Employee) internal.Employee { e.LastName = "Smith"; return e },
+		func(e internal.Employee) int { return e.ID },
+	)
+}
+
+// TestBuyerMysql_Conformance tests the buyer repository against the schema
+func TestBuyerMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewBuyerMysql(db)
+
+	conformance[internal.Buyer](t, rp,
+		internal.Buyer{CardNumberID: 2001, FirstName: "Jane", LastName: "Doe"},
+		func(b internal.Buyer) internal.Buyer { b.FirstName = "Janet"; return b },
+		func(b internal.Buyer) int { return b.ID },
+	)
+}
+
+// TestBusinessKeys_Conformance tests the business key lookups and unique keys of the repositories against the schema
+func TestBusinessKeys_Conformance(t *testing.T) {
+	t.Run("seller cid", func(t *testing.T) {
+		rp := repository.NewSellerMysql(openTxdb(t))
+		seller := internal.Seller{CID: 1, CompanyName: "Company A", Address: "123 Main St", Telephone: "123-456-7890"}
+		require.NoError(t, rp.Save(context.Background(), &seller))
+
+		found, err := rp.FindByCID(seller.CID)
+		require.NoError(t, err)
+		require.Equal(t, seller, found)
+		_, err = rp.FindByCID(seller.CID + 1)
+		require.ErrorIs(t, err, internal.ErrSellerRepositoryNotFound)
+		duplicated := seller
+		require.ErrorIs(t, rp.Save(context.Background(), &duplicated), internal.ErrSellerRepositoryDuplicated)
+
+		// the key of a soft-deleted seller is used again, so the seller is not restored
+		require.NoError(t, rp.Delete(context.Background(), seller.ID))
+		reused := seller
+		require.NoError(t, rp.Save(context.Background(), &reused))
+		require.ErrorIs(t, rp.Restore(context.Background(), seller.ID), internal.ErrSellerCIDDuplicated)
+	})
+
+	t.Run("warehouse code", func(t *testing.T) {
+		rp := repository.NewWarehouseMysql(openTxdb(t))
+		warehouse := internal.Warehouse{WarehouseCode: "WH01", Address: "200 Warehouse Rd", Telephone: "234-567-8901", MinimumCapacity: 100, MinimumTemperature: -5}
+		require.NoError(t, rp.Save(context.Background(), &warehouse))
+
+		found, err := rp.FindByCode(warehouse.WarehouseCode)
+		require.NoError(t, err)
+		require.Equal(t, warehouse, found)
+		_, err = rp.FindByCode("WH02")
+		require.ErrorIs(t, err, internal.ErrWarehouseRepositoryNotFound)
+		duplicated := warehouse
+		require.ErrorIs(t, rp.Save(context.Background(), &duplicated), internal.ErrWarehouseRepositoryDuplicated)
+
+		// the key of a soft-deleted warehouse is used again, so the warehouse is not restored
+		require.NoError(t, rp.Delete(context.Background(), warehouse.ID))
+		reused := warehouse
+		require.NoError(t, rp.Save(context.Background(), &reused))
+		require.ErrorIs(t, rp.Restore(context.Background(), warehouse.ID), internal.ErrWarehouseCodeDuplicated)
+	})
+
+	t.Run("product code", func(t *testing.T) {
+		db := openTxdb(t)
+		rp := repository.NewProductMysql(db)
+		sellerID := saveSeller(t, db)
+		product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+		require.NoError(t, rp.Save(context.Background(), &product))
+
+		found, err := rp.FindByCode(product.ProductCode)
+		require.NoError(t, err)
+		require.Equal(t, product, found)
+		_, err = rp.FindByCode("P002")
+		require.ErrorIs(t, err, internal.ErrProductRepositoryNotFound)
+		duplicated := product
+		require.ErrorIs(t, rp.Save(context.Background(), &duplicated), internal.ErrProductRepositoryDuplicated)
+
+		// the key of a soft-deleted product is used again, so the product is not restored
+		require.NoError(t, rp.Delete(context.Background(), product.ID))
+		reused := product
+		require.NoError(t, rp.Save(context.Background(), &reused))
+		require.ErrorIs(t, rp.Restore(context.Background(), product.ID), internal.ErrProductCodeDuplicated)
+	})
+
+	t.Run("employee card number id", func(t *testing.T) {
+		db := openTxdb(t)
+		rp := repository.NewEmployeeMysql(db)
+		warehouseID := saveWarehouse(t, db)
+		employee := internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouseID}
+		require.NoError(t, rp.Save(context.Background(), &employee))
+
+		found, err := rp.FindByCardNumberID(employee.CardNumberID)
+		require.NoError(t, err)
+		require.Equal(t, employee, found)
+		_, err = rp.FindByCardNumberID(employee.CardNumberID + 1)
+		require.ErrorIs(t, err, internal.ErrEmployeeRepositoryNotFound)
+		duplicated := employee
+		require.ErrorIs(t, rp.Save(context.Background(), &duplicated), internal.ErrEmployeeRepositoryDuplicated)
+
+		// the key of a soft-deleted employee is used again, so the employee is not restored
+		require.NoError(t, rp.Delete(context.Background(), employee.ID))
+		reused := employee
+		require.NoError(t, rp.Save(context.Background(), &reused))
+		require.ErrorIs(t, rp.Restore(context.Background(), employee.ID), internal.ErrEmployeeCardNumberIDDuplicated)
+	})
+
+	t.Run("buyer card number id", func(t *testing.T) {
+		rp := repository.NewBuyerMysql(openTxdb(t))
+		buyer := internal.Buyer{CardNumberID: 2001, FirstName: "Jane", LastName: "Doe"}
+		require.NoError(t, rp.Save(context.Background(), &buyer))
+
+		found, err := rp.FindByCardNumberID(buyer.CardNumberID)
+		require.NoError(t, err)
+		require.Equal(t, buyer, found)
+		_, err = rp.FindByCardNumberID(buyer.CardNumberID + 1)
+		require.ErrorIs(t, err, internal.ErrBuyerRepositoryNotFound)
+		duplicated := buyer
+		require.ErrorIs(t, rp.Save(context.Background(), &duplicated), internal.ErrBuyerRepositoryDuplicated)
+
+		// the key of a soft-deleted buyer is used again, so the buyer is not restored
+		require.NoError(t, rp.Delete(context.Background(), buyer.ID))
+		reused := buyer
+		require.NoError(t, rp.Save(context.Background(), &reused))
+		require.ErrorIs(t, rp.Restore(context.Background(), buyer.ID), internal.ErrBuyerCardNumberIDDuplicated)
+	})
+}
+
+// TestBuyerMysql_SaveAll tests the bulk import of the buyer repository against the schema
+func TestBuyerMysql_SaveAll(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewBuyerMysql(db)
+
+	// save all
+	buyers := []*internal.Buyer{
+		{CardNumberID: 2001, FirstName: "Jane", LastName: "Doe"},
+		{CardNumberID: 2002, FirstName: "John", LastName: "Doe"},
+	}
+	err := rp.SaveAll(context.Background(), buyers)
+	require.NoError(t, err)
+	require.NotZero(t, buyers[0].ID)
+	require.NotZero(t, buyers[1].ID)
+
+	// a duplicated row rolls back the whole import
+	buyers = []*internal.Buyer{
+		{CardNumberID: 2003, FirstName: "Janet", LastName: "Doe"},
+		{CardNumberID: 2001, FirstName: "Jane", LastName: "Doe"},
+	}
+	err = rp.SaveAll(context.Background(), buyers)
+	require.ErrorIs(t, err, internal.ErrBuyerRepositoryDuplicated)
+	var bulkErr internal.BulkError
+	require.ErrorAs(t, err, &bulkErr)
+	require.Equal(t, 2, bulkErr[0].Row)
+	_, err = rp.FindByID(buyers[0].ID)
+	require.ErrorIs(t, err, internal.ErrBuyerRepositoryNotFound)
+}
+
+// TestAuditMysql_Conformance tests the audit repository against the schema
+func TestAuditMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewAuditMysql(db)
+
+	// save
+	audit := internal.Audit{Entity: "sellers", EntityID: 1, Operation: internal.AuditOperationCreate, After: []byte(`{"ID": 1}`), RequestID: "req-1", CreatedAt: time.Now()}
+	err := rp.Save(context.Background(), &audit)
+	require.NoError(t, err)
+	require.NotZero(t, audit.ID)
+
+	// find by entity
+	audits, err := rp.FindByEntity("sellers", 1)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	require.Equal(t, audit.ID, audits[0].ID)
+	require.Nil(t, audits[0].Before)
+	require.JSONEq(t, string(audit.After), string(audits[0].After))
+}
+
+// TestReferences_Conformance tests the references between the entities are kept by the repositories against the schema
+func TestReferences_Conformance(t *testing.T) {
+	t.Run("a seller with products is not deleted", func(t *testing.T) {
+		db := openTxdb(t)
+		rp := repository.NewSellerMysql(db)
+		rpProduct := repository.NewProductMysql(db)
+		sellerID := saveSeller(t, db)
+		product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+		require.NoError(t, rpProduct.Save(context.Background(), &product))
+
+		err := rp.Delete(context.Background(), sellerID)
+		require.ErrorIs(t, err, internal.ErrSellerHasProducts)
+		require.NoError(t, rpProduct.Delete(context.Background(), product.ID))
+		require.NoError(t, rp.Delete(context.Background(), sellerID))
+	})
+
+	t.Run("a warehouse with sections or employees is not deleted", func(t *testing.T) {
+		db := openTxdb(t)
+		rp := repository.NewWarehouseMysql(db)
+		rpSection := repository.NewSectionMysql(db)
+		rpEmployee := repository.NewEmployeeMysql(db)
+		warehouseID := saveWarehouse(t, db)
+		section := internal.Section{SectionNumber: 1, CurrentTemperature: 0, MinimumTemperature: -5, CurrentCapacity: 50, MinimumCapacity: 20, MaximumCapacity: 100, WarehouseID: warehouseID, ProductTypeID: 1}
+		require.NoError(t, rpSection.Save(context.Background(), &section))
+		employee := internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouseID}
+		require.NoError(t, rpEmployee.Save(context.Background(), &employee))
+
+		err := rp.Delete(context.Background(), warehouseID)
+		require.ErrorIs(t, err, internal.ErrWarehouseHasSections)
+		require.NoError(t, rpSection.Delete(context.Background(), section.ID))
+		err = rp.Delete(context.Background(), warehouseID)
+		require.ErrorIs(t, err, internal.ErrWarehouseHasEmployees)
+		require.NoError(t, rpEmployee.Delete(context.Background(), employee.ID))
+		require.NoError(t, rp.Delete(context.Background(), warehouseID))
+	})
+
+	t.Run("nothing is saved for a deleted entity", func(t *testing.T) {
+		db := openTxdb(t)
+		warehouseID := saveWarehouse(t, db)
+		sellerID := saveSeller(t, db)
+		require.NoError(t, repository.NewWarehouseMysql(db).Delete(context.Background(), warehouseID))
+		require.NoError(t, repository.NewSellerMysql(db).Delete(context.Background(), sellerID))
+
+		section := internal.Section{SectionNumber: 1, CurrentTemperature: 0, MinimumTemperature: -5, CurrentCapacity: 50, MinimumCapacity: 20, MaximumCapacity: 100, WarehouseID: warehouseID, ProductTypeID: 1}
+		err := repository.NewSectionMysql(db).Save(context.Background(), &section)
+		require.ErrorIs(t, err, internal.ErrSectionWarehouseNotFound)
+		employee := internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouseID}
+		err = repository.NewEmployeeMysql(db).Save(context.Background(), &employee)
+		require.ErrorIs(t, err, internal.ErrEmployeeWarehouseNotFound)
+		product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+		err = repository.NewProductMysql(db).Save(context.Background(), &product)
+		require.ErrorIs(t, err, internal.ErrProductSellerNotFound)
+	})
+
+	t.Run("an entity is not restored while the one it references is deleted", func(t *testing.T) {
+		db := openTxdb(t)
+		rpWarehouse := repository.NewWarehouseMysql(db)
+		rpSeller := repository.NewSellerMysql(db)
+		rpSection := repository.NewSectionMysql(db)
+		rpEmployee := repository.NewEmployeeMysql(db)
+		rpProduct := repository.NewProductMysql(db)
+		warehouseID := saveWarehouse(t, db)
+		sellerID := saveSeller(t, db)
+		section := internal.Section{SectionNumber: 1, CurrentTemperature: 0, MinimumTemperature: -5, CurrentCapacity: 50, MinimumCapacity: 20, MaximumCapacity: 100, WarehouseID: warehouseID, ProductTypeID: 1}
+		require.NoError(t, rpSection.Save(context.Background(), &section))
+		employee := internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouseID}
+		require.NoError(t, rpEmployee.Save(context.Background(), &employee))
+		product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+		require.NoError(t, rpProduct.Save(context.Background(), &product))
+		require.NoError(t, rpSection.Delete(context.Background(), section.ID))
+		require.NoError(t, rpEmployee.Delete(context.Background(), employee.ID))
+		require.NoError(t, rpProduct.Delete(context.Background(), product.ID))
+		require.NoError(t, rpWarehouse.Delete(context.Background(), warehouseID))
+		require.NoError(t, rpSeller.Delete(context.Background(), sellerID))
+
+		err := rpSection.Restore(context.Background(), section.ID)
+		require.ErrorIs(t, err, internal.ErrSectionWarehouseNotFound)
+		err = rpEmployee.Restore(context.Background(), employee.ID)
+		require.ErrorIs(t, err, internal.ErrEmployeeWarehouseNotFound)
+		err = rpProduct.Restore(context.Background(), product.ID)
+		require.ErrorIs(t, err, internal.ErrProductSellerNotFound)
+
+		require.NoError(t, rpWarehouse.Restore(context.Background(), warehouseID))
+		require.NoError(t, rpSeller.Restore(context.Background(), sellerID))
+		require.NoError(t, rpSection.Restore(context.Background(), section.ID))
+		require.NoError(t, rpEmployee.Restore(context.Background(), employee.ID))
+		require.NoError(t, rpProduct.Restore(context.Background(), product.ID))
+	})
+}
+
+// auditFailing is an audit repository whose entries can not be saved
+type auditFailing struct {
+	*repository.AuditMysql
+}
+
+// Save returns an error without saving the entry
+func (r auditFailing) Save(ctx context.Context, audit *internal.Audit) error {
+	return errors.New("audit log unavailable")
+}
+
+// TestAuditDecorator_Conformance tests the audit entries are saved in the same transaction as the changes they record
+func TestAuditDecorator_Conformance(t *testing.T) {
+	t.Run("the change and its entry are saved with the request id of the context", func(t *testing.T) {
+		db := openTxdb(t)
+		rpAudit := repository.NewAuditMysql(db)
+		rp := repository.NewSellerAudit(repository.NewSellerMysql(db), rpAudit)
+
+		seller := internal.Seller{CID: 1, CompanyName: "Company A", Address: "123 Main St", Telephone: "123-456-7890"}
+		err := rp.Save(internal.ContextWithRequestID(context.Background(), "req-1"), &seller)
+		require.NoError(t, err)
+
+		audits, err := rpAudit.FindByEntity("sellers", seller.ID)
+		require.NoError(t, err)
+		require.Len(t, audits, 1)
+		require.Equal(t, internal.AuditOperationCreate, audits[0].Operation)
+		require.Equal(t, "req-1", audits[0].RequestID)
+	})
+
+	t.Run("the change is rolled back when its entry can not be saved", func(t *testing.T) {
+		db := openTxdb(t)
+		rpSeller := repository.NewSellerMysql(db)
+		rp := repository.NewSellerAudit(rpSeller, auditFailing{repository.NewAuditMysql(db)})
+
+		seller := internal.Seller{CID: 1, CompanyName: "Company A", Address: "123 Main St", Telephone: "123-456-7890"}
+		err := rp.Save(context.Background(), &seller)
+		require.Error(t, err)
+		sellers, err := rpSeller.FindAllWithDeleted()
+		require.NoError(t, err)
+		require.Empty(t, sellers)
+
+		saved := internal.Seller{CID: 2, CompanyName: "Company B", Address: "456 Main St", Telephone: "123-456-7890"}
+		require.NoError(t, rpSeller.Save(context.Background(), &saved))
+		err = rp.Delete(context.Background(), saved.ID)
+		require.Error(t, err)
+		_, err = rpSeller.FindByID(saved.ID)
+		require.NoError(t, err)
+	})
+}
+
+// TestProductBatchMysql_Conformance tests the product batch repository against the schema
+func TestProductBatchMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewProductBatchMysql(db)
+	rpSection := repository.NewSectionMysql(db)
+	rpProduct := repository.NewProductMysql(db)
+
+	// set-up
+	warehouseID := saveWarehouse(t, db)
+	sellerID := saveSeller(t, db)
+	section := internal.Section{SectionNumber: 1, CurrentTemperature: -10, MinimumTemperature: -20, CurrentCapacity: 0, MinimumCapacity: 0, MaximumCapacity: 100, WarehouseID: warehouseID, ProductTypeID: 1}
+	require.NoError(t, rpSection.Save(context.Background(), &section))
+	product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+	require.NoError(t, rpProduct.Save(context.Background(), &product))
+	manufacturingDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// save
+	productBatch := internal.ProductBatch{BatchNumber: 1, CurrentQuantity: 60, InitialQuantity: 60, CurrentTemperature: -10, MinimumTemperature: -15, ManufacturingDate: manufacturingDate, ManufacturingHour: 8, DueDate: manufacturingDate.AddDate(0, 6, 0), ProductID: product.ID, SectionID: section.ID}
+	err := rp.Save(context.Background(), &productBatch)
+	require.NoError(t, err)
+	require.NotZero(t, productBatch.ID)
+
+	// find by id
+	found, err := rp.FindByID(productBatch.ID)
+	require.NoError(t, err)
+	require.Equal(t, productBatch, found)
+
+	// the section capacity is updated, as a new version of the section
+	version := section.Version
+	section, err = rpSection.FindByID(section.ID)
+	require.NoError(t, err)
+	require.Equal(t, 60, section.CurrentCapacity)
+	require.Equal(t, version+1, section.Version)
+
+	// the section capacity can not be exceeded
+	exceeding := productBatch
+	exceeding.BatchNumber = 2
+	err = rp.Save(context.Background(), &exceeding)
+	require.ErrorIs(t, err, internal.ErrProductBatchRepositoryCapacityExceeded)
+
+	// report products
+	report, err := rp.ReportProducts(section.ID)
+	require.NoError(t, err)
+	require.Equal(t, internal.SectionProductsReport{SectionID: section.ID, SectionNumber: 1, ProductsCount: 60}, report)
+
+	// find expiring: the batch is long past its due date
+	expiring, err := rp.FindExpiring(0)
+	require.NoError(t, err)
+	require.Equal(t, []internal.ProductBatch{productBatch}, expiring)
+
+	// the section and the product are not deleted while they have the batch
+	err = rpSection.Delete(context.Background(), section.ID)
+	require.ErrorIs(t, err, internal.ErrSectionHasProductBatches)
+	err = rpProduct.Delete(context.Background(), product.ID)
+	require.ErrorIs(t, err, internal.ErrProductHasProductBatches)
+}
+
+// TestProductBatchAudit_Conformance tests the creation of a product batch and the capacity change of its section are both recorded
+func TestProductBatchAudit_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rpAudit := repository.NewAuditMysql(db)
+	rpSection := repository.NewSectionMysql(db)
+	rp := repository.NewProductBatchAudit(repository.NewProductBatchMysql(db), rpSection, rpAudit)
+
+	// set-up
+	warehouseID := saveWarehouse(t, db)
+	sellerID := saveSeller(t, db)
+	section := internal.Section{SectionNumber: 1, CurrentTemperature: -10, MinimumTemperature: -20, CurrentCapacity: 0, MinimumCapacity: 0, MaximumCapacity: 100, WarehouseID: warehouseID, ProductTypeID: 1}
+	require.NoError(t, rpSection.Save(context.Background(), &section))
+	product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+	require.NoError(t, repository.NewProductMysql(db).Save(context.Background(), &product))
+	manufacturingDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// save
+	productBatch := internal.ProductBatch{BatchNumber: 1, CurrentQuantity: 60, InitialQuantity: 60, CurrentTemperature: -10, MinimumTemperature: -15, ManufacturingDate: manufacturingDate, ManufacturingHour: 8, DueDate: manufacturingDate.AddDate(0, 6, 0), ProductID: product.ID, SectionID: section.ID}
+	err := rp.Save(internal.ContextWithRequestID(context.Background(), "req-1"), &productBatch)
+	require.NoError(t, err)
+
+	// the batch is recorded as created
+	audits, err := rpAudit.FindByEntity("product_batches", productBatch.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	require.Equal(t, internal.AuditOperationCreate, audits[0].Operation)
+
+	// the section is recorded as updated, with its capacity and version
+	audits, err = rpAudit.FindByEntity("sections", section.ID)
+	require.NoError(t, err)
+	require.Len(t, audits, 1)
+	require.Equal(t, internal.AuditOperationUpdate, audits[0].Operation)
+	require.Equal(t, "req-1", audits[0].RequestID)
+	require.JSONEq(t, fmt.Sprintf(`{"CurrentCapacity":0,"Version":%d}`, section.Version), string(audits[0].Before))
+	require.JSONEq(t, fmt.Sprintf(`{"CurrentCapacity":60,"Version":%d}`, section.Version+1), string(audits[0].After))
+
+	// a section that does not exist records nothing
+	missing := productBatch
+	missing.BatchNumber = 2
+	missing.SectionID = section.ID + 1
+	err = rp.Save(context.Background(), &missing)
+	require.ErrorIs(t, err, internal.ErrProductBatchRepositorySectionNotFound)
+}
+
+// TestProductTypeMysql_Conformance tests the product type repository against the schema
+func TestProductTypeMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewProductTypeMysql(db)
+
+	// set-up
+	result, err := db.Exec("INSERT INTO `product_types` (`description`) VALUES (?)", "Frozen")
+	require.NoError(t, err)
+	id, err := result.LastInsertId()
+	require.NoError(t, err)
+
+	// find by id
+	found, err := rp.FindByID(int(id))
+	require.NoError(t, err)
+	require.Equal(t, internal.ProductType{ID: int(id), Description: "Frozen"}, found)
+	_, err = rp.FindByID(int(id) + 1)
+	require.ErrorIs(t, err, internal.ErrProductTypeRepositoryNotFound)
+}
+
+// TestProductRecordMysql_Conformance tests the product record repository against the schema
+func TestProductRecordMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewProductRecordMysql(db)
+
+	// set-up
+	sellerID := saveSeller(t, db)
+	product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+	require.NoError(t, repository.NewProductMysql(db).Save(context.Background(), &product))
+
+	// save
+	productRecord := internal.ProductRecord{LastUpdateDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), PurchasePrice: 10.5, SalePrice: 15.25, ProductID: product.ID}
+	err := rp.Save(context.Background(), &productRecord)
+	require.NoError(t, err)
+	require.NotZero(t, productRecord.ID)
+
+	// the product must exist
+	orphan := productRecord
+	orphan.ProductID = product.ID + 1
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrProductRecordRepositoryProductNotFound)
+
+	// report records
+	reports, err := rp.ReportRecords(product.ID)
+	require.NoError(t, err)
+	require.Equal(t, []internal.ProductRecordsReport{{ProductID: product.ID, Description: "Frozen peas", RecordsCount: 1}}, reports)
+
+	// report records of a product that does not exist
+	_, err = rp.ReportRecords(product.ID + 1)
+	require.ErrorIs(t, err, internal.ErrProductRepositoryNotFound)
+
+	// the product is not deleted while it has the record
+	err = repository.NewProductMysql(db).Delete(context.Background(), product.ID)
+	require.ErrorIs(t, err, internal.ErrProductHasProductRecords)
+
+	// nothing is saved for a soft-deleted product
+	deleted := internal.Product{ProductCode: "P002", Description: "Ice cream", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+	require.NoError(t, repository.NewProductMysql(db).Save(context.Background(), &deleted))
+	require.NoError(t, repository.NewProductMysql(db).Delete(context.Background(), deleted.ID))
+	orphan.ProductID = deleted.ID
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrProductRecordRepositoryProductNotFound)
+}
+
+// TestWarehouseReport_Conformance tests the warehouse aggregates of the section and employee repositories against the schema
+func TestWarehouseReport_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rpWarehouse := repository.NewWarehouseMysql(db)
+	rpSection := repository.NewSectionMysql(db)
+	rpEmployee := repository.NewEmployeeMysql(db)
+
+	// set-up
+	warehouse := internal.Warehouse{WarehouseCode: "WH01", Address: "200 Warehouse Rd", Telephone: "234-567-8901", MinimumCapacity: 100, MinimumTemperature: -5}
+	require.NoError(t, rpWarehouse.Save(context.Background(), &warehouse))
+	sections := []internal.Section{
+		{SectionNumber: 1, CurrentTemperature: 0, MinimumTemperature: -5, CurrentCapacity: 50, MinimumCapacity: 20, MaximumCapacity: 100, WarehouseID: warehouse.ID, ProductTypeID: 1},
+		{SectionNumber: 2, CurrentTemperature: -10, MinimumTemperature: -15, CurrentCapacity: 10, MinimumCapacity: 0, MaximumCapacity: 80, WarehouseID: warehouse.ID, ProductTypeID: 1},
+	}
+	for i := range sections {
+		require.NoError(t, rpSection.Save(context.Background(), &sections[i]))
+	}
+	employee := internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouse.ID}
+	require.NoError(t, rpEmployee.Save(context.Background(), &employee))
+
+	// report by warehouse
+	report, err := rpSection.ReportByWarehouse(warehouse.ID)
+	require.NoError(t, err)
+	require.Equal(t, internal.SectionWarehouseReport{SectionsCount: 2, TotalCapacity: 180, UsedCapacity: 60, SectionsBelowMinimumTemperature: 1}, report)
+
+	// count by warehouse
+	count, err := rpEmployee.CountByWarehouse(warehouse.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
+
+// TestPurchaseOrderMysql_Conformance tests the purchase order repository against the schema
+func TestPurchaseOrderMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewPurchaseOrderMysql(db)
+	rpBuyer := repository.NewBuyerMysql(db)
+
+	// set-up
+	buyer := internal.Buyer{CardNumberID: 2001, FirstName: "Jane", LastName: "Doe"}
+	require.NoError(t, rpBuyer.Save(context.Background(), &buyer))
+	locality := internal.Locality{LocalityName: "Palermo", ProvinceName: "Buenos Aires", CountryName: "Argentina"}
+	require.NoError(t, repository.NewLocalityMysql(db).Save(context.Background(), &locality))
+	carrier := internal.Carrier{CID: "CAR01", CompanyName: "Carrier A", Address: "300 Route Ave", Telephone: "345-678-9012", LocalityID: locality.ID}
+	require.NoError(t, repository.NewCarrierMysql(db).Save(context.Background(), &carrier))
+	sellerID := saveSeller(t, db)
+	product := internal.Product{ProductCode: "P001", Description: "Frozen peas", Height: 10, Length: 20, Width: 5, Weight: 1.5, ExpirationRate: 0.5, FreezingRate: 0.25, RecomFreezTemp: -18, ProductTypeID: 1, SellerID: sellerID}
+	require.NoError(t, repository.NewProductMysql(db).Save(context.Background(), &product))
+	productRecord := internal.ProductRecord{LastUpdateDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), PurchasePrice: 10.5, SalePrice: 15.25, ProductID: product.ID}
+	require.NoError(t, repository.NewProductRecordMysql(db).Save(context.Background(), &productRecord))
+
+	// save
+	purchaseOrder := internal.PurchaseOrder{OrderNumber: "PO-0001", OrderDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), TrackingCode: "TRK-0001", BuyerID: buyer.ID, ProductRecordID: productRecord.ID, CarrierID: carrier.ID, Status: internal.PurchaseOrderStatusPending}
+	err := rp.Save(context.Background(), &purchaseOrder)
+	require.NoError(t, err)
+	require.NotZero(t, purchaseOrder.ID)
+
+	// find by id
+	found, err := rp.FindByID(purchaseOrder.ID)
+	require.NoError(t, err)
+	require.Equal(t, purchaseOrder, found)
+
+	// the order number is unique
+	duplicated := purchaseOrder
+	err = rp.Save(context.Background(), &duplicated)
+	require.ErrorIs(t, err, internal.ErrPurchaseOrderRepositoryDuplicated)
+
+	// the buyer must exist
+	orphan := purchaseOrder
+	orphan.OrderNumber, orphan.BuyerID = "PO-0002", buyer.ID+1
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrPurchaseOrderRepositoryBuyerNotFound)
+
+	// the carrier must exist
+	orphan = purchaseOrder
+	orphan.OrderNumber, orphan.CarrierID = "PO-0003", carrier.ID+1
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrPurchaseOrderRepositoryCarrierNotFound)
+
+	// the product record must exist
+	orphan = purchaseOrder
+	orphan.OrderNumber, orphan.ProductRecordID = "PO-0004", productRecord.ID+1
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrPurchaseOrderRepositoryProductRecordNotFound)
+
+	// report by buyer
+	report, err := rp.ReportByBuyer(buyer.ID)
+	require.NoError(t, err)
+	require.Equal(t, internal.BuyerPurchaseOrdersReport{Buyer: buyer, PurchaseOrdersCount: 1}, report)
+
+	// the buyer is not deleted while it has the purchase order
+	err = rpBuyer.Delete(context.Background(), buyer.ID)
+	require.ErrorIs(t, err, internal.ErrBuyerHasPurchaseOrders)
+	err = rpBuyer.Delete(context.Background(), buyer.ID+1)
+	require.ErrorIs(t, err, internal.ErrBuyerRepositoryNotFound)
+}
+
+// TestInboundOrderMysql_Conformance tests the inbound order repository and the employee report against the schema
+func TestInboundOrderMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewInboundOrderMysql(db)
+	rpEmployee := repository.NewEmployeeMysql(db)
+
+	// set-up
+	warehouseID := saveWarehouse(t, db)
+	employee := internal.Employee{CardNumberID: 1001, FirstName: "John", LastName: "Doe", WarehouseID: warehouseID}
+	require.NoError(t, rpEmployee.Save(context.Background(), &employee))
+	_, err := db.Exec("INSERT INTO `product_batches` (`batch_number`, `current_quantity`, `initial_quantity`, `current_temperature`, `minimum_temperature`, `manufacturing_date`, `manufacturing_hour`, `due_date`, `product_id`, `section_id`) VALUES (1, 10, 10, 0, -5, '2024-01-01', 8, '2024-06-01', 1, 1)")
+	require.NoError(t, err)
+	var productBatchID int
+	require.NoError(t, db.QueryRow("SELECT LAST_INSERT_ID()").Scan(&productBatchID))
+
+	// save
+	inboundOrder := internal.InboundOrder{OrderDate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), OrderNumber: "IO-0001", EmployeeID: employee.ID, ProductBatchID: productBatchID, WarehouseID: warehouseID}
+	err = rp.Save(context.Background(), &inboundOrder)
+	require.NoError(t, err)
+	require.NotZero(t, inboundOrder.ID)
+
+	// find all
+	inboundOrders, err := rp.FindAll()
+	require.NoError(t, err)
+	require.Contains(t, inboundOrders, inboundOrder)
+
+	// the product batch must exist
+	orphan := inboundOrder
+	orphan.OrderNumber, orphan.ProductBatchID = "IO-0002", productBatchID+1
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrInboundOrderRepositoryProductBatchNotFound)
+
+	// the employee is not deleted while it has the inbound order
+	err = rpEmployee.Delete(context.Background(), employee.ID)
+	require.ErrorIs(t, err, internal.ErrEmployeeHasInboundOrders)
+
+	// the employee must not be deleted
+	deleted := internal.Employee{CardNumberID: 1002, FirstName: "Jane", LastName: "Doe", WarehouseID: warehouseID}
+	require.NoError(t, rpEmployee.Save(context.Background(), &deleted))
+	require.NoError(t, rpEmployee.Delete(context.Background(), deleted.ID))
+	orphan.OrderNumber, orphan.ProductBatchID, orphan.EmployeeID = "IO-0003", productBatchID, deleted.ID
+	err = rp.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrInboundOrderRepositoryEmployeeNotFound)
+
+	// report inbound orders
+	reports, err := rpEmployee.ReportInboundOrders(employee.ID)
+	require.NoError(t, err)
+	require.Equal(t, []internal.EmployeeInboundOrdersReport{{Employee: employee, InboundOrdersCount: 1}}, reports)
+	_, err = rpEmployee.ReportInboundOrders(employee.ID + 1)
+	require.ErrorIs(t, err, internal.ErrEmployeeRepositoryNotFound)
+}
+
+// TestLocalityMysql_Conformance tests the locality and carrier repositories against the schema
+func TestLocalityMysql_Conformance(t *testing.T) {
+	db := openTxdb(t)
+	rp := repository.NewLocalityMysql(db)
+	rpCarrier := repository.NewCarrierMysql(db)
+	rpSeller := repository.NewSellerMysql(db)
+
+	// save
+	locality := internal.Locality{ID: 1414, LocalityName: "Palermo", ProvinceName: "Buenos Aires", CountryName: "Argentina"}
+	err := rp.Save(context.Background(), &locality)
+	require.NoError(t, err)
+	require.Equal(t, 1414, locality.ID)
+	other := internal.Locality{LocalityName: "Belgrano", ProvinceName: "Buenos Aires", CountryName: "Argentina"}
+	err = rp.Save(context.Background(), &other)
+	require.NoError(t, err)
+
+	// find by id
+	found, err := rp.FindByID(locality.ID)
+	require.NoError(t, err)
+	require.Equal(t, locality, found)
+
+	// the locality name is unique within its province
+	duplicated := internal.Locality{LocalityName: "Palermo", ProvinceName: "Buenos Aires", CountryName: "Argentina"}
+	err = rp.Save(context.Background(), &duplicated)
+	require.ErrorIs(t, err, internal.ErrLocalityRepositoryDuplicated)
+
+	// carriers
+	carrier := internal.Carrier{CID: "CAR01", CompanyName: "Carrier A", Address: "300 Route Ave", Telephone: "345-678-9012", LocalityID: locality.ID}
+	err = rpCarrier.Save(context.Background(), &carrier)
+	require.NoError(t, err)
+	carriers, err := rpCarrier.FindAll()
+	require.NoError(t, err)
+	require.Contains(t, carriers, carrier)
+	orphan := carrier
+	orphan.CID, orphan.LocalityID = "CAR02", 1
+	err = rpCarrier.Save(context.Background(), &orphan)
+	require.ErrorIs(t, err, internal.ErrCarrierRepositoryLocalityNotFound)
+
+	// sellers
+	seller := internal.Seller{CID: 1, CompanyName: "Company A", Address: "123 Main St", Telephone: "123-456-7890", LocalityID: locality.ID}
+	require.NoError(t, rpSeller.Save(context.Background(), &seller))
+	orphanSeller := internal.Seller{CID: 2, CompanyName: "Company B", Address: "456 Main St", Telephone: "123-456-7891", LocalityID: 1}
+	err = rpSeller.Save(context.Background(), &orphanSeller)
+	require.ErrorIs(t, err, internal.ErrSellerLocalityNotFound)
+	seller.LocalityID = 1
+	err = rpSeller.Update(context.Background(), &seller)
+	require.ErrorIs(t, err, internal.ErrSellerLocalityNotFound)
+
+	// warehouses
+	rpWarehouse := repository.NewWarehouseMysql(db)
+	warehouse := internal.Warehouse{WarehouseCode: "W-LOC", Address: "789 Dock St", Telephone: "123-456-7892", MinimumCapacity: 10, MinimumTemperature: -5, LocalityID: 1}
+	err = rpWarehouse.Save(context.Background(), &warehouse)
+	require.ErrorIs(t, err, internal.ErrWarehouseLocalityNotFound)
+	warehouse.LocalityID = locality.ID
+	require.NoError(t, rpWarehouse.Save(context.Background(), &warehouse))
+	warehouse.LocalityID = 1
+	err = rpWarehouse.Update(context.Background(), &warehouse)
+	require.ErrorIs(t, err, internal.ErrWarehouseLocalityNotFound)
+
+	// report sellers and carriers
+	sellers, err := rp.ReportSellers(locality.ID)
+	require.NoError(t, err)
+	require.Equal(t, []internal.LocalityCountReport{{LocalityID: locality.ID, LocalityName: "Palermo", Count: 1}}, sellers)
+	carriersReport, err := rp.ReportCarriers(other.ID)
+	require.NoError(t, err)
+	require.Equal(t, []internal.LocalityCountReport{{LocalityID: other.ID, LocalityName: "Belgrano", Count: 0}}, carriersReport)
+	_, err = rp.ReportSellers(other.ID + 1)
+	require.ErrorIs(t, err, internal.ErrLocalityRepositoryNotFound)
+}
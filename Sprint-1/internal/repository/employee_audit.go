@@ -0,0 +1,34 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewEmployeeAudit creates a new instance of the employee audit decorator
+func NewEmployeeAudit(rp internal.EmployeeRepository, au internal.AuditRepository) *EmployeeAudit {
+	return &EmployeeAudit{
+		AuditDecorator: NewAuditDecorator[internal.Employee](rp, au, "employees", func(employee internal.Employee) int { return employee.ID }),
+		rp:             rp,
+	}
+}
+
+// EmployeeAudit is the audit decorator of the employee repository
+// - the write operations are recorded by the embedded decorator, the employee specific reads go straight to rp
+type EmployeeAudit struct {
+	*AuditDecorator[internal.Employee]
+	// rp is the decorated repository
+	rp internal.EmployeeRepository
+}
+
+// FindByCardNumberID returns an employee by its card_number_id
+func (r *EmployeeAudit) FindByCardNumberID(cardNumberID int) (internal.Employee, error) {
+	return r.rp.FindByCardNumberID(cardNumberID)
+}
+
+// CountByWarehouse returns the number of employees of a warehouse
+func (r *EmployeeAudit) CountByWarehouse(warehouseID int) (int, error) {
+	return r.rp.CountByWarehouse(warehouseID)
+}
+
+// ReportInboundOrders returns the number of inbound orders received by an employee, or by every employee
+func (r *EmployeeAudit) ReportInboundOrders(id int) ([]internal.EmployeeInboundOrdersReport, error) {
+	return r.rp.ReportInboundOrders(id)
+}
@@ -0,0 +1,177 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewEmployeeMock creates a new instance of the employee repository mock
+func NewEmployeeMock() *EmployeeMock {
+	return &EmployeeMock{}
+}
+
+// EmployeeMock is a mock of the employee repository
+// - each method calls its Func field and counts the call in Spy
+type EmployeeMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Employee, error)
+	// FuncFindAllWithDeleted is the function called by FindAllWithDeleted
+	FuncFindAllWithDeleted func() ([]internal.Employee, error)
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Employee, error)
+	// FuncFindByIDForUpdate is the function called by FindByIDForUpdate
+	FuncFindByIDForUpdate func(ctx context.Context, id int) (internal.Employee, error)
+	// FuncFindByCardNumberID is the function called by FindByCardNumberID
+	FuncFindByCardNumberID func(cardNumberID int) (internal.Employee, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, employee *internal.Employee) error
+	// FuncSaveAll is the function called by SaveAll
+	FuncSaveAll func(ctx context.Context, employees []*internal.Employee) error
+	// FuncUpdate is the function called by Update
+	FuncUpdate func(ctx context.Context, employee *internal.Employee) error
+	// FuncDelete is the function called by Delete
+	FuncDelete func(ctx context.Context, id int) error
+	// FuncRestore is the function called by Restore
+	FuncRestore func(ctx context.Context, id int) error
+	// FuncCountByWarehouse is the function called by CountByWarehouse
+	FuncCountByWarehouse func(warehouseID int) (int, error)
+	// FuncReportInboundOrders is the function called by ReportInboundOrders
+	FuncReportInboundOrders func(id int) ([]internal.EmployeeInboundOrdersReport, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// FindAllWithDeleted is the number of times FindAllWithDeleted was called
+		FindAllWithDeleted int
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// FindByIDForUpdate is the number of times FindByIDForUpdate was called
+		FindByIDForUpdate int
+		// FindByCardNumberID is the number of times FindByCardNumberID was called
+		FindByCardNumberID int
+		// Save is the number of times Save was called
+		Save int
+		// SaveAll is the number of times SaveAll was called
+		SaveAll int
+		// Update is the number of times Update was called
+		Update int
+		// Delete is the number of times Delete was called
+		Delete int
+		// Restore is the number of times Restore was called
+		Restore int
+		// CountByWarehouse is the number of times CountByWarehouse was called
+		CountByWarehouse int
+		// ReportInboundOrders is the number of times ReportInboundOrders was called
+		ReportInboundOrders int
+	}
+}
+
+// FindAll returns all the employees
+func (r *EmployeeMock) FindAll() ([]internal.Employee, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// FindAllWithDeleted returns all the employees, including the soft-deleted ones
+func (r *EmployeeMock) FindAllWithDeleted() ([]internal.Employee, error) {
+	// spy
+	r.Spy.FindAllWithDeleted++
+
+	// mock
+	return r.FuncFindAllWithDeleted()
+}
+
+// FindByID returns the employee with the given ID
+func (r *EmployeeMock) FindByID(id int) (internal.Employee, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// FindByIDForUpdate returns the employee with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *EmployeeMock) FindByIDForUpdate(ctx context.Context, id int) (internal.Employee, error) {
+	// spy
+	r.Spy.FindByIDForUpdate++
+
+	// mock
+	return r.FuncFindByIDForUpdate(ctx, id)
+}
+
+// FindByCardNumberID returns the employee with the given card_number_id
+func (r *EmployeeMock) FindByCardNumberID(cardNumberID int) (internal.Employee, error) {
+	// spy
+	r.Spy.FindByCardNumberID++
+
+	// mock
+	return r.FuncFindByCardNumberID(cardNumberID)
+}
+
+// Save saves the given employee
+func (r *EmployeeMock) Save(ctx context.Context, employee *internal.Employee) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, employee)
+}
+
+// SaveAll saves the given employees in a single transaction, none of them are saved if one fails
+func (r *EmployeeMock) SaveAll(ctx context.Context, employees []*internal.Employee) error {
+	// spy
+	r.Spy.SaveAll++
+
+	// mock
+	return r.FuncSaveAll(ctx, employees)
+}
+
+// Update updates the given employee, ErrVersionConflict is returned if its version is not the current one
+func (r *EmployeeMock) Update(ctx context.Context, employee *internal.Employee) error {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	return r.FuncUpdate(ctx, employee)
+}
+
+// Delete soft-deletes the employee with the given ID, ErrEmployeeHasInboundOrders is returned if it still has inbound orders
+func (r *EmployeeMock) Delete(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	return r.FuncDelete(ctx, id)
+}
+
+// Restore restores the soft-deleted employee with the given ID, ErrEmployeeWarehouseNotFound is returned if its warehouse was deleted
+func (r *EmployeeMock) Restore(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Restore++
+
+	// mock
+	return r.FuncRestore(ctx, id)
+}
+
+// CountByWarehouse returns the number of employees of the warehouse with the given ID
+func (r *EmployeeMock) CountByWarehouse(warehouseID int) (int, error) {
+	// spy
+	r.Spy.CountByWarehouse++
+
+	// mock
+	return r.FuncCountByWarehouse(warehouseID)
+}
+
+// ReportInboundOrders returns the number of inbound orders received by the employee with the given ID, or by every employee if it is zero
+func (r *EmployeeMock) ReportInboundOrders(id int) ([]internal.EmployeeInboundOrdersReport, error) {
+	// spy
+	r.Spy.ReportInboundOrders++
+
+	// mock
+	return r.FuncReportInboundOrders(id)
+}
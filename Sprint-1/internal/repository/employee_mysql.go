@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -20,10 +21,28 @@ type EmployeeMysql struct {
 	db *sql.DB
 }
 
-// FindAll returns all employees from the database
+// FindAll returns all employees from the database, except the soft-deleted ones
 func (r *EmployeeMysql) FindAll() (employees []internal.Employee, err error) {
+	employees, err = r.findAll(false)
+	return
+}
+
+// FindAllWithDeleted returns all employees from the database, including the soft-deleted ones
+func (r *EmployeeMysql) FindAllWithDeleted() (employees []internal.Employee, err error) {
+	employees, err = r.findAll(true)
+	return
+}
+
+// findAll returns the employees from the database, including the soft-deleted ones if requested
+func (r *EmployeeMysql) findAll(includeDeleted bool) (employees []internal.Employee, err error) {
+	// build the query
+	query := "SELECT `e`.`id`, `e`.`card_number_id`, `e`.`first_name`, `e`.`last_name`, `e`.`warehouse_id`, `e`.`version` FROM `employees` AS `e`"
+	if !includeDeleted {
+		query += " WHERE `e`.`deleted_at` IS NULL"
+	}
+
 	// execute the query
-	rows, err := r.db.Query("SELECT `e.id`, `e.card_number_id`, `e.first_name`, `e.last_name`, `e.warehouse_id` FROM `employees` AS `e`")
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return
 	}
@@ -32,7 +51,7 @@ func (r *EmployeeMysql) FindAll() (employees []internal.Employee, err error) {
 	for rows.Next() {
 		// create a new employee
 		var employee internal.Employee
-		err = rows.Scan(&employee.ID, &employee.CardNumberID, &employee.FirstName, &employee.LastName, &employee.WarehouseID)
+		err = rows.Scan(&employee.ID, &employee.CardNumberID, &employee.FirstName, &employee.LastName, &employee.WarehouseID, &employee.Version)
 		if err != nil {
 			return
 		}
@@ -53,10 +72,10 @@ func (r *EmployeeMysql) FindAll() (employees []internal.Employee, err error) {
 // FindByID returns a employee from the database by its id
 func (r *EmployeeMysql) FindByID(id int) (employee internal.Employee, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `e.id`, `e.card_number_id`, `e.first_name`, `e.last_name`, `e.warehouse_id` FROM `employees` AS `e` WHERE `e.id` = ?", id)
+	row := r.db.QueryRow("SELECT `e`.`id`, `e`.`card_number_id`, `e`.`first_name`, `e`.`last_name`, `e`.`warehouse_id`, `e`.`version` FROM `employees` AS `e` WHERE `e`.`id` = ? AND `e`.`deleted_at` IS NULL", id)
 
 	// scan the row into the employee
-	err = row.Scan(&employee.ID, &employee.CardNumberID, &employee.FirstName, &employee.LastName, &employee.WarehouseID)
+	err = row.Scan(&employee.ID, &employee.CardNumberID, &employee.FirstName, &employee.LastName, &employee.WarehouseID, &employee.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrEmployeeRepositoryNotFound
@@ -67,72 +86,277 @@ func (r *EmployeeMysql) FindByID(id int) (employee internal.Employee, err error)
 	return
 }
 
-// Save saves the given employee in the database
-func (r *EmployeeMysql) Save(employee *internal.Employee) (err error) {
+// FindByIDForUpdate returns an employee from the database by its id, also if it is soft-deleted, locking its row in the transaction of the context
+func (r *EmployeeMysql) FindByIDForUpdate(ctx context.Context, id int) (employee internal.Employee, err error) {
 	// execute the query
-	result, err := r.db.Exec(
-		"INSERT INTO `employees` (`card_number_id`, `first_name`, `last_name`, `warehouse_id`) VALUES (?, ?, ?, ?)",
-		(*employee).CardNumberID, (*employee).FirstName, (*employee).LastName, (*employee).WarehouseID,
-	)
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT `e`.`id`, `e`.`card_number_id`, `e`.`first_name`, `e`.`last_name`, `e`.`warehouse_id`, `e`.`version` FROM `employees` AS `e` WHERE `e`.`id` = ? FOR UPDATE", id)
+
+	// scan the row into the employee
+	err = row.Scan(&employee.ID, &employee.CardNumberID, &employee.FirstName, &employee.LastName, &employee.WarehouseID, &employee.Version)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrEmployeeRepositoryDuplicated
-			default:
-				// ...
-			}
-			return
+		if err == sql.ErrNoRows {
+			err = internal.ErrEmployeeRepositoryNotFound
 		}
-
 		return
 	}
 
-	// get the id of the inserted employee
-	id, err := result.LastInsertId()
+	return
+}
+
+// FindByCardNumberID returns an employee from the database by its card_number_id
+func (r *EmployeeMysql) FindByCardNumberID(cardNumberID int) (employee internal.Employee, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `e`.`id`, `e`.`card_number_id`, `e`.`first_name`, `e`.`last_name`, `e`.`warehouse_id`, `e`.`version` FROM `employees` AS `e` WHERE `e`.`card_number_id` = ? AND `e`.`deleted_at` IS NULL", cardNumberID)
+
+	// scan the row into the employee
+	err = row.Scan(&employee.ID, &employee.CardNumberID, &employee.FirstName, &employee.LastName, &employee.WarehouseID, &employee.Version)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrEmployeeRepositoryNotFound
+		}
 		return
 	}
 
-	// set the id of the employee
-	(*employee).ID = int(id)
+	return
+}
+
+// Save saves the given employee in the database
+func (r *EmployeeMysql) Save(ctx context.Context, employee *internal.Employee) (err error) {
+	err = r.save(ctx, employee)
+	return
+}
+
+// SaveAll saves the given employees into the database in a single transaction
+// - if an employee fails, none of them are saved and the error is returned as an internal.BulkError with its row
+func (r *EmployeeMysql) SaveAll(ctx context.Context, employees []*internal.Employee) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// save the employees
+		for i, employee := range employees {
+			err = r.save(ctx, employee)
+			if err != nil {
+				err = internal.BulkError{{Row: i + 1, Err: err}}
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// save inserts an employee, in the transaction of the context or in a new one, and sets its id
+// - its warehouse is locked first, internal.ErrEmployeeWarehouseNotFound is returned if it was deleted
+func (r *EmployeeMysql) save(ctx context.Context, employee *internal.Employee) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the warehouse, so it is not deleted while the employee is saved
+		err = lock(ctx, r.db, "warehouses", (*employee).WarehouseID, internal.ErrEmployeeWarehouseNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `employees` (`card_number_id`, `first_name`, `last_name`, `warehouse_id`) VALUES (?, ?, ?, ?)",
+			(*employee).CardNumberID, (*employee).FirstName, (*employee).LastName, (*employee).WarehouseID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrEmployeeRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
 
+		// get the id of the inserted employee
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the employee
+		(*employee).ID = int(id)
+		// a new employee starts at its first version
+		(*employee).Version = 1
+
+		return
+	})
 	return
 }
 
 // Update updates the given employee in the database
-func (r *EmployeeMysql) Update(employee *internal.Employee) (err error) {
-	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `employees` SET `card_number_id` = ?, `first_name` = ?, `last_name` = ?, `warehouse_id` = ? WHERE `id` = ?",
-		(*employee).CardNumberID, (*employee).FirstName, (*employee).LastName, (*employee).WarehouseID, (*employee).ID,
-	)
-	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrEmployeeRepositoryDuplicated
-			default:
-				// ...
+// - a soft-deleted employee is not updated, internal.ErrEmployeeRepositoryNotFound is returned
+// - its warehouse is locked first, internal.ErrEmployeeWarehouseNotFound is returned if it was deleted
+func (r *EmployeeMysql) Update(ctx context.Context, employee *internal.Employee) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the warehouse, so it is not deleted while the employee is saved
+		err = lock(ctx, r.db, "warehouses", (*employee).WarehouseID, internal.ErrEmployeeWarehouseNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"UPDATE `employees` SET `card_number_id` = ?, `first_name` = ?, `last_name` = ?, `warehouse_id` = ?, `version` = `version` + 1 WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL",
+			(*employee).CardNumberID, (*employee).FirstName, (*employee).LastName, (*employee).WarehouseID, (*employee).ID, (*employee).Version,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrEmployeeRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// check the version was the current one, of a employee not deleted
+		err = checkUpdated(ctx, r.db, result, "employees", (*employee).ID, internal.ErrEmployeeRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// the employee is now at its next version
+		(*employee).Version++
+
+		return
+	})
+	return
+}
+
+// Delete soft-deletes the employee with the given id, keeping its row for the entities that reference it
+// - an employee that still has inbound orders is not deleted, internal.ErrEmployeeHasInboundOrders is returned
+func (r *EmployeeMysql) Delete(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the employee, so nothing that references it is saved while it is deleted
+		err = lock(ctx, r.db, "employees", id, internal.ErrEmployeeRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// check the employee has no inbound orders
+		err = checkNone(ctx, r.db, "SELECT COUNT(`io`.`id`) FROM `inbound_orders` AS `io` WHERE `io`.`employee_id` = ?", id, internal.ErrEmployeeHasInboundOrders)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `employees` SET `deleted_at` = NOW() WHERE `id` = ?", id)
+		return
+	})
+	return
+}
+
+// Restore restores the soft-deleted employee with the given id
+// - its warehouse is checked again, internal.ErrEmployeeWarehouseNotFound is returned if it was deleted meanwhile
+// - internal.ErrEmployeeCardNumberIDDuplicated is returned if an employee saved since it was deleted has its card_number_id
+func (r *EmployeeMysql) Restore(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the soft-deleted employee and get its warehouse
+		var warehouseID int
+		err = conn(ctx, r.db).QueryRowContext(ctx, "SELECT `e`.`warehouse_id` FROM `employees` AS `e` WHERE `e`.`id` = ? AND `e`.`deleted_at` IS NOT NULL FOR UPDATE", id).Scan(&warehouseID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = internal.ErrEmployeeRepositoryNotFound
+			}
+			return
+		}
+
+		// lock the warehouse, so it is not deleted while the employee is restored
+		err = lock(ctx, r.db, "warehouses", warehouseID, internal.ErrEmployeeWarehouseNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `employees` SET `deleted_at` = NULL WHERE `id` = ?", id)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrEmployeeCardNumberIDDuplicated
+				default:
+					// ...
+				}
+				return
 			}
+
 			return
 		}
 
+		return
+	})
+	return
+}
+
+// CountByWarehouse returns the number of employees of a warehouse from the database
+func (r *EmployeeMysql) CountByWarehouse(warehouseID int) (count int, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT COUNT(`e`.`id`) FROM `employees` AS `e` WHERE `e`.`warehouse_id` = ? AND `e`.`deleted_at` IS NULL", warehouseID)
+
+	// scan the row into the count
+	err = row.Scan(&count)
+	if err != nil {
 		return
 	}
 
 	return
 }
 
-// Delete deletes the given employee from the database
-func (r *EmployeeMysql) Delete(id int) (err error) {
+// ReportInboundOrders returns the number of inbound orders received by an employee, or by every employee if id is zero, from the database
+func (r *EmployeeMysql) ReportInboundOrders(id int) (reports []internal.EmployeeInboundOrdersReport, err error) {
+	// build the query
+	query := "SELECT `e`.`id`, `e`.`card_number_id`, `e`.`first_name`, `e`.`last_name`, `e`.`warehouse_id`, `e`.`version`, COUNT(`io`.`id`) FROM `employees` AS `e` LEFT JOIN `inbound_orders` AS `io` ON `io`.`employee_id` = `e`.`id` WHERE `e`.`deleted_at` IS NULL"
+	var args []any
+	if id != 0 {
+		query += " AND `e`.`id` = ?"
+		args = append(args, id)
+	}
+	query += " GROUP BY `e`.`id`, `e`.`card_number_id`, `e`.`first_name`, `e`.`last_name`, `e`.`warehouse_id`, `e`.`version` ORDER BY `e`.`id`"
+
 	// execute the query
-	_, err = r.db.Exec("DELETE FROM `employees` WHERE `id` = ?", id)
+	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return
 	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		var report internal.EmployeeInboundOrdersReport
+		err = rows.Scan(&report.ID, &report.CardNumberID, &report.FirstName, &report.LastName, &report.WarehouseID, &report.Version, &report.InboundOrdersCount)
+		if err != nil {
+			return
+		}
+
+		// append the report to the slice
+		reports = append(reports, report)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	// the requested employee must exist
+	if id != 0 && len(reports) == 0 {
+		err = internal.ErrEmployeeRepositoryNotFound
+		return
+	}
 
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// executor is the set of methods shared by *sql.DB and *sql.Tx to run a query
+// - it lets the same statements run on their own or as part of a transaction
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// txKey is the key of the transaction carried by a context
+type txKey struct{}
+
+// conn returns the transaction carried by the context, or the database when there is none
+// - the write operations run through it, so the ones that share a context share its transaction
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// transaction runs fn in the transaction carried by the context
+// - when there is none, a new one is begun for fn, committed if it succeeds and rolled back otherwise
+func transaction(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) (err error) {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		err = fn(ctx)
+		return
+	}
+
+	// begin the transaction
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	// run fn with the transaction
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		tx.Rollback()
+		return
+	}
+
+	// commit the transaction
+	err = tx.Commit()
+	return
+}
+
+// lock locks the row with the given id of the table in the transaction of the context, until it ends
+// - a row that does not exist or is soft-deleted is not locked, errNotFound is returned
+// - the entities that reference the row lock it before they are saved, and the row locks itself before it is deleted, so both do not overlap
+func lock(ctx context.Context, db *sql.DB, table string, id int, errNotFound error) (err error) {
+	// execute the query
+	var lockedID int
+	err = conn(ctx, db).QueryRowContext(ctx, "SELECT `id` FROM `"+table+"` WHERE `id` = ? AND `deleted_at` IS NULL FOR UPDATE", id).Scan(&lockedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = errNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// lockRow locks the row with the given id of a table without soft-delete, in the transaction of the context, until it ends
+// - a row that does not exist is not locked, errNotFound is returned
+func lockRow(ctx context.Context, db *sql.DB, table string, id int, errNotFound error) (err error) {
+	// execute the query
+	var lockedID int
+	err = conn(ctx, db).QueryRowContext(ctx, "SELECT `id` FROM `"+table+"` WHERE `id` = ? FOR UPDATE", id).Scan(&lockedID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = errNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// checkNone returns errFound if the given query, which counts the rows that reference the id, counts any
+func checkNone(ctx context.Context, db *sql.DB, query string, id int, errFound error) (err error) {
+	// execute the query
+	var count int
+	err = conn(ctx, db).QueryRowContext(ctx, query, id).Scan(&count)
+	if err != nil {
+		return
+	}
+
+	// check the count
+	if count > 0 {
+		err = errFound
+		return
+	}
+
+	return
+}
+
+// checkUpdated returns the error of an update over the version of the row with the given id of the table that changed none
+// - errNotFound if the row does not exist or is soft-deleted, internal.ErrVersionConflict if it is at another version
+func checkUpdated(ctx context.Context, db *sql.DB, result sql.Result, table string, id int, errNotFound error) (err error) {
+	// check the updated rows
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return
+	}
+	if rowsAffected > 0 {
+		return
+	}
+
+	// check the row, the version is only wrong if it is still there
+	err = lock(ctx, db, table, id, errNotFound)
+	if err != nil {
+		return
+	}
+	err = internal.ErrVersionConflict
+	return
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewInboundOrderAudit creates a new instance of the inbound order audit decorator
+func NewInboundOrderAudit(rp internal.InboundOrderRepository, au internal.AuditRepository) *InboundOrderAudit {
+	return &InboundOrderAudit{
+		AuditSaveDecorator: NewAuditSaveDecorator[internal.InboundOrder](rp, au, "inbound_orders", func(inboundOrder internal.InboundOrder) int { return inboundOrder.ID }),
+		rp:                 rp,
+	}
+}
+
+// InboundOrderAudit is the audit decorator of the inbound order repository
+// - the inbound orders are only created, their creation is recorded by the embedded decorator and the reads go straight to rp
+type InboundOrderAudit struct {
+	*AuditSaveDecorator[internal.InboundOrder]
+	// rp is the decorated repository
+	rp internal.InboundOrderRepository
+}
+
+// FindAll returns all the inbound orders
+func (r *InboundOrderAudit) FindAll() ([]internal.InboundOrder, error) {
+	return r.rp.FindAll()
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewInboundOrderMock creates a new instance of the inbound order repository mock
+func NewInboundOrderMock() *InboundOrderMock {
+	return &InboundOrderMock{}
+}
+
+// InboundOrderMock is a mock of the inbound order repository
+// - each method calls its Func field and counts the call in Spy
+type InboundOrderMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.InboundOrder, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, inboundOrder *internal.InboundOrder) error
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// Save is the number of times Save was called
+		Save int
+	}
+}
+
+// FindAll returns all the inbound orders
+func (r *InboundOrderMock) FindAll() ([]internal.InboundOrder, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// Save saves the given inbound order
+func (r *InboundOrderMock) Save(ctx context.Context, inboundOrder *internal.InboundOrder) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, inboundOrder)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NewInboundOrderMysql creates a new instance of the inbound order repository
+func NewInboundOrderMysql(db *sql.DB) *InboundOrderMysql {
+	return &InboundOrderMysql{db}
+}
+
+// InboundOrderMysql is the mysql implementation of the inbound order repository
+type InboundOrderMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindAll returns all inbound orders from the database
+func (r *InboundOrderMysql) FindAll() (inboundOrders []internal.InboundOrder, err error) {
+	// execute the query
+	rows, err := r.db.Query("SELECT `io`.`id`, `io`.`order_date`, `io`.`order_number`, `io`.`employee_id`, `io`.`product_batch_id`, `io`.`warehouse_id` FROM `inbound_orders` AS `io`")
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		// create a new inbound order
+		var inboundOrder internal.InboundOrder
+		err = rows.Scan(&inboundOrder.ID, &inboundOrder.OrderDate, &inboundOrder.OrderNumber, &inboundOrder.EmployeeID, &inboundOrder.ProductBatchID, &inboundOrder.WarehouseID)
+		if err != nil {
+			return
+		}
+
+		// append the inbound order to the slice
+		inboundOrders = append(inboundOrders, inboundOrder)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Save saves an inbound order into the database
+func (r *InboundOrderMysql) Save(ctx context.Context, inboundOrder *internal.InboundOrder) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the product batch and the employee, so they are not deleted while the inbound order is saved
+		err = lockRow(ctx, r.db, "product_batches", (*inboundOrder).ProductBatchID, internal.ErrInboundOrderRepositoryProductBatchNotFound)
+		if err != nil {
+			return
+		}
+		err = lock(ctx, r.db, "employees", (*inboundOrder).EmployeeID, internal.ErrInboundOrderRepositoryEmployeeNotFound)
+		if err != nil {
+			return
+		}
+
+		// insert the inbound order
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `inbound_orders` (`order_date`, `order_number`, `employee_id`, `product_batch_id`, `warehouse_id`) VALUES (?, ?, ?, ?, ?)",
+			(*inboundOrder).OrderDate, (*inboundOrder).OrderNumber, (*inboundOrder).EmployeeID, (*inboundOrder).ProductBatchID, (*inboundOrder).WarehouseID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+				err = internal.ErrInboundOrderRepositoryDuplicated
+			}
+			return
+		}
+
+		// get the id of the inserted inbound order
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the inbound order
+		(*inboundOrder).ID = int(id)
+
+		return
+	})
+	return
+}
@@ -0,0 +1,34 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewLocalityAudit creates a new instance of the locality audit decorator
+func NewLocalityAudit(rp internal.LocalityRepository, au internal.AuditRepository) *LocalityAudit {
+	return &LocalityAudit{
+		AuditSaveDecorator: NewAuditSaveDecorator[internal.Locality](rp, au, "localities", func(locality internal.Locality) int { return locality.ID }),
+		rp:                 rp,
+	}
+}
+
+// LocalityAudit is the audit decorator of the locality repository
+// - the localities are only created, their creation is recorded by the embedded decorator and the reads go straight to rp
+type LocalityAudit struct {
+	*AuditSaveDecorator[internal.Locality]
+	// rp is the decorated repository
+	rp internal.LocalityRepository
+}
+
+// FindByID returns the locality with the given ID
+func (r *LocalityAudit) FindByID(id int) (internal.Locality, error) {
+	return r.rp.FindByID(id)
+}
+
+// ReportSellers returns the number of sellers of the locality with the given ID, or of every locality if it is zero
+func (r *LocalityAudit) ReportSellers(id int) ([]internal.LocalityCountReport, error) {
+	return r.rp.ReportSellers(id)
+}
+
+// ReportCarriers returns the number of carriers of the locality with the given ID, or of every locality if it is zero
+func (r *LocalityAudit) ReportCarriers(id int) ([]internal.LocalityCountReport, error) {
+	return r.rp.ReportCarriers(id)
+}
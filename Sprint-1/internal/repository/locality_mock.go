@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewLocalityMock creates a new instance of the locality repository mock
+func NewLocalityMock() *LocalityMock {
+	return &LocalityMock{}
+}
+
+// LocalityMock is a mock of the locality repository
+// - each method calls its Func field and counts the call in Spy
+type LocalityMock struct {
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Locality, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, locality *internal.Locality) error
+	// FuncReportSellers is the function called by ReportSellers
+	FuncReportSellers func(id int) ([]internal.LocalityCountReport, error)
+	// FuncReportCarriers is the function called by ReportCarriers
+	FuncReportCarriers func(id int) ([]internal.LocalityCountReport, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// Save is the number of times Save was called
+		Save int
+		// ReportSellers is the number of times ReportSellers was called
+		ReportSellers int
+		// ReportCarriers is the number of times ReportCarriers was called
+		ReportCarriers int
+	}
+}
+
+// FindByID returns the locality with the given ID
+func (r *LocalityMock) FindByID(id int) (internal.Locality, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// Save saves the given locality, creating its province and country if they do not exist
+func (r *LocalityMock) Save(ctx context.Context, locality *internal.Locality) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, locality)
+}
+
+// ReportSellers returns the number of sellers of the locality with the given ID, or of every locality if it is zero
+func (r *LocalityMock) ReportSellers(id int) ([]internal.LocalityCountReport, error) {
+	// spy
+	r.Spy.ReportSellers++
+
+	// mock
+	return r.FuncReportSellers(id)
+}
+
+// ReportCarriers returns the number of carriers of the locality with the given ID, or of every locality if it is zero
+func (r *LocalityMock) ReportCarriers(id int) ([]internal.LocalityCountReport, error) {
+	// spy
+	r.Spy.ReportCarriers++
+
+	// mock
+	return r.FuncReportCarriers(id)
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NewLocalityMysql creates a new instance of the locality repository
+func NewLocalityMysql(db *sql.DB) *LocalityMysql {
+	return &LocalityMysql{db}
+}
+
+// LocalityMysql is the mysql implementation of the locality repository
+type LocalityMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByID returns a locality from the database by its id
+func (r *LocalityMysql) FindByID(id int) (locality internal.Locality, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `l`.`id`, `l`.`name`, `p`.`name`, `c`.`name` FROM `localities` AS `l` INNER JOIN `provinces` AS `p` ON `p`.`id` = `l`.`province_id` INNER JOIN `countries` AS `c` ON `c`.`id` = `p`.`country_id` WHERE `l`.`id` = ?", id)
+
+	// scan the row into the locality
+	err = row.Scan(&locality.ID, &locality.LocalityName, &locality.ProvinceName, &locality.CountryName)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrLocalityRepositoryNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// Save saves a locality into the database
+// - the country and the province are looked up by name and created if they do not exist yet
+// - the id of the locality is kept if it is given, e.g. its postal code
+func (r *LocalityMysql) Save(ctx context.Context, locality *internal.Locality) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// upsert the country
+		result, err := conn(ctx, r.db).ExecContext(ctx, "INSERT INTO `countries` (`name`) VALUES (?) ON DUPLICATE KEY UPDATE `id` = LAST_INSERT_ID(`id`)", (*locality).CountryName)
+		if err != nil {
+			return
+		}
+		countryID, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// upsert the province
+		result, err = conn(ctx, r.db).ExecContext(ctx, "INSERT INTO `provinces` (`name`, `country_id`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `id` = LAST_INSERT_ID(`id`)", (*locality).ProvinceName, countryID)
+		if err != nil {
+			return
+		}
+		provinceID, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// insert the locality
+		result, err = conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `localities` (`id`, `name`, `province_id`) VALUES (NULLIF(?, 0), ?, ?)",
+			(*locality).ID, (*locality).LocalityName, provinceID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrLocalityRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the id of the inserted locality
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the locality
+		(*locality).ID = int(id)
+
+		return
+	})
+	return
+}
+
+// ReportSellers returns the number of sellers of a locality, or of every locality if id is zero, from the database
+func (r *LocalityMysql) ReportSellers(id int) (reports []internal.LocalityCountReport, err error) {
+	reports, err = r.reportCount("SELECT `l`.`id`, `l`.`name`, COUNT(`s`.`id`) FROM `localities` AS `l` LEFT JOIN `sellers` AS `s` ON `s`.`locality_id` = `l`.`id` AND `s`.`deleted_at` IS NULL", id)
+	return
+}
+
+// ReportCarriers returns the number of carriers of a locality, or of every locality if id is zero, from the database
+func (r *LocalityMysql) ReportCarriers(id int) (reports []internal.LocalityCountReport, err error) {
+	reports, err = r.reportCount("SELECT `l`.`id`, `l`.`name`, COUNT(`c`.`id`) FROM `localities` AS `l` LEFT JOIN `carriers` AS `c` ON `c`.`locality_id` = `l`.`id`", id)
+	return
+}
+
+// reportCount completes the given count query of the localities, filtered by id if it is not zero, and scans its rows
+func (r *LocalityMysql) reportCount(query string, id int) (reports []internal.LocalityCountReport, err error) {
+	// build the query
+	var args []any
+	if id != 0 {
+		query += " WHERE `l`.`id` = ?"
+		args = append(args, id)
+	}
+	query += " GROUP BY `l`.`id`, `l`.`name` ORDER BY `l`.`id`"
+
+	// execute the query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		var report internal.LocalityCountReport
+		err = rows.Scan(&report.LocalityID, &report.LocalityName, &report.Count)
+		if err != nil {
+			return
+		}
+
+		// append the report to the slice
+		reports = append(reports, report)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	// the requested locality must exist
+	if id != 0 && len(reports) == 0 {
+		err = internal.ErrLocalityRepositoryNotFound
+		return
+	}
+
+	return
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewProductAudit creates a new instance of the product audit decorator
+func NewProductAudit(rp internal.ProductRepository, au internal.AuditRepository) *ProductAudit {
+	return &ProductAudit{
+		AuditDecorator: NewAuditDecorator[internal.Product](rp, au, "products", func(product internal.Product) int { return product.ID }),
+		rp:             rp,
+	}
+}
+
+// ProductAudit is the audit decorator of the product repository
+// - the write operations are recorded by the embedded decorator, the product specific reads go straight to rp
+type ProductAudit struct {
+	*AuditDecorator[internal.Product]
+	// rp is the decorated repository
+	rp internal.ProductRepository
+}
+
+// FindByCode returns a product by its product_code
+func (r *ProductAudit) FindByCode(code string) (internal.Product, error) {
+	return r.rp.FindByCode(code)
+}
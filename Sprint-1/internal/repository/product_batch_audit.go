@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductBatchAudit creates a new instance of the product batch audit decorator
+// - rpSection is the repository of the sections the batches are stored in, their capacity changes are recorded as section updates
+func NewProductBatchAudit(rp internal.ProductBatchRepository, rpSection internal.SectionRepository, au internal.AuditRepository) *ProductBatchAudit {
+	return &ProductBatchAudit{
+		AuditSaveDecorator: NewAuditSaveDecorator[internal.ProductBatch](rp, au, "product_batches", func(productBatch internal.ProductBatch) int { return productBatch.ID }),
+		rp:                 rp,
+		rpSection:          rpSection,
+		section:            NewAuditSaveDecorator[internal.Section](rpSection, au, "sections", func(section internal.Section) int { return section.ID }),
+	}
+}
+
+// ProductBatchAudit is the audit decorator of the product batch repository
+// - the product batches are only created, their creation is recorded by the embedded decorator and the reads go straight to rp
+// - saving a batch also changes the current capacity and the version of its section, recorded as an update of the section
+type ProductBatchAudit struct {
+	*AuditSaveDecorator[internal.ProductBatch]
+	// rp is the decorated repository
+	rp internal.ProductBatchRepository
+	// rpSection is the repository of the sections
+	rpSection internal.SectionRepository
+	// section records the audit entries of the sections
+	section *AuditSaveDecorator[internal.Section]
+}
+
+// Save saves the given product batch and records its creation and the update of its section
+func (r *ProductBatchAudit) Save(ctx context.Context, productBatch *internal.ProductBatch) (err error) {
+	err = r.au.Transaction(ctx, func(ctx context.Context) (err error) {
+		// get the section before the batch is stored in it, locked until the entries are recorded
+		prev, err := r.rpSection.FindByIDForUpdate(ctx, (*productBatch).SectionID)
+		if err != nil {
+			if errors.Is(err, internal.ErrSectionRepositoryNotFound) {
+				err = internal.ErrProductBatchRepositorySectionNotFound
+			}
+			return
+		}
+
+		// save the product batch and record its creation
+		err = r.AuditSaveDecorator.Save(ctx, productBatch)
+		if err != nil {
+			return
+		}
+
+		// record the fields of the section that changed
+		next, err := r.rpSection.FindByIDForUpdate(ctx, (*productBatch).SectionID)
+		if err != nil {
+			return
+		}
+		err = r.section.recordUpdate(ctx, prev, next)
+		return
+	})
+	return
+}
+
+// FindByID returns the product batch with the given ID
+func (r *ProductBatchAudit) FindByID(id int) (internal.ProductBatch, error) {
+	return r.rp.FindByID(id)
+}
+
+// ReportProducts returns the quantity of products stored in the section with the given ID
+func (r *ProductBatchAudit) ReportProducts(sectionID int) (internal.SectionProductsReport, error) {
+	return r.rp.ReportProducts(sectionID)
+}
+
+// FindExpiring returns the batches with products left that expire within the given number of days
+func (r *ProductBatchAudit) FindExpiring(days int) ([]internal.ProductBatch, error) {
+	return r.rp.FindExpiring(days)
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductBatchMock creates a new instance of the product batch repository mock
+func NewProductBatchMock() *ProductBatchMock {
+	return &ProductBatchMock{}
+}
+
+// ProductBatchMock is a mock of the product batch repository
+// - each method calls its Func field and counts the call in Spy
+type ProductBatchMock struct {
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.ProductBatch, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, productBatch *internal.ProductBatch) error
+	// FuncReportProducts is the function called by ReportProducts
+	FuncReportProducts func(sectionID int) (internal.SectionProductsReport, error)
+	// FuncFindExpiring is the function called by FindExpiring
+	FuncFindExpiring func(days int) ([]internal.ProductBatch, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// Save is the number of times Save was called
+		Save int
+		// ReportProducts is the number of times ReportProducts was called
+		ReportProducts int
+		// FindExpiring is the number of times FindExpiring was called
+		FindExpiring int
+	}
+}
+
+// FindByID returns the product batch with the given ID
+func (r *ProductBatchMock) FindByID(id int) (internal.ProductBatch, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// Save saves the given product batch, adding its quantity to the current capacity of its section
+func (r *ProductBatchMock) Save(ctx context.Context, productBatch *internal.ProductBatch) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, productBatch)
+}
+
+// ReportProducts returns the quantity of products stored in the section with the given ID
+func (r *ProductBatchMock) ReportProducts(sectionID int) (internal.SectionProductsReport, error) {
+	// spy
+	r.Spy.ReportProducts++
+
+	// mock
+	return r.FuncReportProducts(sectionID)
+}
+
+// FindExpiring returns the batches with products left that expire within the given number of days
+func (r *ProductBatchMock) FindExpiring(days int) ([]internal.ProductBatch, error) {
+	// spy
+	r.Spy.FindExpiring++
+
+	// mock
+	return r.FuncFindExpiring(days)
+}
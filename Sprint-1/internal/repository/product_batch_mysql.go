@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NewProductBatchMysql creates a new instance of the product batch repository
+func NewProductBatchMysql(db *sql.DB) *ProductBatchMysql {
+	return &ProductBatchMysql{db}
+}
+
+// ProductBatchMysql is the mysql implementation of the product batch repository
+type ProductBatchMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByID returns a product batch from the database by its id
+func (r *ProductBatchMysql) FindByID(id int) (productBatch internal.ProductBatch, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `pb`.`id`, `pb`.`batch_number`, `pb`.`current_quantity`, `pb`.`initial_quantity`, `pb`.`current_temperature`, `pb`.`minimum_temperature`, `pb`.`manufacturing_date`, `pb`.`manufacturing_hour`, `pb`.`due_date`, `pb`.`product_id`, `pb`.`section_id` FROM `product_batches` AS `pb` WHERE `pb`.`id` = ?", id)
+
+	// scan the row into the product batch
+	err = row.Scan(&productBatch.ID, &productBatch.BatchNumber, &productBatch.CurrentQuantity, &productBatch.InitialQuantity, &productBatch.CurrentTemperature, &productBatch.MinimumTemperature, &productBatch.ManufacturingDate, &productBatch.ManufacturingHour, &productBatch.DueDate, &productBatch.ProductID, &productBatch.SectionID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrProductBatchRepositoryNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// FindExpiring returns the batches with products left that expire within the given number of days from the database
+// - a batch expires the expiration rate of its product, in days rounded up, before its due date
+func (r *ProductBatchMysql) FindExpiring(days int) (productBatches []internal.ProductBatch, err error) {
+	// execute the query
+	rows, err := r.db.Query(
+		"SELECT `pb`.`id`, `pb`.`batch_number`, `pb`.`current_quantity`, `pb`.`initial_quantity`, `pb`.`current_temperature`, `pb`.`minimum_temperature`, `pb`.`manufacturing_date`, `pb`.`manufacturing_hour`, `pb`.`due_date`, `pb`.`product_id`, `pb`.`section_id` FROM `product_batches` AS `pb` INNER JOIN `products` AS `p` ON `p`.`id` = `pb`.`product_id` WHERE `pb`.`current_quantity` > 0 AND DATE_SUB(`pb`.`due_date`, INTERVAL CEIL(`p`.`expiration_rate`) DAY) <= DATE_ADD(CURDATE(), INTERVAL ? DAY) ORDER BY `pb`.`due_date`, `pb`.`id`",
+		days,
+	)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		// create a new product batch
+		var productBatch internal.ProductBatch
+		err = rows.Scan(&productBatch.ID, &productBatch.BatchNumber, &productBatch.CurrentQuantity, &productBatch.InitialQuantity, &productBatch.CurrentTemperature, &productBatch.MinimumTemperature, &productBatch.ManufacturingDate, &productBatch.ManufacturingHour, &productBatch.DueDate, &productBatch.ProductID, &productBatch.SectionID)
+		if err != nil {
+			return
+		}
+
+		// append the product batch to the slice
+		productBatches = append(productBatches, productBatch)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	return
+}
+
+// Save saves a product batch into the database
+// - the section is locked while its product type, temperature and capacity are checked, and its current capacity
+// is increased by the quantity of the batch in the same transaction
+func (r *ProductBatchMysql) Save(ctx context.Context, productBatch *internal.ProductBatch) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the section
+		var section internal.Section
+		err = conn(ctx, r.db).QueryRowContext(ctx,
+			"SELECT `s`.`current_temperature`, `s`.`current_capacity`, `s`.`maximum_capacity`, `s`.`product_type_id` FROM `sections` AS `s` WHERE `s`.`id` = ? AND `s`.`deleted_at` IS NULL FOR UPDATE",
+			(*productBatch).SectionID,
+		).Scan(&section.CurrentTemperature, &section.CurrentCapacity, &section.MaximumCapacity, &section.ProductTypeID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = internal.ErrProductBatchRepositorySectionNotFound
+			}
+			return
+		}
+
+		// check the product, locking it so it is not deleted while the batch is saved
+		var productTypeID int
+		err = conn(ctx, r.db).QueryRowContext(ctx,
+			"SELECT `p`.`product_type_id` FROM `products` AS `p` WHERE `p`.`id` = ? AND `p`.`deleted_at` IS NULL FOR UPDATE",
+			(*productBatch).ProductID,
+		).Scan(&productTypeID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = internal.ErrProductBatchRepositoryProductNotFound
+			}
+			return
+		}
+
+		// check the section can hold the batch
+		switch {
+		case productTypeID != section.ProductTypeID:
+			err = internal.ErrProductBatchRepositoryProductType
+			return
+		case section.CurrentTemperature < (*productBatch).MinimumTemperature:
+			err = internal.ErrProductBatchRepositoryTemperature
+			return
+		case section.CurrentCapacity+(*productBatch).CurrentQuantity > section.MaximumCapacity:
+			err = internal.ErrProductBatchRepositoryCapacityExceeded
+			return
+		}
+
+		// insert the product batch
+		result, err := conn(ctx, r.db).ExecContext(ctx,
+			"INSERT INTO `product_batches` (`batch_number`, `current_quantity`, `initial_quantity`, `current_temperature`, `minimum_temperature`, `manufacturing_date`, `manufacturing_hour`, `due_date`, `product_id`, `section_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			(*productBatch).BatchNumber, (*productBatch).CurrentQuantity, (*productBatch).InitialQuantity, (*productBatch).CurrentTemperature, (*productBatch).MinimumTemperature, (*productBatch).ManufacturingDate, (*productBatch).ManufacturingHour, (*productBatch).DueDate, (*productBatch).ProductID, (*productBatch).SectionID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrProductBatchRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the id of the inserted product batch
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// update the current capacity of the section
+		_, err = conn(ctx, r.db).ExecContext(ctx,
+			"UPDATE `sections` SET `current_capacity` = `current_capacity` + ?, `version` = `version` + 1 WHERE `id` = ?",
+			(*productBatch).CurrentQuantity, (*productBatch).SectionID,
+		)
+		if err != nil {
+			return
+		}
+
+		// set the id of the product batch
+		(*productBatch).ID = int(id)
+		return
+	})
+	return
+}
+
+// ReportProducts returns the quantity of products stored in a section from the database
+func (r *ProductBatchMysql) ReportProducts(sectionID int) (report internal.SectionProductsReport, err error) {
+	// execute the query
+	row := r.db.QueryRow(
+		"SELECT `s`.`id`, `s`.`section_number`, COALESCE(SUM(`pb`.`current_quantity`), 0) FROM `sections` AS `s` LEFT JOIN `product_batches` AS `pb` ON `pb`.`section_id` = `s`.`id` WHERE `s`.`id` = ? AND `s`.`deleted_at` IS NULL GROUP BY `s`.`id`, `s`.`section_number`",
+		sectionID,
+	)
+
+	// scan the row into the report
+	err = row.Scan(&report.SectionID, &report.SectionNumber, &report.ProductsCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrProductBatchRepositorySectionNotFound
+		}
+		return
+	}
+
+	return
+}
+
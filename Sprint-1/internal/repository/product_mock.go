@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductMock creates a new instance of the product repository mock
+func NewProductMock() *ProductMock {
+	return &ProductMock{}
+}
+
+// ProductMock is a mock of the product repository
+// - each method calls its Func field and counts the call in Spy
+type ProductMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Product, error)
+	// FuncFindAllWithDeleted is the function called by FindAllWithDeleted
+	FuncFindAllWithDeleted func() ([]internal.Product, error)
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Product, error)
+	// FuncFindByIDForUpdate is the function called by FindByIDForUpdate
+	FuncFindByIDForUpdate func(ctx context.Context, id int) (internal.Product, error)
+	// FuncFindByCode is the function called by FindByCode
+	FuncFindByCode func(code string) (internal.Product, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, product *internal.Product) error
+	// FuncSaveAll is the function called by SaveAll
+	FuncSaveAll func(ctx context.Context, products []*internal.Product) error
+	// FuncUpdate is the function called by Update
+	FuncUpdate func(ctx context.Context, product *internal.Product) error
+	// FuncDelete is the function called by Delete
+	FuncDelete func(ctx context.Context, id int) error
+	// FuncRestore is the function called by Restore
+	FuncRestore func(ctx context.Context, id int) error
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// FindAllWithDeleted is the number of times FindAllWithDeleted was called
+		FindAllWithDeleted int
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// FindByIDForUpdate is the number of times FindByIDForUpdate was called
+		FindByIDForUpdate int
+		// FindByCode is the number of times FindByCode was called
+		FindByCode int
+		// Save is the number of times Save was called
+		Save int
+		// SaveAll is the number of times SaveAll was called
+		SaveAll int
+		// Update is the number of times Update was called
+		Update int
+		// Delete is the number of times Delete was called
+		Delete int
+		// Restore is the number of times Restore was called
+		Restore int
+	}
+}
+
+// FindAll returns all the products
+func (r *ProductMock) FindAll() ([]internal.Product, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// FindAllWithDeleted returns all the products, including the soft-deleted ones
+func (r *ProductMock) FindAllWithDeleted() ([]internal.Product, error) {
+	// spy
+	r.Spy.FindAllWithDeleted++
+
+	// mock
+	return r.FuncFindAllWithDeleted()
+}
+
+// FindByID returns the product with the given ID
+func (r *ProductMock) FindByID(id int) (internal.Product, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// FindByIDForUpdate returns the product with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *ProductMock) FindByIDForUpdate(ctx context.Context, id int) (internal.Product, error) {
+	// spy
+	r.Spy.FindByIDForUpdate++
+
+	// mock
+	return r.FuncFindByIDForUpdate(ctx, id)
+}
+
+// FindByCode returns the product with the given product_code
+func (r *ProductMock) FindByCode(code string) (internal.Product, error) {
+	// spy
+	r.Spy.FindByCode++
+
+	// mock
+	return r.FuncFindByCode(code)
+}
+
+// Save saves the given product
+func (r *ProductMock) Save(ctx context.Context, product *internal.Product) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, product)
+}
+
+// SaveAll saves the given products in a single transaction, none of them are saved if one fails
+func (r *ProductMock) SaveAll(ctx context.Context, products []*internal.Product) error {
+	// spy
+	r.Spy.SaveAll++
+
+	// mock
+	return r.FuncSaveAll(ctx, products)
+}
+
+// Update updates the given product, ErrVersionConflict is returned if its version is not the current one
+func (r *ProductMock) Update(ctx context.Context, product *internal.Product) error {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	return r.FuncUpdate(ctx, product)
+}
+
+// Delete soft-deletes the product with the given ID, ErrProductHasProductBatches or ErrProductHasProductRecords is returned if it still has them
+func (r *ProductMock) Delete(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	return r.FuncDelete(ctx, id)
+}
+
+// Restore restores the soft-deleted product with the given ID, ErrProductSellerNotFound is returned if its seller was deleted
+func (r *ProductMock) Restore(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Restore++
+
+	// mock
+	return r.FuncRestore(ctx, id)
+}
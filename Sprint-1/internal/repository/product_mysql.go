@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -20,10 +21,28 @@ type ProductMysql struct {
 	db *sql.DB
 }
 
-// FindAll returns all products from the database
+// FindAll returns all products from the database, except the soft-deleted ones
 func (r *ProductMysql) FindAll() (products []internal.Product, err error) {
+	products, err = r.findAll(false)
+	return
+}
+
+// FindAllWithDeleted returns all products from the database, including the soft-deleted ones
+func (r *ProductMysql) FindAllWithDeleted() (products []internal.Product, err error) {
+	products, err = r.findAll(true)
+	return
+}
+
+// findAll returns the products from the database, including the soft-deleted ones if requested
+func (r *ProductMysql) findAll(includeDeleted bool) (products []internal.Product, err error) {
+	// build the query
+	query := "SELECT `p`.`id`, `p`.`product_code`, `p`.`description`, `p`.`height`, `p`.`lenght`, `p`.`width`, `p`.`weight`, `p`.`expiration_rate`, `p`.`freezing_rate`, `p`.`recommended_freezing_temperature`, `p`.`product_type_id`, `p`.`seller_id`, `p`.`version` FROM `products` AS `p`"
+	if !includeDeleted {
+		query += " WHERE `p`.`deleted_at` IS NULL"
+	}
+
 	// execute the query
-	rows, err := r.db.Query("SELECT `p.id`, `p.product_code`, `p.description`, `p.height`, `p.length`, `p.width`, `p.weight`, `p.expiration_rate`, `p.freezing_rate`, `p.recom_freez_temp`, `p.product_type_id`, `p.seller_id` FROM `products` AS `p`")
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return
 	}
@@ -32,7 +51,7 @@ func (r *ProductMysql) FindAll() (products []internal.Product, err error) {
 	for rows.Next() {
 		// create a new product
 		var product internal.Product
-		err = rows.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.Width, &product.Weight, &product.ExpirationRate, &product.FreezingRate, &product.RecomFreezTemp, &product.ProductTypeID, &product.SellerID)
+		err = rows.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.Width, &product.Weight, &product.ExpirationRate, &product.FreezingRate, &product.RecomFreezTemp, &product.ProductTypeID, &product.SellerID, &product.Version)
 		if err != nil {
 			return
 		}
@@ -53,10 +72,10 @@ func (r *ProductMysql) FindAll() (products []internal.Product, err error) {
 // FindByID returns a product from the database by its id
 func (r *ProductMysql) FindByID(id int) (product internal.Product, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `p.id`, `p.product_code`, `p.description`, `p.height`, `p.length`, `p.width`, `p.weight`, `p.expiration_rate`, `p.freezing_rate`, `p.recom_freez_temp`, `p.product_type_id`, `p.seller_id` FROM `products` AS `p` WHERE `p.id` = ?", id)
+	row := r.db.QueryRow("SELECT `p`.`id`, `p`.`product_code`, `p`.`description`, `p`.`height`, `p`.`lenght`, `p`.`width`, `p`.`weight`, `p`.`expiration_rate`, `p`.`freezing_rate`, `p`.`recommended_freezing_temperature`, `p`.`product_type_id`, `p`.`seller_id`, `p`.`version` FROM `products` AS `p` WHERE `p`.`id` = ? AND `p`.`deleted_at` IS NULL", id)
 
 	// scan the row into the product
-	err = row.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.Width, &product.Weight, &product.ExpirationRate, &product.FreezingRate, &product.RecomFreezTemp, &product.ProductTypeID, &product.SellerID)
+	err = row.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.Width, &product.Weight, &product.ExpirationRate, &product.FreezingRate, &product.RecomFreezTemp, &product.ProductTypeID, &product.SellerID, &product.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrProductRepositoryNotFound
@@ -67,70 +86,225 @@ func (r *ProductMysql) FindByID(id int) (product internal.Product, err error) {
 	return
 }
 
-// Save saves a product into the database
-func (r *ProductMysql) Save(product *internal.Product) (err error) {
+// FindByIDForUpdate returns a product from the database by its id, also if it is soft-deleted, locking its row in the transaction of the context
+func (r *ProductMysql) FindByIDForUpdate(ctx context.Context, id int) (product internal.Product, err error) {
 	// execute the query
-	result, err := r.db.Exec(
-		"INSERT INTO `products` (`product_code`, `description`, `height`, `length`, `width`, `weight`, `expiration_rate`, `freezing_rate`, `recom_freez_temp`, `product_type_id`, `seller_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
-		(*product).ProductCode, (*product).Description, (*product).Height, (*product).Length, (*product).Width, (*product).Weight, (*product).ExpirationRate, (*product).FreezingRate, (*product).RecomFreezTemp, (*product).ProductTypeID, (*product).SellerID,
-	)
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT `p`.`id`, `p`.`product_code`, `p`.`description`, `p`.`height`, `p`.`lenght`, `p`.`width`, `p`.`weight`, `p`.`expiration_rate`, `p`.`freezing_rate`, `p`.`recommended_freezing_temperature`, `p`.`product_type_id`, `p`.`seller_id`, `p`.`version` FROM `products` AS `p` WHERE `p`.`id` = ? FOR UPDATE", id)
+
+	// scan the row into the product
+	err = row.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.Width, &product.Weight, &product.ExpirationRate, &product.FreezingRate, &product.RecomFreezTemp, &product.ProductTypeID, &product.SellerID, &product.Version)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrProductRepositoryDuplicated
-			default:
-				// ...
-			}
-			return
+		if err == sql.ErrNoRows {
+			err = internal.ErrProductRepositoryNotFound
 		}
-
 		return
 	}
 
-	// get the id of the inserted product
-	id, err := result.LastInsertId()
+	return
+}
+
+// FindByCode returns a product from the database by its product_code
+func (r *ProductMysql) FindByCode(code string) (product internal.Product, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `p`.`id`, `p`.`product_code`, `p`.`description`, `p`.`height`, `p`.`lenght`, `p`.`width`, `p`.`weight`, `p`.`expiration_rate`, `p`.`freezing_rate`, `p`.`recommended_freezing_temperature`, `p`.`product_type_id`, `p`.`seller_id`, `p`.`version` FROM `products` AS `p` WHERE `p`.`product_code` = ? AND `p`.`deleted_at` IS NULL", code)
+
+	// scan the row into the product
+	err = row.Scan(&product.ID, &product.ProductCode, &product.Description, &product.Height, &product.Length, &product.Width, &product.Weight, &product.ExpirationRate, &product.FreezingRate, &product.RecomFreezTemp, &product.ProductTypeID, &product.SellerID, &product.Version)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrProductRepositoryNotFound
+		}
 		return
 	}
 
-	// set the id of the product
-	(*product).ID = int(id)
+	return
+}
+
+// Save saves a product into the database
+func (r *ProductMysql) Save(ctx context.Context, product *internal.Product) (err error) {
+	err = r.save(ctx, product)
+	return
+}
+
+// SaveAll saves the given products into the database in a single transaction
+// - if a product fails, none of them are saved and the error is returned as an internal.BulkError with its row
+func (r *ProductMysql) SaveAll(ctx context.Context, products []*internal.Product) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// save the products
+		for i, product := range products {
+			err = r.save(ctx, product)
+			if err != nil {
+				err = internal.BulkError{{Row: i + 1, Err: err}}
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// save inserts a product, in the transaction of the context or in a new one, and sets its id
+// - its seller is locked first, internal.ErrProductSellerNotFound is returned if it was deleted
+func (r *ProductMysql) save(ctx context.Context, product *internal.Product) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the seller, so it is not deleted while the product is saved
+		err = lock(ctx, r.db, "sellers", (*product).SellerID, internal.ErrProductSellerNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `products` (`product_code`, `description`, `height`, `lenght`, `width`, `weight`, `expiration_rate`, `freezing_rate`, `recommended_freezing_temperature`, `product_type_id`, `seller_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			(*product).ProductCode, (*product).Description, (*product).Height, (*product).Length, (*product).Width, (*product).Weight, (*product).ExpirationRate, (*product).FreezingRate, (*product).RecomFreezTemp, (*product).ProductTypeID, (*product).SellerID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrProductRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the id of the inserted product
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the product
+		(*product).ID = int(id)
+		// a new product starts at its first version
+		(*product).Version = 1
 
+		return
+	})
 	return
 }
 
 // Update updates a product in the database
-func (r *ProductMysql) Update(product *internal.Product) (err error) {
-	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `products` SET `product_code` = ?, `description` = ?, `height` = ?, `length` = ?, `width` = ?, `weight` = ?, `expiration_rate` = ?, `freezing_rate` = ?, `recom_freez_temp` = ?, `product_type_id` = ?, `seller_id` = ? WHERE `id` = ?",
-		(*product).ProductCode, (*product).Description, (*product).Height, (*product).Length, (*product).Width, (*product).Weight, (*product).ExpirationRate, (*product).FreezingRate, (*product).RecomFreezTemp, (*product).ProductTypeID, (*product).SellerID, (*product).ID,
-	)
-	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrProductRepositoryDuplicated
-			default:
-				// ...
+// - the update only applies over the version of the product, otherwise internal.ErrVersionConflict is returned
+// - a soft-deleted product is not updated, internal.ErrProductRepositoryNotFound is returned
+// - its seller is locked first, internal.ErrProductSellerNotFound is returned if it was deleted
+func (r *ProductMysql) Update(ctx context.Context, product *internal.Product) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the seller, so it is not deleted while the product is saved
+		err = lock(ctx, r.db, "sellers", (*product).SellerID, internal.ErrProductSellerNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"UPDATE `products` SET `product_code` = ?, `description` = ?, `height` = ?, `lenght` = ?, `width` = ?, `weight` = ?, `expiration_rate` = ?, `freezing_rate` = ?, `recommended_freezing_temperature` = ?, `product_type_id` = ?, `seller_id` = ?, `version` = `version` + 1 WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL",
+			(*product).ProductCode, (*product).Description, (*product).Height, (*product).Length, (*product).Width, (*product).Weight, (*product).ExpirationRate, (*product).FreezingRate, (*product).RecomFreezTemp, (*product).ProductTypeID, (*product).SellerID, (*product).ID, (*product).Version,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrProductRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
 			}
+
+			return
+		}
+
+		// check the version was the current one, of a product not deleted
+		err = checkUpdated(ctx, r.db, result, "products", (*product).ID, internal.ErrProductRepositoryNotFound)
+		if err != nil {
 			return
 		}
-	}
 
+		// the product is now at its next version
+		(*product).Version++
+
+		return
+	})
 	return
 }
 
-// Delete deletes a product from the database by its id
-func (r *ProductMysql) Delete(id int) (err error) {
-	// execute the query
-	_, err = r.db.Exec("DELETE FROM `products` WHERE `id` = ?", id)
-	if err != nil {
+// Delete soft-deletes the product with the given id, keeping its row for the entities that reference it
+// - a product that still has batches or records is not deleted, internal.ErrProductHasProductBatches or internal.ErrProductHasProductRecords is returned
+func (r *ProductMysql) Delete(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the product, so nothing that references it is saved while it is deleted
+		err = lock(ctx, r.db, "products", id, internal.ErrProductRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// check the product has no product batches
+		err = checkNone(ctx, r.db, "SELECT COUNT(`pb`.`id`) FROM `product_batches` AS `pb` WHERE `pb`.`product_id` = ?", id, internal.ErrProductHasProductBatches)
+		if err != nil {
+			return
+		}
+
+		// check the product has no product records
+		err = checkNone(ctx, r.db, "SELECT COUNT(`pr`.`id`) FROM `product_records` AS `pr` WHERE `pr`.`product_id` = ?", id, internal.ErrProductHasProductRecords)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `products` SET `deleted_at` = NOW() WHERE `id` = ?", id)
 		return
-	}
+	})
+	return
+}
+
+// Restore restores the soft-deleted product with the given id
+// - its seller is checked again, internal.ErrProductSellerNotFound is returned if it was deleted meanwhile
+// - internal.ErrProductCodeDuplicated is returned if a product saved since it was deleted has its product_code
+func (r *ProductMysql) Restore(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the soft-deleted product and get its seller
+		var sellerID int
+		err = conn(ctx, r.db).QueryRowContext(ctx, "SELECT `p`.`seller_id` FROM `products` AS `p` WHERE `p`.`id` = ? AND `p`.`deleted_at` IS NOT NULL FOR UPDATE", id).Scan(&sellerID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = internal.ErrProductRepositoryNotFound
+			}
+			return
+		}
 
+		// lock the seller, so it is not deleted while the product is restored
+		err = lock(ctx, r.db, "sellers", sellerID, internal.ErrProductSellerNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `products` SET `deleted_at` = NULL WHERE `id` = ?", id)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrProductCodeDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		return
+	})
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewProductRecordAudit creates a new instance of the product record audit decorator
+func NewProductRecordAudit(rp internal.ProductRecordRepository, au internal.AuditRepository) *ProductRecordAudit {
+	return &ProductRecordAudit{
+		AuditSaveDecorator: NewAuditSaveDecorator[internal.ProductRecord](rp, au, "product_records", func(productRecord internal.ProductRecord) int { return productRecord.ID }),
+		rp:                 rp,
+	}
+}
+
+// ProductRecordAudit is the audit decorator of the product record repository
+// - the product records are only created, their creation is recorded by the embedded decorator and the reads go straight to rp
+type ProductRecordAudit struct {
+	*AuditSaveDecorator[internal.ProductRecord]
+	// rp is the decorated repository
+	rp internal.ProductRecordRepository
+}
+
+// ReportRecords returns the number of records of the product with the given ID, or of every product if it is zero
+func (r *ProductRecordAudit) ReportRecords(productID int) ([]internal.ProductRecordsReport, error) {
+	return r.rp.ReportRecords(productID)
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductRecordMock creates a new instance of the product record repository mock
+func NewProductRecordMock() *ProductRecordMock {
+	return &ProductRecordMock{}
+}
+
+// ProductRecordMock is a mock of the product record repository
+// - each method calls its Func field and counts the call in Spy
+type ProductRecordMock struct {
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, productRecord *internal.ProductRecord) error
+	// FuncReportRecords is the function called by ReportRecords
+	FuncReportRecords func(productID int) ([]internal.ProductRecordsReport, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// Save is the number of times Save was called
+		Save int
+		// ReportRecords is the number of times ReportRecords was called
+		ReportRecords int
+	}
+}
+
+// Save saves the given product record
+func (r *ProductRecordMock) Save(ctx context.Context, productRecord *internal.ProductRecord) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, productRecord)
+}
+
+// ReportRecords returns the number of records of the product with the given ID, or of every product if it is zero
+func (r *ProductRecordMock) ReportRecords(productID int) ([]internal.ProductRecordsReport, error) {
+	// spy
+	r.Spy.ReportRecords++
+
+	// mock
+	return r.FuncReportRecords(productID)
+}
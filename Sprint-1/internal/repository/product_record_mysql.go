@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductRecordMysql creates a new instance of the product record repository
+func NewProductRecordMysql(db *sql.DB) *ProductRecordMysql {
+	return &ProductRecordMysql{db}
+}
+
+// ProductRecordMysql is the mysql implementation of the product record repository
+type ProductRecordMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// Save saves a product record into the database
+func (r *ProductRecordMysql) Save(ctx context.Context, productRecord *internal.ProductRecord) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the product, so it is not deleted while the product record is saved
+		err = lock(ctx, r.db, "products", (*productRecord).ProductID, internal.ErrProductRecordRepositoryProductNotFound)
+		if err != nil {
+			return
+		}
+
+		// insert the product record
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `product_records` (`last_update_date`, `purchase_price`, `sale_price`, `product_id`) VALUES (?, ?, ?, ?)",
+			(*productRecord).LastUpdateDate, (*productRecord).PurchasePrice, (*productRecord).SalePrice, (*productRecord).ProductID,
+		)
+		if err != nil {
+			return
+		}
+
+		// get the id of the inserted product record
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the product record
+		(*productRecord).ID = int(id)
+
+		return
+	})
+	return
+}
+
+// ReportRecords returns the number of records of a product, or of every product if productID is zero, from the database
+func (r *ProductRecordMysql) ReportRecords(productID int) (reports []internal.ProductRecordsReport, err error) {
+	// build the query
+	query := "SELECT `p`.`id`, `p`.`description`, COUNT(`pr`.`id`) FROM `products` AS `p` LEFT JOIN `product_records` AS `pr` ON `pr`.`product_id` = `p`.`id` WHERE `p`.`deleted_at` IS NULL"
+	var args []any
+	if productID != 0 {
+		query += " AND `p`.`id` = ?"
+		args = append(args, productID)
+	}
+	query += " GROUP BY `p`.`id`, `p`.`description` ORDER BY `p`.`id`"
+
+	// execute the query
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	// iterate over the rows
+	for rows.Next() {
+		var report internal.ProductRecordsReport
+		err = rows.Scan(&report.ProductID, &report.Description, &report.RecordsCount)
+		if err != nil {
+			return
+		}
+
+		// append the report to the slice
+		reports = append(reports, report)
+	}
+
+	// check for errors
+	err = rows.Err()
+	if err != nil {
+		return
+	}
+
+	// the requested product must exist
+	if productID != 0 && len(reports) == 0 {
+		err = internal.ErrProductRepositoryNotFound
+		return
+	}
+
+	return
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductTypeMock creates a new instance of the product type repository mock
+func NewProductTypeMock() *ProductTypeMock {
+	return &ProductTypeMock{}
+}
+
+// ProductTypeMock is a mock of the product type repository
+// - each method calls its Func field and counts the call in Spy
+type ProductTypeMock struct {
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.ProductType, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindByID is the number of times FindByID was called
+		FindByID int
+	}
+}
+
+// FindByID returns the product type with the given ID
+func (r *ProductTypeMock) FindByID(id int) (internal.ProductType, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
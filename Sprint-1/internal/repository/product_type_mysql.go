@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductTypeMysql creates a new instance of the product type repository
+func NewProductTypeMysql(db *sql.DB) *ProductTypeMysql {
+	return &ProductTypeMysql{db}
+}
+
+// ProductTypeMysql is the mysql implementation of the product type repository
+type ProductTypeMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByID returns a product type from the database by its id
+func (r *ProductTypeMysql) FindByID(id int) (productType internal.ProductType, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `pt`.`id`, `pt`.`description` FROM `product_types` AS `pt` WHERE `pt`.`id` = ?", id)
+
+	// scan the row into the product type
+	err = row.Scan(&productType.ID, &productType.Description)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrProductTypeRepositoryNotFound
+		}
+		return
+	}
+
+	return
+}
@@ -0,0 +1,29 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewPurchaseOrderAudit creates a new instance of the purchase order audit decorator
+func NewPurchaseOrderAudit(rp internal.PurchaseOrderRepository, au internal.AuditRepository) *PurchaseOrderAudit {
+	return &PurchaseOrderAudit{
+		AuditSaveDecorator: NewAuditSaveDecorator[internal.PurchaseOrder](rp, au, "purchase_orders", func(purchaseOrder internal.PurchaseOrder) int { return purchaseOrder.ID }),
+		rp:                 rp,
+	}
+}
+
+// PurchaseOrderAudit is the audit decorator of the purchase order repository
+// - the purchase orders are only created, their creation is recorded by the embedded decorator and the reads go straight to rp
+type PurchaseOrderAudit struct {
+	*AuditSaveDecorator[internal.PurchaseOrder]
+	// rp is the decorated repository
+	rp internal.PurchaseOrderRepository
+}
+
+// FindByID returns the purchase order with the given ID
+func (r *PurchaseOrderAudit) FindByID(id int) (internal.PurchaseOrder, error) {
+	return r.rp.FindByID(id)
+}
+
+// ReportByBuyer returns the number of purchase orders placed by the buyer with the given ID
+func (r *PurchaseOrderAudit) ReportByBuyer(buyerID int) (internal.BuyerPurchaseOrdersReport, error) {
+	return r.rp.ReportByBuyer(buyerID)
+}
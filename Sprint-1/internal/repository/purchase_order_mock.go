@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewPurchaseOrderMock creates a new instance of the purchase order repository mock
+func NewPurchaseOrderMock() *PurchaseOrderMock {
+	return &PurchaseOrderMock{}
+}
+
+// PurchaseOrderMock is a mock of the purchase order repository
+// - each method calls its Func field and counts the call in Spy
+type PurchaseOrderMock struct {
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.PurchaseOrder, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, purchaseOrder *internal.PurchaseOrder) error
+	// FuncReportByBuyer is the function called by ReportByBuyer
+	FuncReportByBuyer func(buyerID int) (internal.BuyerPurchaseOrdersReport, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// Save is the number of times Save was called
+		Save int
+		// ReportByBuyer is the number of times ReportByBuyer was called
+		ReportByBuyer int
+	}
+}
+
+// FindByID returns the purchase order with the given ID
+func (r *PurchaseOrderMock) FindByID(id int) (internal.PurchaseOrder, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// Save saves the given purchase order
+func (r *PurchaseOrderMock) Save(ctx context.Context, purchaseOrder *internal.PurchaseOrder) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, purchaseOrder)
+}
+
+// ReportByBuyer returns the number of purchase orders placed by the buyer with the given ID
+func (r *PurchaseOrderMock) ReportByBuyer(buyerID int) (internal.BuyerPurchaseOrdersReport, error) {
+	// spy
+	r.Spy.ReportByBuyer++
+
+	// mock
+	return r.FuncReportByBuyer(buyerID)
+}
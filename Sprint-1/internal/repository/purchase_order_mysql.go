@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// NewPurchaseOrderMysql creates a new instance of the purchase order repository
+func NewPurchaseOrderMysql(db *sql.DB) *PurchaseOrderMysql {
+	return &PurchaseOrderMysql{db}
+}
+
+// PurchaseOrderMysql is the mysql implementation of the purchase order repository
+type PurchaseOrderMysql struct {
+	// db is the database connection to mysql
+	db *sql.DB
+}
+
+// FindByID returns a purchase order from the database by its id
+func (r *PurchaseOrderMysql) FindByID(id int) (purchaseOrder internal.PurchaseOrder, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `po`.`id`, `po`.`order_number`, `po`.`order_date`, `po`.`tracking_code`, `po`.`buyer_id`, `po`.`product_record_id`, `po`.`carrier_id`, `po`.`status` FROM `purchase_orders` AS `po` WHERE `po`.`id` = ?", id)
+
+	// scan the row into the purchase order
+	err = row.Scan(&purchaseOrder.ID, &purchaseOrder.OrderNumber, &purchaseOrder.OrderDate, &purchaseOrder.TrackingCode, &purchaseOrder.BuyerID, &purchaseOrder.ProductRecordID, &purchaseOrder.CarrierID, &purchaseOrder.Status)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrPurchaseOrderRepositoryNotFound
+		}
+		return
+	}
+
+	return
+}
+
+// Save saves a purchase order into the database
+// - the buyer, the carrier and the product record are locked while the purchase order is inserted, so they can not be deleted in between
+func (r *PurchaseOrderMysql) Save(ctx context.Context, purchaseOrder *internal.PurchaseOrder) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the buyer, the carrier and the product record, so they are not deleted while the purchase order is saved
+		err = lock(ctx, r.db, "buyers", (*purchaseOrder).BuyerID, internal.ErrPurchaseOrderRepositoryBuyerNotFound)
+		if err != nil {
+			return
+		}
+		err = lockRow(ctx, r.db, "carriers", (*purchaseOrder).CarrierID, internal.ErrPurchaseOrderRepositoryCarrierNotFound)
+		if err != nil {
+			return
+		}
+		err = lockRow(ctx, r.db, "product_records", (*purchaseOrder).ProductRecordID, internal.ErrPurchaseOrderRepositoryProductRecordNotFound)
+		if err != nil {
+			return
+		}
+
+		// insert the purchase order
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `purchase_orders` (`order_number`, `order_date`, `tracking_code`, `buyer_id`, `product_record_id`, `carrier_id`, `status`) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			(*purchaseOrder).OrderNumber, (*purchaseOrder).OrderDate, (*purchaseOrder).TrackingCode, (*purchaseOrder).BuyerID, (*purchaseOrder).ProductRecordID, (*purchaseOrder).CarrierID, (*purchaseOrder).Status,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+				err = internal.ErrPurchaseOrderRepositoryDuplicated
+			}
+			return
+		}
+
+		// get the id of the inserted purchase order
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
+
+		// set the id of the purchase order
+		(*purchaseOrder).ID = int(id)
+
+		return
+	})
+	return
+}
+
+// ReportByBuyer returns the number of purchase orders placed by a buyer from the database
+func (r *PurchaseOrderMysql) ReportByBuyer(buyerID int) (report internal.BuyerPurchaseOrdersReport, err error) {
+	// execute the query
+	row := r.db.QueryRow(
+		"SELECT `b`.`id`, `b`.`card_number_id`, `b`.`first_name`, `b`.`last_name`, `b`.`version`, COUNT(`po`.`id`) FROM `buyers` AS `b` LEFT JOIN `purchase_orders` AS `po` ON `po`.`buyer_id` = `b`.`id` WHERE `b`.`id` = ? AND `b`.`deleted_at` IS NULL GROUP BY `b`.`id`, `b`.`card_number_id`, `b`.`first_name`, `b`.`last_name`, `b`.`version`",
+		buyerID,
+	)
+
+	// scan the row into the report
+	err = row.Scan(&report.ID, &report.CardNumberID, &report.FirstName, &report.LastName, &report.Version, &report.PurchaseOrdersCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrPurchaseOrderRepositoryBuyerNotFound
+		}
+		return
+	}
+
+	return
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewSectionAudit creates a new instance of the section audit decorator
+func NewSectionAudit(rp internal.SectionRepository, au internal.AuditRepository) *SectionAudit {
+	return &SectionAudit{
+		AuditDecorator: NewAuditDecorator[internal.Section](rp, au, "sections", func(section internal.Section) int { return section.ID }),
+		rp:             rp,
+	}
+}
+
+// SectionAudit is the audit decorator of the section repository
+// - the write operations are recorded by the embedded decorator, the section specific reads go straight to rp
+type SectionAudit struct {
+	*AuditDecorator[internal.Section]
+	// rp is the decorated repository
+	rp internal.SectionRepository
+}
+
+// ReportByWarehouse returns the aggregates of the sections of a warehouse
+func (r *SectionAudit) ReportByWarehouse(warehouseID int) (internal.SectionWarehouseReport, error) {
+	return r.rp.ReportByWarehouse(warehouseID)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewSectionMock creates a new instance of the section repository mock
+func NewSectionMock() *SectionMock {
+	return &SectionMock{}
+}
+
+// SectionMock is a mock of the section repository
+// - each method calls its Func field and counts the call in Spy
+type SectionMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Section, error)
+	// FuncFindAllWithDeleted is the function called by FindAllWithDeleted
+	FuncFindAllWithDeleted func() ([]internal.Section, error)
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Section, error)
+	// FuncFindByIDForUpdate is the function called by FindByIDForUpdate
+	FuncFindByIDForUpdate func(ctx context.Context, id int) (internal.Section, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, section *internal.Section) error
+	// FuncSaveAll is the function called by SaveAll
+	FuncSaveAll func(ctx context.Context, sections []*internal.Section) error
+	// FuncUpdate is the function called by Update
+	FuncUpdate func(ctx context.Context, section *internal.Section) error
+	// FuncDelete is the function called by Delete
+	FuncDelete func(ctx context.Context, id int) error
+	// FuncRestore is the function called by Restore
+	FuncRestore func(ctx context.Context, id int) error
+	// FuncReportByWarehouse is the function called by ReportByWarehouse
+	FuncReportByWarehouse func(warehouseID int) (internal.SectionWarehouseReport, error)
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// FindAllWithDeleted is the number of times FindAllWithDeleted was called
+		FindAllWithDeleted int
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// FindByIDForUpdate is the number of times FindByIDForUpdate was called
+		FindByIDForUpdate int
+		// Save is the number of times Save was called
+		Save int
+		// SaveAll is the number of times SaveAll was called
+		SaveAll int
+		// Update is the number of times Update was called
+		Update int
+		// Delete is the number of times Delete was called
+		Delete int
+		// Restore is the number of times Restore was called
+		Restore int
+		// ReportByWarehouse is the number of times ReportByWarehouse was called
+		ReportByWarehouse int
+	}
+}
+
+// FindAll returns all the sections
+func (r *SectionMock) FindAll() ([]internal.Section, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// FindAllWithDeleted returns all the sections, including the soft-deleted ones
+func (r *SectionMock) FindAllWithDeleted() ([]internal.Section, error) {
+	// spy
+	r.Spy.FindAllWithDeleted++
+
+	// mock
+	return r.FuncFindAllWithDeleted()
+}
+
+// FindByID returns the section with the given ID
+func (r *SectionMock) FindByID(id int) (internal.Section, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// FindByIDForUpdate returns the section with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *SectionMock) FindByIDForUpdate(ctx context.Context, id int) (internal.Section, error) {
+	// spy
+	r.Spy.FindByIDForUpdate++
+
+	// mock
+	return r.FuncFindByIDForUpdate(ctx, id)
+}
+
+// Save saves the given section
+func (r *SectionMock) Save(ctx context.Context, section *internal.Section) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, section)
+}
+
+// SaveAll saves the given sections in a single transaction, none of them are saved if one fails
+func (r *SectionMock) SaveAll(ctx context.Context, sections []*internal.Section) error {
+	// spy
+	r.Spy.SaveAll++
+
+	// mock
+	return r.FuncSaveAll(ctx, sections)
+}
+
+// Update updates the given section, ErrVersionConflict is returned if its version is not the current one
+func (r *SectionMock) Update(ctx context.Context, section *internal.Section) error {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	return r.FuncUpdate(ctx, section)
+}
+
+// Delete soft-deletes the section with the given ID, ErrSectionHasProductBatches is returned if it still stores product batches
+func (r *SectionMock) Delete(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	return r.FuncDelete(ctx, id)
+}
+
+// Restore restores the soft-deleted section with the given ID, ErrSectionWarehouseNotFound is returned if its warehouse was deleted
+func (r *SectionMock) Restore(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Restore++
+
+	// mock
+	return r.FuncRestore(ctx, id)
+}
+
+// ReportByWarehouse returns the aggregates of the sections of the warehouse with the given ID
+func (r *SectionMock) ReportByWarehouse(warehouseID int) (internal.SectionWarehouseReport, error) {
+	// spy
+	r.Spy.ReportByWarehouse++
+
+	// mock
+	return r.FuncReportByWarehouse(warehouseID)
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -20,10 +21,28 @@ type SectionMysql struct {
 	db *sql.DB
 }
 
-// FindAll returns all sections from the database
+// FindAll returns all sections from the database, except the soft-deleted ones
 func (r *SectionMysql) FindAll() (sections []internal.Section, err error) {
+	sections, err = r.findAll(false)
+	return
+}
+
+// FindAllWithDeleted returns all sections from the database, including the soft-deleted ones
+func (r *SectionMysql) FindAllWithDeleted() (sections []internal.Section, err error) {
+	sections, err = r.findAll(true)
+	return
+}
+
+// findAll returns the sections from the database, including the soft-deleted ones if requested
+func (r *SectionMysql) findAll(includeDeleted bool) (sections []internal.Section, err error) {
+	// build the query
+	query := "SELECT `s`.`id`, `s`.`section_number`, `s`.`current_temperature`, `s`.`minimum_temperature`, `s`.`current_capacity`, `s`.`minimum_capacity`, `s`.`maximum_capacity`, `s`.`warehouse_id`, `s`.`product_type_id`, `s`.`version` FROM `sections` AS `s`"
+	if !includeDeleted {
+		query += " WHERE `s`.`deleted_at` IS NULL"
+	}
+
 	// execute the query
-	rows, err := r.db.Query("SELECT `s.id`, `s.section_number`, `s.current_temperature`, `s.minimum_temperature`, `s.current_capacity`, `s.minimum_capacity`, `s.maximum_capacity`, `s.warehouse_id`, `s.product_type_id` FROM `sections` AS `s`")
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return
 	}
@@ -32,7 +51,7 @@ func (r *SectionMysql) FindAll() (sections []internal.Section, err error) {
 	for rows.Next() {
 		// create a new section
 		var section internal.Section
-		err = rows.Scan(&section.ID, &section.SectionNumber, &section.CurrentTemperature, &section.MinimumTemperature, &section.CurrentCapacity, &section.MinimumCapacity, &section.MaximumCapacity, &section.WarehouseID, &section.ProductTypeID)
+		err = rows.Scan(&section.ID, &section.SectionNumber, &section.CurrentTemperature, &section.MinimumTemperature, &section.CurrentCapacity, &section.MinimumCapacity, &section.MaximumCapacity, &section.WarehouseID, &section.ProductTypeID, &section.Version)
 		if err != nil {
 			return
 		}
@@ -53,10 +72,10 @@ func (r *SectionMysql) FindAll() (sections []internal.Section, err error) {
 // FindByID returns a section from the database by its id
 func (r *SectionMysql) FindByID(id int) (section internal.Section, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `s.id`, `s.section_number`, `s.current_temperature`, `s.minimum_temperature`, `s.current_capacity`, `s.minimum_capacity`, `s.maximum_capacity`, `s.warehouse_id`, `s.product_type_id` FROM `sections` AS `s` WHERE `s.id` = ?", id)
+	row := r.db.QueryRow("SELECT `s`.`id`, `s`.`section_number`, `s`.`current_temperature`, `s`.`minimum_temperature`, `s`.`current_capacity`, `s`.`minimum_capacity`, `s`.`maximum_capacity`, `s`.`warehouse_id`, `s`.`product_type_id`, `s`.`version` FROM `sections` AS `s` WHERE `s`.`id` = ? AND `s`.`deleted_at` IS NULL", id)
 
 	// scan the row into the section
-	err = row.Scan(&section.ID, &section.SectionNumber, &section.CurrentTemperature, &section.MinimumTemperature, &section.CurrentCapacity, &section.MinimumCapacity, &section.MaximumCapacity, &section.WarehouseID, &section.ProductTypeID)
+	err = row.Scan(&section.ID, &section.SectionNumber, &section.CurrentTemperature, &section.MinimumTemperature, &section.CurrentCapacity, &section.MinimumCapacity, &section.MaximumCapacity, &section.WarehouseID, &section.ProductTypeID, &section.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrSectionRepositoryNotFound
@@ -67,72 +86,204 @@ func (r *SectionMysql) FindByID(id int) (section internal.Section, err error) {
 	return
 }
 
-// Save saves a section into the database
-func (r *SectionMysql) Save(section *internal.Section) (err error) {
+// FindByIDForUpdate returns a section from the database by its id, also if it is soft-deleted, locking its row in the transaction of the context
+func (r *SectionMysql) FindByIDForUpdate(ctx context.Context, id int) (section internal.Section, err error) {
 	// execute the query
-	result, err := r.db.Exec(
-		"INSERT INTO `sections` (`section_number`, `current_temperature`, `minimum_temperature`, `current_capacity`, `minimum_capacity`, `maximum_capacity`, `warehouse_id`, `product_type_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
-		(*section).SectionNumber, (*section).CurrentTemperature, (*section).MinimumTemperature, (*section).CurrentCapacity, (*section).MinimumCapacity, (*section).MaximumCapacity, (*section).WarehouseID, (*section).ProductTypeID,
-	)
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT `s`.`id`, `s`.`section_number`, `s`.`current_temperature`, `s`.`minimum_temperature`, `s`.`current_capacity`, `s`.`minimum_capacity`, `s`.`maximum_capacity`, `s`.`warehouse_id`, `s`.`product_type_id`, `s`.`version` FROM `sections` AS `s` WHERE `s`.`id` = ? FOR UPDATE", id)
+
+	// scan the row into the section
+	err = row.Scan(&section.ID, &section.SectionNumber, &section.CurrentTemperature, &section.MinimumTemperature, &section.CurrentCapacity, &section.MinimumCapacity, &section.MaximumCapacity, &section.WarehouseID, &section.ProductTypeID, &section.Version)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrSectionRepositoryDuplicated
-			default:
-				// ...
-			}
-			return
+		if err == sql.ErrNoRows {
+			err = internal.ErrSectionRepositoryNotFound
 		}
-
 		return
 	}
 
-	// get the id of the inserted section
-	id, err := result.LastInsertId()
-	if err != nil {
+	return
+}
+
+// Save saves a section into the database
+func (r *SectionMysql) Save(ctx context.Context, section *internal.Section) (err error) {
+	err = r.save(ctx, section)
+	return
+}
+
+// SaveAll saves the given sections into the database in a single transaction
+// - if a section fails, none of them are saved and the error is returned as an internal.BulkError with its row
+func (r *SectionMysql) SaveAll(ctx context.Context, sections []*internal.Section) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// save the sections
+		for i, section := range sections {
+			err = r.save(ctx, section)
+			if err != nil {
+				err = internal.BulkError{{Row: i + 1, Err: err}}
+				return
+			}
+		}
 		return
-	}
+	})
+	return
+}
+
+// save inserts a section, in the transaction of the context or in a new one, and sets its id
+// - its warehouse is locked first, internal.ErrSectionWarehouseNotFound is returned if it was deleted
+func (r *SectionMysql) save(ctx context.Context, section *internal.Section) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the warehouse, so it is not deleted while the section is saved
+		err = lock(ctx, r.db, "warehouses", (*section).WarehouseID, internal.ErrSectionWarehouseNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `sections` (`section_number`, `current_temperature`, `minimum_temperature`, `current_capacity`, `minimum_capacity`, `maximum_capacity`, `warehouse_id`, `product_type_id`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			(*section).SectionNumber, (*section).CurrentTemperature, (*section).MinimumTemperature, (*section).CurrentCapacity, (*section).MinimumCapacity, (*section).MaximumCapacity, (*section).WarehouseID, (*section).ProductTypeID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrSectionRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the id of the inserted section
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
 
-	// set the id of the section
-	(*section).ID = int(id)
+		// set the id of the section
+		(*section).ID = int(id)
+		// a new section starts at its first version
+		(*section).Version = 1
 
+		return
+	})
 	return
 }
 
 // Update updates a section in the database
-func (r *SectionMysql) Update(section *internal.Section) (err error) {
-	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `sections` SET `section_number` = ?, `current_temperature` = ?, `minimum_temperature` = ?, `current_capacity` = ?, `minimum_capacity` = ?, `maximum_capacity` = ?, `warehouse_id` = ?, `product_type_id` = ? WHERE `id` = ?",
-		(*section).SectionNumber, (*section).CurrentTemperature, (*section).MinimumTemperature, (*section).CurrentCapacity, (*section).MinimumCapacity, (*section).MaximumCapacity, (*section).WarehouseID, (*section).ProductTypeID, (*section).ID,
-	)
-	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrSectionRepositoryDuplicated
-			default:
-				// ...
+// - the update only applies over the version of the section, otherwise internal.ErrVersionConflict is returned
+// - a soft-deleted section is not updated, internal.ErrSectionRepositoryNotFound is returned
+// - the current capacity is not updated, it is only changed by the product batches stored in the section
+// - its warehouse is locked first, internal.ErrSectionWarehouseNotFound is returned if it was deleted
+func (r *SectionMysql) Update(ctx context.Context, section *internal.Section) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the warehouse, so it is not deleted while the section is saved
+		err = lock(ctx, r.db, "warehouses", (*section).WarehouseID, internal.ErrSectionWarehouseNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"UPDATE `sections` SET `section_number` = ?, `current_temperature` = ?, `minimum_temperature` = ?, `minimum_capacity` = ?, `maximum_capacity` = ?, `warehouse_id` = ?, `product_type_id` = ?, `version` = `version` + 1 WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL",
+			(*section).SectionNumber, (*section).CurrentTemperature, (*section).MinimumTemperature, (*section).MinimumCapacity, (*section).MaximumCapacity, (*section).WarehouseID, (*section).ProductTypeID, (*section).ID, (*section).Version,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrSectionRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
 			}
+
+			return
+		}
+
+		// check the version was the current one, of a section not deleted
+		err = checkUpdated(ctx, r.db, result, "sections", (*section).ID, internal.ErrSectionRepositoryNotFound)
+		if err != nil {
 			return
 		}
 
+		// the section is now at its next version
+		(*section).Version++
+
 		return
-	}
+	})
+	return
+}
+
+// Delete soft-deletes the section with the given id, keeping its row for the entities that reference it
+// - a section that still stores product batches is not deleted, internal.ErrSectionHasProductBatches is returned
+func (r *SectionMysql) Delete(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the section, so no product batch is stored in it while it is deleted
+		err = lock(ctx, r.db, "sections", id, internal.ErrSectionRepositoryNotFound)
+		if err != nil {
+			return
+		}
 
+		// check the section has no product batches
+		err = checkNone(ctx, r.db, "SELECT COUNT(`pb`.`id`) FROM `product_batches` AS `pb` WHERE `pb`.`section_id` = ?", id, internal.ErrSectionHasProductBatches)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `sections` SET `deleted_at` = NOW() WHERE `id` = ?", id)
+		return
+	})
 	return
 }
 
-// Delete deletes a section from the database by its id
-func (r *SectionMysql) Delete(id int) (err error) {
+// Restore restores the soft-deleted section with the given id
+// - its warehouse is checked again, internal.ErrSectionWarehouseNotFound is returned if it was deleted meanwhile
+func (r *SectionMysql) Restore(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the soft-deleted section and get its warehouse
+		var warehouseID int
+		err = conn(ctx, r.db).QueryRowContext(ctx, "SELECT `s`.`warehouse_id` FROM `sections` AS `s` WHERE `s`.`id` = ? AND `s`.`deleted_at` IS NOT NULL FOR UPDATE", id).Scan(&warehouseID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				err = internal.ErrSectionRepositoryNotFound
+			}
+			return
+		}
+
+		// lock the warehouse, so it is not deleted while the section is restored
+		err = lock(ctx, r.db, "warehouses", warehouseID, internal.ErrSectionWarehouseNotFound)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `sections` SET `deleted_at` = NULL WHERE `id` = ?", id)
+		return
+	})
+	return
+}
+
+// ReportByWarehouse returns the aggregates of the sections of a warehouse from the database
+func (r *SectionMysql) ReportByWarehouse(warehouseID int) (report internal.SectionWarehouseReport, err error) {
 	// execute the query
-	_, err = r.db.Exec("DELETE FROM `sections` WHERE `id` = ?", id)
+	row := r.db.QueryRow(
+		"SELECT COUNT(`s`.`id`), COALESCE(SUM(`s`.`maximum_capacity`), 0), COALESCE(SUM(`s`.`current_capacity`), 0), COALESCE(SUM(`s`.`current_temperature` < `w`.`minimum_temperature`), 0) FROM `warehouses` AS `w` LEFT JOIN `sections` AS `s` ON `s`.`warehouse_id` = `w`.`id` AND `s`.`deleted_at` IS NULL WHERE `w`.`id` = ?",
+		warehouseID,
+	)
+
+	// scan the row into the report
+	err = row.Scan(&report.SectionsCount, &report.TotalCapacity, &report.UsedCapacity, &report.SectionsBelowMinimumTemperature)
 	if err != nil {
 		return
 	}
 
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewSellerAudit creates a new instance of the seller audit decorator
+func NewSellerAudit(rp internal.SellerRepository, au internal.AuditRepository) *SellerAudit {
+	return &SellerAudit{
+		AuditDecorator: NewAuditDecorator[internal.Seller](rp, au, "sellers", func(seller internal.Seller) int { return seller.ID }),
+		rp:             rp,
+	}
+}
+
+// SellerAudit is the audit decorator of the seller repository
+// - the write operations are recorded by the embedded decorator, the seller specific reads go straight to rp
+type SellerAudit struct {
+	*AuditDecorator[internal.Seller]
+	// rp is the decorated repository
+	rp internal.SellerRepository
+}
+
+// FindByCID returns a seller by its cid
+func (r *SellerAudit) FindByCID(cid int) (internal.Seller, error) {
+	return r.rp.FindByCID(cid)
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewSellerMock creates a new instance of the seller repository mock
+func NewSellerMock() *SellerMock {
+	return &SellerMock{}
+}
+
+// SellerMock is a mock of the seller repository
+// - each method calls its Func field and counts the call in Spy
+type SellerMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Seller, error)
+	// FuncFindAllWithDeleted is the function called by FindAllWithDeleted
+	FuncFindAllWithDeleted func() ([]internal.Seller, error)
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Seller, error)
+	// FuncFindByIDForUpdate is the function called by FindByIDForUpdate
+	FuncFindByIDForUpdate func(ctx context.Context, id int) (internal.Seller, error)
+	// FuncFindByCID is the function called by FindByCID
+	FuncFindByCID func(cid int) (internal.Seller, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, seller *internal.Seller) error
+	// FuncSaveAll is the function called by SaveAll
+	FuncSaveAll func(ctx context.Context, sellers []*internal.Seller) error
+	// FuncUpdate is the function called by Update
+	FuncUpdate func(ctx context.Context, seller *internal.Seller) error
+	// FuncDelete is the function called by Delete
+	FuncDelete func(ctx context.Context, id int) error
+	// FuncRestore is the function called by Restore
+	FuncRestore func(ctx context.Context, id int) error
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// FindAllWithDeleted is the number of times FindAllWithDeleted was called
+		FindAllWithDeleted int
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// FindByIDForUpdate is the number of times FindByIDForUpdate was called
+		FindByIDForUpdate int
+		// FindByCID is the number of times FindByCID was called
+		FindByCID int
+		// Save is the number of times Save was called
+		Save int
+		// SaveAll is the number of times SaveAll was called
+		SaveAll int
+		// Update is the number of times Update was called
+		Update int
+		// Delete is the number of times Delete was called
+		Delete int
+		// Restore is the number of times Restore was called
+		Restore int
+	}
+}
+
+// FindAll returns all the sellers
+func (r *SellerMock) FindAll() ([]internal.Seller, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// FindAllWithDeleted returns all the sellers, including the soft-deleted ones
+func (r *SellerMock) FindAllWithDeleted() ([]internal.Seller, error) {
+	// spy
+	r.Spy.FindAllWithDeleted++
+
+	// mock
+	return r.FuncFindAllWithDeleted()
+}
+
+// FindByID returns the seller with the given ID
+func (r *SellerMock) FindByID(id int) (internal.Seller, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// FindByIDForUpdate returns the seller with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *SellerMock) FindByIDForUpdate(ctx context.Context, id int) (internal.Seller, error) {
+	// spy
+	r.Spy.FindByIDForUpdate++
+
+	// mock
+	return r.FuncFindByIDForUpdate(ctx, id)
+}
+
+// FindByCID returns the seller with the given cid
+func (r *SellerMock) FindByCID(cid int) (internal.Seller, error) {
+	// spy
+	r.Spy.FindByCID++
+
+	// mock
+	return r.FuncFindByCID(cid)
+}
+
+// Save saves the given seller
+func (r *SellerMock) Save(ctx context.Context, seller *internal.Seller) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, seller)
+}
+
+// SaveAll saves the given sellers in a single transaction, none of them are saved if one fails
+func (r *SellerMock) SaveAll(ctx context.Context, sellers []*internal.Seller) error {
+	// spy
+	r.Spy.SaveAll++
+
+	// mock
+	return r.FuncSaveAll(ctx, sellers)
+}
+
+// Update updates the given seller, ErrVersionConflict is returned if its version is not the current one
+func (r *SellerMock) Update(ctx context.Context, seller *internal.Seller) error {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	return r.FuncUpdate(ctx, seller)
+}
+
+// Delete soft-deletes the seller with the given ID, ErrSellerHasProducts is returned if it still has products
+func (r *SellerMock) Delete(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	return r.FuncDelete(ctx, id)
+}
+
+// Restore restores the soft-deleted seller with the given ID, ErrSellerCIDDuplicated is returned if another seller has its cid
+func (r *SellerMock) Restore(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Restore++
+
+	// mock
+	return r.FuncRestore(ctx, id)
+}
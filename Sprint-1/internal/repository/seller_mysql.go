@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -20,10 +21,28 @@ type SellerMysql struct {
 	db *sql.DB
 }
 
-// FindAll returns all sellers from the database
+// FindAll returns all sellers from the database, except the soft-deleted ones
 func (r *SellerMysql) FindAll() (sellers []internal.Seller, err error) {
+	sellers, err = r.findAll(false)
+	return
+}
+
+// FindAllWithDeleted returns all sellers from the database, including the soft-deleted ones
+func (r *SellerMysql) FindAllWithDeleted() (sellers []internal.Seller, err error) {
+	sellers, err = r.findAll(true)
+	return
+}
+
+// findAll returns the sellers from the database, including the soft-deleted ones if requested
+func (r *SellerMysql) findAll(includeDeleted bool) (sellers []internal.Seller, err error) {
+	// build the query
+	query := "SELECT `s`.`id`, `s`.`cid`, `s`.`company_name`, `s`.`address`, `s`.`telephone`, COALESCE(`s`.`locality_id`, 0), `s`.`version` FROM `sellers` AS `s`"
+	if !includeDeleted {
+		query += " WHERE `s`.`deleted_at` IS NULL"
+	}
+
 	// execute the query
-	rows, err := r.db.Query("SELECT `s.id`, `s.cid`, `c.company_name`, `c.address`, `c.telephone` FROM `sellers` AS `s`")
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return
 	}
@@ -32,7 +51,7 @@ func (r *SellerMysql) FindAll() (sellers []internal.Seller, err error) {
 	for rows.Next() {
 		// create a new seller
 		var seller internal.Seller
-		err = rows.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone)
+		err = rows.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.LocalityID, &seller.Version)
 		if err != nil {
 			return
 		}
@@ -53,10 +72,10 @@ func (r *SellerMysql) FindAll() (sellers []internal.Seller, err error) {
 // FindByID returns a seller from the database by its id
 func (r *SellerMysql) FindByID(id int) (seller internal.Seller, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `s.id`, `s.cid`, `c.company_name`, `c.address`, `c.telephone` FROM `sellers` AS `s` WHERE `s.id` = ?", id)
+	row := r.db.QueryRow("SELECT `s`.`id`, `s`.`cid`, `s`.`company_name`, `s`.`address`, `s`.`telephone`, COALESCE(`s`.`locality_id`, 0), `s`.`version` FROM `sellers` AS `s` WHERE `s`.`id` = ? AND `s`.`deleted_at` IS NULL", id)
 
 	// scan the row into the seller
-	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone)
+	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.LocalityID, &seller.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrSellerRepositoryNotFound
@@ -68,53 +87,198 @@ func (r *SellerMysql) FindByID(id int) (seller internal.Seller, err error) {
 	return
 }
 
-// Save saves a seller into the database
-func (r *SellerMysql) Save(seller *internal.Seller) (err error) {
+// FindByIDForUpdate returns a seller from the database by its id, also if it is soft-deleted, locking its row in the transaction of the context
+func (r *SellerMysql) FindByIDForUpdate(ctx context.Context, id int) (seller internal.Seller, err error) {
 	// execute the query
-	result, err := r.db.Exec(
-		"INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`) VALUES (?, ?, ?, ?)",
-		(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone,
-	)
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT `s`.`id`, `s`.`cid`, `s`.`company_name`, `s`.`address`, `s`.`telephone`, COALESCE(`s`.`locality_id`, 0), `s`.`version` FROM `sellers` AS `s` WHERE `s`.`id` = ? FOR UPDATE", id)
+
+	// scan the row into the seller
+	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.LocalityID, &seller.Version)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrSellerRepositoryDuplicated
-			default:
-				// ...
-			}
+		if err == sql.ErrNoRows {
+			err = internal.ErrSellerRepositoryNotFound
 			return
 		}
-
 		return
 	}
 
-	// get the last inserted id
-	id, err := result.LastInsertId()
+	return
+}
+
+// FindByCID returns a seller from the database by its cid
+func (r *SellerMysql) FindByCID(cid int) (seller internal.Seller, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `s`.`id`, `s`.`cid`, `s`.`company_name`, `s`.`address`, `s`.`telephone`, COALESCE(`s`.`locality_id`, 0), `s`.`version` FROM `sellers` AS `s` WHERE `s`.`cid` = ? AND `s`.`deleted_at` IS NULL", cid)
+
+	// scan the row into the seller
+	err = row.Scan(&seller.ID, &seller.CID, &seller.CompanyName, &seller.Address, &seller.Telephone, &seller.LocalityID, &seller.Version)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrSellerRepositoryNotFound
+			return
+		}
 		return
 	}
 
-	// set the id of the seller
-	(*seller).ID = int(id)
+	return
+}
+
+// Save saves a seller into the database
+func (r *SellerMysql) Save(ctx context.Context, seller *internal.Seller) (err error) {
+	err = r.save(ctx, seller)
+	return
+}
+
+// SaveAll saves the given sellers into the database in a single transaction
+// - if a seller fails, none of them are saved and the error is returned as an internal.BulkError with its row
+func (r *SellerMysql) SaveAll(ctx context.Context, sellers []*internal.Seller) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// save the sellers
+		for i, seller := range sellers {
+			err = r.save(ctx, seller)
+			if err != nil {
+				err = internal.BulkError{{Row: i + 1, Err: err}}
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// save inserts a seller, in the transaction of the context or in a new one, and sets its id
+// - its locality is locked first, if it has one, internal.ErrSellerLocalityNotFound is returned if it does not exist
+func (r *SellerMysql) save(ctx context.Context, seller *internal.Seller) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the locality, so it is checked to exist while the seller is saved
+		if (*seller).LocalityID != 0 {
+			err = lockRow(ctx, r.db, "localities", (*seller).LocalityID, internal.ErrSellerLocalityNotFound)
+			if err != nil {
+				return
+			}
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `sellers` (`cid`, `company_name`, `address`, `telephone`, `locality_id`) VALUES (?, ?, ?, ?, NULLIF(?, 0))",
+			(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).LocalityID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrSellerRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the last inserted id
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
 
+		// set the id of the seller
+		(*seller).ID = int(id)
+		// a new seller starts at its first version
+		(*seller).Version = 1
+
+		return
+	})
 	return
 }
 
 // Update updates a seller in the database
-func (r *SellerMysql) Update(seller *internal.Seller) (err error) {
+// - the update only applies over the version of the seller, otherwise internal.ErrVersionConflict is returned
+// - a soft-deleted seller is not updated, internal.ErrSellerRepositoryNotFound is returned
+// - its locality is locked first, if it has one, internal.ErrSellerLocalityNotFound is returned if it does not exist
+func (r *SellerMysql) Update(ctx context.Context, seller *internal.Seller) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the locality, so it is checked to exist while the seller is saved
+		if (*seller).LocalityID != 0 {
+			err = lockRow(ctx, r.db, "localities", (*seller).LocalityID, internal.ErrSellerLocalityNotFound)
+			if err != nil {
+				return
+			}
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ?, `locality_id` = NULLIF(?, 0), `version` = `version` + 1 WHERE `id` = ? AND `version` = ? AND `deleted_at` IS NULL",
+			(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).LocalityID, (*seller).ID, (*seller).Version,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrSellerRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// check the version was the current one, of a seller not deleted
+		err = checkUpdated(ctx, r.db, result, "sellers", (*seller).ID, internal.ErrSellerRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// the seller is now at its next version
+		(*seller).Version++
+
+		return
+	})
+	return
+}
+
+// Delete soft-deletes the seller with the given id, keeping its row for the entities that reference it
+// - a seller that still has products is not deleted, internal.ErrSellerHasProducts is returned
+func (r *SellerMysql) Delete(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the seller, so no product is saved for it while it is deleted
+		err = lock(ctx, r.db, "sellers", id, internal.ErrSellerRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// check the seller has no products
+		err = checkNone(ctx, r.db, "SELECT COUNT(`p`.`id`) FROM `products` AS `p` WHERE `p`.`seller_id` = ? AND `p`.`deleted_at` IS NULL", id, internal.ErrSellerHasProducts)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `sellers` SET `deleted_at` = NOW() WHERE `id` = ?", id)
+		return
+	})
+	return
+}
+
+// Restore restores the soft-deleted seller with the given id
+// - its locality is never deleted, so there is no reference to check again
+// - internal.ErrSellerCIDDuplicated is returned if a seller saved since it was deleted has its cid
+func (r *SellerMysql) Restore(ctx context.Context, id int) (err error) {
 	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `sellers` SET `cid` = ?, `company_name` = ?, `address` = ?, `telephone` = ? WHERE `id` = ?",
-		(*seller).CID, (*seller).CompanyName, (*seller).Address, (*seller).Telephone, (*seller).ID,
-	)
+	result, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE `sellers` SET `deleted_at` = NULL WHERE `id` = ? AND `deleted_at` IS NOT NULL", id)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) {
 			switch mysqlErr.Number {
 			case 1062:
-				err = internal.ErrSellerRepositoryDuplicated
+				err = internal.ErrSellerCIDDuplicated
 			default:
 				// ...
 			}
@@ -124,16 +288,15 @@ func (r *SellerMysql) Update(seller *internal.Seller) (err error) {
 		return
 	}
 
-	return
-}
-
-// Delete deletes a seller from the database
-func (r *SellerMysql) Delete(id int) (err error) {
-	// execute the query
-	_, err = r.db.Exec("DELETE FROM `sellers` WHERE `id` = ?", id)
+	// check the affected rows
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return
 	}
+	if rowsAffected == 0 {
+		err = internal.ErrSellerRepositoryNotFound
+		return
+	}
 
 	return
 }
\ No newline at end of file
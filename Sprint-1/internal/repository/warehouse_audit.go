@@ -0,0 +1,24 @@
+package repository
+
+import "github.com/usuario/repositorio/internal"
+
+// NewWarehouseAudit creates a new instance of the warehouse audit decorator
+func NewWarehouseAudit(rp internal.WarehouseRepository, au internal.AuditRepository) *WarehouseAudit {
+	return &WarehouseAudit{
+		AuditDecorator: NewAuditDecorator[internal.Warehouse](rp, au, "warehouses", func(warehouse internal.Warehouse) int { return warehouse.ID }),
+		rp:             rp,
+	}
+}
+
+// WarehouseAudit is the audit decorator of the warehouse repository
+// - the write operations are recorded by the embedded decorator, the warehouse specific reads go straight to rp
+type WarehouseAudit struct {
+	*AuditDecorator[internal.Warehouse]
+	// rp is the decorated repository
+	rp internal.WarehouseRepository
+}
+
+// FindByCode returns a warehouse by its warehouse_code
+func (r *WarehouseAudit) FindByCode(code string) (internal.Warehouse, error) {
+	return r.rp.FindByCode(code)
+}
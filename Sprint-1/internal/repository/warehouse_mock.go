@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewWarehouseMock creates a new instance of the warehouse repository mock
+func NewWarehouseMock() *WarehouseMock {
+	return &WarehouseMock{}
+}
+
+// WarehouseMock is a mock of the warehouse repository
+// - each method calls its Func field and counts the call in Spy
+type WarehouseMock struct {
+	// FuncFindAll is the function called by FindAll
+	FuncFindAll func() ([]internal.Warehouse, error)
+	// FuncFindAllWithDeleted is the function called by FindAllWithDeleted
+	FuncFindAllWithDeleted func() ([]internal.Warehouse, error)
+	// FuncFindByID is the function called by FindByID
+	FuncFindByID func(id int) (internal.Warehouse, error)
+	// FuncFindByIDForUpdate is the function called by FindByIDForUpdate
+	FuncFindByIDForUpdate func(ctx context.Context, id int) (internal.Warehouse, error)
+	// FuncFindByCode is the function called by FindByCode
+	FuncFindByCode func(code string) (internal.Warehouse, error)
+	// FuncSave is the function called by Save
+	FuncSave func(ctx context.Context, warehouse *internal.Warehouse) error
+	// FuncSaveAll is the function called by SaveAll
+	FuncSaveAll func(ctx context.Context, warehouses []*internal.Warehouse) error
+	// FuncUpdate is the function called by Update
+	FuncUpdate func(ctx context.Context, warehouse *internal.Warehouse) error
+	// FuncDelete is the function called by Delete
+	FuncDelete func(ctx context.Context, id int) error
+	// FuncRestore is the function called by Restore
+	FuncRestore func(ctx context.Context, id int) error
+
+	// Spy counts the calls of each method
+	Spy struct {
+		// FindAll is the number of times FindAll was called
+		FindAll int
+		// FindAllWithDeleted is the number of times FindAllWithDeleted was called
+		FindAllWithDeleted int
+		// FindByID is the number of times FindByID was called
+		FindByID int
+		// FindByIDForUpdate is the number of times FindByIDForUpdate was called
+		FindByIDForUpdate int
+		// FindByCode is the number of times FindByCode was called
+		FindByCode int
+		// Save is the number of times Save was called
+		Save int
+		// SaveAll is the number of times SaveAll was called
+		SaveAll int
+		// Update is the number of times Update was called
+		Update int
+		// Delete is the number of times Delete was called
+		Delete int
+		// Restore is the number of times Restore was called
+		Restore int
+	}
+}
+
+// FindAll returns all the warehouses
+func (r *WarehouseMock) FindAll() ([]internal.Warehouse, error) {
+	// spy
+	r.Spy.FindAll++
+
+	// mock
+	return r.FuncFindAll()
+}
+
+// FindAllWithDeleted returns all the warehouses, including the soft-deleted ones
+func (r *WarehouseMock) FindAllWithDeleted() ([]internal.Warehouse, error) {
+	// spy
+	r.Spy.FindAllWithDeleted++
+
+	// mock
+	return r.FuncFindAllWithDeleted()
+}
+
+// FindByID returns the warehouse with the given ID
+func (r *WarehouseMock) FindByID(id int) (internal.Warehouse, error) {
+	// spy
+	r.Spy.FindByID++
+
+	// mock
+	return r.FuncFindByID(id)
+}
+
+// FindByIDForUpdate returns the warehouse with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+func (r *WarehouseMock) FindByIDForUpdate(ctx context.Context, id int) (internal.Warehouse, error) {
+	// spy
+	r.Spy.FindByIDForUpdate++
+
+	// mock
+	return r.FuncFindByIDForUpdate(ctx, id)
+}
+
+// FindByCode returns the warehouse with the given warehouse_code
+func (r *WarehouseMock) FindByCode(code string) (internal.Warehouse, error) {
+	// spy
+	r.Spy.FindByCode++
+
+	// mock
+	return r.FuncFindByCode(code)
+}
+
+// Save saves the given warehouse
+func (r *WarehouseMock) Save(ctx context.Context, warehouse *internal.Warehouse) error {
+	// spy
+	r.Spy.Save++
+
+	// mock
+	return r.FuncSave(ctx, warehouse)
+}
+
+// SaveAll saves the given warehouses in a single transaction, none of them are saved if one fails
+func (r *WarehouseMock) SaveAll(ctx context.Context, warehouses []*internal.Warehouse) error {
+	// spy
+	r.Spy.SaveAll++
+
+	// mock
+	return r.FuncSaveAll(ctx, warehouses)
+}
+
+// Update updates the given warehouse, ErrVersionConflict is returned if its version is not the current one
+func (r *WarehouseMock) Update(ctx context.Context, warehouse *internal.Warehouse) error {
+	// spy
+	r.Spy.Update++
+
+	// mock
+	return r.FuncUpdate(ctx, warehouse)
+}
+
+// Delete soft-deletes the warehouse with the given ID, ErrWarehouseHasSections or ErrWarehouseHasEmployees is returned if it still has them
+func (r *WarehouseMock) Delete(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Delete++
+
+	// mock
+	return r.FuncDelete(ctx, id)
+}
+
+// Restore restores the soft-deleted warehouse with the given ID, ErrWarehouseCodeDuplicated is returned if another warehouse has its warehouse_code
+func (r *WarehouseMock) Restore(ctx context.Context, id int) error {
+	// spy
+	r.Spy.Restore++
+
+	// mock
+	return r.FuncRestore(ctx, id)
+}
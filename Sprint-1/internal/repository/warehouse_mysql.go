@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 
@@ -20,10 +21,28 @@ type WarehouseMysql struct {
 	db *sql.DB
 }
 
-// FindAll returns all warehouses from the database
+// FindAll returns all warehouses from the database, except the soft-deleted ones
 func (r *WarehouseMysql) FindAll() (warehouses []internal.Warehouse, err error) {
+	warehouses, err = r.findAll(false)
+	return
+}
+
+// FindAllWithDeleted returns all warehouses from the database, including the soft-deleted ones
+func (r *WarehouseMysql) FindAllWithDeleted() (warehouses []internal.Warehouse, err error) {
+	warehouses, err = r.findAll(true)
+	return
+}
+
+// findAll returns the warehouses from the database, including the soft-deleted ones if requested
+func (r *WarehouseMysql) findAll(includeDeleted bool) (warehouses []internal.Warehouse, err error) {
+	// build the query
+	query := "SELECT `w`.`id`, `w`.`warehouse_code`, `w`.`address`, `w`.`telephone`, `w`.`minimum_capacity`, `w`.`minimum_temperature`, COALESCE(`w`.`locality_id`, 0), `w`.`version` FROM `warehouses` AS `w`"
+	if !includeDeleted {
+		query += " WHERE `w`.`deleted_at` IS NULL"
+	}
+
 	// execute the query
-	rows, err := r.db.Query("SELECT `w.id`, `w.warehouse_code`, `w.address`, `w.telephone`, `w.minimum_capacity`, `w.minimum_temperature` FROM `warehouses` AS `w`")
+	rows, err := r.db.Query(query)
 	if err != nil {
 		return
 	}
@@ -32,7 +51,7 @@ func (r *WarehouseMysql) FindAll() (warehouses []internal.Warehouse, err error)
 	for rows.Next() {
 		// create a new warehouse
 		var warehouse internal.Warehouse
-		err = rows.Scan(&warehouse.ID, &warehouse.WarehouseCode, &warehouse.Address, &warehouse.Telephone, &warehouse.MinimumCapacity, &warehouse.MinimumTemperature)
+		err = rows.Scan(&warehouse.ID, &warehouse.WarehouseCode, &warehouse.Address, &warehouse.Telephone, &warehouse.MinimumCapacity, &warehouse.MinimumTemperature, &warehouse.LocalityID, &warehouse.Version)
 		if err != nil {
 			return
 		}
@@ -53,10 +72,10 @@ func (r *WarehouseMysql) FindAll() (warehouses []internal.Warehouse, err error)
 // FindByID returns a warehouse from the database by its id
 func (r *WarehouseMysql) FindByID(id int) (warehouse internal.Warehouse, err error) {
 	// execute the query
-	row := r.db.QueryRow("SELECT `w.id`, `w.warehouse_code`, `w.address`, `w.telephone`, `w.minimum_capacity`, `w.minimum_temperature` FROM `warehouses` AS `w` WHERE `w.id` = ?", id)
+	row := r.db.QueryRow("SELECT `w`.`id`, `w`.`warehouse_code`, `w`.`address`, `w`.`telephone`, `w`.`minimum_capacity`, `w`.`minimum_temperature`, COALESCE(`w`.`locality_id`, 0), `w`.`version` FROM `warehouses` AS `w` WHERE `w`.`id` = ? AND `w`.`deleted_at` IS NULL", id)
 
 	// scan the row into the warehouse
-	err = row.Scan(&warehouse.ID, &warehouse.WarehouseCode, &warehouse.Address, &warehouse.Telephone, &warehouse.MinimumCapacity, &warehouse.MinimumTemperature)
+	err = row.Scan(&warehouse.ID, &warehouse.WarehouseCode, &warehouse.Address, &warehouse.Telephone, &warehouse.MinimumCapacity, &warehouse.MinimumTemperature, &warehouse.LocalityID, &warehouse.Version)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			err = internal.ErrWarehouseRepositoryNotFound
@@ -67,53 +86,202 @@ func (r *WarehouseMysql) FindByID(id int) (warehouse internal.Warehouse, err err
 	return
 }
 
-// Save saves a warehouse into the database
-func (r *WarehouseMysql) Save(warehouse *internal.Warehouse) (err error) {
+// FindByIDForUpdate returns a warehouse from the database by its id, also if it is soft-deleted, locking its row in the transaction of the context
+func (r *WarehouseMysql) FindByIDForUpdate(ctx context.Context, id int) (warehouse internal.Warehouse, err error) {
 	// execute the query
-	result, err := r.db.Exec(
-		"INSERT INTO `warehouses` (`warehouse_code`, `address`, `telephone`, `minimum_capacity`, `minimum_temperature`) VALUES (?, ?, ?, ?, ?)",
-		(*warehouse).WarehouseCode, (*warehouse).Address, (*warehouse).Telephone, (*warehouse).MinimumCapacity, (*warehouse).MinimumTemperature,
-	)
+	row := conn(ctx, r.db).QueryRowContext(ctx, "SELECT `w`.`id`, `w`.`warehouse_code`, `w`.`address`, `w`.`telephone`, `w`.`minimum_capacity`, `w`.`minimum_temperature`, COALESCE(`w`.`locality_id`, 0), `w`.`version` FROM `warehouses` AS `w` WHERE `w`.`id` = ? FOR UPDATE", id)
+
+	// scan the row into the warehouse
+	err = row.Scan(&warehouse.ID, &warehouse.WarehouseCode, &warehouse.Address, &warehouse.Telephone, &warehouse.MinimumCapacity, &warehouse.MinimumTemperature, &warehouse.LocalityID, &warehouse.Version)
 	if err != nil {
-		var mysqlErr *mysql.MySQLError
-		if errors.As(err, &mysqlErr) {
-			switch mysqlErr.Number {
-			case 1062:
-				err = internal.ErrWarehouseRepositoryDuplicated
-			default:
-				// ...
-			}
-			return
+		if err == sql.ErrNoRows {
+			err = internal.ErrWarehouseRepositoryNotFound
 		}
-
 		return
 	}
 
-	// get the last inserted id
-	id, err := result.LastInsertId()
+	return
+}
+
+// FindByCode returns a warehouse from the database by its warehouse_code
+func (r *WarehouseMysql) FindByCode(code string) (warehouse internal.Warehouse, err error) {
+	// execute the query
+	row := r.db.QueryRow("SELECT `w`.`id`, `w`.`warehouse_code`, `w`.`address`, `w`.`telephone`, `w`.`minimum_capacity`, `w`.`minimum_temperature`, COALESCE(`w`.`locality_id`, 0), `w`.`version` FROM `warehouses` AS `w` WHERE `w`.`warehouse_code` = ? AND `w`.`deleted_at` IS NULL", code)
+
+	// scan the row into the warehouse
+	err = row.Scan(&warehouse.ID, &warehouse.WarehouseCode, &warehouse.Address, &warehouse.Telephone, &warehouse.MinimumCapacity, &warehouse.MinimumTemperature, &warehouse.LocalityID, &warehouse.Version)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			err = internal.ErrWarehouseRepositoryNotFound
+		}
 		return
 	}
 
-	// set the id of the warehouse
-	(*warehouse).ID = int(id)
+	return
+}
+
+// Save saves a warehouse into the database
+func (r *WarehouseMysql) Save(ctx context.Context, warehouse *internal.Warehouse) (err error) {
+	err = r.save(ctx, warehouse)
+	return
+}
+
+// SaveAll saves the given warehouses into the database in a single transaction
+// - if a warehouse fails, none of them are saved and the error is returned as an internal.BulkError with its row
+func (r *WarehouseMysql) SaveAll(ctx context.Context, warehouses []*internal.Warehouse) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// save the warehouses
+		for i, warehouse := range warehouses {
+			err = r.save(ctx, warehouse)
+			if err != nil {
+				err = internal.BulkError{{Row: i + 1, Err: err}}
+				return
+			}
+		}
+		return
+	})
+	return
+}
+
+// save inserts a warehouse, in the transaction of the context or in a new one, and sets its id
+// - its locality is locked first, if it has one, internal.ErrWarehouseLocalityNotFound is returned if it does not exist
+func (r *WarehouseMysql) save(ctx context.Context, warehouse *internal.Warehouse) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the locality, so it is checked to exist while the warehouse is saved
+		if (*warehouse).LocalityID != 0 {
+			err = lockRow(ctx, r.db, "localities", (*warehouse).LocalityID, internal.ErrWarehouseLocalityNotFound)
+			if err != nil {
+				return
+			}
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"INSERT INTO `warehouses` (`warehouse_code`, `address`, `telephone`, `minimum_capacity`, `minimum_temperature`, `locality_id`) VALUES (?, ?, ?, ?, ?, NULLIF(?, 0))",
+			(*warehouse).WarehouseCode, (*warehouse).Address, (*warehouse).Telephone, (*warehouse).MinimumCapacity, (*warehouse).MinimumTemperature, (*warehouse).LocalityID,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrWarehouseRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// get the last inserted id
+		id, err := result.LastInsertId()
+		if err != nil {
+			return
+		}
 
+		// set the id of the warehouse
+		(*warehouse).ID = int(id)
+		// a new warehouse starts at its first version
+		(*warehouse).Version = 1
+
+		return
+	})
 	return
 }
 
 // Update updates a warehouse in the database
-func (r *WarehouseMysql) Update(warehouse *internal.Warehouse) (err error) {
+// - the update only applies over the version of the warehouse, otherwise internal.ErrVersionConflict is returned
+// - a soft-deleted warehouse is not updated, internal.ErrWarehouseRepositoryNotFound is returned
+// - its locality is locked first, if it has one, internal.ErrWarehouseLocalityNotFound is returned if it does not exist
+func (r *WarehouseMysql) Update(ctx context.Context, warehouse *internal.Warehouse) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the locality, so it is checked to exist while the warehouse is saved
+		if (*warehouse).LocalityID != 0 {
+			err = lockRow(ctx, r.db, "localities", (*warehouse).LocalityID, internal.ErrWarehouseLocalityNotFound)
+			if err != nil {
+				return
+			}
+		}
+
+		// execute the query
+		result, err := conn(ctx, r.db).ExecContext(
+			ctx,
+			"UPDATE `warehouses` AS `w` SET `w`.`warehouse_code` = ?, `w`.`address` = ?, `w`.`telephone` = ?, `w`.`minimum_capacity` = ?, `w`.`minimum_temperature` = ?, `w`.`locality_id` = NULLIF(?, 0), `w`.`version` = `w`.`version` + 1 WHERE `w`.`id` = ? AND `w`.`version` = ? AND `w`.`deleted_at` IS NULL",
+			(*warehouse).WarehouseCode, (*warehouse).Address, (*warehouse).Telephone, (*warehouse).MinimumCapacity, (*warehouse).MinimumTemperature, (*warehouse).LocalityID, (*warehouse).ID, (*warehouse).Version,
+		)
+		if err != nil {
+			var mysqlErr *mysql.MySQLError
+			if errors.As(err, &mysqlErr) {
+				switch mysqlErr.Number {
+				case 1062:
+					err = internal.ErrWarehouseRepositoryDuplicated
+				default:
+					// ...
+				}
+				return
+			}
+
+			return
+		}
+
+		// check the version was the current one, of a warehouse not deleted
+		err = checkUpdated(ctx, r.db, result, "warehouses", (*warehouse).ID, internal.ErrWarehouseRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// the warehouse is now at its next version
+		(*warehouse).Version++
+
+		return
+	})
+	return
+}
+
+// Delete soft-deletes the warehouse with the given id, keeping its row for the entities that reference it
+// - a warehouse that still has sections or employees is not deleted, internal.ErrWarehouseHasSections or internal.ErrWarehouseHasEmployees is returned
+func (r *WarehouseMysql) Delete(ctx context.Context, id int) (err error) {
+	err = transaction(ctx, r.db, func(ctx context.Context) (err error) {
+		// lock the warehouse, so no section or employee is saved for it while it is deleted
+		err = lock(ctx, r.db, "warehouses", id, internal.ErrWarehouseRepositoryNotFound)
+		if err != nil {
+			return
+		}
+
+		// check the warehouse has no sections
+		err = checkNone(ctx, r.db, "SELECT COUNT(`s`.`id`) FROM `sections` AS `s` WHERE `s`.`warehouse_id` = ? AND `s`.`deleted_at` IS NULL", id, internal.ErrWarehouseHasSections)
+		if err != nil {
+			return
+		}
+
+		// check the warehouse has no employees
+		err = checkNone(ctx, r.db, "SELECT COUNT(`e`.`id`) FROM `employees` AS `e` WHERE `e`.`warehouse_id` = ? AND `e`.`deleted_at` IS NULL", id, internal.ErrWarehouseHasEmployees)
+		if err != nil {
+			return
+		}
+
+		// execute the query
+		_, err = conn(ctx, r.db).ExecContext(ctx, "UPDATE `warehouses` SET `deleted_at` = NOW() WHERE `id` = ?", id)
+		return
+	})
+	return
+}
+
+// Restore restores the soft-deleted warehouse with the given id
+// - its locality is never deleted, so there is no reference to check again
+// - internal.ErrWarehouseCodeDuplicated is returned if a warehouse saved since it was deleted has its warehouse_code
+func (r *WarehouseMysql) Restore(ctx context.Context, id int) (err error) {
 	// execute the query
-	_, err = r.db.Exec(
-		"UPDATE `warehouses` AS `w` SET `w.warehouse_code` = ?, `w.address` = ?, `w.telephone` = ?, `w.minimum_capacity` = ?, `w.minimum_temperature` = ? WHERE `w.id` = ?",
-		(*warehouse).WarehouseCode, (*warehouse).Address, (*warehouse).Telephone, (*warehouse).MinimumCapacity, (*warehouse).MinimumTemperature, (*warehouse).ID,
-	)
+	result, err := conn(ctx, r.db).ExecContext(ctx, "UPDATE `warehouses` SET `deleted_at` = NULL WHERE `id` = ? AND `deleted_at` IS NOT NULL", id)
 	if err != nil {
 		var mysqlErr *mysql.MySQLError
 		if errors.As(err, &mysqlErr) {
 			switch mysqlErr.Number {
 			case 1062:
-				err = internal.ErrWarehouseRepositoryDuplicated
+				err = internal.ErrWarehouseCodeDuplicated
 			default:
 				// ...
 			}
@@ -123,16 +291,15 @@ func (r *WarehouseMysql) Update(warehouse *internal.Warehouse) (err error) {
 		return
 	}
 
-	return
-}
-
-// Delete deletes a warehouse from the database
-func (r *WarehouseMysql) Delete(id int) (err error) {
-	// execute the query
-	_, err = r.db.Exec("DELETE FROM `warehouses` WHERE `id` = ?", id)
+	// check the affected rows
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return
 	}
+	if rowsAffected == 0 {
+		err = internal.ErrWarehouseRepositoryNotFound
+		return
+	}
 
 	return
 }
\ No newline at end of file
@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Section is a struct that contains the section's information
 type Section struct {
@@ -22,6 +26,43 @@ type Section struct {
 	WarehouseID int
 	// ProductTypeID is the unique identifier of the type of product stored in the section
 	ProductTypeID int
+	// Version is the number of times the section was written, used to detect concurrent updates
+	Version int
+}
+
+// Validate returns ErrSectionInvalid wrapped with the first field of the section that is not valid
+func (s Section) Validate() (err error) {
+	switch {
+	case s.SectionNumber <= 0:
+		err = fmt.Errorf("%w: section_number must be greater than 0", ErrSectionInvalid)
+	case s.CurrentCapacity < 0:
+		err = fmt.Errorf("%w: current_capacity must not be negative", ErrSectionInvalid)
+	case s.MinimumCapacity < 0:
+		err = fmt.Errorf("%w: minimum_capacity must not be negative", ErrSectionInvalid)
+	case s.MaximumCapacity <= 0:
+		err = fmt.Errorf("%w: maximum_capacity must be greater than 0", ErrSectionInvalid)
+	case s.WarehouseID <= 0:
+		err = fmt.Errorf("%w: warehouse_id must be greater than 0", ErrSectionInvalid)
+	case s.ProductTypeID <= 0:
+		err = fmt.Errorf("%w: product_type_id must be greater than 0", ErrSectionInvalid)
+	case s.MinimumCapacity > s.MaximumCapacity:
+		err = fmt.Errorf("%w: minimum_capacity must not exceed maximum_capacity", ErrSectionInvalid)
+	case s.CurrentCapacity > s.MaximumCapacity:
+		err = fmt.Errorf("%w: current_capacity must not exceed maximum_capacity", ErrSectionInvalid)
+	}
+	return
+}
+
+// SectionWarehouseReport is a struct that contains the aggregates of the sections of a warehouse
+type SectionWarehouseReport struct {
+	// SectionsCount is the number of sections of the warehouse
+	SectionsCount int
+	// TotalCapacity is the sum of the maximum capacity of the sections
+	TotalCapacity int
+	// UsedCapacity is the sum of the current capacity of the sections
+	UsedCapacity int
+	// SectionsBelowMinimumTemperature is the number of sections whose current temperature is below the minimum temperature of the warehouse
+	SectionsBelowMinimumTemperature int
 }
 
 var (
@@ -29,32 +70,62 @@ var (
 	ErrSectionRepositoryNotFound = errors.New("repository: section not found")
 	// ErrSectionRepositoryDuplicated is returned when the section already exists
 	ErrSectionRepositoryDuplicated = errors.New("repository: section already exists")
+	// ErrSectionReferenceNotFound is returned when an entity referenced by the section does not exist
+	ErrSectionReferenceNotFound = errors.New("section: reference not found")
+	// ErrSectionWarehouseNotFound is returned when the warehouse of the section does not exist
+	ErrSectionWarehouseNotFound = fmt.Errorf("%w: warehouse_id", ErrSectionReferenceNotFound)
+	// ErrSectionProductTypeNotFound is returned when the product type of the section does not exist
+	ErrSectionProductTypeNotFound = fmt.Errorf("%w: product_type_id", ErrSectionReferenceNotFound)
+	// ErrSectionHasDependents is returned when the section can not be deleted because other entities reference it
+	ErrSectionHasDependents = errors.New("section: has dependents")
+	// ErrSectionHasProductBatches is returned when the section still stores product batches
+	ErrSectionHasProductBatches = fmt.Errorf("%w: product batches", ErrSectionHasDependents)
+	// ErrSectionInvalid is returned when the section has invalid fields
+	ErrSectionInvalid = errors.New("section: invalid fields")
 )
 
 // SectionRepository is an interface that contains the methods that the section repository should support
 type SectionRepository interface {
 	// FindAll returns all the sections
 	FindAll() ([]Section, error)
+	// FindAllWithDeleted returns all the sections, including the soft-deleted ones
+	FindAllWithDeleted() ([]Section, error)
 	// FindByID returns the section with the given ID
 	FindByID(id int) (Section, error)
+	// FindByIDForUpdate returns the section with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (Section, error)
 	// Save saves the given section
-	Save(section *Section) error
-	// Update updates the given section
-	Update(section *Section) error
-	// Delete deletes the section with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, section *Section) error
+	// SaveAll saves the given sections in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, sections []*Section) error
+	// Update updates the given section, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, section *Section) error
+	// Delete soft-deletes the section with the given ID, ErrSectionHasProductBatches is returned if it still stores product batches
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted section with the given ID, ErrSectionWarehouseNotFound is returned if its warehouse was deleted
+	Restore(ctx context.Context, id int) error
+	// ReportByWarehouse returns the aggregates of the sections of the warehouse with the given ID
+	ReportByWarehouse(warehouseID int) (SectionWarehouseReport, error)
 }
 
 // SectionService is an interface that contains the methods that the section service should support
 type SectionService interface {
 	// FindAll returns all the sections
 	FindAll() ([]Section, error)
+	// FindAllWithDeleted returns all the sections, including the soft-deleted ones
+	FindAllWithDeleted() ([]Section, error)
 	// FindByID returns the section with the given ID
 	FindByID(id int) (Section, error)
 	// Save saves the given section
-	Save(section *Section) error
-	// Update updates the given section
-	Update(section *Section) error
-	// Delete deletes the section with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, section *Section) error
+	// SaveAll validates and saves the given sections in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, sections []*Section) error
+	// Update updates the given section, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, section *Section) error
+	// Patch partially updates the section with the given ID, fn receives the current section and changes the fields to update
+	Patch(ctx context.Context, id int, fn func(section *Section) error) (Section, error)
+	// Delete soft-deletes the section with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted section with the given ID
+	Restore(ctx context.Context, id int) error
 }
\ No newline at end of file
@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Seller is a struct that contains the seller's information
 type Seller struct {
@@ -14,6 +18,27 @@ type Seller struct {
 	Address string
 	// Telephone is the telephone number of the company
 	Telephone string
+	// LocalityID is the unique identifier of the locality of the company, zero if it is not known
+	LocalityID int
+	// Version is the number of times the seller was written, used to detect concurrent updates
+	Version int
+}
+
+// Validate returns ErrSellerInvalid wrapped with the first field of the seller that is not valid
+func (s Seller) Validate() (err error) {
+	switch {
+	case s.CID <= 0:
+		err = fmt.Errorf("%w: cid must be greater than 0", ErrSellerInvalid)
+	case s.CompanyName == "":
+		err = fmt.Errorf("%w: company_name is required", ErrSellerInvalid)
+	case s.Address == "":
+		err = fmt.Errorf("%w: address is required", ErrSellerInvalid)
+	case s.Telephone == "":
+		err = fmt.Errorf("%w: telephone is required", ErrSellerInvalid)
+	case s.LocalityID < 0:
+		err = fmt.Errorf("%w: locality_id must not be negative", ErrSellerInvalid)
+	}
+	return
 }
 
 var (
@@ -21,32 +46,64 @@ var (
 	ErrSellerRepositoryNotFound = errors.New("repository: seller not found")
 	// ErrSellerRepositoryDuplicated is returned when the seller already exists
 	ErrSellerRepositoryDuplicated = errors.New("repository: seller already exists")
+	// ErrSellerCIDDuplicated is returned when another seller already has the same cid
+	ErrSellerCIDDuplicated = fmt.Errorf("%w: cid already in use", ErrSellerRepositoryDuplicated)
+	// ErrSellerReferenceNotFound is returned when an entity referenced by the seller does not exist
+	ErrSellerReferenceNotFound = errors.New("seller: reference not found")
+	// ErrSellerLocalityNotFound is returned when the locality of the seller does not exist
+	ErrSellerLocalityNotFound = fmt.Errorf("%w: locality_id", ErrSellerReferenceNotFound)
+	// ErrSellerHasDependents is returned when the seller can not be deleted because other entities reference it
+	ErrSellerHasDependents = errors.New("seller: has dependents")
+	// ErrSellerHasProducts is returned when the seller still has products
+	ErrSellerHasProducts = fmt.Errorf("%w: products", ErrSellerHasDependents)
+	// ErrSellerInvalid is returned when the seller has invalid fields
+	ErrSellerInvalid = errors.New("seller: invalid fields")
 )
 
 // SellerRepository is an interface that contains the methods that the seller repository should support
 type SellerRepository interface {
 	// FindAll returns all the sellers
 	FindAll() ([]Seller, error)
+	// FindAllWithDeleted returns all the sellers, including the soft-deleted ones
+	FindAllWithDeleted() ([]Seller, error)
 	// FindByID returns the seller with the given ID
 	FindByID(id int) (Seller, error)
-	// Save saves the given seller
-	Save(seller *Seller) error
-	// Update updates the given seller
-	Update(seller *Seller) error
-	// Delete deletes the seller with the given ID
-	Delete(id int) error
+	// FindByIDForUpdate returns the seller with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (Seller, error)
+	// FindByCID returns the seller with the given cid
+	FindByCID(cid int) (Seller, error)
+	// Save saves the given seller, ErrSellerLocalityNotFound is returned if its locality does not exist
+	Save(ctx context.Context, seller *Seller) error
+	// SaveAll saves the given sellers in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, sellers []*Seller) error
+	// Update updates the given seller, ErrVersionConflict is returned if its version is not the current one and ErrSellerLocalityNotFound if its locality does not exist
+	Update(ctx context.Context, seller *Seller) error
+	// Delete soft-deletes the seller with the given ID, ErrSellerHasProducts is returned if it still has products
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted seller with the given ID, ErrSellerCIDDuplicated is returned if another seller has its cid
+	Restore(ctx context.Context, id int) error
 }
 
 // SellerService is an interface that contains the methods that the seller service should support
 type SellerService interface {
 	// FindAll returns all the sellers
 	FindAll() ([]Seller, error)
+	// FindAllWithDeleted returns all the sellers, including the soft-deleted ones
+	FindAllWithDeleted() ([]Seller, error)
 	// FindByID returns the seller with the given ID
 	FindByID(id int) (Seller, error)
+	// FindByCID returns the seller with the given cid
+	FindByCID(cid int) (Seller, error)
 	// Save saves the given seller
-	Save(seller *Seller) error
-	// Update updates the given seller
-	Update(seller *Seller) error
-	// Delete deletes the seller with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, seller *Seller) error
+	// SaveAll validates and saves the given sellers in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, sellers []*Seller) error
+	// Update updates the given seller, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, seller *Seller) error
+	// Patch partially updates the seller with the given ID, fn receives the current seller and changes the fields to update
+	Patch(ctx context.Context, id int, fn func(seller *Seller) error) (Seller, error)
+	// Delete soft-deletes the seller with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted seller with the given ID
+	Restore(ctx context.Context, id int) error
 }
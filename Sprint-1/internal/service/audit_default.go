@@ -0,0 +1,22 @@
+package service
+
+import "github.com/usuario/repositorio/internal"
+
+// NewAuditDefault creates a new instance of the audit service
+func NewAuditDefault(rp internal.AuditRepository) *AuditDefault {
+	return &AuditDefault{
+		rp: rp,
+	}
+}
+
+// AuditDefault is the default implementation of the audit service
+type AuditDefault struct {
+	// rp is the repository used by the service
+	rp internal.AuditRepository
+}
+
+// FindByEntity returns the audit entries of an entity
+func (s *AuditDefault) FindByEntity(entity string, entityID int) (audits []internal.Audit, err error) {
+	audits, err = s.rp.FindByEntity(entity, entityID)
+	return
+}
@@ -1,6 +1,11 @@
 package service
 
-import "github.com/usuario/repositorio/internal"
+import (
+	"context"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+)
 
 // NewBuyerDefault creates a new instance of the buyer service
 func NewBuyerDefault(rp internal.BuyerRepository) *BuyerDefault {
@@ -17,25 +22,142 @@ type BuyerDefault struct {
 
 // FindAll returns all buyers
 func (s *BuyerDefault) FindAll() (buyers []internal.Buyer, err error) {
+	buyers, err = s.rp.FindAll()
+	return
+}
+
+// FindAllWithDeleted returns all buyers, including the soft-deleted ones
+func (s *BuyerDefault) FindAllWithDeleted() (buyers []internal.Buyer, err error) {
+	buyers, err = s.rp.FindAllWithDeleted()
 	return
 }
 
 // FindByID returns a buyer
 func (s *BuyerDefault) FindByID(id int) (buyer internal.Buyer, err error) {
+	buyer, err = s.rp.FindByID(id)
+	return
+}
+
+// FindByCardNumberID returns a buyer by its card_number_id
+func (s *BuyerDefault) FindByCardNumberID(cardNumberID int) (buyer internal.Buyer, err error) {
+	buyer, err = s.rp.FindByCardNumberID(cardNumberID)
 	return
 }
 
 // Save creates a new buyer
-func (s *BuyerDefault) Save(buyer *internal.Buyer) (err error) {
+func (s *BuyerDefault) Save(ctx context.Context, buyer *internal.Buyer) (err error) {
+	// validate the buyer
+	err = (*buyer).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the card_number_id is not in use
+	err = s.checkCardNumberID(*buyer)
+	if err != nil {
+		return
+	}
+
+	// save the buyer
+	err = s.rp.Save(ctx, buyer)
+	return
+}
+
+// SaveAll creates the given buyers in a single import
+// - every buyer is validated before any of them is saved, the invalid or duplicated ones are returned as an internal.BulkError
+func (s *BuyerDefault) SaveAll(ctx context.Context, buyers []*internal.Buyer) (err error) {
+	// check there is something to import
+	if len(buyers) == 0 {
+		err = internal.ErrBulkEmpty
+		return
+	}
+
+	// validate the buyers and check their card_number_id is not in use, neither by a saved buyer nor by a previous row
+	var bulkErr internal.BulkError
+	seen := make(map[int]bool, len(buyers))
+	for i, buyer := range buyers {
+		rowErr := (*buyer).Validate()
+		if rowErr == nil && seen[(*buyer).CardNumberID] {
+			rowErr = internal.ErrBuyerCardNumberIDDuplicated
+		}
+		if rowErr == nil {
+			rowErr = s.checkCardNumberID(*buyer)
+		}
+		if rowErr != nil {
+			bulkErr = append(bulkErr, internal.BulkRowError{Row: i + 1, Err: rowErr})
+		}
+		seen[(*buyer).CardNumberID] = true
+	}
+	if len(bulkErr) > 0 {
+		err = bulkErr
+		return
+	}
+
+	// save the buyers
+	err = s.rp.SaveAll(ctx, buyers)
 	return
 }
 
 // Update updates a buyer
-func (s *BuyerDefault) Update(buyer *internal.Buyer) (err error) {
+func (s *BuyerDefault) Update(ctx context.Context, buyer *internal.Buyer) (err error) {
+	// check that the buyer exists and was not modified since the version the update is based on
+	current, err := s.rp.FindByID((*buyer).ID)
+	if err != nil {
+		return
+	}
+	err = checkVersion(&(*buyer).Version, current.Version)
+	if err != nil {
+		return
+	}
+
+	// validate the buyer
+	err = (*buyer).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the card_number_id is not in use by another buyer
+	err = s.checkCardNumberID(*buyer)
+	if err != nil {
+		return
+	}
+
+	// update the buyer
+	err = s.rp.Update(ctx, buyer)
+	return
+}
+
+// Patch partially updates a buyer, fn receives the current buyer and changes the fields to update
+func (s *BuyerDefault) Patch(ctx context.Context, id int, fn func(buyer *internal.Buyer) error) (buyer internal.Buyer, err error) {
+	buyer, err = patch(ctx, s.rp.FindByID, s.Update, id, fn)
 	return
 }
 
-// Delete deletes a buyer
-func (s *BuyerDefault) Delete(id int) (err error) {
+// Delete soft-deletes a buyer
+// - a buyer that still has purchase orders is not deleted, internal.ErrBuyerHasPurchaseOrders is returned
+func (s *BuyerDefault) Delete(ctx context.Context, id int) (err error) {
+	err = s.rp.Delete(ctx, id)
 	return
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted buyer
+func (s *BuyerDefault) Restore(ctx context.Context, id int) (err error) {
+	err = s.rp.Restore(ctx, id)
+	return
+}
+
+// checkCardNumberID returns internal.ErrBuyerCardNumberIDDuplicated if a buyer other than the given one already has its card_number_id
+// - soft-deleted buyers are not found here, their card_number_id can be used again
+func (s *BuyerDefault) checkCardNumberID(buyer internal.Buyer) (err error) {
+	found, err := s.rp.FindByCardNumberID(buyer.CardNumberID)
+	if err != nil {
+		if errors.Is(err, internal.ErrBuyerRepositoryNotFound) {
+			err = nil
+		}
+		return
+	}
+	if found.ID != buyer.ID {
+		err = internal.ErrBuyerCardNumberIDDuplicated
+	}
+	return
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewCarrierDefault creates a new instance of the carrier service
+func NewCarrierDefault(rp internal.CarrierRepository) *CarrierDefault {
+	return &CarrierDefault{
+		rp: rp,
+	}
+}
+
+// CarrierDefault is the default implementation of the carrier service
+type CarrierDefault struct {
+	// rp is the repository used by the service
+	rp internal.CarrierRepository
+}
+
+// FindAll returns all carriers
+func (s *CarrierDefault) FindAll() (carriers []internal.Carrier, err error) {
+	carriers, err = s.rp.FindAll()
+	return
+}
+
+// Save creates a new carrier
+func (s *CarrierDefault) Save(ctx context.Context, carrier *internal.Carrier) (err error) {
+	// validate the carrier
+	err = (*carrier).Validate()
+	if err != nil {
+		return
+	}
+
+	// save the carrier
+	err = s.rp.Save(ctx, carrier)
+	return
+}
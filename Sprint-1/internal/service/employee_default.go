@@ -1,11 +1,17 @@
 package service
 
-import "github.com/usuario/repositorio/internal"
+import (
+	"context"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+)
 
 // NewEmployeeDefault creates a new instance of the employee service
-func NewEmployeeDefault(rp internal.EmployeeRepository) *EmployeeDefault {
+func NewEmployeeDefault(rp internal.EmployeeRepository, rpWarehouse internal.WarehouseRepository) *EmployeeDefault {
 	return &EmployeeDefault{
-		rp: rp,
+		rp:          rp,
+		rpWarehouse: rpWarehouse,
 	}
 }
 
@@ -13,29 +19,175 @@ func NewEmployeeDefault(rp internal.EmployeeRepository) *EmployeeDefault {
 type EmployeeDefault struct {
 	// rp is the repository used by the service
 	rp internal.EmployeeRepository
+	// rpWarehouse is the repository of the warehouses the employees work at
+	rpWarehouse internal.WarehouseRepository
 }
 
 // FindAll returns all employees
 func (s *EmployeeDefault) FindAll() (employees []internal.Employee, err error) {
+	employees, err = s.rp.FindAll()
+	return
+}
+
+// FindAllWithDeleted returns all employees, including the soft-deleted ones
+func (s *EmployeeDefault) FindAllWithDeleted() (employees []internal.Employee, err error) {
+	employees, err = s.rp.FindAllWithDeleted()
 	return
 }
 
 // FindByID returns a employee
 func (s *EmployeeDefault) FindByID(id int) (employee internal.Employee, err error) {
+	employee, err = s.rp.FindByID(id)
+	return
+}
+
+// FindByCardNumberID returns an employee by its card_number_id
+func (s *EmployeeDefault) FindByCardNumberID(cardNumberID int) (employee internal.Employee, err error) {
+	employee, err = s.rp.FindByCardNumberID(cardNumberID)
 	return
 }
 
 // Save creates a new employee
-func (s *EmployeeDefault) Save(employee *internal.Employee) (err error) {
+func (s *EmployeeDefault) Save(ctx context.Context, employee *internal.Employee) (err error) {
+	// validate the employee
+	err = (*employee).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the card_number_id is not in use
+	err = s.checkCardNumberID(*employee)
+	if err != nil {
+		return
+	}
+
+	// check the warehouse exists
+	err = s.checkReferences(*employee)
+	if err != nil {
+		return
+	}
+
+	// save the employee
+	err = s.rp.Save(ctx, employee)
+	return
+}
+
+// SaveAll creates the given employees in a single import
+// - every employee is validated before any of them is saved, the invalid or duplicated ones are returned as an internal.BulkError
+func (s *EmployeeDefault) SaveAll(ctx context.Context, employees []*internal.Employee) (err error) {
+	// check there is something to import
+	if len(employees) == 0 {
+		err = internal.ErrBulkEmpty
+		return
+	}
+
+	// validate the employees, check their card_number_id is not in use, neither by a saved employee nor by a previous row, and their warehouse exists
+	var bulkErr internal.BulkError
+	seen := make(map[int]bool, len(employees))
+	for i, employee := range employees {
+		rowErr := (*employee).Validate()
+		if rowErr == nil && seen[(*employee).CardNumberID] {
+			rowErr = internal.ErrEmployeeCardNumberIDDuplicated
+		}
+		if rowErr == nil {
+			rowErr = s.checkCardNumberID(*employee)
+		}
+		if rowErr == nil {
+			rowErr = s.checkReferences(*employee)
+		}
+		if rowErr != nil {
+			bulkErr = append(bulkErr, internal.BulkRowError{Row: i + 1, Err: rowErr})
+		}
+		seen[(*employee).CardNumberID] = true
+	}
+	if len(bulkErr) > 0 {
+		err = bulkErr
+		return
+	}
+
+	// save the employees
+	err = s.rp.SaveAll(ctx, employees)
 	return
 }
 
 // Update updates a employee
-func (s *EmployeeDefault) Update(employee *internal.Employee) (err error) {
+func (s *EmployeeDefault) Update(ctx context.Context, employee *internal.Employee) (err error) {
+	// check that the employee exists and was not modified since the version the update is based on
+	current, err := s.rp.FindByID((*employee).ID)
+	if err != nil {
+		return
+	}
+	err = checkVersion(&(*employee).Version, current.Version)
+	if err != nil {
+		return
+	}
+
+	// validate the employee
+	err = (*employee).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the card_number_id is not in use by another employee
+	err = s.checkCardNumberID(*employee)
+	if err != nil {
+		return
+	}
+
+	// check the warehouse exists
+	err = s.checkReferences(*employee)
+	if err != nil {
+		return
+	}
+
+	// update the employee
+	err = s.rp.Update(ctx, employee)
+	return
+}
+
+// Patch partially updates an employee, fn receives the current employee and changes the fields to update
+func (s *EmployeeDefault) Patch(ctx context.Context, id int, fn func(employee *internal.Employee) error) (employee internal.Employee, err error) {
+	employee, err = patch(ctx, s.rp.FindByID, s.Update, id, fn)
+	return
+}
+
+// Delete soft-deletes a employee
+// - an employee that still has inbound orders is not deleted, internal.ErrEmployeeHasInboundOrders is returned
+func (s *EmployeeDefault) Delete(ctx context.Context, id int) (err error) {
+	err = s.rp.Delete(ctx, id)
+	return
+}
+
+// Restore restores a soft-deleted employee
+func (s *EmployeeDefault) Restore(ctx context.Context, id int) (err error) {
+	err = s.rp.Restore(ctx, id)
+	return
+}
+
+// ReportInboundOrders returns the number of inbound orders received by an employee, or by every employee if id is zero
+func (s *EmployeeDefault) ReportInboundOrders(id int) (reports []internal.EmployeeInboundOrdersReport, err error) {
+	reports, err = s.rp.ReportInboundOrders(id)
+	return
+}
+
+// checkCardNumberID returns internal.ErrEmployeeCardNumberIDDuplicated if a employee other than the given one already has its card_number_id
+// - soft-deleted employees are not found here, their card_number_id can be used again
+func (s *EmployeeDefault) checkCardNumberID(employee internal.Employee) (err error) {
+	found, err := s.rp.FindByCardNumberID(employee.CardNumberID)
+	if err != nil {
+		if errors.Is(err, internal.ErrEmployeeRepositoryNotFound) {
+			err = nil
+		}
+		return
+	}
+	if found.ID != employee.ID {
+		err = internal.ErrEmployeeCardNumberIDDuplicated
+	}
 	return
 }
 
-// Delete deletes a employee
-func (s *EmployeeDefault) Delete(id int) (err error) {
+// checkReferences returns internal.ErrEmployeeWarehouseNotFound if the warehouse of the employee does not exist
+func (s *EmployeeDefault) checkReferences(employee internal.Employee) (err error) {
+	err = checkReference(s.rpWarehouse.FindByID, employee.WarehouseID, internal.ErrWarehouseRepositoryNotFound, internal.ErrEmployeeWarehouseNotFound)
 	return
 }
\ No newline at end of file
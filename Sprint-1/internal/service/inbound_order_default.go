@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewInboundOrderDefault creates a new instance of the inbound order service
+func NewInboundOrderDefault(rp internal.InboundOrderRepository, rpEmployee internal.EmployeeRepository) *InboundOrderDefault {
+	return &InboundOrderDefault{
+		rp:         rp,
+		rpEmployee: rpEmployee,
+	}
+}
+
+// InboundOrderDefault is the default implementation of the inbound order service
+type InboundOrderDefault struct {
+	// rp is the repository used by the service
+	rp internal.InboundOrderRepository
+	// rpEmployee is the repository of the employees that receive the inbound orders
+	rpEmployee internal.EmployeeRepository
+}
+
+// FindAll returns all inbound orders
+func (s *InboundOrderDefault) FindAll() (inboundOrders []internal.InboundOrder, err error) {
+	inboundOrders, err = s.rp.FindAll()
+	return
+}
+
+// Save creates a new inbound order
+func (s *InboundOrderDefault) Save(ctx context.Context, inboundOrder *internal.InboundOrder) (err error) {
+	// validate the inbound order
+	err = (*inboundOrder).Validate()
+	if err != nil {
+		return
+	}
+
+	// check that the employee works in the warehouse of the inbound order
+	employee, err := s.rpEmployee.FindByID((*inboundOrder).EmployeeID)
+	if err != nil {
+		return
+	}
+	if employee.WarehouseID != (*inboundOrder).WarehouseID {
+		err = internal.ErrInboundOrderWarehouseMismatch
+		return
+	}
+
+	// save the inbound order
+	err = s.rp.Save(ctx, inboundOrder)
+	return
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewLocalityDefault creates a new instance of the locality service
+func NewLocalityDefault(rp internal.LocalityRepository) *LocalityDefault {
+	return &LocalityDefault{
+		rp: rp,
+	}
+}
+
+// LocalityDefault is the default implementation of the locality service
+type LocalityDefault struct {
+	// rp is the repository used by the service
+	rp internal.LocalityRepository
+}
+
+// FindByID returns a locality
+func (s *LocalityDefault) FindByID(id int) (locality internal.Locality, err error) {
+	locality, err = s.rp.FindByID(id)
+	return
+}
+
+// Save creates a new locality
+func (s *LocalityDefault) Save(ctx context.Context, locality *internal.Locality) (err error) {
+	// validate the locality
+	err = (*locality).Validate()
+	if err != nil {
+		return
+	}
+
+	// save the locality
+	err = s.rp.Save(ctx, locality)
+	return
+}
+
+// ReportSellers returns the number of sellers of a locality, or of every locality if id is zero
+func (s *LocalityDefault) ReportSellers(id int) (reports []internal.LocalityCountReport, err error) {
+	reports, err = s.rp.ReportSellers(id)
+	return
+}
+
+// ReportCarriers returns the number of carriers of a locality, or of every locality if id is zero
+func (s *LocalityDefault) ReportCarriers(id int) (reports []internal.LocalityCountReport, err error) {
+	reports, err = s.rp.ReportCarriers(id)
+	return
+}
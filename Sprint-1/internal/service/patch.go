@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// checkVersion compares the version an update is based on with the current version of the entity
+// - an update without a version (zero) is applied over the current version
+func checkVersion(version *int, current int) (err error) {
+	switch {
+	case *version == 0:
+		*version = current
+	case *version != current:
+		err = internal.ErrVersionConflict
+	}
+	return
+}
+
+// patch applies a partial update to the entity with the given id
+// - find reads the current entity, fn changes the fields to update on it and update saves the result
+// - the fields fn leaves alone keep their current value, and the update is based on the version that was read
+// (unless fn sets another one), so a concurrent write between the read and the update returns internal.ErrVersionConflict
+func patch[T any](ctx context.Context, find func(id int) (T, error), update func(ctx context.Context, entity *T) error, id int, fn func(entity *T) error) (entity T, err error) {
+	// read the current entity
+	entity, err = find(id)
+	if err != nil {
+		return
+	}
+
+	// apply the changes
+	err = fn(&entity)
+	if err != nil {
+		return
+	}
+
+	// update the entity
+	err = update(ctx, &entity)
+	return
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductBatchDefault creates a new instance of the product batch service
+func NewProductBatchDefault(rp internal.ProductBatchRepository) *ProductBatchDefault {
+	return &ProductBatchDefault{
+		rp: rp,
+	}
+}
+
+// ProductBatchDefault is the default implementation of the product batch service
+type ProductBatchDefault struct {
+	// rp is the repository used by the service
+	rp internal.ProductBatchRepository
+}
+
+// FindByID returns a product batch
+func (s *ProductBatchDefault) FindByID(id int) (productBatch internal.ProductBatch, err error) {
+	productBatch, err = s.rp.FindByID(id)
+	return
+}
+
+// Save creates a new product batch
+func (s *ProductBatchDefault) Save(ctx context.Context, productBatch *internal.ProductBatch) (err error) {
+	// validate the product batch
+	err = (*productBatch).Validate()
+	if err != nil {
+		return
+	}
+
+	// save the product batch
+	err = s.rp.Save(ctx, productBatch)
+	return
+}
+
+// FindExpiring returns the batches with products left that expire within the given number of days
+func (s *ProductBatchDefault) FindExpiring(days int) (productBatches []internal.ProductBatch, err error) {
+	// validate the days
+	if days < 0 {
+		err = internal.ErrProductBatchInvalidDays
+		return
+	}
+
+	productBatches, err = s.rp.FindExpiring(days)
+	return
+}
+
+// ReportProducts returns the quantity of products stored in a section
+func (s *ProductBatchDefault) ReportProducts(sectionID int) (report internal.SectionProductsReport, err error) {
+	report, err = s.rp.ReportProducts(sectionID)
+	return
+}
@@ -1,11 +1,18 @@
 package service
 
-import "github.com/usuario/repositorio/internal"
+import (
+	"context"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+)
 
 // NewProductDefault creates a new instance of the product service
-func NewProductDefault(rp internal.ProductRepository) *ProductDefault {
+func NewProductDefault(rp internal.ProductRepository, rpSeller internal.SellerRepository, rpProductType internal.ProductTypeRepository) *ProductDefault {
 	return &ProductDefault{
-		rp: rp,
+		rp:            rp,
+		rpSeller:      rpSeller,
+		rpProductType: rpProductType,
 	}
 }
 
@@ -13,29 +20,175 @@ func NewProductDefault(rp internal.ProductRepository) *ProductDefault {
 type ProductDefault struct {
 	// rp is the repository used by the service
 	rp internal.ProductRepository
+	// rpSeller is the repository of the sellers of the products
+	rpSeller internal.SellerRepository
+	// rpProductType is the repository of the types of the products
+	rpProductType internal.ProductTypeRepository
 }
 
 // FindAll returns all products
 func (s *ProductDefault) FindAll() (products []internal.Product, err error) {
+	products, err = s.rp.FindAll()
+	return
+}
+
+// FindAllWithDeleted returns all products, including the soft-deleted ones
+func (s *ProductDefault) FindAllWithDeleted() (products []internal.Product, err error) {
+	products, err = s.rp.FindAllWithDeleted()
 	return
 }
 
 // FindByID returns a product
 func (s *ProductDefault) FindByID(id int) (product internal.Product, err error) {
+	product, err = s.rp.FindByID(id)
+	return
+}
+
+// FindByCode returns a product by its product_code
+func (s *ProductDefault) FindByCode(code string) (product internal.Product, err error) {
+	product, err = s.rp.FindByCode(code)
 	return
 }
 
 // Save creates a new product
-func (s *ProductDefault) Save(product *internal.Product) (err error) {
+func (s *ProductDefault) Save(ctx context.Context, product *internal.Product) (err error) {
+	// validate the product
+	err = (*product).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the product_code is not in use
+	err = s.checkProductCode(*product)
+	if err != nil {
+		return
+	}
+
+	// check the seller and the product type exist
+	err = s.checkReferences(*product)
+	if err != nil {
+		return
+	}
+
+	// save the product
+	err = s.rp.Save(ctx, product)
+	return
+}
+
+// SaveAll creates the given products in a single import
+// - every product is validated before any of them is saved, the invalid or duplicated ones are returned as an internal.BulkError
+func (s *ProductDefault) SaveAll(ctx context.Context, products []*internal.Product) (err error) {
+	// check there is something to import
+	if len(products) == 0 {
+		err = internal.ErrBulkEmpty
+		return
+	}
+
+	// validate the products, check their product_code is not in use, neither by a saved product nor by a previous row, and their seller and product type exist
+	var bulkErr internal.BulkError
+	seen := make(map[string]bool, len(products))
+	for i, product := range products {
+		rowErr := (*product).Validate()
+		if rowErr == nil && seen[(*product).ProductCode] {
+			rowErr = internal.ErrProductCodeDuplicated
+		}
+		if rowErr == nil {
+			rowErr = s.checkProductCode(*product)
+		}
+		if rowErr == nil {
+			rowErr = s.checkReferences(*product)
+		}
+		if rowErr != nil {
+			bulkErr = append(bulkErr, internal.BulkRowError{Row: i + 1, Err: rowErr})
+		}
+		seen[(*product).ProductCode] = true
+	}
+	if len(bulkErr) > 0 {
+		err = bulkErr
+		return
+	}
+
+	// save the products
+	err = s.rp.SaveAll(ctx, products)
 	return
 }
 
 // Update updates a product
-func (s *ProductDefault) Update(product *internal.Product) (err error) {
+func (s *ProductDefault) Update(ctx context.Context, product *internal.Product) (err error) {
+	// check that the product exists and was not modified since the version the update is based on
+	current, err := s.rp.FindByID((*product).ID)
+	if err != nil {
+		return
+	}
+	err = checkVersion(&(*product).Version, current.Version)
+	if err != nil {
+		return
+	}
+
+	// validate the product
+	err = (*product).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the product_code is not in use by another product
+	err = s.checkProductCode(*product)
+	if err != nil {
+		return
+	}
+
+	// check the seller and the product type exist
+	err = s.checkReferences(*product)
+	if err != nil {
+		return
+	}
+
+	// update the product
+	err = s.rp.Update(ctx, product)
+	return
+}
+
+// Patch partially updates a product, fn receives the current product and changes the fields to update
+func (s *ProductDefault) Patch(ctx context.Context, id int, fn func(product *internal.Product) error) (product internal.Product, err error) {
+	product, err = patch(ctx, s.rp.FindByID, s.Update, id, fn)
+	return
+}
+
+// Delete soft-deletes a product
+// - a product that still has batches or records is not deleted, internal.ErrProductHasProductBatches or internal.ErrProductHasProductRecords is returned
+func (s *ProductDefault) Delete(ctx context.Context, id int) (err error) {
+	err = s.rp.Delete(ctx, id)
+	return
+}
+
+// Restore restores a soft-deleted product
+func (s *ProductDefault) Restore(ctx context.Context, id int) (err error) {
+	err = s.rp.Restore(ctx, id)
 	return
 }
 
-// Delete deletes a product
-func (s *ProductDefault) Delete(id int) (err error) {
+// checkProductCode returns internal.ErrProductCodeDuplicated if a product other than the given one already has its product_code
+// - soft-deleted products are not found here, their product_code can be used again
+func (s *ProductDefault) checkProductCode(product internal.Product) (err error) {
+	found, err := s.rp.FindByCode(product.ProductCode)
+	if err != nil {
+		if errors.Is(err, internal.ErrProductRepositoryNotFound) {
+			err = nil
+		}
+		return
+	}
+	if found.ID != product.ID {
+		err = internal.ErrProductCodeDuplicated
+	}
+	return
+}
+
+// checkReferences returns internal.ErrProductSellerNotFound or internal.ErrProductProductTypeNotFound if the seller or the product type of the product does not exist
+func (s *ProductDefault) checkReferences(product internal.Product) (err error) {
+	err = checkReference(s.rpSeller.FindByID, product.SellerID, internal.ErrSellerRepositoryNotFound, internal.ErrProductSellerNotFound)
+	if err != nil {
+		return
+	}
+	err = checkReference(s.rpProductType.FindByID, product.ProductTypeID, internal.ErrProductTypeRepositoryNotFound, internal.ErrProductProductTypeNotFound)
 	return
 }
\ No newline at end of file
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewProductRecordDefault creates a new instance of the product record service
+func NewProductRecordDefault(rp internal.ProductRecordRepository) *ProductRecordDefault {
+	return &ProductRecordDefault{
+		rp: rp,
+	}
+}
+
+// ProductRecordDefault is the default implementation of the product record service
+type ProductRecordDefault struct {
+	// rp is the repository used by the service
+	rp internal.ProductRecordRepository
+}
+
+// Save creates a new product record
+func (s *ProductRecordDefault) Save(ctx context.Context, productRecord *internal.ProductRecord) (err error) {
+	// validate the product record
+	err = (*productRecord).Validate()
+	if err != nil {
+		return
+	}
+
+	// save the product record
+	err = s.rp.Save(ctx, productRecord)
+	return
+}
+
+// ReportRecords returns the number of records of a product, or of every product if productID is zero
+func (s *ProductRecordDefault) ReportRecords(productID int) (reports []internal.ProductRecordsReport, err error) {
+	reports, err = s.rp.ReportRecords(productID)
+	return
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
+
+// NewPurchaseOrderDefault creates a new instance of the purchase order service
+func NewPurchaseOrderDefault(rp internal.PurchaseOrderRepository) *PurchaseOrderDefault {
+	return &PurchaseOrderDefault{
+		rp: rp,
+	}
+}
+
+// PurchaseOrderDefault is the default implementation of the purchase order service
+type PurchaseOrderDefault struct {
+	// rp is the repository used by the service
+	rp internal.PurchaseOrderRepository
+}
+
+// FindByID returns a purchase order
+func (s *PurchaseOrderDefault) FindByID(id int) (purchaseOrder internal.PurchaseOrder, err error) {
+	purchaseOrder, err = s.rp.FindByID(id)
+	return
+}
+
+// Save creates a new purchase order
+func (s *PurchaseOrderDefault) Save(ctx context.Context, purchaseOrder *internal.PurchaseOrder) (err error) {
+	// new purchase orders are pending unless stated otherwise
+	if (*purchaseOrder).Status == "" {
+		(*purchaseOrder).Status = internal.PurchaseOrderStatusPending
+	}
+
+	// validate the purchase order
+	err = (*purchaseOrder).Validate()
+	if err != nil {
+		return
+	}
+
+	// save the purchase order
+	err = s.rp.Save(ctx, purchaseOrder)
+	return
+}
+
+// ReportByBuyer returns the number of purchase orders placed by a buyer
+func (s *PurchaseOrderDefault) ReportByBuyer(buyerID int) (report internal.BuyerPurchaseOrdersReport, err error) {
+	report, err = s.rp.ReportByBuyer(buyerID)
+	return
+}
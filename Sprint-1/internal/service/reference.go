@@ -0,0 +1,14 @@
+package service
+
+import "errors"
+
+// checkReference looks up an entity referenced by another one
+// - the notFound error of the lookup is returned as refErr, so the caller knows which reference failed
+// - soft-deleted entities are not found by the repositories, so they can not be referenced either
+func checkReference[T any](find func(id int) (T, error), id int, notFound error, refErr error) (err error) {
+	_, err = find(id)
+	if errors.Is(err, notFound) {
+		err = refErr
+	}
+	return
+}
@@ -1,11 +1,17 @@
 package service
 
-import "github.com/usuario/repositorio/internal"
+import (
+	"context"
+
+	"github.com/usuario/repositorio/internal"
+)
 
 // NewSectionDefault creates a new instance of the section service
-func NewSectionDefault(rp internal.SectionRepository) *SectionDefault {
+func NewSectionDefault(rp internal.SectionRepository, rpWarehouse internal.WarehouseRepository, rpProductType internal.ProductTypeRepository) *SectionDefault {
 	return &SectionDefault{
-		rp: rp,
+		rp:            rp,
+		rpWarehouse:   rpWarehouse,
+		rpProductType: rpProductType,
 	}
 }
 
@@ -13,29 +19,135 @@ func NewSectionDefault(rp internal.SectionRepository) *SectionDefault {
 type SectionDefault struct {
 	// rp is the repository used by the service
 	rp internal.SectionRepository
+	// rpWarehouse is the repository of the warehouses the sections belong to
+	rpWarehouse internal.WarehouseRepository
+	// rpProductType is the repository of the product types stored in the sections
+	rpProductType internal.ProductTypeRepository
 }
 
 // FindAll returns all sections
 func (s *SectionDefault) FindAll() (sections []internal.Section, err error) {
+	sections, err = s.rp.FindAll()
+	return
+}
+
+// FindAllWithDeleted returns all sections, including the soft-deleted ones
+func (s *SectionDefault) FindAllWithDeleted() (sections []internal.Section, err error) {
+	sections, err = s.rp.FindAllWithDeleted()
 	return
 }
 
 // FindByID returns a section
 func (s *SectionDefault) FindByID(id int) (section internal.Section, err error) {
+	section, err = s.rp.FindByID(id)
 	return
 }
 
 // Save creates a new section
-func (s *SectionDefault) Save(section *internal.Section) (err error) {
+func (s *SectionDefault) Save(ctx context.Context, section *internal.Section) (err error) {
+	// validate the section
+	err = (*section).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the warehouse and the product type exist
+	err = s.checkReferences(*section)
+	if err != nil {
+		return
+	}
+
+	// save the section
+	err = s.rp.Save(ctx, section)
+	return
+}
+
+// SaveAll creates the given sections in a single import
+// - every section is validated before any of them is saved, the invalid ones or the ones with a missing reference are returned as an internal.BulkError
+func (s *SectionDefault) SaveAll(ctx context.Context, sections []*internal.Section) (err error) {
+	// check there is something to import
+	if len(sections) == 0 {
+		err = internal.ErrBulkEmpty
+		return
+	}
+
+	// validate the sections and check their warehouse and product type exist
+	var bulkErr internal.BulkError
+	for i, section := range sections {
+		rowErr := (*section).Validate()
+		if rowErr == nil {
+			rowErr = s.checkReferences(*section)
+		}
+		if rowErr != nil {
+			bulkErr = append(bulkErr, internal.BulkRowError{Row: i + 1, Err: rowErr})
+		}
+	}
+	if len(bulkErr) > 0 {
+		err = bulkErr
+		return
+	}
+
+	// save the sections
+	err = s.rp.SaveAll(ctx, sections)
 	return
 }
 
 // Update updates a section
-func (s *SectionDefault) Update(section *internal.Section) (err error) {
+func (s *SectionDefault) Update(ctx context.Context, section *internal.Section) (err error) {
+	// check that the section exists and was not modified since the version the update is based on
+	current, err := s.rp.FindByID((*section).ID)
+	if err != nil {
+		return
+	}
+	err = checkVersion(&(*section).Version, current.Version)
+	if err != nil {
+		return
+	}
+	// - the current capacity is only changed by the product batches stored in the section
+	(*section).CurrentCapacity = current.CurrentCapacity
+
+	// validate the section
+	err = (*section).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the warehouse and the product type exist
+	err = s.checkReferences(*section)
+	if err != nil {
+		return
+	}
+
+	// update the section
+	err = s.rp.Update(ctx, section)
+	return
+}
+
+// Patch partially updates a section, fn receives the current section and changes the fields to update
+func (s *SectionDefault) Patch(ctx context.Context, id int, fn func(section *internal.Section) error) (section internal.Section, err error) {
+	section, err = patch(ctx, s.rp.FindByID, s.Update, id, fn)
+	return
+}
+
+// Delete soft-deletes a section
+// - a section that still stores product batches is not deleted, internal.ErrSectionHasProductBatches is returned
+func (s *SectionDefault) Delete(ctx context.Context, id int) (err error) {
+	err = s.rp.Delete(ctx, id)
 	return
 }
 
-// Delete deletes a section
-func (s *SectionDefault) Delete(id int) (err error) {
+// Restore restores a soft-deleted section
+func (s *SectionDefault) Restore(ctx context.Context, id int) (err error) {
+	err = s.rp.Restore(ctx, id)
+	return
+}
+
+// checkReferences returns internal.ErrSectionWarehouseNotFound or internal.ErrSectionProductTypeNotFound if the warehouse or the product type of the section does not exist
+func (s *SectionDefault) checkReferences(section internal.Section) (err error) {
+	err = checkReference(s.rpWarehouse.FindByID, section.WarehouseID, internal.ErrWarehouseRepositoryNotFound, internal.ErrSectionWarehouseNotFound)
+	if err != nil {
+		return
+	}
+	err = checkReference(s.rpProductType.FindByID, section.ProductTypeID, internal.ErrProductTypeRepositoryNotFound, internal.ErrSectionProductTypeNotFound)
 	return
 }
\ No newline at end of file
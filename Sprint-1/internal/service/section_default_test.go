@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for SectionDefault.Save
+func TestSectionDefault_Save(t *testing.T) {
+	t.Run("case 1: error - the minimum capacity exceeds the maximum one", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rpWarehouse := repository.NewWarehouseMock()
+		sv := service.NewSectionDefault(rp, rpWarehouse, repository.NewProductTypeMock())
+
+		// act
+		section := internal.Section{SectionNumber: 1, MinimumCapacity: 20, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 1}
+		err := sv.Save(context.Background(), &section)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrSectionInvalid)
+		require.Equal(t, 0, rpWarehouse.Spy.FindByID)
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the warehouse is soft-deleted or does not exist", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rpWarehouse := repository.NewWarehouseMock()
+		rpWarehouse.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{}, internal.ErrWarehouseRepositoryNotFound
+		}
+		rpProductType := repository.NewProductTypeMock()
+		sv := service.NewSectionDefault(rp, rpWarehouse, rpProductType)
+
+		// act
+		section := internal.Section{SectionNumber: 1, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 1}
+		err := sv.Save(context.Background(), &section)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrSectionWarehouseNotFound)
+		require.Equal(t, 0, rpProductType.Spy.FindByID)
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for SectionDefault.Update
+func TestSectionDefault_Update(t *testing.T) {
+	t.Run("case 1: success - the version that was read and the current capacity are kept", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncFindByID = func(id int) (internal.Section, error) {
+			return internal.Section{ID: id, SectionNumber: 1, CurrentCapacity: 5, MaximumCapacity: 10, WarehouseID: 1, ProductTypeID: 1, Version: 7}, nil
+		}
+		var updated internal.Section
+		rp.FuncUpdate = func(ctx context.Context, section *internal.Section) error {
+			updated = *section
+			return nil
+		}
+		rpWarehouse := repository.NewWarehouseMock()
+		rpWarehouse.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return internal.Warehouse{ID: id}, nil
+		}
+		rpProductType := repository.NewProductTypeMock()
+		rpProductType.FuncFindByID = func(id int) (internal.ProductType, error) {
+			return internal.ProductType{ID: id}, nil
+		}
+		sv := service.NewSectionDefault(rp, rpWarehouse, rpProductType)
+
+		// act
+		section := internal.Section{ID: 1, SectionNumber: 2, MaximumCapacity: 20, WarehouseID: 1, ProductTypeID: 1}
+		err := sv.Update(context.Background(), &section)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, internal.Section{ID: 1, SectionNumber: 2, CurrentCapacity: 5, MaximumCapacity: 20, WarehouseID: 1, ProductTypeID: 1, Version: 7}, updated)
+		require.Equal(t, 1, rp.Spy.Update)
+	})
+
+	t.Run("case 2: error - the section is not found", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSectionMock()
+		rp.FuncFindByID = func(id int) (internal.Section, error) {
+			return internal.Section{}, internal.ErrSectionRepositoryNotFound
+		}
+		sv := service.NewSectionDefault(rp, repository.NewWarehouseMock(), repository.NewProductTypeMock())
+
+		// act
+		section := internal.Section{ID: 1, SectionNumber: 2, MaximumCapacity: 20, WarehouseID: 1, ProductTypeID: 1}
+		err := sv.Update(context.Background(), &section)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrSectionRepositoryNotFound)
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+}
@@ -1,6 +1,11 @@
 package service
 
-import "github.com/usuario/repositorio/internal"
+import (
+	"context"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+)
 
 // NewSellerDefault creates a new instance of the seller service
 func NewSellerDefault(rp internal.SellerRepository) *SellerDefault {
@@ -17,25 +22,142 @@ type SellerDefault struct {
 
 // FindAll returns all sellers
 func (s *SellerDefault) FindAll() (sellers []internal.Seller, err error) {
+	sellers, err = s.rp.FindAll()
+	return
+}
+
+// FindAllWithDeleted returns all sellers, including the soft-deleted ones
+func (s *SellerDefault) FindAllWithDeleted() (sellers []internal.Seller, err error) {
+	sellers, err = s.rp.FindAllWithDeleted()
 	return
 }
 
 // FindByID returns a seller
 func (s *SellerDefault) FindByID(id int) (seller internal.Seller, err error) {
+	seller, err = s.rp.FindByID(id)
+	return
+}
+
+// FindByCID returns a seller by its cid
+func (s *SellerDefault) FindByCID(cid int) (seller internal.Seller, err error) {
+	seller, err = s.rp.FindByCID(cid)
 	return
 }
 
 // Save creates a new seller
-func (s *SellerDefault) Save(seller *internal.Seller) (err error) {
+func (s *SellerDefault) Save(ctx context.Context, seller *internal.Seller) (err error) {
+	// validate the seller
+	err = (*seller).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the cid is not in use
+	err = s.checkCID(*seller)
+	if err != nil {
+		return
+	}
+
+	// save the seller
+	err = s.rp.Save(ctx, seller)
+	return
+}
+
+// SaveAll creates the given sellers in a single import
+// - every seller is validated before any of them is saved, the invalid or duplicated ones are returned as an internal.BulkError
+func (s *SellerDefault) SaveAll(ctx context.Context, sellers []*internal.Seller) (err error) {
+	// check there is something to import
+	if len(sellers) == 0 {
+		err = internal.ErrBulkEmpty
+		return
+	}
+
+	// validate the sellers and check their cid is not in use, neither by a saved seller nor by a previous row
+	var bulkErr internal.BulkError
+	seen := make(map[int]bool, len(sellers))
+	for i, seller := range sellers {
+		rowErr := (*seller).Validate()
+		if rowErr == nil && seen[(*seller).CID] {
+			rowErr = internal.ErrSellerCIDDuplicated
+		}
+		if rowErr == nil {
+			rowErr = s.checkCID(*seller)
+		}
+		if rowErr != nil {
+			bulkErr = append(bulkErr, internal.BulkRowError{Row: i + 1, Err: rowErr})
+		}
+		seen[(*seller).CID] = true
+	}
+	if len(bulkErr) > 0 {
+		err = bulkErr
+		return
+	}
+
+	// save the sellers
+	err = s.rp.SaveAll(ctx, sellers)
 	return
 }
 
 // Update updates a seller
-func (s *SellerDefault) Update(seller *internal.Seller) (err error) {
+func (s *SellerDefault) Update(ctx context.Context, seller *internal.Seller) (err error) {
+	// check that the seller exists and was not modified since the version the update is based on
+	current, err := s.rp.FindByID((*seller).ID)
+	if err != nil {
+		return
+	}
+	err = checkVersion(&(*seller).Version, current.Version)
+	if err != nil {
+		return
+	}
+
+	// validate the seller
+	err = (*seller).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the cid is not in use by another seller
+	err = s.checkCID(*seller)
+	if err != nil {
+		return
+	}
+
+	// update the seller
+	err = s.rp.Update(ctx, seller)
+	return
+}
+
+// Patch partially updates a seller, fn receives the current seller and changes the fields to update
+func (s *SellerDefault) Patch(ctx context.Context, id int, fn func(seller *internal.Seller) error) (seller internal.Seller, err error) {
+	seller, err = patch(ctx, s.rp.FindByID, s.Update, id, fn)
 	return
 }
 
-// Delete deletes a seller
-func (s *SellerDefault) Delete(id int) (err error) {
+// Delete soft-deletes a seller
+// - a seller that still has products is not deleted, internal.ErrSellerHasProducts is returned
+func (s *SellerDefault) Delete(ctx context.Context, id int) (err error) {
+	err = s.rp.Delete(ctx, id)
 	return
-}
\ No newline at end of file
+}
+
+// Restore restores a soft-deleted seller
+func (s *SellerDefault) Restore(ctx context.Context, id int) (err error) {
+	err = s.rp.Restore(ctx, id)
+	return
+}
+
+// checkCID returns internal.ErrSellerCIDDuplicated if a seller other than the given one already has its cid
+// - soft-deleted sellers are not found here, their cid can be used again
+func (s *SellerDefault) checkCID(seller internal.Seller) (err error) {
+	found, err := s.rp.FindByCID(seller.CID)
+	if err != nil {
+		if errors.Is(err, internal.ErrSellerRepositoryNotFound) {
+			err = nil
+		}
+		return
+	}
+	if found.ID != seller.ID {
+		err = internal.ErrSellerCIDDuplicated
+	}
+	return
+}
@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for SellerDefault.Save
+func TestSellerDefault_Save(t *testing.T) {
+	t.Run("case 1: success - saves the seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		rp.FuncSave = func(ctx context.Context, seller *internal.Seller) error {
+			(*seller).ID = 1
+			return nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller := internal.Seller{CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111"}
+		err := sv.Save(context.Background(), &seller)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, 1, seller.ID)
+		require.Equal(t, 1, rp.Spy.Save)
+	})
+
+	t.Run("case 2: error - the seller is not valid, it is not saved", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller := internal.Seller{CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", LocalityID: -1}
+		err := sv.Save(context.Background(), &seller)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrSellerInvalid)
+		require.EqualError(t, err, "seller: invalid fields: locality_id must not be negative")
+		require.Equal(t, 0, rp.Spy.FindByCID)
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+
+	t.Run("case 3: error - the cid lookup fails", func(t *testing.T) {
+		// arrange
+		errLookup := errors.New("connection refused")
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{}, errLookup
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller := internal.Seller{CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111"}
+		err := sv.Save(context.Background(), &seller)
+
+		// assert
+		require.ErrorIs(t, err, errLookup)
+		require.Equal(t, 0, rp.Spy.Save)
+	})
+}
+
+// Tests for SellerDefault.SaveAll
+func TestSellerDefault_SaveAll(t *testing.T) {
+	t.Run("case 1: error - a cid repeated in the import is reported on its row", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{}, internal.ErrSellerRepositoryNotFound
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		sellers := []*internal.Seller{
+			{CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111"},
+			{CID: 10, CompanyName: "Other", Address: "Street 2", Telephone: "222"},
+		}
+		err := sv.SaveAll(context.Background(), sellers)
+
+		// assert
+		var bulkErr internal.BulkError
+		require.ErrorAs(t, err, &bulkErr)
+		require.Len(t, bulkErr, 1)
+		require.Equal(t, 2, bulkErr[0].Row)
+		require.ErrorIs(t, bulkErr[0].Err, internal.ErrSellerCIDDuplicated)
+		require.Equal(t, 0, rp.Spy.SaveAll)
+	})
+}
+
+// Tests for SellerDefault.Update
+func TestSellerDefault_Update(t *testing.T) {
+	t.Run("case 1: success - an update without version is applied over the current one", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 4}, nil
+		}
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 1, CID: cid}, nil
+		}
+		var updated internal.Seller
+		rp.FuncUpdate = func(ctx context.Context, seller *internal.Seller) error {
+			updated = *seller
+			return nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller := internal.Seller{ID: 1, CID: 10, CompanyName: "Acme Inc", Address: "Street 2", Telephone: "222"}
+		err := sv.Update(context.Background(), &seller)
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, internal.Seller{ID: 1, CID: 10, CompanyName: "Acme Inc", Address: "Street 2", Telephone: "222", Version: 4}, updated)
+	})
+
+	t.Run("case 2: error - the update is based on a stale version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 4}, nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller := internal.Seller{ID: 1, CID: 10, CompanyName: "Acme Inc", Address: "Street 2", Telephone: "222", Version: 3}
+		err := sv.Update(context.Background(), &seller)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrVersionConflict)
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 3: error - the cid belongs to another seller", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 1}, nil
+		}
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 2, CID: cid}, nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller := internal.Seller{ID: 1, CID: 20, CompanyName: "Acme", Address: "Street 1", Telephone: "111"}
+		err := sv.Update(context.Background(), &seller)
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrSellerCIDDuplicated)
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+}
+
+// Tests for SellerDefault.Patch
+func TestSellerDefault_Patch(t *testing.T) {
+	t.Run("case 1: success - the fields left alone keep their current value", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", LocalityID: 2, Version: 4}, nil
+		}
+		rp.FuncFindByCID = func(cid int) (internal.Seller, error) {
+			return internal.Seller{ID: 1, CID: cid}, nil
+		}
+		rp.FuncUpdate = func(ctx context.Context, seller *internal.Seller) error {
+			(*seller).Version++
+			return nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		seller, err := sv.Patch(context.Background(), 1, func(seller *internal.Seller) error {
+			(*seller).Telephone = "222"
+			return nil
+		})
+
+		// assert
+		require.NoError(t, err)
+		require.Equal(t, internal.Seller{ID: 1, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "222", LocalityID: 2, Version: 5}, seller)
+	})
+
+	t.Run("case 2: error - the changes are not applied when fn fails", func(t *testing.T) {
+		// arrange
+		errChange := errors.New("invalid body")
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 4}, nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		_, err := sv.Patch(context.Background(), 1, func(seller *internal.Seller) error {
+			return errChange
+		})
+
+		// assert
+		require.ErrorIs(t, err, errChange)
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+
+	t.Run("case 3: error - fn bases the changes on a stale version", func(t *testing.T) {
+		// arrange
+		rp := repository.NewSellerMock()
+		rp.FuncFindByID = func(id int) (internal.Seller, error) {
+			return internal.Seller{ID: id, CID: 10, CompanyName: "Acme", Address: "Street 1", Telephone: "111", Version: 4}, nil
+		}
+		sv := service.NewSellerDefault(rp)
+
+		// act
+		_, err := sv.Patch(context.Background(), 1, func(seller *internal.Seller) error {
+			(*seller).Telephone = "222"
+			(*seller).Version = 3
+			return nil
+		})
+
+		// assert
+		require.ErrorIs(t, err, internal.ErrVersionConflict)
+		require.Equal(t, 0, rp.Spy.Update)
+	})
+}
@@ -1,11 +1,18 @@
 package service
 
-import "github.com/usuario/repositorio/internal"
+import (
+	"context"
+	"errors"
+
+	"github.com/usuario/repositorio/internal"
+)
 
 // NewWarehouseDefault creates a new instance of the warehouse service
-func NewWarehouseDefault(rp internal.WarehouseRepository) *WarehouseDefault {
+func NewWarehouseDefault(rp internal.WarehouseRepository, rpSection internal.SectionRepository, rpEmployee internal.EmployeeRepository) *WarehouseDefault {
 	return &WarehouseDefault{
-		rp: rp,
+		rp:         rp,
+		rpSection:  rpSection,
+		rpEmployee: rpEmployee,
 	}
 }
 
@@ -13,29 +20,180 @@ func NewWarehouseDefault(rp internal.WarehouseRepository) *WarehouseDefault {
 type WarehouseDefault struct {
 	// rp is the repository used by the service
 	rp internal.WarehouseRepository
+	// rpSection is the repository of the sections of the warehouses
+	rpSection internal.SectionRepository
+	// rpEmployee is the repository of the employees of the warehouses
+	rpEmployee internal.EmployeeRepository
 }
 
 // FindAll returns all warehouses
 func (s *WarehouseDefault) FindAll() (warehouses []internal.Warehouse, err error) {
+	warehouses, err = s.rp.FindAll()
+	return
+}
+
+// FindAllWithDeleted returns all warehouses, including the soft-deleted ones
+func (s *WarehouseDefault) FindAllWithDeleted() (warehouses []internal.Warehouse, err error) {
+	warehouses, err = s.rp.FindAllWithDeleted()
 	return
 }
 
 // FindByID returns a warehouse
 func (s *WarehouseDefault) FindByID(id int) (warehouse internal.Warehouse, err error) {
+	warehouse, err = s.rp.FindByID(id)
+	return
+}
+
+// FindByCode returns a warehouse by its warehouse_code
+func (s *WarehouseDefault) FindByCode(code string) (warehouse internal.Warehouse, err error) {
+	warehouse, err = s.rp.FindByCode(code)
 	return
 }
 
 // Save creates a new warehouse
-func (s *WarehouseDefault) Save(warehouse *internal.Warehouse) (err error) {
+func (s *WarehouseDefault) Save(ctx context.Context, warehouse *internal.Warehouse) (err error) {
+	// validate the warehouse
+	err = (*warehouse).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the warehouse_code is not in use
+	err = s.checkWarehouseCode(*warehouse)
+	if err != nil {
+		return
+	}
+
+	// save the warehouse
+	err = s.rp.Save(ctx, warehouse)
+	return
+}
+
+// SaveAll creates the given warehouses in a single import
+// - every warehouse is validated before any of them is saved, the invalid or duplicated ones are returned as an internal.BulkError
+func (s *WarehouseDefault) SaveAll(ctx context.Context, warehouses []*internal.Warehouse) (err error) {
+	// check there is something to import
+	if len(warehouses) == 0 {
+		err = internal.ErrBulkEmpty
+		return
+	}
+
+	// validate the warehouses and check their warehouse_code is not in use, neither by a saved warehouse nor by a previous row
+	var bulkErr internal.BulkError
+	seen := make(map[string]bool, len(warehouses))
+	for i, warehouse := range warehouses {
+		rowErr := (*warehouse).Validate()
+		if rowErr == nil && seen[(*warehouse).WarehouseCode] {
+			rowErr = internal.ErrWarehouseCodeDuplicated
+		}
+		if rowErr == nil {
+			rowErr = s.checkWarehouseCode(*warehouse)
+		}
+		if rowErr != nil {
+			bulkErr = append(bulkErr, internal.BulkRowError{Row: i + 1, Err: rowErr})
+		}
+		seen[(*warehouse).WarehouseCode] = true
+	}
+	if len(bulkErr) > 0 {
+		err = bulkErr
+		return
+	}
+
+	// save the warehouses
+	err = s.rp.SaveAll(ctx, warehouses)
 	return
 }
 
 // Update updates a warehouse
-func (s *WarehouseDefault) Update(warehouse *internal.Warehouse) (err error) {
+func (s *WarehouseDefault) Update(ctx context.Context, warehouse *internal.Warehouse) (err error) {
+	// check that the warehouse exists and was not modified since the version the update is based on
+	current, err := s.rp.FindByID((*warehouse).ID)
+	if err != nil {
+		return
+	}
+	err = checkVersion(&(*warehouse).Version, current.Version)
+	if err != nil {
+		return
+	}
+
+	// validate the warehouse
+	err = (*warehouse).Validate()
+	if err != nil {
+		return
+	}
+
+	// check the warehouse_code is not in use by another warehouse
+	err = s.checkWarehouseCode(*warehouse)
+	if err != nil {
+		return
+	}
+
+	// update the warehouse
+	err = s.rp.Update(ctx, warehouse)
+	return
+}
+
+// Patch partially updates a warehouse, fn receives the current warehouse and changes the fields to update
+func (s *WarehouseDefault) Patch(ctx context.Context, id int, fn func(warehouse *internal.Warehouse) error) (warehouse internal.Warehouse, err error) {
+	warehouse, err = patch(ctx, s.rp.FindByID, s.Update, id, fn)
+	return
+}
+
+// Delete soft-deletes a warehouse
+// - a warehouse that still has sections or employees is not deleted, internal.ErrWarehouseHasSections or internal.ErrWarehouseHasEmployees is returned
+func (s *WarehouseDefault) Delete(ctx context.Context, id int) (err error) {
+	err = s.rp.Delete(ctx, id)
+	return
+}
+
+// Restore restores a soft-deleted warehouse
+func (s *WarehouseDefault) Restore(ctx context.Context, id int) (err error) {
+	err = s.rp.Restore(ctx, id)
+	return
+}
+
+// Report returns the aggregates of a warehouse across its sections and employees
+func (s *WarehouseDefault) Report(id int) (report internal.WarehouseReport, err error) {
+	// find the warehouse
+	warehouse, err := s.rp.FindByID(id)
+	if err != nil {
+		return
+	}
+
+	// aggregate the sections
+	sections, err := s.rpSection.ReportByWarehouse(id)
+	if err != nil {
+		return
+	}
+
+	// count the employees
+	employeesCount, err := s.rpEmployee.CountByWarehouse(id)
+	if err != nil {
+		return
+	}
+
+	report = internal.WarehouseReport{
+		WarehouseID:            warehouse.ID,
+		WarehouseCode:          warehouse.WarehouseCode,
+		SectionWarehouseReport: sections,
+		EmployeesCount:         employeesCount,
+		Compliant:              sections.TotalCapacity >= warehouse.MinimumCapacity && sections.SectionsBelowMinimumTemperature == 0,
+	}
 	return
 }
 
-// Delete deletes a warehouse
-func (s *WarehouseDefault) Delete(id int) (err error) {
+// checkWarehouseCode returns internal.ErrWarehouseCodeDuplicated if a warehouse other than the given one already has its warehouse_code
+// - soft-deleted warehouses are not found here, their warehouse_code can be used again
+func (s *WarehouseDefault) checkWarehouseCode(warehouse internal.Warehouse) (err error) {
+	found, err := s.rp.FindByCode(warehouse.WarehouseCode)
+	if err != nil {
+		if errors.Is(err, internal.ErrWarehouseRepositoryNotFound) {
+			err = nil
+		}
+		return
+	}
+	if found.ID != warehouse.ID {
+		err = internal.ErrWarehouseCodeDuplicated
+	}
 	return
-}
\ No newline at end of file
+}
@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/usuario/repositorio/internal"
+	"github.com/usuario/repositorio/internal/repository"
+	"github.com/usuario/repositorio/internal/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for WarehouseDefault.Report
+func TestWarehouseDefault_Report(t *testing.T) {
+	// newService creates the warehouse service over mocks that return the given warehouse and section aggregates
+	newService := func(warehouse internal.Warehouse, sections internal.SectionWarehouseReport) *service.WarehouseDefault {
+		rp := repository.NewWarehouseMock()
+		rp.FuncFindByID = func(id int) (internal.Warehouse, error) {
+			return warehouse, nil
+		}
+		rpSection := repository.NewSectionMock()
+		rpSection.FuncReportByWarehouse = func(warehouseID int) (internal.SectionWarehouseReport, error) {
+			return sections, nil
+		}
+		rpEmployee := repository.NewEmployeeMock()
+		rpEmployee.FuncCountByWarehouse = func(warehouseID int) (int, error) {
+			return 1, nil
+		}
+		return service.NewWarehouseDefault(rp, rpSection, rpEmployee)
+	}
+
+	t.Run("case 1: success - a warehouse with enough capacity and no cold section is compliant", func(t *testing.T) {
+		// arrange
+		sv := newService(
+			internal.Warehouse{ID: 1, WarehouseCode: "W1", MinimumCapacity: 100},
+			internal.SectionWarehouseReport{SectionsCount: 1, TotalCapacity: 100},
+		)
+
+		// act
+		report, err := sv.Report(1)
+
+		// assert
+		require.NoError(t, err)
+		require.True(t, report.Compliant)
+		require.Equal(t, 1, report.EmployeesCount)
+	})
+
+	t.Run("case 2: success - a warehouse below its minimum capacity is not compliant", func(t *testing.T) {
+		// arrange
+		sv := newService(
+			internal.Warehouse{ID: 1, WarehouseCode: "W1", MinimumCapacity: 100},
+			internal.SectionWarehouseReport{SectionsCount: 1, TotalCapacity: 99},
+		)
+
+		// act
+		report, err := sv.Report(1)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, report.Compliant)
+	})
+
+	t.Run("case 3: success - a warehouse with a section below its minimum temperature is not compliant", func(t *testing.T) {
+		// arrange
+		sv := newService(
+			internal.Warehouse{ID: 1, WarehouseCode: "W1", MinimumCapacity: 100},
+			internal.SectionWarehouseReport{SectionsCount: 2, TotalCapacity: 200, SectionsBelowMinimumTemperature: 1},
+		)
+
+		// act
+		report, err := sv.Report(1)
+
+		// assert
+		require.NoError(t, err)
+		require.False(t, report.Compliant)
+	})
+}
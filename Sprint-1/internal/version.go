@@ -0,0 +1,7 @@
+package internal
+
+import "errors"
+
+// ErrVersionConflict is returned when an entity is updated from a version that is no longer its current one
+// - every write increases the version of an entity, so an update based on an older version would overwrite a concurrent change
+var ErrVersionConflict = errors.New("version conflict: the entity was modified by another request")
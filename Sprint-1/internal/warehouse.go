@@ -1,6 +1,10 @@
 package internal
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"fmt"
+)
 
 // Warehouse is a struct that contains the warehouse's information
 type Warehouse struct {
@@ -16,6 +20,41 @@ type Warehouse struct {
 	MinimumCapacity int
 	// MinimumTemperature is the minimum temperature that can be maintained in the warehouse
 	MinimumTemperature float64
+	// LocalityID is the unique identifier of the locality of the warehouse, zero if it is not known
+	LocalityID int
+	// Version is the number of times the warehouse was written, used to detect concurrent updates
+	Version int
+}
+
+// Validate returns ErrWarehouseInvalid wrapped with the first field of the warehouse that is not valid
+func (w Warehouse) Validate() (err error) {
+	switch {
+	case w.WarehouseCode == "":
+		err = fmt.Errorf("%w: warehouse_code is required", ErrWarehouseInvalid)
+	case w.Address == "":
+		err = fmt.Errorf("%w: address is required", ErrWarehouseInvalid)
+	case w.Telephone == "":
+		err = fmt.Errorf("%w: telephone is required", ErrWarehouseInvalid)
+	case w.MinimumCapacity < 0:
+		err = fmt.Errorf("%w: minimum_capacity must not be negative", ErrWarehouseInvalid)
+	case w.LocalityID < 0:
+		err = fmt.Errorf("%w: locality_id must not be negative", ErrWarehouseInvalid)
+	}
+	return
+}
+
+// WarehouseReport is a struct that contains the aggregates of a warehouse across its sections and employees
+type WarehouseReport struct {
+	// WarehouseID is the unique identifier of the warehouse
+	WarehouseID int
+	// WarehouseCode is the unique code of the warehouse
+	WarehouseCode string
+	// SectionWarehouseReport is the aggregates of the sections of the warehouse
+	SectionWarehouseReport
+	// EmployeesCount is the number of employees of the warehouse
+	EmployeesCount int
+	// Compliant is true when the sections reach the minimum capacity of the warehouse and none of them is below its minimum temperature
+	Compliant bool
 }
 
 var (
@@ -23,32 +62,68 @@ var (
 	ErrWarehouseRepositoryNotFound = errors.New("repository: warehouse not found")
 	// ErrWarehouseRepositoryDuplicated is returned when the warehouse already exists
 	ErrWarehouseRepositoryDuplicated = errors.New("repository: warehouse already exists")
+	// ErrWarehouseCodeDuplicated is returned when another warehouse already has the same warehouse_code
+	ErrWarehouseCodeDuplicated = fmt.Errorf("%w: warehouse_code already in use", ErrWarehouseRepositoryDuplicated)
+	// ErrWarehouseReferenceNotFound is returned when an entity referenced by the warehouse does not exist
+	ErrWarehouseReferenceNotFound = errors.New("warehouse: reference not found")
+	// ErrWarehouseLocalityNotFound is returned when the locality of the warehouse does not exist
+	ErrWarehouseLocalityNotFound = fmt.Errorf("%w: locality_id", ErrWarehouseReferenceNotFound)
+	// ErrWarehouseHasDependents is returned when the warehouse can not be deleted because other entities reference it
+	ErrWarehouseHasDependents = errors.New("warehouse: has dependents")
+	// ErrWarehouseHasSections is returned when the warehouse still has sections
+	ErrWarehouseHasSections = fmt.Errorf("%w: sections", ErrWarehouseHasDependents)
+	// ErrWarehouseHasEmployees is returned when the warehouse still has employees
+	ErrWarehouseHasEmployees = fmt.Errorf("%w: employees", ErrWarehouseHasDependents)
+	// ErrWarehouseInvalid is returned when the warehouse has invalid fields
+	ErrWarehouseInvalid = errors.New("warehouse: invalid fields")
 )
 
 // WarehouseRepository is an interface that contains the methods that the warehouse repository should support
 type WarehouseRepository interface {
 	// FindAll returns all the warehouses
 	FindAll() ([]Warehouse, error)
+	// FindAllWithDeleted returns all the warehouses, including the soft-deleted ones
+	FindAllWithDeleted() ([]Warehouse, error)
 	// FindByID returns the warehouse with the given ID
 	FindByID(id int) (Warehouse, error)
-	// Save saves the given warehouse
-	Save(warehouse *Warehouse) error
-	// Update updates the given warehouse
-	Update(warehouse *Warehouse) error
-	// Delete deletes the warehouse with the given ID
-	Delete(id int) error
+	// FindByIDForUpdate returns the warehouse with the given ID, also if it is soft-deleted, locking it in the transaction of the context
+	FindByIDForUpdate(ctx context.Context, id int) (Warehouse, error)
+	// FindByCode returns the warehouse with the given warehouse_code
+	FindByCode(code string) (Warehouse, error)
+	// Save saves the given warehouse, ErrWarehouseLocalityNotFound is returned if its locality does not exist
+	Save(ctx context.Context, warehouse *Warehouse) error
+	// SaveAll saves the given warehouses in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, warehouses []*Warehouse) error
+	// Update updates the given warehouse, ErrVersionConflict is returned if its version is not the current one and ErrWarehouseLocalityNotFound if its locality does not exist
+	Update(ctx context.Context, warehouse *Warehouse) error
+	// Delete soft-deletes the warehouse with the given ID, ErrWarehouseHasSections or ErrWarehouseHasEmployees is returned if it still has them
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted warehouse with the given ID, ErrWarehouseCodeDuplicated is returned if another warehouse has its warehouse_code
+	Restore(ctx context.Context, id int) error
 }
 
 // WarehouseService is an interface that contains the methods that the warehouse service should support
 type WarehouseService interface {
 	// FindAll returns all the warehouses
 	FindAll() ([]Warehouse, error)
+	// FindAllWithDeleted returns all the warehouses, including the soft-deleted ones
+	FindAllWithDeleted() ([]Warehouse, error)
 	// FindByID returns the warehouse with the given ID
 	FindByID(id int) (Warehouse, error)
+	// FindByCode returns the warehouse with the given warehouse_code
+	FindByCode(code string) (Warehouse, error)
 	// Save saves the given warehouse
-	Save(warehouse *Warehouse) error
-	// Update updates the given warehouse
-	Update(warehouse *Warehouse) error
-	// Delete deletes the warehouse with the given ID
-	Delete(id int) error
+	Save(ctx context.Context, warehouse *Warehouse) error
+	// SaveAll validates and saves the given warehouses in a single transaction, none of them are saved if one fails
+	SaveAll(ctx context.Context, warehouses []*Warehouse) error
+	// Update updates the given warehouse, ErrVersionConflict is returned if its version is not the current one
+	Update(ctx context.Context, warehouse *Warehouse) error
+	// Patch partially updates the warehouse with the given ID, fn receives the current warehouse and changes the fields to update
+	Patch(ctx context.Context, id int, fn func(warehouse *Warehouse) error) (Warehouse, error)
+	// Delete soft-deletes the warehouse with the given ID
+	Delete(ctx context.Context, id int) error
+	// Restore restores the soft-deleted warehouse with the given ID
+	Restore(ctx context.Context, id int) error
+	// Report returns the aggregates of the warehouse with the given ID
+	Report(id int) (WarehouseReport, error)
 }
@@ -0,0 +1,184 @@
+package request
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrRequestContentTypeNotCSV is used when the request content type is not text/csv.
+	ErrRequestContentTypeNotCSV = errors.New("request content type is not text/csv")
+	// ErrRequestCSVInvalid is used when the request csv is invalid.
+	ErrRequestCSVInvalid = errors.New("request csv invalid")
+)
+
+// CSVRowError is the error of a csv row that can not be decoded
+type CSVRowError struct {
+	// Row is the position of the row in the decoded slice, starting at 1
+	Row int
+	// Err is the error of the row, with its line and column
+	Err error
+}
+
+// Error returns the error of the row
+func (e CSVRowError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error of the row
+func (e CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// CSVRowsError is returned when one or more rows of the csv can not be decoded
+// - the other rows are still decoded, so every row keeps its position in the slice
+type CSVRowsError []CSVRowError
+
+// Error returns the errors of the rows joined by a semicolon
+func (e CSVRowsError) Error() string {
+	msgs := make([]string, len(e))
+	for i, rowErr := range e {
+		msgs[i] = rowErr.Error()
+	}
+	return fmt.Sprintf("%s. %s", ErrRequestCSVInvalid, strings.Join(msgs, "; "))
+}
+
+// Unwrap returns ErrRequestCSVInvalid and the errors of the rows
+func (e CSVRowsError) Unwrap() []error {
+	errs := []error{ErrRequestCSVInvalid}
+	for _, rowErr := range e {
+		errs = append(errs, rowErr)
+	}
+	return errs
+}
+
+// CSV decodes csv from request body to ptr, a pointer to a slice of structs
+// - the first record is the header, each column is matched to the struct field with the same json tag
+// - empty values and unknown columns are skipped
+// - the rows that can not be decoded are returned as a CSVRowsError, the slice is set with every row
+func CSV(r *http.Request, ptr any) (err error) {
+	// check content type
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "text/csv" {
+		err = ErrRequestContentTypeNotCSV
+		return
+	}
+
+	// check the destination
+	slice := reflect.ValueOf(ptr)
+	if slice.Kind() != reflect.Pointer || slice.Elem().Kind() != reflect.Slice || slice.Elem().Type().Elem().Kind() != reflect.Struct {
+		err = fmt.Errorf("%w. destination must be a pointer to a slice of structs", ErrRequestCSVInvalid)
+		return
+	}
+	slice = slice.Elem()
+	item := slice.Type().Elem()
+
+	// get the header
+	// - the rows must have as many fields as the header
+	reader := csv.NewReader(r.Body)
+	header, err := reader.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = fmt.Errorf("%w. missing header", ErrRequestCSVInvalid)
+			return
+		}
+		err = fmt.Errorf("%w. %v", ErrRequestCSVInvalid, err)
+		return
+	}
+
+	// match the header to the struct fields
+	fields := make([]int, len(header))
+	for i, column := range header {
+		fields[i] = -1
+		for j := 0; j < item.NumField(); j++ {
+			name, _, _ := strings.Cut(item.Field(j).Tag.Get("json"), ",")
+			if name == strings.TrimSpace(column) {
+				fields[i] = j
+				break
+			}
+		}
+	}
+
+	// decode the records
+	values := reflect.MakeSlice(slice.Type(), 0, 0)
+	var rowsErr CSVRowsError
+	for row := 1; ; row++ {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if readErr != nil && !errors.As(readErr, &parseErr) {
+			err = fmt.Errorf("%w. %v", ErrRequestCSVInvalid, readErr)
+			return
+		}
+
+		// decode the record, a malformed one is reported as the error of its row
+		value := reflect.New(item).Elem()
+		rowErr := readErr
+		if rowErr == nil {
+			rowErr = decodeRecord(value, header, fields, record)
+			if rowErr != nil {
+				line, _ := reader.FieldPos(0)
+				rowErr = fmt.Errorf("line %d, %w", line, rowErr)
+			}
+		}
+		if rowErr != nil {
+			rowsErr = append(rowsErr, CSVRowError{Row: row, Err: rowErr})
+		}
+		values = reflect.Append(values, value)
+	}
+	slice.Set(values)
+	if len(rowsErr) > 0 {
+		err = rowsErr
+		return
+	}
+
+	return
+}
+
+// decodeRecord sets the fields of value from the columns of the record matched to them
+func decodeRecord(value reflect.Value, header []string, fields []int, record []string) (err error) {
+	for i, raw := range record {
+		raw = strings.TrimSpace(raw)
+		if fields[i] == -1 || raw == "" {
+			continue
+		}
+		err = setField(value.Field(fields[i]), raw)
+		if err != nil {
+			err = fmt.Errorf("column %s: %w", header[i], err)
+			return
+		}
+	}
+	return
+}
+
+// setField parses raw into the field according to its kind
+func setField(field reflect.Value, raw string) (err error) {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		v, err = strconv.ParseInt(raw, 10, field.Type().Bits())
+		field.SetInt(v)
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		v, err = strconv.ParseFloat(raw, field.Type().Bits())
+		field.SetFloat(v)
+	case reflect.Bool:
+		var v bool
+		v, err = strconv.ParseBool(raw)
+		field.SetBool(v)
+	default:
+		err = fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return
+}
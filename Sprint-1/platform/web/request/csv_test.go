@@ -0,0 +1,116 @@
+package request_test
+
+import (
+	"encoding/csv"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/usuario/repositorio/platform/web/request"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Tests for CSV function
+func TestRequestCSV(t *testing.T) {
+	type schema struct {
+		Name  string  `json:"name"`
+		Age   int     `json:"age"`
+		Score float64 `json:"score,omitempty"`
+	}
+
+	t.Run("success", func(t *testing.T) {
+		// act
+		var inputSchema []schema
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"text/csv"}},
+			Body:   io.NopCloser(strings.NewReader("name,age,score,unknown\ntest,20,9.5,x\nother,30,,y\n")),
+		}
+		err := request.CSV(&inputRequest, &inputSchema)
+
+		// assert
+		expectedSchema := []schema{{Name: "test", Age: 20, Score: 9.5}, {Name: "other", Age: 30}}
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, inputSchema)
+	})
+
+	t.Run("success - content-type with parameters", func(t *testing.T) {
+		// act
+		var inputSchema []schema
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"text/csv; charset=utf-8"}},
+			Body:   io.NopCloser(strings.NewReader("name,age\ntest,20\n")),
+		}
+		err := request.CSV(&inputRequest, &inputSchema)
+
+		// assert
+		expectedSchema := []schema{{Name: "test", Age: 20}}
+		require.NoError(t, err)
+		require.Equal(t, expectedSchema, inputSchema)
+	})
+
+	t.Run("error - content-type", func(t *testing.T) {
+		// act
+		var inputSchema []schema
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"application/json"}},
+			Body:   io.NopCloser(strings.NewReader("name,age\ntest,20\n")),
+		}
+		err := request.CSV(&inputRequest, &inputSchema)
+
+		// assert
+		require.ErrorIs(t, err, request.ErrRequestContentTypeNotCSV)
+		require.Nil(t, inputSchema)
+	})
+
+	t.Run("error - csv", func(t *testing.T) {
+		// act
+		var inputSchema []schema
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"text/csv"}},
+			Body:   io.NopCloser(strings.NewReader("name,age\ntest,twenty\n")),
+		}
+		err := request.CSV(&inputRequest, &inputSchema)
+
+		// assert
+		require.ErrorIs(t, err, request.ErrRequestCSVInvalid)
+		require.EqualError(t, err, `request csv invalid. line 2, column age: strconv.ParseInt: parsing "twenty": invalid syntax`)
+		require.Equal(t, []schema{{Name: "test"}}, inputSchema)
+	})
+
+	t.Run("error - csv rows", func(t *testing.T) {
+		// act
+		var inputSchema []schema
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"text/csv"}},
+			Body:   io.NopCloser(strings.NewReader("name,age\ntest,twenty\nother,30\nthird,40,extra\n")),
+		}
+		err := request.CSV(&inputRequest, &inputSchema)
+
+		// assert
+		var rowsErr request.CSVRowsError
+		require.ErrorAs(t, err, &rowsErr)
+		require.Len(t, rowsErr, 2)
+		require.Equal(t, 1, rowsErr[0].Row)
+		require.Equal(t, 3, rowsErr[1].Row)
+		require.ErrorIs(t, rowsErr[1], csv.ErrFieldCount)
+		require.Len(t, inputSchema, 3)
+		require.Equal(t, schema{Name: "other", Age: 30}, inputSchema[1])
+	})
+
+	t.Run("error - csv header", func(t *testing.T) {
+		// act
+		var inputSchema []schema
+		inputRequest := http.Request{
+			Header: http.Header{"Content-Type": []string{"text/csv"}},
+			Body:   io.NopCloser(strings.NewReader("")),
+		}
+		err := request.CSV(&inputRequest, &inputSchema)
+
+		// assert
+		require.ErrorIs(t, err, request.ErrRequestCSVInvalid)
+		require.EqualError(t, err, "request csv invalid. missing header")
+		require.Nil(t, inputSchema)
+	})
+}